@@ -2,16 +2,67 @@ package main
 
 import (
 	authcontroller "KTOnlinePlatform/internal/controllers/authentication"
+	docscontroller "KTOnlinePlatform/internal/controllers/docs"
 	filmscontroller "KTOnlinePlatform/internal/controllers/films"
+	filmsimportcontroller "KTOnlinePlatform/internal/controllers/filmsimport"
+	graphcontroller "KTOnlinePlatform/internal/controllers/graph"
+	jobscontroller "KTOnlinePlatform/internal/controllers/jobs"
+	listscontroller "KTOnlinePlatform/internal/controllers/lists"
+	mediacontroller "KTOnlinePlatform/internal/controllers/media"
+	moderationcontroller "KTOnlinePlatform/internal/controllers/moderation"
+	peoplecontroller "KTOnlinePlatform/internal/controllers/people"
+	progresscontroller "KTOnlinePlatform/internal/controllers/progress"
+	ratingscontroller "KTOnlinePlatform/internal/controllers/ratings"
+	recommendationscontroller "KTOnlinePlatform/internal/controllers/recommendations"
+	reviewscontroller "KTOnlinePlatform/internal/controllers/reviews"
+	trendingcontroller "KTOnlinePlatform/internal/controllers/trending"
+	videoscontroller "KTOnlinePlatform/internal/controllers/videos"
+	"KTOnlinePlatform/internal/docs"
+	"KTOnlinePlatform/internal/models/consts"
+	"KTOnlinePlatform/internal/models/kterrors"
 	"KTOnlinePlatform/internal/repositories/authentication"
 	"KTOnlinePlatform/internal/repositories/films"
+	"KTOnlinePlatform/internal/repositories/jobs"
+	"KTOnlinePlatform/internal/repositories/lists"
+	"KTOnlinePlatform/internal/repositories/moderation"
+	"KTOnlinePlatform/internal/repositories/people"
+	"KTOnlinePlatform/internal/repositories/progress"
+	"KTOnlinePlatform/internal/repositories/ratings"
+	"KTOnlinePlatform/internal/repositories/recommendations"
+	"KTOnlinePlatform/internal/repositories/reviews"
+	"KTOnlinePlatform/internal/repositories/trending"
+	"KTOnlinePlatform/internal/repositories/videos"
 	authservice "KTOnlinePlatform/internal/services/authentication"
 	filmsservice "KTOnlinePlatform/internal/services/films"
+	filmsimportservice "KTOnlinePlatform/internal/services/filmsimport"
+	graphservice "KTOnlinePlatform/internal/services/graph"
+	jobsservice "KTOnlinePlatform/internal/services/jobs"
+	listsservice "KTOnlinePlatform/internal/services/lists"
+	mediaservice "KTOnlinePlatform/internal/services/media"
+	moderationservice "KTOnlinePlatform/internal/services/moderation"
+	peopleservice "KTOnlinePlatform/internal/services/people"
+	progressservice "KTOnlinePlatform/internal/services/progress"
+	ratingsservice "KTOnlinePlatform/internal/services/ratings"
+	recommendationsservice "KTOnlinePlatform/internal/services/recommendations"
+	reviewsservice "KTOnlinePlatform/internal/services/reviews"
+	trendingservice "KTOnlinePlatform/internal/services/trending"
+	videosservice "KTOnlinePlatform/internal/services/videos"
+	"KTOnlinePlatform/pkg/blobstore"
 	"KTOnlinePlatform/pkg/configuration"
+	"KTOnlinePlatform/pkg/cursor"
 	"KTOnlinePlatform/pkg/database"
 	"KTOnlinePlatform/pkg/logger"
+	"KTOnlinePlatform/pkg/mediasign"
 	"KTOnlinePlatform/pkg/middlewares"
+	"KTOnlinePlatform/pkg/openapi"
+	"KTOnlinePlatform/pkg/transcoder"
 	"KTOnlinePlatform/pkg/webutils"
+	"KTOnlinePlatform/pkg/wordfilter"
+	"context"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
 )
 
 func main() {
@@ -21,16 +72,134 @@ func main() {
 	}
 	logger.Initialize(config.ConfigLogger)
 	db := database.NewDatabase(config.ConfigDatabase)
-	e := webutils.NewEcho(config.ConfigEcho)
+	messages, err := kterrors.NewMessages()
+	if err != nil {
+		panic(err)
+	}
+	e := webutils.NewEcho(config.ConfigEcho, messages, kterrors.Statuses)
+
+	// the background loops and jobs stop after the server, the requests it drains still reach them
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	background, cancelBackground := context.WithCancel(context.Background())
+	var backgroundDone sync.WaitGroup
 
-	middleware := middlewares.NewMiddleware(config.JWTSecret)
 	authRep := authentication.NewRepository(db)
+	middleware := middlewares.NewMiddleware(config.JWTSecret, authRep)
 	authService := authservice.NewService(authRep, middleware)
 	authcontroller.NewController(authService).RegisterRoutes(e)
 
 	filmRepo := films.NewRepository(db)
-	filmService := filmsservice.NewService(filmRepo)
+	blobs := newBlobStore(config.ConfigBlobStore)
+	mediaSigner := mediasign.NewSigner(config.MediaSigningKeys, config.MediaBaseURL+consts.MediaPath)
+	contentFilter := wordfilter.NewFilter(config.BannedWords)
+	filmService := filmsservice.NewService(filmRepo, cursor.NewSigner(config.CursorSecret), contentFilter, blobs, mediaSigner)
 	filmscontroller.NewController(filmService, middleware).RegisterRoutes(e)
 
-	webutils.StartEcho(e, config.AddressEcho)
+	mediaService := mediaservice.NewService(blobs, mediaSigner)
+	mediacontroller.NewController(mediaService).RegisterRoutes(e)
+
+	peopleRepo := people.NewRepository(db)
+	peopleService := peopleservice.NewService(peopleRepo)
+	peoplecontroller.NewController(peopleService, middleware).RegisterRoutes(e)
+
+	ratingRepo := ratings.NewRepository(db)
+	ratingService := ratingsservice.NewService(ratingRepo)
+	ratingscontroller.NewController(ratingService, middleware).RegisterRoutes(e)
+
+	reviewRepo := reviews.NewRepository(db)
+	reviewService := reviewsservice.NewService(reviewRepo, contentFilter)
+	reviewscontroller.NewController(reviewService, middleware).RegisterRoutes(e)
+
+	moderationRepo := moderation.NewRepository(db)
+	moderationService := moderationservice.NewService(moderationRepo)
+	moderationcontroller.NewController(moderationService, middleware).RegisterRoutes(e)
+
+	listRepo := lists.NewRepository(db)
+	listService := listsservice.NewService(listRepo)
+	listscontroller.NewController(listService, middleware).RegisterRoutes(e)
+
+	progressRepo := progress.NewRepository(db)
+	progressService := progressservice.NewService(progressRepo, consts.ProgressFlushSize, consts.ProgressMaxPending)
+	backgroundDone.Add(1)
+	go func() {
+		defer backgroundDone.Done()
+		progressService.Run(background, consts.ProgressFlushInterval)
+	}()
+	progresscontroller.NewController(progressService, middleware).RegisterRoutes(e)
+
+	recommendationRepo := recommendations.NewRepository(db)
+	recommendationService := recommendationsservice.NewService(recommendationRepo, filmService)
+	backgroundDone.Add(1)
+	go func() {
+		defer backgroundDone.Done()
+		recommendationService.Run(background, consts.RecommendationRebuildInterval)
+	}()
+	recommendationscontroller.NewController(recommendationService, middleware).RegisterRoutes(e)
+
+	trendingRepo := trending.NewRepository(db)
+	trendingService := trendingservice.NewService(trendingRepo, filmService)
+	backgroundDone.Add(1)
+	go func() {
+		defer backgroundDone.Done()
+		trendingService.Run(background, consts.TrendingRebuildInterval)
+	}()
+	trendingcontroller.NewController(trendingService, middleware).RegisterRoutes(e)
+
+	jobRepo := jobs.NewRepository(db)
+	jobService := jobsservice.NewService(background, jobRepo)
+	if err := jobService.FailInterruptedJobs(ctx); err != nil {
+		logger.Error().Err(err).Msg("cannot fail the interrupted jobs")
+	}
+	jobscontroller.NewController(jobService, middleware).RegisterRoutes(e)
+
+	filmsImportService := filmsimportservice.NewService(filmRepo, jobService, contentFilter)
+	filmsimportcontroller.NewController(filmsImportService, middleware).RegisterRoutes(e)
+
+	videoRepo := videos.NewRepository(db)
+	videoService := videosservice.NewService(videoRepo, blobs, mediaSigner, jobService, transcoder.New(config.FFmpegPath, consts.HLSSegmentSeconds))
+	backgroundDone.Add(1)
+	go func() {
+		defer backgroundDone.Done()
+		videoService.Run(background, consts.VideoSweepInterval)
+	}()
+	videoscontroller.NewController(videoService, middleware).RegisterRoutes(e)
+
+	graphService := graphservice.NewService(filmService, listService, authService, messages)
+	graphcontroller.NewController(graphService, middleware).RegisterRoutes(e)
+
+	docscontroller.NewController(e.Routes).RegisterRoutes(e)
+	if config.ValidateRequests {
+		// every route is registered, the document is complete
+		e.Use(openapi.NewValidator(docs.Document(e.Routes()), kterrors.ValidationError).Requests())
+	}
+
+	webutils.StartEcho(ctx, e, config.AddressEcho)
+	cancelBackground()
+	backgroundDone.Wait()
+	jobService.Wait()
+	logger.Info().Msg("Stopped")
+}
+
+type blobStore interface {
+	filmsservice.BlobStore
+	mediaservice.BlobStore
+	videosservice.BlobStore
+}
+
+func newBlobStore(config configuration.ConfigBlobStore) blobStore {
+	if config.BlobStore == consts.BlobStoreS3 {
+		store, err := blobstore.NewS3Store(blobstore.S3Config{
+			Endpoint:  config.S3Endpoint,
+			Region:    config.S3Region,
+			Bucket:    config.S3Bucket,
+			AccessKey: config.S3AccessKey,
+			SecretKey: config.S3SecretKey,
+		}, nil)
+		if err != nil {
+			panic(err)
+		}
+		return store
+	}
+	return blobstore.NewLocalStore(config.BlobLocalDir)
 }
@@ -11,6 +11,7 @@ import (
 type service interface {
 	Login(ctx context.Context, request dto.Login) (dto.JWTTokens, error)
 	CreateUser(ctx context.Context, request dto.CreateUserRequest) error
+	Refresh(ctx context.Context, request dto.RefreshRequest) (dto.JWTTokens, error)
 }
 
 type Controller struct {
@@ -31,8 +32,8 @@ func (c *Controller) RegisterRoutes(e *echo.Echo) {
 
 	g.PUT("/login", c.logIn)
 	g.POST("/register", c.createUser)
-	// should exist in the future, to refresh the token when the access token is expired
-	// g.POST("/refresh-token", c.refresh) // TODO: implement refresh token
+	// the access token expires after 15 minutes, the refresh token gets new ones for a day
+	g.POST("/refresh-token", c.refresh)
 }
 
 func (c *Controller) logIn(context echo.Context) error {
@@ -55,6 +56,26 @@ func (c *Controller) logIn(context echo.Context) error {
 	return context.JSON(http.StatusOK, tokens)
 }
 
+func (c *Controller) refresh(context echo.Context) error {
+	request := dto.RefreshRequest{}
+	err := context.Bind(&request)
+	if err != nil {
+		return err
+	}
+	err = context.Validate(request)
+	if err != nil {
+		logger.Error().Err(err).Msg("validation failed")
+		return err
+	}
+
+	tokens, err := c.service.Refresh(context.Request().Context(), request)
+	if err != nil {
+		logger.Error().Err(err).Msg("refresh failed")
+		return err
+	}
+	return context.JSON(http.StatusOK, tokens)
+}
+
 func (c *Controller) createUser(context echo.Context) error {
 	request := dto.CreateUserRequest{}
 	err := context.Bind(&request)
@@ -0,0 +1,58 @@
+package docs
+
+import (
+	"KTOnlinePlatform/internal/docs"
+	"KTOnlinePlatform/pkg/logger"
+	_ "embed"
+	"encoding/json"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"sync"
+)
+
+// docsPolicy keeps the docs page to its own inline script and style and to this API
+const docsPolicy = "default-src 'none'; script-src 'unsafe-inline'; style-src 'unsafe-inline'; connect-src 'self'"
+
+// docsPage renders the OpenAPI document without loading anything from another origin
+//
+//go:embed docs.html
+var docsPage []byte
+
+type Controller struct {
+	// routes are the routes registered in echo, read on the first call once every controller registered its own
+	routes   func() []*echo.Route
+	once     sync.Once
+	document []byte
+	err      error
+}
+
+func NewController(routes func() []*echo.Route) *Controller {
+	if routes == nil {
+		panic(routes)
+	}
+	return &Controller{
+		routes: routes,
+	}
+}
+
+// RegisterRoutes serves the OpenAPI document and its docs UI without authentication
+func (c *Controller) RegisterRoutes(e *echo.Echo) {
+	e.GET(docs.DocumentPath, c.getDocument)
+	e.GET(docs.UIPath, c.getDocs)
+}
+
+func (c *Controller) getDocument(context echo.Context) error {
+	c.once.Do(func() {
+		c.document, c.err = json.Marshal(docs.Document(c.routes()))
+	})
+	if c.err != nil {
+		logger.Error().Err(c.err).Msg("marshal openapi document failed")
+		return c.err
+	}
+	return context.Blob(http.StatusOK, echo.MIMEApplicationJSON, c.document)
+}
+
+func (c *Controller) getDocs(context echo.Context) error {
+	context.Response().Header().Set(echo.HeaderContentSecurityPolicy, docsPolicy)
+	return context.HTMLBlob(http.StatusOK, docsPage)
+}
@@ -3,21 +3,40 @@ package authentication
 import (
 	"KTOnlinePlatform/internal/dto"
 	"KTOnlinePlatform/internal/models/consts"
+	"KTOnlinePlatform/internal/models/kterrors"
+	"KTOnlinePlatform/pkg/customerror"
 	"KTOnlinePlatform/pkg/logger"
 	"KTOnlinePlatform/pkg/middlewares"
 	"KTOnlinePlatform/pkg/utils"
 	"KTOnlinePlatform/pkg/webutils"
 	"context"
+	"errors"
+	"fmt"
 	"github.com/labstack/echo/v4"
+	"io"
+	"mime"
 	"net/http"
 )
 
+const exportFileNameFormat = "films.%s"
+
 type service interface {
 	GetFilmPaginated(ctx context.Context, request dto.FilmSearchRequest) (dto.FilmsPaginated, error)
-	GetFilmDetail(ctx context.Context, ID int) (dto.FilmDetail, error)
+	GetFilmDetail(ctx context.Context, ID int, locales []string) (dto.FilmDetail, error)
 	DeleteFilm(ctx context.Context, filmID int, userID int) error
 	CreateFilm(ctx context.Context, request dto.FilmCreateRequest) error
 	UpdateFilm(ctx context.Context, request dto.FilmUpdateRequest) error
+	PatchFilm(ctx context.Context, request dto.FilmPatchRequest) error
+	ExportContentType(format string) (string, error)
+	ExportFilms(ctx context.Context, request dto.FilmExportRequest, w io.Writer) error
+	AddFilmCredit(ctx context.Context, request dto.FilmCreditCreateRequest) error
+	DeleteFilmCredit(ctx context.Context, filmID int, creditID int, userID int) error
+	UploadPoster(ctx context.Context, request dto.PosterUploadRequest) (dto.Poster, error)
+	UploadSubtitles(ctx context.Context, request dto.SubtitlesUploadRequest) (dto.SubtitleTrack, error)
+	DeleteSubtitles(ctx context.Context, filmID int, language string, userID int) error
+	GetFilmTranslations(ctx context.Context, filmID int) ([]dto.FilmTranslation, error)
+	SaveFilmTranslation(ctx context.Context, request dto.FilmTranslationRequest) (dto.FilmTranslation, error)
+	DeleteFilmTranslation(ctx context.Context, filmID int, locale string, userID int) error
 }
 
 type Controller struct {
@@ -41,11 +60,21 @@ func NewController(service service, middleware middlewares.AuthMiddleware) *Cont
 func (c *Controller) RegisterRoutes(e *echo.Echo) {
 	g := e.Group("/api/v1/films", c.AuthMiddleware.Authenticated())
 
-	g.GET("", c.getFilmPaginated) // TODO: include query params to filter the films
+	g.GET("", c.getFilmPaginated)
+	g.GET("/export", c.exportFilms)
 	g.GET("/:id", c.getFilmDetail)
 	g.PUT("/:id", c.updateFilmDetail)
+	g.PATCH("/:id", c.patchFilm)
 	g.DELETE("/:id", c.deleteFilm)
 	g.POST("", c.createFilm)
+	g.POST("/:id/credits", c.addFilmCredit)
+	g.DELETE("/:id/credits/:creditId", c.deleteFilmCredit)
+	g.POST("/:id/poster", c.uploadPoster)
+	g.POST("/:id/subtitles", c.uploadSubtitles)
+	g.DELETE("/:id/subtitles/:language", c.deleteSubtitles)
+	g.GET("/:id/translations", c.getFilmTranslations)
+	g.PUT("/:id/translations/:locale", c.saveFilmTranslation)
+	g.DELETE("/:id/translations/:locale", c.deleteFilmTranslation)
 }
 
 func (c *Controller) getFilmPaginated(context echo.Context) error {
@@ -61,26 +90,70 @@ func (c *Controller) getFilmPaginated(context echo.Context) error {
 	if request.PageSize == 0 {
 		request.PageSize = consts.PaginationDefaultPageSize
 	}
+	request.Locales = webutils.AcceptedLanguages(context)
 
 	result, err := c.service.GetFilmPaginated(context.Request().Context(), request)
 	if err != nil {
 		logger.Error().Err(err).Msg("get film paginated failed")
 		return err
 	}
+	context.Response().Header().Add(echo.HeaderVary, webutils.HeaderAcceptLanguage)
 	return context.JSON(http.StatusOK, result)
 }
 
+func (c *Controller) exportFilms(context echo.Context) error {
+	request := dto.FilmExportRequest{}
+	err := context.Bind(&request)
+	if err != nil {
+		return err
+	}
+	if request.Format == "" {
+		request.Format = consts.ExportFormatCSV
+	}
+	contentType, err := c.service.ExportContentType(request.Format)
+	if err != nil {
+		return err
+	}
+
+	header := context.Response().Header()
+	header.Set(echo.HeaderContentType, contentType)
+	header.Set(echo.HeaderContentDisposition, mime.FormatMediaType("attachment", map[string]string{
+		"filename": fmt.Sprintf(exportFileNameFormat, request.Format),
+	}))
+
+	// the 200 goes with the first bytes of the file, once the first film was read
+	err = c.service.ExportFilms(context.Request().Context(), request, context.Response())
+	if err != nil {
+		logger.Error().Err(err).Msg("export films failed")
+		if !context.Response().Committed {
+			header.Del(echo.HeaderContentType)
+			header.Del(echo.HeaderContentDisposition)
+			return err
+		}
+		// the 200 is already sent, the connection is cut so the client sees a broken download
+		// instead of a file silently missing its end
+		panic(http.ErrAbortHandler)
+	}
+	return nil
+}
+
 func (c *Controller) getFilmDetail(context echo.Context) error {
 	filmID, err := webutils.CheckParamToInt(context, "id")
 	if err != nil {
 		return err
 	}
 
-	result, err := c.service.GetFilmDetail(context.Request().Context(), filmID)
+	result, err := c.service.GetFilmDetail(context.Request().Context(), filmID, webutils.AcceptedLanguages(context))
 	if err != nil {
 		logger.Error().Err(err).Msg("get film detail failed")
 		return err
 	}
+	header := context.Response().Header()
+	header.Set(webutils.HeaderETag, webutils.FormatETag(result.Version))
+	header.Add(echo.HeaderVary, webutils.HeaderAcceptLanguage)
+	if result.Locale != "" {
+		header.Set(webutils.HeaderContentLanguage, result.Locale)
+	}
 	return context.JSON(http.StatusOK, result)
 }
 
@@ -132,6 +205,14 @@ func (c *Controller) updateFilmDetail(context echo.Context) error {
 	if err != nil {
 		return err
 	}
+	// If-Match takes precedence over the version sent in the body
+	version, err := webutils.IfMatchVersion(context)
+	if err != nil {
+		return err
+	}
+	if version != 0 {
+		request.Version = version
+	}
 	err = context.Validate(request)
 	if err != nil {
 		return err
@@ -148,3 +229,238 @@ func (c *Controller) updateFilmDetail(context echo.Context) error {
 	}
 	return context.NoContent(http.StatusNoContent)
 }
+
+func (c *Controller) patchFilm(context echo.Context) error {
+	filmID, err := webutils.CheckParamToInt(context, "id")
+	if err != nil {
+		return err
+	}
+	patchType, _, err := mime.ParseMediaType(context.Request().Header.Get(echo.HeaderContentType))
+	if err != nil {
+		return echo.ErrUnsupportedMediaType
+	}
+	patch, err := io.ReadAll(http.MaxBytesReader(context.Response(), context.Request().Body, consts.FilmPatchMaxSize))
+	if err != nil {
+		var maxBytesError *http.MaxBytesError
+		if errors.As(err, &maxBytesError) {
+			return customerror.NewCustomErrorWithHttpCode(kterrors.FilmPatchTooLargeError, http.StatusRequestEntityTooLarge)
+		}
+		return err
+	}
+	request := dto.FilmPatchRequest{
+		ID:        filmID,
+		PatchType: patchType,
+		Patch:     patch,
+	}
+	request.Version, err = webutils.IfMatchVersion(context)
+	if err != nil {
+		return err
+	}
+	request.UserID, err = utils.GetUserID(context)
+	if err != nil {
+		return err
+	}
+
+	err = c.service.PatchFilm(context.Request().Context(), request)
+	if err != nil {
+		logger.Error().Err(err).Msg("patch film failed")
+		return err
+	}
+	return context.NoContent(http.StatusNoContent)
+}
+
+func (c *Controller) addFilmCredit(context echo.Context) error {
+	request := dto.FilmCreditCreateRequest{}
+	err := context.Bind(&request)
+	if err != nil {
+		return err
+	}
+	err = context.Validate(request)
+	if err != nil {
+		return err
+	}
+	request.UserID, err = utils.GetUserID(context)
+	if err != nil {
+		return err
+	}
+
+	err = c.service.AddFilmCredit(context.Request().Context(), request)
+	if err != nil {
+		logger.Error().Err(err).Msg("add film credit failed")
+		return err
+	}
+	return context.NoContent(http.StatusNoContent)
+}
+
+func (c *Controller) deleteFilmCredit(context echo.Context) error {
+	filmID, err := webutils.CheckParamToInt(context, "id")
+	if err != nil {
+		return err
+	}
+	creditID, err := webutils.CheckParamToInt(context, "creditId")
+	if err != nil {
+		return err
+	}
+
+	userID, err := utils.GetUserID(context)
+	if err != nil {
+		return err
+	}
+
+	err = c.service.DeleteFilmCredit(context.Request().Context(), filmID, creditID, userID)
+	if err != nil {
+		logger.Error().Err(err).Msg("delete film credit failed")
+		return err
+	}
+	return context.NoContent(http.StatusNoContent)
+}
+
+func (c *Controller) uploadPoster(context echo.Context) error {
+	filmID, err := webutils.CheckParamToInt(context, "id")
+	if err != nil {
+		return err
+	}
+	// room for the multipart envelope, the service rejects a poster over the limit itself
+	context.Request().Body = http.MaxBytesReader(context.Response(), context.Request().Body, 2*consts.PosterMaxSize)
+	file, err := context.FormFile(consts.PosterFormField)
+	if err != nil {
+		return customerror.NewCustomError(kterrors.PosterRequiredError)
+	}
+	src, err := file.Open()
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	data, err := io.ReadAll(io.LimitReader(src, consts.PosterMaxSize+1))
+	if err != nil {
+		return err
+	}
+	request := dto.PosterUploadRequest{
+		FilmID: filmID,
+		Data:   data,
+	}
+	request.UserID, err = utils.GetUserID(context)
+	if err != nil {
+		return err
+	}
+
+	poster, err := c.service.UploadPoster(context.Request().Context(), request)
+	if err != nil {
+		logger.Error().Err(err).Msg("upload poster failed")
+		return err
+	}
+	return context.JSON(http.StatusOK, poster)
+}
+
+func (c *Controller) uploadSubtitles(context echo.Context) error {
+	filmID, err := webutils.CheckParamToInt(context, "id")
+	if err != nil {
+		return err
+	}
+	context.Request().Body = http.MaxBytesReader(context.Response(), context.Request().Body, 2*consts.SubtitlesMaxSize)
+	file, err := context.FormFile(consts.SubtitlesFormField)
+	if err != nil {
+		return customerror.NewCustomError(kterrors.SubtitlesRequiredError)
+	}
+	src, err := file.Open()
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	data, err := io.ReadAll(io.LimitReader(src, consts.SubtitlesMaxSize+1))
+	if err != nil {
+		return err
+	}
+	request := dto.SubtitlesUploadRequest{
+		FilmID:   filmID,
+		Language: context.FormValue("language"),
+		Label:    context.FormValue("label"),
+		Data:     data,
+	}
+	request.UserID, err = utils.GetUserID(context)
+	if err != nil {
+		return err
+	}
+
+	track, err := c.service.UploadSubtitles(context.Request().Context(), request)
+	if err != nil {
+		logger.Error().Err(err).Msg("upload subtitles failed")
+		return err
+	}
+	return context.JSON(http.StatusOK, track)
+}
+
+func (c *Controller) deleteSubtitles(context echo.Context) error {
+	filmID, err := webutils.CheckParamToInt(context, "id")
+	if err != nil {
+		return err
+	}
+
+	userID, err := utils.GetUserID(context)
+	if err != nil {
+		return err
+	}
+
+	err = c.service.DeleteSubtitles(context.Request().Context(), filmID, context.Param("language"), userID)
+	if err != nil {
+		logger.Error().Err(err).Msg("delete subtitles failed")
+		return err
+	}
+	return context.NoContent(http.StatusNoContent)
+}
+
+func (c *Controller) getFilmTranslations(context echo.Context) error {
+	filmID, err := webutils.CheckParamToInt(context, "id")
+	if err != nil {
+		return err
+	}
+
+	result, err := c.service.GetFilmTranslations(context.Request().Context(), filmID)
+	if err != nil {
+		logger.Error().Err(err).Msg("get film translations failed")
+		return err
+	}
+	return context.JSON(http.StatusOK, result)
+}
+
+func (c *Controller) saveFilmTranslation(context echo.Context) error {
+	request := dto.FilmTranslationRequest{}
+	err := context.Bind(&request)
+	if err != nil {
+		return err
+	}
+	err = context.Validate(request)
+	if err != nil {
+		return err
+	}
+	request.UserID, err = utils.GetUserID(context)
+	if err != nil {
+		return err
+	}
+
+	result, err := c.service.SaveFilmTranslation(context.Request().Context(), request)
+	if err != nil {
+		logger.Error().Err(err).Msg("save film translation failed")
+		return err
+	}
+	return context.JSON(http.StatusOK, result)
+}
+
+func (c *Controller) deleteFilmTranslation(context echo.Context) error {
+	filmID, err := webutils.CheckParamToInt(context, "id")
+	if err != nil {
+		return err
+	}
+
+	userID, err := utils.GetUserID(context)
+	if err != nil {
+		return err
+	}
+
+	err = c.service.DeleteFilmTranslation(context.Request().Context(), filmID, context.Param("locale"), userID)
+	if err != nil {
+		logger.Error().Err(err).Msg("delete film translation failed")
+		return err
+	}
+	return context.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,215 @@
+package authentication
+
+import (
+	"KTOnlinePlatform/internal/dto"
+	"KTOnlinePlatform/internal/models/consts"
+	"KTOnlinePlatform/internal/models/kterrors"
+	"KTOnlinePlatform/pkg/configuration"
+	"KTOnlinePlatform/pkg/customerror"
+	"KTOnlinePlatform/pkg/logger"
+	"KTOnlinePlatform/pkg/middlewares"
+	"KTOnlinePlatform/pkg/webutils"
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// fakeService embeds the interface for the methods the tests do not call
+type fakeService struct {
+	service
+	updates   []dto.FilmUpdateRequest
+	openErr   error
+	exportErr error
+}
+
+func (s *fakeService) UpdateFilm(ctx context.Context, request dto.FilmUpdateRequest) error {
+	s.updates = append(s.updates, request)
+	return nil
+}
+
+func (s *fakeService) ExportContentType(format string) (string, error) {
+	return consts.NDJSONContentType, nil
+}
+
+// ExportFilms fails with openErr before writing anything, or writes a first row before failing
+// with exportErr
+func (s *fakeService) ExportFilms(ctx context.Context, request dto.FilmExportRequest, w io.Writer) error {
+	if s.openErr != nil {
+		return s.openErr
+	}
+	_, err := io.WriteString(w, `{"id":1,"title":"Alien"}`+"\n")
+	if err != nil {
+		return err
+	}
+	return s.exportErr
+}
+
+// activeUsers is never suspended
+type activeUsers struct{}
+
+func (activeUsers) IsUserSuspended(ctx context.Context, userID int) (bool, error) {
+	return false, nil
+}
+
+func newTestEcho(t *testing.T, films *fakeService) (*echo.Echo, string) {
+	logger.InitializeForTest()
+	messages, err := kterrors.NewMessages()
+	require.NoError(t, err)
+	e := webutils.NewEcho(configuration.ConfigEcho{AllowedOrigins: "*"}, messages, kterrors.Statuses)
+	middleware := middlewares.NewMiddleware("secret", activeUsers{})
+	NewController(films, middleware).RegisterRoutes(e)
+
+	tokens, err := middleware.GenerateAuthTokens(1, "ripley")
+	require.NoError(t, err)
+	return e, tokens.AccessToken
+}
+
+func TestMalformedRequests(t *testing.T) {
+	tests := []struct {
+		name           string
+		method         string
+		target         string
+		ifMatch        string
+		contentType    string
+		body           string
+		expectedStatus int
+		expectedCode   string
+		expectedParams map[string]interface{}
+	}{
+		{
+			name:           "Non numeric id",
+			method:         http.MethodGet,
+			target:         "/api/v1/films/abc",
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   kterrors.InvalidPathParamError,
+			expectedParams: map[string]interface{}{"param": "id"},
+		},
+		{
+			name:           "Malformed If-Match on update",
+			method:         http.MethodPut,
+			target:         "/api/v1/films/1",
+			ifMatch:        `"abc"`,
+			contentType:    echo.MIMEApplicationJSON,
+			body:           `{"title": "Alien", "version": 1}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   kterrors.InvalidETagError,
+			expectedParams: map[string]interface{}{"etag": `"abc"`},
+		},
+		{
+			name:           "Malformed If-Match on patch",
+			method:         http.MethodPatch,
+			target:         "/api/v1/films/1",
+			ifMatch:        "3",
+			contentType:    "application/merge-patch+json",
+			body:           `{"title": "Alien"}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   kterrors.InvalidETagError,
+			expectedParams: map[string]interface{}{"etag": "3"},
+		},
+		{
+			name:           "Patch over the size limit",
+			method:         http.MethodPatch,
+			target:         "/api/v1/films/1",
+			ifMatch:        `"3"`,
+			contentType:    "application/merge-patch+json",
+			body:           `{"synopsis": "` + strings.Repeat("a", consts.FilmPatchMaxSize) + `"}`,
+			expectedStatus: http.StatusRequestEntityTooLarge,
+			expectedCode:   kterrors.FilmPatchTooLargeError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			films := &fakeService{}
+			e, token := newTestEcho(t, films)
+
+			request := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+			request.Header.Set(echo.HeaderAuthorization, "Bearer "+token)
+			if tt.contentType != "" {
+				request.Header.Set(echo.HeaderContentType, tt.contentType)
+			}
+			if tt.ifMatch != "" {
+				request.Header.Set(webutils.HeaderIfMatch, tt.ifMatch)
+			}
+			recorder := httptest.NewRecorder()
+			e.ServeHTTP(recorder, request)
+
+			assert.Equal(t, tt.expectedStatus, recorder.Code, recorder.Body.String())
+			var problem customerror.Problem
+			require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &problem))
+			assert.Equal(t, tt.expectedCode, problem.Code)
+			assert.Equal(t, tt.expectedParams, problem.Params)
+			assert.Empty(t, films.updates, "the service is not called")
+		})
+	}
+}
+
+func TestUpdateFilmIfMatchAny(t *testing.T) {
+	films := &fakeService{}
+	e, token := newTestEcho(t, films)
+
+	request := httptest.NewRequest(http.MethodPut, "/api/v1/films/1", strings.NewReader(`{"title": "Alien"}`))
+	request.Header.Set(echo.HeaderAuthorization, "Bearer "+token)
+	request.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	request.Header.Set(webutils.HeaderIfMatch, "*")
+	recorder := httptest.NewRecorder()
+	e.ServeHTTP(recorder, request)
+
+	assert.Equal(t, http.StatusNoContent, recorder.Code, recorder.Body.String())
+	require.Len(t, films.updates, 1)
+	assert.Equal(t, consts.AnyVersion, films.updates[0].Version, "* matches any version")
+}
+
+func TestExportFilms(t *testing.T) {
+	tests := []struct {
+		name          string
+		openErr       error
+		exportErr     error
+		expectedAbort bool
+	}{
+		{
+			name: "Complete export",
+		},
+		{
+			name:    "Export failing before the first row is an error response",
+			openErr: errors.New("statement timeout"),
+		},
+		{
+			name:          "Export failing after the first rows cuts the connection",
+			exportErr:     errors.New("connection reset"),
+			expectedAbort: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			films := &fakeService{openErr: tt.openErr, exportErr: tt.exportErr}
+			e, token := newTestEcho(t, films)
+
+			request := httptest.NewRequest(http.MethodGet, "/api/v1/films/export?format=ndjson", nil)
+			request.Header.Set(echo.HeaderAuthorization, "Bearer "+token)
+			recorder := httptest.NewRecorder()
+			serve := func() { e.ServeHTTP(recorder, request) }
+
+			if tt.expectedAbort {
+				assert.PanicsWithValue(t, http.ErrAbortHandler, serve)
+				return
+			}
+			assert.NotPanics(t, serve)
+			if tt.openErr != nil {
+				assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+				assert.Equal(t, customerror.ProblemContentType, recorder.Header().Get(echo.HeaderContentType))
+				assert.Empty(t, recorder.Header().Get(echo.HeaderContentDisposition))
+				return
+			}
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			assert.Equal(t, `{"id":1,"title":"Alien"}`+"\n", recorder.Body.String())
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package filmsimport
+
+import (
+	"KTOnlinePlatform/internal/dto"
+	"KTOnlinePlatform/internal/models/consts"
+	"KTOnlinePlatform/internal/models/kterrors"
+	"KTOnlinePlatform/pkg/customerror"
+	"KTOnlinePlatform/pkg/logger"
+	"KTOnlinePlatform/pkg/middlewares"
+	"KTOnlinePlatform/pkg/utils"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/labstack/echo/v4"
+	"io"
+	"mime"
+	"net/http"
+)
+
+const jobLocationFormat = "/api/v1/jobs/%d"
+
+type service interface {
+	ImportFilms(ctx context.Context, request dto.FilmImportRequest) (dto.Job, error)
+}
+
+type Controller struct {
+	service service
+	middlewares.AuthMiddleware
+}
+
+func NewController(service service, middleware middlewares.AuthMiddleware) *Controller {
+	if service == nil {
+		panic(service)
+	}
+	if middleware == nil {
+		panic(middleware)
+	}
+	return &Controller{
+		service:        service,
+		AuthMiddleware: middleware,
+	}
+}
+
+func (c *Controller) RegisterRoutes(e *echo.Echo) {
+	g := e.Group("/api/v1/films", c.AuthMiddleware.Authenticated())
+
+	g.POST("/import", c.importFilms)
+}
+
+func (c *Controller) importFilms(context echo.Context) error {
+	format, _, err := mime.ParseMediaType(context.Request().Header.Get(echo.HeaderContentType))
+	if err != nil {
+		return echo.ErrUnsupportedMediaType
+	}
+	data, err := io.ReadAll(http.MaxBytesReader(context.Response(), context.Request().Body, consts.FilmImportMaxSize))
+	if err != nil {
+		var maxBytesError *http.MaxBytesError
+		if errors.As(err, &maxBytesError) {
+			return customerror.NewCustomErrorWithHttpCode(kterrors.ImportFileTooLargeError, http.StatusRequestEntityTooLarge)
+		}
+		return err
+	}
+	request := dto.FilmImportRequest{
+		Format:     format,
+		OnConflict: context.QueryParam("onConflict"),
+		Data:       data,
+	}
+	request.UserID, err = utils.GetUserID(context)
+	if err != nil {
+		return err
+	}
+
+	job, err := c.service.ImportFilms(context.Request().Context(), request)
+	if err != nil {
+		logger.Error().Err(err).Msg("import films failed")
+		return err
+	}
+	context.Response().Header().Set(echo.HeaderLocation, fmt.Sprintf(jobLocationFormat, job.ID))
+	return context.JSON(http.StatusAccepted, job)
+}
@@ -0,0 +1,62 @@
+package filmsimport
+
+import (
+	"KTOnlinePlatform/internal/dto"
+	"KTOnlinePlatform/internal/models/consts"
+	"KTOnlinePlatform/internal/models/kterrors"
+	"KTOnlinePlatform/pkg/configuration"
+	"KTOnlinePlatform/pkg/customerror"
+	"KTOnlinePlatform/pkg/logger"
+	"KTOnlinePlatform/pkg/middlewares"
+	"KTOnlinePlatform/pkg/webutils"
+	"context"
+	"encoding/json"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type fakeService struct {
+	imports []dto.FilmImportRequest
+}
+
+func (s *fakeService) ImportFilms(ctx context.Context, request dto.FilmImportRequest) (dto.Job, error) {
+	s.imports = append(s.imports, request)
+	return dto.Job{ID: 1, Status: consts.JobStatusPending}, nil
+}
+
+// activeUsers is never suspended
+type activeUsers struct{}
+
+func (activeUsers) IsUserSuspended(ctx context.Context, userID int) (bool, error) {
+	return false, nil
+}
+
+func TestImportFilmsTooLarge(t *testing.T) {
+	logger.InitializeForTest()
+	messages, err := kterrors.NewMessages()
+	require.NoError(t, err)
+	e := webutils.NewEcho(configuration.ConfigEcho{AllowedOrigins: "*"}, messages, kterrors.Statuses)
+	middleware := middlewares.NewMiddleware("secret", activeUsers{})
+	films := &fakeService{}
+	NewController(films, middleware).RegisterRoutes(e)
+	tokens, err := middleware.GenerateAuthTokens(1, "ripley")
+	require.NoError(t, err)
+
+	body := "title,director,release_date\n" + strings.Repeat("a", consts.FilmImportMaxSize)
+	request := httptest.NewRequest(http.MethodPost, "/api/v1/films/import", strings.NewReader(body))
+	request.Header.Set(echo.HeaderAuthorization, "Bearer "+tokens.AccessToken)
+	request.Header.Set(echo.HeaderContentType, consts.CSVContentType)
+	recorder := httptest.NewRecorder()
+	e.ServeHTTP(recorder, request)
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, recorder.Code, recorder.Body.String())
+	var problem customerror.Problem
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &problem))
+	assert.Equal(t, kterrors.ImportFileTooLargeError, problem.Code)
+	assert.Empty(t, films.imports, "the service is not called")
+}
@@ -0,0 +1,63 @@
+package graph
+
+import (
+	"KTOnlinePlatform/internal/dto"
+	"KTOnlinePlatform/pkg/logger"
+	"KTOnlinePlatform/pkg/middlewares"
+	"KTOnlinePlatform/pkg/utils"
+	"KTOnlinePlatform/pkg/webutils"
+	"context"
+	"github.com/labstack/echo/v4"
+	"net/http"
+)
+
+type service interface {
+	Execute(ctx context.Context, request dto.GraphQLRequest) dto.GraphQLResponse
+}
+
+type Controller struct {
+	service service
+	middlewares.AuthMiddleware
+}
+
+func NewController(service service, middleware middlewares.AuthMiddleware) *Controller {
+	if service == nil {
+		panic(service)
+	}
+	if middleware == nil {
+		panic(middleware)
+	}
+	return &Controller{
+		service:        service,
+		AuthMiddleware: middleware,
+	}
+}
+
+func (c *Controller) RegisterRoutes(e *echo.Echo) {
+	g := e.Group("/graphql", c.AuthMiddleware.Authenticated())
+
+	g.POST("", c.query)
+}
+
+// query answers 200 even when the query fails, the errors are in the GraphQL response like the
+// GraphQL clients expect. Only a body that is not a GraphQL request gets a problem
+func (c *Controller) query(context echo.Context) error {
+	request := dto.GraphQLRequest{}
+	err := context.Bind(&request)
+	if err != nil {
+		return err
+	}
+	err = context.Validate(request)
+	if err != nil {
+		logger.Error().Err(err).Msg("validation failed")
+		return err
+	}
+	request.UserID, err = utils.GetUserID(context)
+	if err != nil {
+		return err
+	}
+	request.Locales = webutils.AcceptedLanguages(context)
+
+	result := c.service.Execute(context.Request().Context(), request)
+	return context.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,59 @@
+package jobs
+
+import (
+	"KTOnlinePlatform/internal/dto"
+	"KTOnlinePlatform/pkg/logger"
+	"KTOnlinePlatform/pkg/middlewares"
+	"KTOnlinePlatform/pkg/utils"
+	"KTOnlinePlatform/pkg/webutils"
+	"context"
+	"github.com/labstack/echo/v4"
+	"net/http"
+)
+
+type service interface {
+	GetJob(ctx context.Context, jobID int, userID int) (dto.Job, error)
+}
+
+type Controller struct {
+	service service
+	middlewares.AuthMiddleware
+}
+
+func NewController(service service, middleware middlewares.AuthMiddleware) *Controller {
+	if service == nil {
+		panic(service)
+	}
+	if middleware == nil {
+		panic(middleware)
+	}
+	return &Controller{
+		service:        service,
+		AuthMiddleware: middleware,
+	}
+}
+
+func (c *Controller) RegisterRoutes(e *echo.Echo) {
+	g := e.Group("/api/v1/jobs", c.AuthMiddleware.Authenticated())
+
+	g.GET("/:id", c.getJob)
+}
+
+func (c *Controller) getJob(context echo.Context) error {
+	jobID, err := webutils.CheckParamToInt(context, "id")
+	if err != nil {
+		return err
+	}
+
+	userID, err := utils.GetUserID(context)
+	if err != nil {
+		return err
+	}
+
+	result, err := c.service.GetJob(context.Request().Context(), jobID, userID)
+	if err != nil {
+		logger.Error().Err(err).Msg("get job failed")
+		return err
+	}
+	return context.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,235 @@
+package lists
+
+import (
+	"KTOnlinePlatform/internal/dto"
+	"KTOnlinePlatform/pkg/logger"
+	"KTOnlinePlatform/pkg/middlewares"
+	"KTOnlinePlatform/pkg/utils"
+	"KTOnlinePlatform/pkg/webutils"
+	"context"
+	"github.com/labstack/echo/v4"
+	"net/http"
+)
+
+type service interface {
+	GetUserLists(ctx context.Context, userID int) ([]dto.UserList, error)
+	GetUserList(ctx context.Context, listID int, userID int) (dto.UserList, error)
+	GetSharedList(ctx context.Context, slug string) (dto.UserList, error)
+	CreateList(ctx context.Context, request dto.UserListCreateRequest) error
+	UpdateList(ctx context.Context, request dto.UserListUpdateRequest) error
+	DeleteList(ctx context.Context, listID int, userID int) error
+	AddListEntry(ctx context.Context, request dto.UserListEntryRequest) error
+	RemoveListEntry(ctx context.Context, listID int, filmID int, userID int) error
+	ReorderListEntries(ctx context.Context, request dto.UserListOrderRequest) error
+}
+
+type Controller struct {
+	service service
+	middlewares.AuthMiddleware
+}
+
+func NewController(service service, middleware middlewares.AuthMiddleware) *Controller {
+	if service == nil {
+		panic(service)
+	}
+	if middleware == nil {
+		panic(middleware)
+	}
+	return &Controller{
+		service:        service,
+		AuthMiddleware: middleware,
+	}
+}
+
+func (c *Controller) RegisterRoutes(e *echo.Echo) {
+	g := e.Group("/api/v1/me/lists", c.AuthMiddleware.Authenticated())
+
+	g.GET("", c.getUserLists)
+	g.POST("", c.createList)
+	g.GET("/:id", c.getUserList)
+	g.PUT("/:id", c.updateList)
+	g.DELETE("/:id", c.deleteList)
+	g.POST("/:id/entries", c.addListEntry)
+	g.PUT("/:id/entries", c.reorderListEntries)
+	g.DELETE("/:id/entries/:filmId", c.removeListEntry)
+
+	// anyone with the link can read a public list
+	shared := e.Group("/api/v1/lists")
+	shared.GET("/:slug", c.getSharedList)
+}
+
+func (c *Controller) getUserLists(context echo.Context) error {
+	userID, err := utils.GetUserID(context)
+	if err != nil {
+		return err
+	}
+
+	result, err := c.service.GetUserLists(context.Request().Context(), userID)
+	if err != nil {
+		logger.Error().Err(err).Msg("get user lists failed")
+		return err
+	}
+	return context.JSON(http.StatusOK, result)
+}
+
+func (c *Controller) getUserList(context echo.Context) error {
+	listID, err := webutils.CheckParamToInt(context, "id")
+	if err != nil {
+		return err
+	}
+
+	userID, err := utils.GetUserID(context)
+	if err != nil {
+		return err
+	}
+
+	result, err := c.service.GetUserList(context.Request().Context(), listID, userID)
+	if err != nil {
+		logger.Error().Err(err).Msg("get user list failed")
+		return err
+	}
+	return context.JSON(http.StatusOK, result)
+}
+
+func (c *Controller) getSharedList(context echo.Context) error {
+	result, err := c.service.GetSharedList(context.Request().Context(), context.Param("slug"))
+	if err != nil {
+		logger.Error().Err(err).Msg("get shared list failed")
+		return err
+	}
+	return context.JSON(http.StatusOK, result)
+}
+
+func (c *Controller) createList(context echo.Context) error {
+	request := dto.UserListCreateRequest{}
+	err := context.Bind(&request)
+	if err != nil {
+		return err
+	}
+	err = context.Validate(request)
+	if err != nil {
+		return err
+	}
+	request.UserID, err = utils.GetUserID(context)
+	if err != nil {
+		return err
+	}
+
+	err = c.service.CreateList(context.Request().Context(), request)
+	if err != nil {
+		logger.Error().Err(err).Msg("create list failed")
+		return err
+	}
+	return context.NoContent(http.StatusNoContent)
+}
+
+func (c *Controller) updateList(context echo.Context) error {
+	request := dto.UserListUpdateRequest{}
+	err := context.Bind(&request)
+	if err != nil {
+		return err
+	}
+	err = context.Validate(request)
+	if err != nil {
+		return err
+	}
+	request.UserID, err = utils.GetUserID(context)
+	if err != nil {
+		return err
+	}
+
+	err = c.service.UpdateList(context.Request().Context(), request)
+	if err != nil {
+		logger.Error().Err(err).Msg("update list failed")
+		return err
+	}
+	return context.NoContent(http.StatusNoContent)
+}
+
+func (c *Controller) deleteList(context echo.Context) error {
+	listID, err := webutils.CheckParamToInt(context, "id")
+	if err != nil {
+		return err
+	}
+
+	userID, err := utils.GetUserID(context)
+	if err != nil {
+		return err
+	}
+
+	err = c.service.DeleteList(context.Request().Context(), listID, userID)
+	if err != nil {
+		logger.Error().Err(err).Msg("delete list failed")
+		return err
+	}
+	return context.NoContent(http.StatusNoContent)
+}
+
+func (c *Controller) addListEntry(context echo.Context) error {
+	request := dto.UserListEntryRequest{}
+	err := context.Bind(&request)
+	if err != nil {
+		return err
+	}
+	err = context.Validate(request)
+	if err != nil {
+		return err
+	}
+	request.UserID, err = utils.GetUserID(context)
+	if err != nil {
+		return err
+	}
+
+	err = c.service.AddListEntry(context.Request().Context(), request)
+	if err != nil {
+		logger.Error().Err(err).Msg("add list entry failed")
+		return err
+	}
+	return context.NoContent(http.StatusNoContent)
+}
+
+func (c *Controller) reorderListEntries(context echo.Context) error {
+	request := dto.UserListOrderRequest{}
+	err := context.Bind(&request)
+	if err != nil {
+		return err
+	}
+	err = context.Validate(request)
+	if err != nil {
+		return err
+	}
+	request.UserID, err = utils.GetUserID(context)
+	if err != nil {
+		return err
+	}
+
+	err = c.service.ReorderListEntries(context.Request().Context(), request)
+	if err != nil {
+		logger.Error().Err(err).Msg("reorder list entries failed")
+		return err
+	}
+	return context.NoContent(http.StatusNoContent)
+}
+
+func (c *Controller) removeListEntry(context echo.Context) error {
+	listID, err := webutils.CheckParamToInt(context, "id")
+	if err != nil {
+		return err
+	}
+	filmID, err := webutils.CheckParamToInt(context, "filmId")
+	if err != nil {
+		return err
+	}
+
+	userID, err := utils.GetUserID(context)
+	if err != nil {
+		return err
+	}
+
+	err = c.service.RemoveListEntry(context.Request().Context(), listID, filmID, userID)
+	if err != nil {
+		logger.Error().Err(err).Msg("remove list entry failed")
+		return err
+	}
+	return context.NoContent(http.StatusNoContent)
+}
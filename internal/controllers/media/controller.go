@@ -0,0 +1,67 @@
+package media
+
+import (
+	"KTOnlinePlatform/internal/dto"
+	"KTOnlinePlatform/internal/models/consts"
+	"KTOnlinePlatform/pkg/logger"
+	"context"
+	"github.com/labstack/echo/v4"
+	"io"
+	"net/http"
+	"path"
+	"time"
+)
+
+// a signed URL stays valid for at least a ttl, every ttl is longer than an hour
+const mediaCacheControl = "public, max-age=3600"
+
+// http.ServeContent guesses the type from the extension, most mime tables do not know these ones
+var mediaContentTypes = map[string]string{
+	".m3u8": consts.HLSContentType,
+	".ts":   consts.MPEGTSContentType,
+	".vtt":  consts.WebVTTContentType,
+}
+
+type service interface {
+	OpenMedia(ctx context.Context, request dto.MediaRequest) (io.ReadSeekCloser, error)
+}
+
+type Controller struct {
+	service service
+}
+
+func NewController(service service) *Controller {
+	if service == nil {
+		panic(service)
+	}
+	return &Controller{
+		service: service,
+	}
+}
+
+// RegisterRoutes serves the media without authentication, the signature of the URL is the
+// authorization. The HLS players do not send the token of the user to the segments
+func (c *Controller) RegisterRoutes(e *echo.Echo) {
+	e.GET(consts.MediaPath+"/*", c.getMedia)
+	e.HEAD(consts.MediaPath+"/*", c.getMedia)
+}
+
+// getMedia lets http.ServeContent answer range and conditional requests, so players can seek
+func (c *Controller) getMedia(context echo.Context) error {
+	request := dto.MediaRequest{
+		Key:   context.Param("*"),
+		Query: context.QueryParams(),
+	}
+	blob, err := c.service.OpenMedia(context.Request().Context(), request)
+	if err != nil {
+		logger.Error().Err(err).Msg("get media failed")
+		return err
+	}
+	defer blob.Close()
+	context.Response().Header().Set(echo.HeaderCacheControl, mediaCacheControl)
+	if contentType, ok := mediaContentTypes[path.Ext(request.Key)]; ok {
+		context.Response().Header().Set(echo.HeaderContentType, contentType)
+	}
+	http.ServeContent(context.Response(), context.Request(), path.Base(request.Key), time.Time{}, blob)
+	return nil
+}
@@ -0,0 +1,167 @@
+package moderation
+
+import (
+	"KTOnlinePlatform/internal/dto"
+	"KTOnlinePlatform/internal/models/consts"
+	"KTOnlinePlatform/pkg/logger"
+	"KTOnlinePlatform/pkg/middlewares"
+	"KTOnlinePlatform/pkg/utils"
+	"KTOnlinePlatform/pkg/webutils"
+	"context"
+	"github.com/labstack/echo/v4"
+	"net/http"
+)
+
+type service interface {
+	CreateReport(ctx context.Context, request dto.ReportCreateRequest) error
+	GetReportsPaginated(ctx context.Context, request dto.ReportSearchRequest) (dto.ReportsPaginated, error)
+	ClaimReport(ctx context.Context, reportID int, userID int) error
+	ResolveReport(ctx context.Context, request dto.ReportResolveRequest) error
+	GetAuditTrail(ctx context.Context, request dto.AuditSearchRequest) (dto.AuditTrail, error)
+}
+
+type Controller struct {
+	service service
+	middlewares.AuthMiddleware
+}
+
+func NewController(service service, middleware middlewares.AuthMiddleware) *Controller {
+	if service == nil {
+		panic(service)
+	}
+	if middleware == nil {
+		panic(middleware)
+	}
+	return &Controller{
+		service:        service,
+		AuthMiddleware: middleware,
+	}
+}
+
+func (c *Controller) RegisterRoutes(e *echo.Echo) {
+	reports := e.Group("/api/v1/reports", c.AuthMiddleware.Authenticated())
+	reports.POST("", c.createReport)
+
+	g := e.Group("/api/v1/moderation", c.AuthMiddleware.Authenticated())
+	g.GET("/reports", c.getReportsPaginated)
+	g.POST("/reports/:id/claim", c.claimReport)
+	g.POST("/reports/:id/resolve", c.resolveReport)
+	g.GET("/audit", c.getAuditTrail)
+}
+
+func (c *Controller) createReport(context echo.Context) error {
+	request := dto.ReportCreateRequest{}
+	err := context.Bind(&request)
+	if err != nil {
+		return err
+	}
+	err = context.Validate(request)
+	if err != nil {
+		return err
+	}
+	request.UserID, err = utils.GetUserID(context)
+	if err != nil {
+		return err
+	}
+
+	err = c.service.CreateReport(context.Request().Context(), request)
+	if err != nil {
+		logger.Error().Err(err).Msg("create report failed")
+		return err
+	}
+	return context.NoContent(http.StatusNoContent)
+}
+
+func (c *Controller) getReportsPaginated(context echo.Context) error {
+	request := dto.ReportSearchRequest{}
+	err := context.Bind(&request)
+	if err != nil {
+		return err
+	}
+	if request.Page == 0 {
+		request.Page = consts.BasicPaginationDefaultPageNumber
+	}
+
+	if request.PageSize == 0 {
+		request.PageSize = consts.PaginationDefaultPageSize
+	}
+	request.UserID, err = utils.GetUserID(context)
+	if err != nil {
+		return err
+	}
+
+	result, err := c.service.GetReportsPaginated(context.Request().Context(), request)
+	if err != nil {
+		logger.Error().Err(err).Msg("get reports paginated failed")
+		return err
+	}
+	return context.JSON(http.StatusOK, result)
+}
+
+func (c *Controller) claimReport(context echo.Context) error {
+	reportID, err := webutils.CheckParamToInt(context, "id")
+	if err != nil {
+		return err
+	}
+
+	userID, err := utils.GetUserID(context)
+	if err != nil {
+		return err
+	}
+
+	err = c.service.ClaimReport(context.Request().Context(), reportID, userID)
+	if err != nil {
+		logger.Error().Err(err).Msg("claim report failed")
+		return err
+	}
+	return context.NoContent(http.StatusNoContent)
+}
+
+func (c *Controller) resolveReport(context echo.Context) error {
+	request := dto.ReportResolveRequest{}
+	err := context.Bind(&request)
+	if err != nil {
+		return err
+	}
+	err = context.Validate(request)
+	if err != nil {
+		return err
+	}
+	request.UserID, err = utils.GetUserID(context)
+	if err != nil {
+		return err
+	}
+
+	err = c.service.ResolveReport(context.Request().Context(), request)
+	if err != nil {
+		logger.Error().Err(err).Msg("resolve report failed")
+		return err
+	}
+	return context.NoContent(http.StatusNoContent)
+}
+
+func (c *Controller) getAuditTrail(context echo.Context) error {
+	request := dto.AuditSearchRequest{}
+	err := context.Bind(&request)
+	if err != nil {
+		return err
+	}
+	if request.Page == 0 {
+		request.Page = consts.BasicPaginationDefaultPageNumber
+	}
+
+	if request.PageSize == 0 {
+		request.PageSize = consts.PaginationDefaultPageSize
+	}
+	request.UserID, err = utils.GetUserID(context)
+	if err != nil {
+		return err
+	}
+
+	result, err := c.service.GetAuditTrail(context.Request().Context(), request)
+	if err != nil {
+		logger.Error().Err(err).Msg("get audit trail failed")
+		return err
+	}
+	return context.JSON(http.StatusOK, result)
+}
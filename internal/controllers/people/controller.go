@@ -0,0 +1,166 @@
+package people
+
+import (
+	"KTOnlinePlatform/internal/dto"
+	"KTOnlinePlatform/internal/models/consts"
+	"KTOnlinePlatform/pkg/logger"
+	"KTOnlinePlatform/pkg/middlewares"
+	"KTOnlinePlatform/pkg/utils"
+	"KTOnlinePlatform/pkg/webutils"
+	"context"
+	"github.com/labstack/echo/v4"
+	"net/http"
+)
+
+type service interface {
+	GetPeoplePaginated(ctx context.Context, request dto.PeopleSearchRequest) (dto.PeoplePaginated, error)
+	GetPerson(ctx context.Context, ID int) (dto.Person, error)
+	CreatePerson(ctx context.Context, request dto.PersonCreateRequest) error
+	UpdatePerson(ctx context.Context, request dto.PersonUpdateRequest) error
+	DeletePerson(ctx context.Context, personID int, userID int) error
+	GetFilmography(ctx context.Context, personID int) (dto.Filmography, error)
+}
+
+type Controller struct {
+	service service
+	middlewares.AuthMiddleware
+}
+
+func NewController(service service, middleware middlewares.AuthMiddleware) *Controller {
+	if service == nil {
+		panic(service)
+	}
+	if middleware == nil {
+		panic(middleware)
+	}
+	return &Controller{
+		service:        service,
+		AuthMiddleware: middleware,
+	}
+}
+
+func (c *Controller) RegisterRoutes(e *echo.Echo) {
+	g := e.Group("/api/v1/people", c.AuthMiddleware.Authenticated())
+
+	g.GET("", c.getPeoplePaginated)
+	g.GET("/:id", c.getPerson)
+	g.GET("/:id/filmography", c.getFilmography)
+	g.PUT("/:id", c.updatePerson)
+	g.DELETE("/:id", c.deletePerson)
+	g.POST("", c.createPerson)
+}
+
+func (c *Controller) getPeoplePaginated(context echo.Context) error {
+	request := dto.PeopleSearchRequest{}
+	err := context.Bind(&request)
+	if err != nil {
+		return err
+	}
+	if request.Page == 0 {
+		request.Page = consts.BasicPaginationDefaultPageNumber
+	}
+
+	if request.PageSize == 0 {
+		request.PageSize = consts.PaginationDefaultPageSize
+	}
+
+	result, err := c.service.GetPeoplePaginated(context.Request().Context(), request)
+	if err != nil {
+		logger.Error().Err(err).Msg("get people paginated failed")
+		return err
+	}
+	return context.JSON(http.StatusOK, result)
+}
+
+func (c *Controller) getPerson(context echo.Context) error {
+	personID, err := webutils.CheckParamToInt(context, "id")
+	if err != nil {
+		return err
+	}
+
+	result, err := c.service.GetPerson(context.Request().Context(), personID)
+	if err != nil {
+		logger.Error().Err(err).Msg("get person failed")
+		return err
+	}
+	return context.JSON(http.StatusOK, result)
+}
+
+func (c *Controller) getFilmography(context echo.Context) error {
+	personID, err := webutils.CheckParamToInt(context, "id")
+	if err != nil {
+		return err
+	}
+
+	result, err := c.service.GetFilmography(context.Request().Context(), personID)
+	if err != nil {
+		logger.Error().Err(err).Msg("get filmography failed")
+		return err
+	}
+	return context.JSON(http.StatusOK, result)
+}
+
+func (c *Controller) createPerson(context echo.Context) error {
+	request := dto.PersonCreateRequest{}
+	err := context.Bind(&request)
+	if err != nil {
+		return err
+	}
+	err = context.Validate(request)
+	if err != nil {
+		return err
+	}
+	request.UserID, err = utils.GetUserID(context)
+	if err != nil {
+		return err
+	}
+
+	err = c.service.CreatePerson(context.Request().Context(), request)
+	if err != nil {
+		logger.Error().Err(err).Msg("create person failed")
+		return err
+	}
+	return context.NoContent(http.StatusNoContent)
+}
+
+func (c *Controller) updatePerson(context echo.Context) error {
+	request := dto.PersonUpdateRequest{}
+	err := context.Bind(&request)
+	if err != nil {
+		return err
+	}
+	err = context.Validate(request)
+	if err != nil {
+		return err
+	}
+	request.UserID, err = utils.GetUserID(context)
+	if err != nil {
+		return err
+	}
+
+	err = c.service.UpdatePerson(context.Request().Context(), request)
+	if err != nil {
+		logger.Error().Err(err).Msg("update person failed")
+		return err
+	}
+	return context.NoContent(http.StatusNoContent)
+}
+
+func (c *Controller) deletePerson(context echo.Context) error {
+	personID, err := webutils.CheckParamToInt(context, "id")
+	if err != nil {
+		return err
+	}
+
+	userID, err := utils.GetUserID(context)
+	if err != nil {
+		return err
+	}
+
+	err = c.service.DeletePerson(context.Request().Context(), personID, userID)
+	if err != nil {
+		logger.Error().Err(err).Msg("delete person failed")
+		return err
+	}
+	return context.NoContent(http.StatusNoContent)
+}
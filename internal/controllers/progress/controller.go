@@ -0,0 +1,93 @@
+package progress
+
+import (
+	"KTOnlinePlatform/internal/dto"
+	"KTOnlinePlatform/internal/models/consts"
+	"KTOnlinePlatform/pkg/logger"
+	"KTOnlinePlatform/pkg/middlewares"
+	"KTOnlinePlatform/pkg/utils"
+	"context"
+	"github.com/labstack/echo/v4"
+	"net/http"
+)
+
+type service interface {
+	RecordProgress(ctx context.Context, request dto.ProgressRequest) error
+	GetHistory(ctx context.Context, request dto.HistorySearchRequest) (dto.ViewingHistory, error)
+}
+
+type Controller struct {
+	service service
+	middlewares.AuthMiddleware
+}
+
+func NewController(service service, middleware middlewares.AuthMiddleware) *Controller {
+	if service == nil {
+		panic(service)
+	}
+	if middleware == nil {
+		panic(middleware)
+	}
+	return &Controller{
+		service:        service,
+		AuthMiddleware: middleware,
+	}
+}
+
+func (c *Controller) RegisterRoutes(e *echo.Echo) {
+	films := e.Group("/api/v1/films", c.AuthMiddleware.Authenticated())
+	films.PUT("/:id/progress", c.recordProgress)
+
+	me := e.Group("/api/v1/me", c.AuthMiddleware.Authenticated())
+	me.GET("/history", c.getHistory)
+}
+
+func (c *Controller) recordProgress(context echo.Context) error {
+	request := dto.ProgressRequest{}
+	err := context.Bind(&request)
+	if err != nil {
+		return err
+	}
+	err = context.Validate(request)
+	if err != nil {
+		return err
+	}
+	request.UserID, err = utils.GetUserID(context)
+	if err != nil {
+		return err
+	}
+
+	err = c.service.RecordProgress(context.Request().Context(), request)
+	if err != nil {
+		logger.Error().Err(err).Msg("record progress failed")
+		return err
+	}
+	// the heartbeat may still be buffered
+	return context.NoContent(http.StatusAccepted)
+}
+
+func (c *Controller) getHistory(context echo.Context) error {
+	request := dto.HistorySearchRequest{}
+	err := context.Bind(&request)
+	if err != nil {
+		return err
+	}
+	if request.Page == 0 {
+		request.Page = consts.BasicPaginationDefaultPageNumber
+	}
+
+	if request.PageSize == 0 {
+		request.PageSize = consts.PaginationDefaultPageSize
+	}
+	request.UserID, err = utils.GetUserID(context)
+	if err != nil {
+		return err
+	}
+
+	result, err := c.service.GetHistory(context.Request().Context(), request)
+	if err != nil {
+		logger.Error().Err(err).Msg("get history failed")
+		return err
+	}
+	return context.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,84 @@
+package ratings
+
+import (
+	"KTOnlinePlatform/internal/dto"
+	"KTOnlinePlatform/pkg/logger"
+	"KTOnlinePlatform/pkg/middlewares"
+	"KTOnlinePlatform/pkg/utils"
+	"KTOnlinePlatform/pkg/webutils"
+	"context"
+	"github.com/labstack/echo/v4"
+	"net/http"
+)
+
+type service interface {
+	RateFilm(ctx context.Context, request dto.FilmRatingRequest) error
+	DeleteRating(ctx context.Context, filmID int, userID int) error
+}
+
+type Controller struct {
+	service service
+	middlewares.AuthMiddleware
+}
+
+func NewController(service service, middleware middlewares.AuthMiddleware) *Controller {
+	if service == nil {
+		panic(service)
+	}
+	if middleware == nil {
+		panic(middleware)
+	}
+	return &Controller{
+		service:        service,
+		AuthMiddleware: middleware,
+	}
+}
+
+func (c *Controller) RegisterRoutes(e *echo.Echo) {
+	g := e.Group("/api/v1/films", c.AuthMiddleware.Authenticated())
+
+	g.PUT("/:id/rating", c.rateFilm)
+	g.DELETE("/:id/rating", c.deleteRating)
+}
+
+func (c *Controller) rateFilm(context echo.Context) error {
+	request := dto.FilmRatingRequest{}
+	err := context.Bind(&request)
+	if err != nil {
+		return err
+	}
+	err = context.Validate(request)
+	if err != nil {
+		return err
+	}
+	request.UserID, err = utils.GetUserID(context)
+	if err != nil {
+		return err
+	}
+
+	err = c.service.RateFilm(context.Request().Context(), request)
+	if err != nil {
+		logger.Error().Err(err).Msg("rate film failed")
+		return err
+	}
+	return context.NoContent(http.StatusNoContent)
+}
+
+func (c *Controller) deleteRating(context echo.Context) error {
+	filmID, err := webutils.CheckParamToInt(context, "id")
+	if err != nil {
+		return err
+	}
+
+	userID, err := utils.GetUserID(context)
+	if err != nil {
+		return err
+	}
+
+	err = c.service.DeleteRating(context.Request().Context(), filmID, userID)
+	if err != nil {
+		logger.Error().Err(err).Msg("delete rating failed")
+		return err
+	}
+	return context.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,88 @@
+package recommendations
+
+import (
+	"KTOnlinePlatform/internal/dto"
+	"KTOnlinePlatform/internal/models/consts"
+	"KTOnlinePlatform/pkg/logger"
+	"KTOnlinePlatform/pkg/middlewares"
+	"KTOnlinePlatform/pkg/utils"
+	"context"
+	"github.com/labstack/echo/v4"
+	"net/http"
+)
+
+type service interface {
+	GetRecommendations(ctx context.Context, request dto.RecommendationsRequest) (dto.Recommendations, error)
+	GetSimilarFilms(ctx context.Context, request dto.SimilarFilmsRequest) (dto.SimilarFilms, error)
+}
+
+type Controller struct {
+	service service
+	middlewares.AuthMiddleware
+}
+
+func NewController(service service, middleware middlewares.AuthMiddleware) *Controller {
+	if service == nil {
+		panic(service)
+	}
+	if middleware == nil {
+		panic(middleware)
+	}
+	return &Controller{
+		service:        service,
+		AuthMiddleware: middleware,
+	}
+}
+
+func (c *Controller) RegisterRoutes(e *echo.Echo) {
+	films := e.Group("/api/v1/films", c.AuthMiddleware.Authenticated())
+	films.GET("/:id/similar", c.getSimilarFilms)
+
+	me := e.Group("/api/v1/me", c.AuthMiddleware.Authenticated())
+	me.GET("/recommendations", c.getRecommendations)
+}
+
+func (c *Controller) getRecommendations(context echo.Context) error {
+	request := dto.RecommendationsRequest{}
+	err := context.Bind(&request)
+	if err != nil {
+		return err
+	}
+	if request.Page == 0 {
+		request.Page = consts.BasicPaginationDefaultPageNumber
+	}
+
+	if request.PageSize == 0 {
+		request.PageSize = consts.PaginationDefaultPageSize
+	}
+	request.UserID, err = utils.GetUserID(context)
+	if err != nil {
+		return err
+	}
+
+	result, err := c.service.GetRecommendations(context.Request().Context(), request)
+	if err != nil {
+		logger.Error().Err(err).Msg("get recommendations failed")
+		return err
+	}
+	return context.JSON(http.StatusOK, result)
+}
+
+func (c *Controller) getSimilarFilms(context echo.Context) error {
+	request := dto.SimilarFilmsRequest{}
+	err := context.Bind(&request)
+	if err != nil {
+		return err
+	}
+	err = context.Validate(request)
+	if err != nil {
+		return err
+	}
+
+	result, err := c.service.GetSimilarFilms(context.Request().Context(), request)
+	if err != nil {
+		logger.Error().Err(err).Msg("get similar films failed")
+		return err
+	}
+	return context.JSON(http.StatusOK, result)
+}
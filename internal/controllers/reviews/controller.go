@@ -0,0 +1,303 @@
+package reviews
+
+import (
+	"KTOnlinePlatform/internal/dto"
+	"KTOnlinePlatform/internal/models/consts"
+	"KTOnlinePlatform/pkg/logger"
+	"KTOnlinePlatform/pkg/middlewares"
+	"KTOnlinePlatform/pkg/utils"
+	"KTOnlinePlatform/pkg/webutils"
+	"context"
+	"github.com/labstack/echo/v4"
+	"net/http"
+)
+
+type service interface {
+	GetReviewsPaginated(ctx context.Context, request dto.ReviewSearchRequest) (dto.ReviewsPaginated, error)
+	GetReview(ctx context.Context, filmID int, reviewID int) (dto.Review, error)
+	CreateReview(ctx context.Context, request dto.ReviewCreateRequest) error
+	UpdateReview(ctx context.Context, request dto.ReviewUpdateRequest) error
+	DeleteReview(ctx context.Context, filmID int, reviewID int, userID int) error
+	LikeReview(ctx context.Context, filmID int, reviewID int, userID int) error
+	UnlikeReview(ctx context.Context, filmID int, reviewID int, userID int) error
+	GetCommentsPaginated(ctx context.Context, request dto.CommentSearchRequest) (dto.CommentsPaginated, error)
+	CreateComment(ctx context.Context, request dto.CommentCreateRequest) error
+	UpdateComment(ctx context.Context, request dto.CommentUpdateRequest) error
+	DeleteComment(ctx context.Context, filmID int, reviewID int, commentID int, userID int) error
+}
+
+type Controller struct {
+	service service
+	middlewares.AuthMiddleware
+}
+
+func NewController(service service, middleware middlewares.AuthMiddleware) *Controller {
+	if service == nil {
+		panic(service)
+	}
+	if middleware == nil {
+		panic(middleware)
+	}
+	return &Controller{
+		service:        service,
+		AuthMiddleware: middleware,
+	}
+}
+
+func (c *Controller) RegisterRoutes(e *echo.Echo) {
+	g := e.Group("/api/v1/films/:id/reviews", c.AuthMiddleware.Authenticated())
+
+	g.GET("", c.getReviewsPaginated)
+	g.POST("", c.createReview)
+	g.GET("/:reviewId", c.getReview)
+	g.PUT("/:reviewId", c.updateReview)
+	g.DELETE("/:reviewId", c.deleteReview)
+	g.PUT("/:reviewId/like", c.likeReview)
+	g.DELETE("/:reviewId/like", c.unlikeReview)
+	g.GET("/:reviewId/comments", c.getCommentsPaginated)
+	g.POST("/:reviewId/comments", c.createComment)
+	g.PUT("/:reviewId/comments/:commentId", c.updateComment)
+	g.DELETE("/:reviewId/comments/:commentId", c.deleteComment)
+}
+
+func (c *Controller) getReviewsPaginated(context echo.Context) error {
+	request := dto.ReviewSearchRequest{}
+	err := context.Bind(&request)
+	if err != nil {
+		return err
+	}
+	if request.Page == 0 {
+		request.Page = consts.BasicPaginationDefaultPageNumber
+	}
+
+	if request.PageSize == 0 {
+		request.PageSize = consts.PaginationDefaultPageSize
+	}
+
+	result, err := c.service.GetReviewsPaginated(context.Request().Context(), request)
+	if err != nil {
+		logger.Error().Err(err).Msg("get reviews paginated failed")
+		return err
+	}
+	return context.JSON(http.StatusOK, result)
+}
+
+func (c *Controller) getReview(context echo.Context) error {
+	filmID, reviewID, err := reviewParams(context)
+	if err != nil {
+		return err
+	}
+
+	result, err := c.service.GetReview(context.Request().Context(), filmID, reviewID)
+	if err != nil {
+		logger.Error().Err(err).Msg("get review failed")
+		return err
+	}
+	return context.JSON(http.StatusOK, result)
+}
+
+func (c *Controller) createReview(context echo.Context) error {
+	request := dto.ReviewCreateRequest{}
+	err := context.Bind(&request)
+	if err != nil {
+		return err
+	}
+	err = context.Validate(request)
+	if err != nil {
+		return err
+	}
+	request.UserID, err = utils.GetUserID(context)
+	if err != nil {
+		return err
+	}
+
+	err = c.service.CreateReview(context.Request().Context(), request)
+	if err != nil {
+		logger.Error().Err(err).Msg("create review failed")
+		return err
+	}
+	return context.NoContent(http.StatusNoContent)
+}
+
+func (c *Controller) updateReview(context echo.Context) error {
+	request := dto.ReviewUpdateRequest{}
+	err := context.Bind(&request)
+	if err != nil {
+		return err
+	}
+	err = context.Validate(request)
+	if err != nil {
+		return err
+	}
+	request.UserID, err = utils.GetUserID(context)
+	if err != nil {
+		return err
+	}
+
+	err = c.service.UpdateReview(context.Request().Context(), request)
+	if err != nil {
+		logger.Error().Err(err).Msg("update review failed")
+		return err
+	}
+	return context.NoContent(http.StatusNoContent)
+}
+
+func (c *Controller) deleteReview(context echo.Context) error {
+	filmID, reviewID, err := reviewParams(context)
+	if err != nil {
+		return err
+	}
+
+	userID, err := utils.GetUserID(context)
+	if err != nil {
+		return err
+	}
+
+	err = c.service.DeleteReview(context.Request().Context(), filmID, reviewID, userID)
+	if err != nil {
+		logger.Error().Err(err).Msg("delete review failed")
+		return err
+	}
+	return context.NoContent(http.StatusNoContent)
+}
+
+func (c *Controller) likeReview(context echo.Context) error {
+	filmID, reviewID, err := reviewParams(context)
+	if err != nil {
+		return err
+	}
+
+	userID, err := utils.GetUserID(context)
+	if err != nil {
+		return err
+	}
+
+	err = c.service.LikeReview(context.Request().Context(), filmID, reviewID, userID)
+	if err != nil {
+		logger.Error().Err(err).Msg("like review failed")
+		return err
+	}
+	return context.NoContent(http.StatusNoContent)
+}
+
+func (c *Controller) unlikeReview(context echo.Context) error {
+	filmID, reviewID, err := reviewParams(context)
+	if err != nil {
+		return err
+	}
+
+	userID, err := utils.GetUserID(context)
+	if err != nil {
+		return err
+	}
+
+	err = c.service.UnlikeReview(context.Request().Context(), filmID, reviewID, userID)
+	if err != nil {
+		logger.Error().Err(err).Msg("unlike review failed")
+		return err
+	}
+	return context.NoContent(http.StatusNoContent)
+}
+
+func (c *Controller) getCommentsPaginated(context echo.Context) error {
+	request := dto.CommentSearchRequest{}
+	err := context.Bind(&request)
+	if err != nil {
+		return err
+	}
+	if request.Page == 0 {
+		request.Page = consts.BasicPaginationDefaultPageNumber
+	}
+
+	if request.PageSize == 0 {
+		request.PageSize = consts.PaginationDefaultPageSize
+	}
+
+	result, err := c.service.GetCommentsPaginated(context.Request().Context(), request)
+	if err != nil {
+		logger.Error().Err(err).Msg("get comments paginated failed")
+		return err
+	}
+	return context.JSON(http.StatusOK, result)
+}
+
+func (c *Controller) createComment(context echo.Context) error {
+	request := dto.CommentCreateRequest{}
+	err := context.Bind(&request)
+	if err != nil {
+		return err
+	}
+	err = context.Validate(request)
+	if err != nil {
+		return err
+	}
+	request.UserID, err = utils.GetUserID(context)
+	if err != nil {
+		return err
+	}
+
+	err = c.service.CreateComment(context.Request().Context(), request)
+	if err != nil {
+		logger.Error().Err(err).Msg("create comment failed")
+		return err
+	}
+	return context.NoContent(http.StatusNoContent)
+}
+
+func (c *Controller) updateComment(context echo.Context) error {
+	request := dto.CommentUpdateRequest{}
+	err := context.Bind(&request)
+	if err != nil {
+		return err
+	}
+	err = context.Validate(request)
+	if err != nil {
+		return err
+	}
+	request.UserID, err = utils.GetUserID(context)
+	if err != nil {
+		return err
+	}
+
+	err = c.service.UpdateComment(context.Request().Context(), request)
+	if err != nil {
+		logger.Error().Err(err).Msg("update comment failed")
+		return err
+	}
+	return context.NoContent(http.StatusNoContent)
+}
+
+func (c *Controller) deleteComment(context echo.Context) error {
+	filmID, reviewID, err := reviewParams(context)
+	if err != nil {
+		return err
+	}
+	commentID, err := webutils.CheckParamToInt(context, "commentId")
+	if err != nil {
+		return err
+	}
+
+	userID, err := utils.GetUserID(context)
+	if err != nil {
+		return err
+	}
+
+	err = c.service.DeleteComment(context.Request().Context(), filmID, reviewID, commentID, userID)
+	if err != nil {
+		logger.Error().Err(err).Msg("delete comment failed")
+		return err
+	}
+	return context.NoContent(http.StatusNoContent)
+}
+
+func reviewParams(context echo.Context) (filmID int, reviewID int, err error) {
+	filmID, err = webutils.CheckParamToInt(context, "id")
+	if err != nil {
+		return 0, 0, err
+	}
+	reviewID, err = webutils.CheckParamToInt(context, "reviewId")
+	if err != nil {
+		return 0, 0, err
+	}
+	return filmID, reviewID, nil
+}
@@ -0,0 +1,109 @@
+package trending
+
+import (
+	"KTOnlinePlatform/internal/dto"
+	"KTOnlinePlatform/internal/models/consts"
+	"KTOnlinePlatform/pkg/logger"
+	"KTOnlinePlatform/pkg/middlewares"
+	"context"
+	"github.com/labstack/echo/v4"
+	"net/http"
+)
+
+type service interface {
+	GetTrending(ctx context.Context, request dto.TrendingRequest) (dto.FilmsPaginated, error)
+	GetPopular(ctx context.Context, request dto.FeedRequest) (dto.FilmsPaginated, error)
+	GetNewReleases(ctx context.Context, request dto.FeedRequest) (dto.FilmsPaginated, error)
+}
+
+type Controller struct {
+	service service
+	middlewares.AuthMiddleware
+}
+
+func NewController(service service, middleware middlewares.AuthMiddleware) *Controller {
+	if service == nil {
+		panic(service)
+	}
+	if middleware == nil {
+		panic(middleware)
+	}
+	return &Controller{
+		service:        service,
+		AuthMiddleware: middleware,
+	}
+}
+
+func (c *Controller) RegisterRoutes(e *echo.Echo) {
+	g := e.Group("/api/v1/films", c.AuthMiddleware.Authenticated())
+
+	g.GET("/trending", c.getTrending)
+	g.GET("/popular", c.getPopular)
+	g.GET("/new", c.getNewReleases)
+}
+
+func (c *Controller) getTrending(context echo.Context) error {
+	request := dto.TrendingRequest{}
+	err := context.Bind(&request)
+	if err != nil {
+		return err
+	}
+	if request.Page == 0 {
+		request.Page = consts.BasicPaginationDefaultPageNumber
+	}
+
+	if request.PageSize == 0 {
+		request.PageSize = consts.PaginationDefaultPageSize
+	}
+
+	result, err := c.service.GetTrending(context.Request().Context(), request)
+	if err != nil {
+		logger.Error().Err(err).Msg("get trending failed")
+		return err
+	}
+	return context.JSON(http.StatusOK, result)
+}
+
+func (c *Controller) getPopular(context echo.Context) error {
+	request, err := bindFeedRequest(context)
+	if err != nil {
+		return err
+	}
+
+	result, err := c.service.GetPopular(context.Request().Context(), request)
+	if err != nil {
+		logger.Error().Err(err).Msg("get popular failed")
+		return err
+	}
+	return context.JSON(http.StatusOK, result)
+}
+
+func (c *Controller) getNewReleases(context echo.Context) error {
+	request, err := bindFeedRequest(context)
+	if err != nil {
+		return err
+	}
+
+	result, err := c.service.GetNewReleases(context.Request().Context(), request)
+	if err != nil {
+		logger.Error().Err(err).Msg("get new releases failed")
+		return err
+	}
+	return context.JSON(http.StatusOK, result)
+}
+
+func bindFeedRequest(context echo.Context) (dto.FeedRequest, error) {
+	request := dto.FeedRequest{}
+	err := context.Bind(&request)
+	if err != nil {
+		return dto.FeedRequest{}, err
+	}
+	if request.Page == 0 {
+		request.Page = consts.BasicPaginationDefaultPageNumber
+	}
+
+	if request.PageSize == 0 {
+		request.PageSize = consts.PaginationDefaultPageSize
+	}
+	return request, nil
+}
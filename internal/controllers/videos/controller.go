@@ -0,0 +1,160 @@
+package videos
+
+import (
+	"KTOnlinePlatform/internal/dto"
+	"KTOnlinePlatform/internal/models/consts"
+	"KTOnlinePlatform/internal/models/kterrors"
+	"KTOnlinePlatform/pkg/customerror"
+	"KTOnlinePlatform/pkg/logger"
+	"KTOnlinePlatform/pkg/middlewares"
+	"KTOnlinePlatform/pkg/utils"
+	"KTOnlinePlatform/pkg/webutils"
+	"context"
+	"fmt"
+	"github.com/labstack/echo/v4"
+	"net/http"
+)
+
+const (
+	jobLocationFormat = "/api/v1/jobs/%d"
+	// the URLs of a playlist expire, a cached playlist would outlive them
+	playlistCacheControl = "private, no-store"
+	// room for the multipart envelope around the video
+	multipartOverhead = 1 << 20
+)
+
+type service interface {
+	UploadVideo(ctx context.Context, request dto.VideoUploadRequest) (dto.Job, error)
+	GetVideo(ctx context.Context, request dto.VideoRequest) (dto.VideoAsset, error)
+	GetMasterPlaylist(ctx context.Context, request dto.StreamRequest) (string, error)
+	GetRenditionPlaylist(ctx context.Context, request dto.StreamRequest) (string, error)
+}
+
+type Controller struct {
+	service service
+	middlewares.AuthMiddleware
+}
+
+func NewController(service service, middleware middlewares.AuthMiddleware) *Controller {
+	if service == nil {
+		panic(service)
+	}
+	if middleware == nil {
+		panic(middleware)
+	}
+	return &Controller{
+		service:        service,
+		AuthMiddleware: middleware,
+	}
+}
+
+func (c *Controller) RegisterRoutes(e *echo.Echo) {
+	g := e.Group("/api/v1/films", c.AuthMiddleware.Authenticated())
+
+	g.POST("/:id/video", c.uploadVideo)
+	g.GET("/:id/video", c.getVideo)
+	g.GET("/:id/stream", c.getMasterPlaylist)
+	// the players fetch the rendition playlists without the token, from the URLs signed in the master
+	e.GET("/api/v1/films/:id/stream/:rendition", c.getRenditionPlaylist)
+}
+
+func (c *Controller) uploadVideo(context echo.Context) error {
+	filmID, err := webutils.CheckParamToInt(context, "id")
+	if err != nil {
+		return err
+	}
+	context.Request().Body = http.MaxBytesReader(context.Response(), context.Request().Body, consts.VideoMaxSize+multipartOverhead)
+	file, err := context.FormFile(consts.VideoFormField)
+	if err != nil {
+		return customerror.NewCustomError(kterrors.VideoRequiredError)
+	}
+	src, err := file.Open()
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	request := dto.VideoUploadRequest{
+		FilmID: filmID,
+		Source: src,
+		Size:   file.Size,
+	}
+	request.UserID, err = utils.GetUserID(context)
+	if err != nil {
+		return err
+	}
+
+	job, err := c.service.UploadVideo(context.Request().Context(), request)
+	if err != nil {
+		logger.Error().Err(err).Msg("upload video failed")
+		return err
+	}
+	context.Response().Header().Set(echo.HeaderLocation, fmt.Sprintf(jobLocationFormat, job.ID))
+	return context.JSON(http.StatusAccepted, job)
+}
+
+func (c *Controller) getVideo(context echo.Context) error {
+	request := dto.VideoRequest{}
+	err := context.Bind(&request)
+	if err != nil {
+		return err
+	}
+	err = context.Validate(request)
+	if err != nil {
+		return err
+	}
+	request.UserID, err = utils.GetUserID(context)
+	if err != nil {
+		return err
+	}
+
+	result, err := c.service.GetVideo(context.Request().Context(), request)
+	if err != nil {
+		logger.Error().Err(err).Msg("get video failed")
+		return err
+	}
+	return context.JSON(http.StatusOK, result)
+}
+
+func (c *Controller) getMasterPlaylist(context echo.Context) error {
+	request, err := bindStreamRequest(context)
+	if err != nil {
+		return err
+	}
+
+	playlist, err := c.service.GetMasterPlaylist(context.Request().Context(), request)
+	if err != nil {
+		logger.Error().Err(err).Msg("get master playlist failed")
+		return err
+	}
+	context.Response().Header().Set(echo.HeaderCacheControl, playlistCacheControl)
+	return context.Blob(http.StatusOK, consts.HLSContentType, []byte(playlist))
+}
+
+func (c *Controller) getRenditionPlaylist(context echo.Context) error {
+	request, err := bindStreamRequest(context)
+	if err != nil {
+		return err
+	}
+	request.Query = context.QueryParams()
+
+	playlist, err := c.service.GetRenditionPlaylist(context.Request().Context(), request)
+	if err != nil {
+		logger.Error().Err(err).Msg("get rendition playlist failed")
+		return err
+	}
+	context.Response().Header().Set(echo.HeaderCacheControl, playlistCacheControl)
+	return context.Blob(http.StatusOK, consts.HLSContentType, []byte(playlist))
+}
+
+func bindStreamRequest(context echo.Context) (dto.StreamRequest, error) {
+	request := dto.StreamRequest{}
+	err := context.Bind(&request)
+	if err != nil {
+		return dto.StreamRequest{}, err
+	}
+	err = context.Validate(request)
+	if err != nil {
+		return dto.StreamRequest{}, err
+	}
+	return request, nil
+}
@@ -0,0 +1,128 @@
+package docs
+
+import (
+	"KTOnlinePlatform/internal/dto"
+	"KTOnlinePlatform/internal/models/consts"
+	"KTOnlinePlatform/pkg/database/entities/entitiescustom"
+	"KTOnlinePlatform/pkg/openapi"
+	"github.com/labstack/echo/v4"
+	"net/http"
+)
+
+const (
+	DocumentPath = "/openapi.json"
+	UIPath       = "/docs"
+
+	tagAuthentication  = "authentication"
+	tagFilms           = "films"
+	tagMedia           = "media"
+	tagPeople          = "people"
+	tagReviews         = "reviews"
+	tagModeration      = "moderation"
+	tagLists           = "lists"
+	tagHistory         = "history"
+	tagRecommendations = "recommendations"
+	tagJobs            = "jobs"
+	tagGraphQL         = "graphql"
+	tagDocs            = "docs"
+)
+
+// NewGenerator describes the types the API does not send as their fields
+func NewGenerator() *openapi.Generator {
+	generator := openapi.NewGenerator(openapi.Info{
+		Title:       "KT Online Platform API",
+		Version:     "1.0.0",
+		Description: "Films, people, reviews, lists and streaming. Errors are RFC 7807 problems.",
+	})
+	generator.Register(entitiescustom.ReleaseDate{}, openapi.Schema{Type: "string", Format: "date"})
+	return generator
+}
+
+// Document describes the routes registered in echo
+func Document(registered []*echo.Route) openapi.Document {
+	return NewGenerator().Document(registered, Routes)
+}
+
+// Routes documents every route of the API, a test fails when one is registered without its Route
+var Routes = []openapi.Route{
+	{Method: http.MethodPut, Path: "/api/v1/login", Tag: tagAuthentication, Summary: "Log in and get the JWT tokens", Public: true, Request: dto.Login{}, Response: dto.JWTTokens{}},
+	{Method: http.MethodPost, Path: "/api/v1/refresh-token", Tag: tagAuthentication, Summary: "Exchange a refresh token for new JWT tokens", Public: true, Request: dto.RefreshRequest{}, Response: dto.JWTTokens{}},
+	{Method: http.MethodPost, Path: "/api/v1/register", Tag: tagAuthentication, Summary: "Create a user", Public: true, Request: dto.CreateUserRequest{}},
+
+	{Method: http.MethodGet, Path: "/api/v1/films", Tag: tagFilms, Summary: "Search films, by page or by cursor", Request: dto.FilmSearchRequest{}, Response: dto.FilmsPaginated{}},
+	{Method: http.MethodPost, Path: "/api/v1/films", Tag: tagFilms, Summary: "Create a film", Request: dto.FilmCreateRequest{}},
+	{Method: http.MethodGet, Path: "/api/v1/films/export", Tag: tagFilms, Summary: "Export the films matching the filters", Request: dto.FilmExportRequest{}, ResponseTypes: []string{consts.CSVContentType, consts.JSONContentType, consts.NDJSONContentType}},
+	{Method: http.MethodPost, Path: "/api/v1/films/import", Tag: tagFilms, Summary: "Import films in a background job", Parameters: []openapi.Parameter{
+		{Name: "onConflict", In: "query", Schema: &openapi.Schema{Type: "string", Enum: []string{consts.ImportConflictSkip, consts.ImportConflictUpdate, consts.ImportConflictFail}}},
+	}, RequestTypes: []string{consts.CSVContentType, consts.JSONContentType, consts.NDJSONContentType}, Status: http.StatusAccepted, Response: dto.Job{}},
+	{Method: http.MethodGet, Path: "/api/v1/films/trending", Tag: tagFilms, Summary: "Get the trending films of a window", Request: dto.TrendingRequest{}, Response: dto.FilmsPaginated{}},
+	{Method: http.MethodGet, Path: "/api/v1/films/popular", Tag: tagFilms, Summary: "Get the films ranked by engagement since the beginning", Request: dto.FeedRequest{}, Response: dto.FilmsPaginated{}},
+	{Method: http.MethodGet, Path: "/api/v1/films/new", Tag: tagFilms, Summary: "Get the latest released films", Request: dto.FeedRequest{}, Response: dto.FilmsPaginated{}},
+	{Method: http.MethodGet, Path: "/api/v1/films/:id", Tag: tagFilms, Summary: "Get a film in the first accepted language", Response: dto.FilmDetail{}},
+	{Method: http.MethodPut, Path: "/api/v1/films/:id", Tag: tagFilms, Summary: "Update a film", Request: dto.FilmUpdateRequest{}},
+	{Method: http.MethodPatch, Path: "/api/v1/films/:id", Tag: tagFilms, Summary: "Patch a film with a JSON Merge Patch or a JSON Patch", RequestTypes: []string{consts.MergePatchContentType, consts.JSONPatchContentType}},
+	{Method: http.MethodDelete, Path: "/api/v1/films/:id", Tag: tagFilms, Summary: "Delete a film"},
+	{Method: http.MethodPost, Path: "/api/v1/films/:id/credits", Tag: tagFilms, Summary: "Credit a person in a film", Request: dto.FilmCreditCreateRequest{}},
+	{Method: http.MethodDelete, Path: "/api/v1/films/:id/credits/:creditId", Tag: tagFilms, Summary: "Remove a credit from a film"},
+	{Method: http.MethodPost, Path: "/api/v1/films/:id/poster", Tag: tagFilms, Summary: "Upload the poster of a film", Upload: consts.PosterFormField, Response: dto.Poster{}},
+	{Method: http.MethodPost, Path: "/api/v1/films/:id/subtitles", Tag: tagFilms, Summary: "Upload an SRT or WebVTT subtitle track", Upload: consts.SubtitlesFormField, FormFields: []string{"language", "label"}, Response: dto.SubtitleTrack{}},
+	{Method: http.MethodDelete, Path: "/api/v1/films/:id/subtitles/:language", Tag: tagFilms, Summary: "Delete a subtitle track"},
+	{Method: http.MethodGet, Path: "/api/v1/films/:id/translations", Tag: tagFilms, Summary: "List the translations of a film", Response: []dto.FilmTranslation{}},
+	{Method: http.MethodPut, Path: "/api/v1/films/:id/translations/:locale", Tag: tagFilms, Summary: "Save the translation of a film in a locale", Request: dto.FilmTranslationRequest{}, Response: dto.FilmTranslation{}},
+	{Method: http.MethodDelete, Path: "/api/v1/films/:id/translations/:locale", Tag: tagFilms, Summary: "Delete the translation of a film in a locale"},
+	{Method: http.MethodPut, Path: "/api/v1/films/:id/rating", Tag: tagFilms, Summary: "Rate a film", Request: dto.FilmRatingRequest{}},
+	{Method: http.MethodDelete, Path: "/api/v1/films/:id/rating", Tag: tagFilms, Summary: "Delete the rating of a film"},
+	{Method: http.MethodGet, Path: "/api/v1/films/:id/similar", Tag: tagRecommendations, Summary: "Get the films similar to a film", Request: dto.SimilarFilmsRequest{}, Response: dto.SimilarFilms{}},
+	{Method: http.MethodPut, Path: "/api/v1/films/:id/progress", Tag: tagHistory, Summary: "Record the playback position of a film", Request: dto.ProgressRequest{}, Status: http.StatusAccepted},
+	{Method: http.MethodPost, Path: "/api/v1/films/:id/video", Tag: tagFilms, Summary: "Upload the video of a film and transcode it in a background job", Upload: consts.VideoFormField, Status: http.StatusAccepted, Response: dto.Job{}},
+	{Method: http.MethodGet, Path: "/api/v1/films/:id/video", Tag: tagFilms, Summary: "Follow the transcoding of the video of a film", Request: dto.VideoRequest{}, Response: dto.VideoAsset{}},
+	{Method: http.MethodGet, Path: "/api/v1/films/:id/stream", Tag: tagMedia, Summary: "Get the HLS master playlist of a film", Request: dto.StreamRequest{}, ResponseTypes: []string{consts.HLSContentType}},
+	{Method: http.MethodGet, Path: "/api/v1/films/:id/stream/:rendition", Tag: tagMedia, Summary: "Get the HLS playlist of a rendition from its signed URL in the master playlist, with signed segment URLs", Public: true, Request: dto.StreamRequest{}, ResponseTypes: []string{consts.HLSContentType}},
+
+	{Method: http.MethodGet, Path: "/api/v1/films/:id/reviews", Tag: tagReviews, Summary: "List the reviews of a film", Request: dto.ReviewSearchRequest{}, Response: dto.ReviewsPaginated{}},
+	{Method: http.MethodPost, Path: "/api/v1/films/:id/reviews", Tag: tagReviews, Summary: "Review a film", Request: dto.ReviewCreateRequest{}},
+	{Method: http.MethodGet, Path: "/api/v1/films/:id/reviews/:reviewId", Tag: tagReviews, Summary: "Get a review", Response: dto.Review{}},
+	{Method: http.MethodPut, Path: "/api/v1/films/:id/reviews/:reviewId", Tag: tagReviews, Summary: "Update a review", Request: dto.ReviewUpdateRequest{}},
+	{Method: http.MethodDelete, Path: "/api/v1/films/:id/reviews/:reviewId", Tag: tagReviews, Summary: "Delete a review"},
+	{Method: http.MethodPut, Path: "/api/v1/films/:id/reviews/:reviewId/like", Tag: tagReviews, Summary: "Like a review"},
+	{Method: http.MethodDelete, Path: "/api/v1/films/:id/reviews/:reviewId/like", Tag: tagReviews, Summary: "Remove the like of a review"},
+	{Method: http.MethodGet, Path: "/api/v1/films/:id/reviews/:reviewId/comments", Tag: tagReviews, Summary: "List the comments of a review", Request: dto.CommentSearchRequest{}, Response: dto.CommentsPaginated{}},
+	{Method: http.MethodPost, Path: "/api/v1/films/:id/reviews/:reviewId/comments", Tag: tagReviews, Summary: "Comment a review", Request: dto.CommentCreateRequest{}},
+	{Method: http.MethodPut, Path: "/api/v1/films/:id/reviews/:reviewId/comments/:commentId", Tag: tagReviews, Summary: "Update a comment", Request: dto.CommentUpdateRequest{}},
+	{Method: http.MethodDelete, Path: "/api/v1/films/:id/reviews/:reviewId/comments/:commentId", Tag: tagReviews, Summary: "Delete a comment"},
+
+	{Method: http.MethodGet, Path: "/api/v1/people", Tag: tagPeople, Summary: "Search people", Request: dto.PeopleSearchRequest{}, Response: dto.PeoplePaginated{}},
+	{Method: http.MethodPost, Path: "/api/v1/people", Tag: tagPeople, Summary: "Create a person", Request: dto.PersonCreateRequest{}},
+	{Method: http.MethodGet, Path: "/api/v1/people/:id", Tag: tagPeople, Summary: "Get a person", Response: dto.Person{}},
+	{Method: http.MethodPut, Path: "/api/v1/people/:id", Tag: tagPeople, Summary: "Update a person", Request: dto.PersonUpdateRequest{}},
+	{Method: http.MethodDelete, Path: "/api/v1/people/:id", Tag: tagPeople, Summary: "Delete a person"},
+	{Method: http.MethodGet, Path: "/api/v1/people/:id/filmography", Tag: tagPeople, Summary: "Get the films of a person by role", Response: dto.Filmography{}},
+
+	{Method: http.MethodPost, Path: "/api/v1/reports", Tag: tagModeration, Summary: "Report a film, a review or a user", Request: dto.ReportCreateRequest{}},
+	{Method: http.MethodGet, Path: "/api/v1/moderation/reports", Tag: tagModeration, Summary: "Get the moderation queue", Request: dto.ReportSearchRequest{}, Response: dto.ReportsPaginated{}},
+	{Method: http.MethodPost, Path: "/api/v1/moderation/reports/:id/claim", Tag: tagModeration, Summary: "Claim an open report"},
+	{Method: http.MethodPost, Path: "/api/v1/moderation/reports/:id/resolve", Tag: tagModeration, Summary: "Resolve a claimed report", Request: dto.ReportResolveRequest{}},
+	{Method: http.MethodGet, Path: "/api/v1/moderation/audit", Tag: tagModeration, Summary: "Get the audit trail of the moderators", Request: dto.AuditSearchRequest{}, Response: dto.AuditTrail{}},
+
+	{Method: http.MethodGet, Path: "/api/v1/me/lists", Tag: tagLists, Summary: "Get the lists of the user", Response: []dto.UserList{}},
+	{Method: http.MethodPost, Path: "/api/v1/me/lists", Tag: tagLists, Summary: "Create a list", Request: dto.UserListCreateRequest{}},
+	{Method: http.MethodGet, Path: "/api/v1/me/lists/:id", Tag: tagLists, Summary: "Get a list of the user", Response: dto.UserList{}},
+	{Method: http.MethodPut, Path: "/api/v1/me/lists/:id", Tag: tagLists, Summary: "Update a list", Request: dto.UserListUpdateRequest{}},
+	{Method: http.MethodDelete, Path: "/api/v1/me/lists/:id", Tag: tagLists, Summary: "Delete a list"},
+	{Method: http.MethodPost, Path: "/api/v1/me/lists/:id/entries", Tag: tagLists, Summary: "Add a film to a list", Request: dto.UserListEntryRequest{}},
+	{Method: http.MethodPut, Path: "/api/v1/me/lists/:id/entries", Tag: tagLists, Summary: "Reorder the films of a list", Request: dto.UserListOrderRequest{}},
+	{Method: http.MethodDelete, Path: "/api/v1/me/lists/:id/entries/:filmId", Tag: tagLists, Summary: "Remove a film from a list"},
+	{Method: http.MethodGet, Path: "/api/v1/lists/:slug", Tag: tagLists, Summary: "Get a public list from its share link", Public: true, Response: dto.UserList{}},
+	{Method: http.MethodGet, Path: "/api/v1/me/history", Tag: tagHistory, Summary: "Get the viewing history of the user", Request: dto.HistorySearchRequest{}, Response: dto.ViewingHistory{}},
+	{Method: http.MethodGet, Path: "/api/v1/me/recommendations", Tag: tagRecommendations, Summary: "Get the recommendations of the user", Request: dto.RecommendationsRequest{}, Response: dto.Recommendations{}},
+
+	{Method: http.MethodGet, Path: "/api/v1/jobs/:id", Tag: tagJobs, Summary: "Follow a background job", Response: dto.Job{}},
+
+	{Method: http.MethodGet, Path: consts.MediaPath + "/*", Tag: tagMedia, Summary: "Download a media from its signed URL, with Range support", Public: true, ResponseTypes: []string{"application/octet-stream"}},
+	{Method: http.MethodHead, Path: consts.MediaPath + "/*", Tag: tagMedia, Summary: "Get the size and type of a media from its signed URL", Public: true, Status: http.StatusOK},
+
+	{Method: http.MethodPost, Path: "/graphql", Tag: tagGraphQL, Summary: "Run a GraphQL query over the films, the lists and the current user", Request: dto.GraphQLRequest{}, Response: dto.GraphQLResponse{}},
+
+	{Method: http.MethodGet, Path: DocumentPath, Tag: tagDocs, Summary: "Get this OpenAPI document", Public: true, ResponseTypes: []string{"application/json"}},
+	{Method: http.MethodGet, Path: UIPath, Tag: tagDocs, Summary: "Browse this OpenAPI document", Public: true, ResponseTypes: []string{"text/html"}},
+}
@@ -0,0 +1,100 @@
+package docs_test
+
+import (
+	authcontroller "KTOnlinePlatform/internal/controllers/authentication"
+	docscontroller "KTOnlinePlatform/internal/controllers/docs"
+	filmscontroller "KTOnlinePlatform/internal/controllers/films"
+	filmsimportcontroller "KTOnlinePlatform/internal/controllers/filmsimport"
+	graphcontroller "KTOnlinePlatform/internal/controllers/graph"
+	jobscontroller "KTOnlinePlatform/internal/controllers/jobs"
+	listscontroller "KTOnlinePlatform/internal/controllers/lists"
+	mediacontroller "KTOnlinePlatform/internal/controllers/media"
+	moderationcontroller "KTOnlinePlatform/internal/controllers/moderation"
+	peoplecontroller "KTOnlinePlatform/internal/controllers/people"
+	progresscontroller "KTOnlinePlatform/internal/controllers/progress"
+	ratingscontroller "KTOnlinePlatform/internal/controllers/ratings"
+	recommendationscontroller "KTOnlinePlatform/internal/controllers/recommendations"
+	reviewscontroller "KTOnlinePlatform/internal/controllers/reviews"
+	trendingcontroller "KTOnlinePlatform/internal/controllers/trending"
+	videoscontroller "KTOnlinePlatform/internal/controllers/videos"
+	"KTOnlinePlatform/internal/docs"
+	"KTOnlinePlatform/pkg/middlewares"
+	"KTOnlinePlatform/pkg/openapi"
+	"context"
+	"encoding/json"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// activeUsers is never suspended
+type activeUsers struct{}
+
+func (activeUsers) IsUserSuspended(ctx context.Context, userID int) (bool, error) {
+	return false, nil
+}
+
+// newEcho registers the routes of every controller like main does, the services are never called
+func newEcho() *echo.Echo {
+	e := echo.New()
+	middleware := middlewares.NewMiddleware("secret", activeUsers{})
+	(&authcontroller.Controller{}).RegisterRoutes(e)
+	(&filmscontroller.Controller{AuthMiddleware: middleware}).RegisterRoutes(e)
+	(&mediacontroller.Controller{}).RegisterRoutes(e)
+	(&peoplecontroller.Controller{AuthMiddleware: middleware}).RegisterRoutes(e)
+	(&ratingscontroller.Controller{AuthMiddleware: middleware}).RegisterRoutes(e)
+	(&reviewscontroller.Controller{AuthMiddleware: middleware}).RegisterRoutes(e)
+	(&moderationcontroller.Controller{AuthMiddleware: middleware}).RegisterRoutes(e)
+	(&listscontroller.Controller{AuthMiddleware: middleware}).RegisterRoutes(e)
+	(&progresscontroller.Controller{AuthMiddleware: middleware}).RegisterRoutes(e)
+	(&recommendationscontroller.Controller{AuthMiddleware: middleware}).RegisterRoutes(e)
+	(&trendingcontroller.Controller{AuthMiddleware: middleware}).RegisterRoutes(e)
+	(&jobscontroller.Controller{AuthMiddleware: middleware}).RegisterRoutes(e)
+	(&filmsimportcontroller.Controller{AuthMiddleware: middleware}).RegisterRoutes(e)
+	(&videoscontroller.Controller{AuthMiddleware: middleware}).RegisterRoutes(e)
+	(&graphcontroller.Controller{AuthMiddleware: middleware}).RegisterRoutes(e)
+	docscontroller.NewController(e.Routes).RegisterRoutes(e)
+	return e
+}
+
+func TestRoutesDocumented(t *testing.T) {
+	e := newEcho()
+
+	assert.Empty(t, openapi.Undocumented(e.Routes(), docs.Routes), "every route needs a Route in docs.Routes")
+}
+
+func TestDocument(t *testing.T) {
+	e := newEcho()
+	recorder := httptest.NewRecorder()
+	e.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, docs.DocumentPath, nil))
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	var document openapi.Document
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &document))
+	assert.Equal(t, openapi.Version, document.OpenAPI)
+
+	login := document.Paths["/api/v1/login"]["put"]
+	if assert.NotNil(t, login) {
+		assert.Equal(t, []openapi.SecurityRequirement{{}}, login.Security)
+		assert.Contains(t, login.Responses, "200")
+	}
+	film := document.Components.Schemas["FilmCreateRequest"]
+	if assert.NotNil(t, film) {
+		assert.Contains(t, film.Required, "title")
+		assert.Equal(t, "date", film.Properties["release_date"].Format)
+	}
+	assert.Contains(t, document.Paths, "/api/v1/films/{id}/reviews/{reviewId}")
+	assert.Contains(t, document.Paths, "/media/{path}")
+}
+
+func TestDocsPageSelfContained(t *testing.T) {
+	e := newEcho()
+	recorder := httptest.NewRecorder()
+	e.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, docs.UIPath, nil))
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Contains(t, recorder.Header().Get(echo.HeaderContentSecurityPolicy), "default-src 'none'")
+	assert.NotContains(t, recorder.Body.String(), "://", "the page loads nothing from another origin")
+}
@@ -0,0 +1,178 @@
+package docs_test
+
+import (
+	authcontroller "KTOnlinePlatform/internal/controllers/authentication"
+	docscontroller "KTOnlinePlatform/internal/controllers/docs"
+	filmscontroller "KTOnlinePlatform/internal/controllers/films"
+	peoplecontroller "KTOnlinePlatform/internal/controllers/people"
+	"KTOnlinePlatform/internal/docs"
+	"KTOnlinePlatform/internal/dto"
+	"KTOnlinePlatform/internal/models/kterrors"
+	authservice "KTOnlinePlatform/internal/services/authentication"
+	filmsservice "KTOnlinePlatform/internal/services/films"
+	peopleservice "KTOnlinePlatform/internal/services/people"
+	"KTOnlinePlatform/pkg/configuration"
+	"KTOnlinePlatform/pkg/customerror"
+	"KTOnlinePlatform/pkg/database/entities/entitiescustom"
+	"KTOnlinePlatform/pkg/logger"
+	"KTOnlinePlatform/pkg/middlewares"
+	"KTOnlinePlatform/pkg/openapi"
+	"KTOnlinePlatform/pkg/webutils"
+	"context"
+	"encoding/json"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// the fakes embed the services for the methods the tests do not call
+
+type filmService struct {
+	*filmsservice.Service
+	created bool
+}
+
+func (s *filmService) GetFilmDetail(ctx context.Context, ID int, locales []string) (dto.FilmDetail, error) {
+	return dto.FilmDetail{
+		ID:          ID,
+		Title:       "Alien",
+		Director:    "Ridley Scott",
+		ReleaseDate: entitiescustom.ReleaseDate{Time: time.Date(1979, 5, 25, 0, 0, 0, 0, time.UTC)},
+		Version:     1,
+		Poster:      &dto.Poster{Small: "/media/small", Medium: "/media/medium", Large: "/media/large"},
+		Credits:     []dto.FilmCredit{{ID: 1, PersonID: 2, Name: "Sigourney Weaver", Role: "ACTOR", CharacterName: "Ripley"}},
+	}, nil
+}
+
+func (s *filmService) CreateFilm(ctx context.Context, request dto.FilmCreateRequest) error {
+	s.created = true
+	return nil
+}
+
+type peopleService struct {
+	*peopleservice.Service
+}
+
+func (peopleService) GetPerson(ctx context.Context, ID int) (dto.Person, error) {
+	return dto.Person{ID: ID, Name: "Ridley Scott"}, nil
+}
+
+type authService struct {
+	*authservice.Service
+	middleware *middlewares.Middleware
+}
+
+func (s authService) Login(ctx context.Context, request dto.Login) (dto.JWTTokens, error) {
+	return s.middleware.GenerateAuthTokens(1, request.Username)
+}
+
+func newValidatedEcho(t *testing.T, films *filmService) (*echo.Echo, *openapi.Validator, string) {
+	logger.InitializeForTest()
+	messages, err := kterrors.NewMessages()
+	assert.NoError(t, err)
+	e := webutils.NewEcho(configuration.ConfigEcho{AllowedOrigins: "*"}, messages, kterrors.Statuses)
+	middleware := middlewares.NewMiddleware("secret", activeUsers{})
+	authcontroller.NewController(authService{middleware: middleware}).RegisterRoutes(e)
+	filmscontroller.NewController(films, middleware).RegisterRoutes(e)
+	peoplecontroller.NewController(peopleService{}, middleware).RegisterRoutes(e)
+	docscontroller.NewController(e.Routes).RegisterRoutes(e)
+
+	tokens, err := middleware.GenerateAuthTokens(1, "ripley")
+	assert.NoError(t, err)
+	validator := openapi.NewValidator(docs.Document(e.Routes()), kterrors.ValidationError)
+	return e, validator, tokens.AccessToken
+}
+
+func TestRequestValidation(t *testing.T) {
+	tests := []struct {
+		name     string
+		method   string
+		target   string
+		body     string
+		expected []customerror.FieldError
+	}{
+		{
+			name:   "Valid film",
+			method: http.MethodPost,
+			target: "/api/v1/films",
+			body:   `{"title": "Alien", "release_date": "1979-05-25"}`,
+		},
+		{
+			name:   "Invalid film",
+			method: http.MethodPost,
+			target: "/api/v1/films",
+			body:   `{"title": "", "director": 1, "release_date": "25/05/1979"}`,
+			expected: []customerror.FieldError{
+				{Field: "director", Rule: "type", Param: "string"},
+				{Field: "release_date", Rule: "format", Param: "date"},
+				{Field: "title", Rule: "minLength", Param: "1"},
+			},
+		},
+		{
+			name:   "Invalid id",
+			method: http.MethodGet,
+			target: "/api/v1/films/alien",
+			expected: []customerror.FieldError{
+				{Field: "id", Rule: "type", Param: "integer"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			films := &filmService{}
+			e, validator, token := newValidatedEcho(t, films)
+			e.Use(validator.Requests())
+
+			request := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+			request.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			request.Header.Set(echo.HeaderAuthorization, "Bearer "+token)
+			recorder := httptest.NewRecorder()
+			e.ServeHTTP(recorder, request)
+
+			if tt.expected == nil {
+				assert.Less(t, recorder.Code, http.StatusBadRequest, recorder.Body.String())
+				return
+			}
+			assert.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+			var problem customerror.Problem
+			assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &problem))
+			assert.Equal(t, kterrors.ValidationError, problem.Code)
+			assert.Equal(t, tt.expected, problem.Errors)
+			assert.False(t, films.created, "the handler is not called")
+		})
+	}
+}
+
+func TestResponsesMatchDocument(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		target string
+		body   string
+	}{
+		{name: "Login", method: http.MethodPut, target: "/api/v1/login", body: `{"Username": "ripley", "Password": "nostromo"}`},
+		{name: "Film", method: http.MethodGet, target: "/api/v1/films/1"},
+		{name: "Person", method: http.MethodGet, target: "/api/v1/people/2"},
+		{name: "Document", method: http.MethodGet, target: docs.DocumentPath},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, validator, token := newValidatedEcho(t, &filmService{})
+			e.Use(validator.Responses(func(err error) {
+				t.Error(err)
+			}))
+
+			request := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+			request.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			request.Header.Set(echo.HeaderAuthorization, "Bearer "+token)
+			recorder := httptest.NewRecorder()
+			e.ServeHTTP(recorder, request)
+
+			assert.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+		})
+	}
+}
@@ -2,44 +2,179 @@ package dto
 
 import "KTOnlinePlatform/pkg/database/entities/entitiescustom"
 
+type FilmFilter struct {
+	Title    string `query:"title"`
+	Director string `query:"director"`
+	Year     int    `query:"year"`
+}
+
 type FilmSearchRequest struct {
-	Page     int `query:"page"`
-	PageSize int `query:"pageSize"`
+	FilmFilter
+	Page         int    `query:"page"`
+	PageSize     int    `query:"pageSize"`
+	Cursor       string `query:"cursor"`
+	IncludeTotal bool   `query:"includeTotal"`
+	Sort         string `query:"sort"`
+	// Locales are the negotiated languages by preference, the original text is the last resort
+	Locales []string
 }
 
 type FilmsPaginated struct {
-	Films    []Film `json:"films"`
-	Count    int    `json:"count"`
-	Page     int    `json:"page"`
-	PageSize int    `json:"pageSize"`
+	Films      []Film `json:"films"`
+	Count      int    `json:"count"`
+	Page       int    `json:"page"`
+	PageSize   int    `json:"pageSize"`
+	NextCursor string `json:"next,omitempty"`
+	PrevCursor string `json:"prev,omitempty"`
 }
 
 type Film struct {
-	ID    int    `json:"id"`
-	Title string `json:"title"`
+	ID            int     `json:"id"`
+	Title         string  `json:"title"`
+	AverageRating float64 `json:"averageRating"`
+	VoteCount     int     `json:"voteCount"`
+	Poster        *Poster `json:"poster,omitempty"`
+	Locale        string  `json:"locale,omitempty"`
 }
 
 type FilmDetail struct {
-	ID          int                        `json:"id"`
-	Title       string                     `json:"title"`
-	Director    string                     `json:"director"`
-	ReleaseDate entitiescustom.ReleaseDate `json:"release_date"`
-	Synopsis    string                     `json:"synopsis"`
+	ID            int                        `json:"id"`
+	Title         string                     `json:"title"`
+	Director      string                     `json:"director"`
+	ReleaseDate   entitiescustom.ReleaseDate `json:"release_date"`
+	Synopsis      string                     `json:"synopsis"`
+	Locale        string                     `json:"locale,omitempty"`
+	Version       int                        `json:"version"`
+	AverageRating float64                    `json:"averageRating"`
+	VoteCount     int                        `json:"voteCount"`
+	Poster        *Poster                    `json:"poster,omitempty"`
+	Credits       []FilmCredit               `json:"credits"`
+	Subtitles     []SubtitleTrack            `json:"subtitles"`
+}
+
+// Poster holds the URLs of the thumbnails of a film poster
+type Poster struct {
+	Small  string `json:"small"`
+	Medium string `json:"medium"`
+	Large  string `json:"large"`
+}
+
+type PosterUploadRequest struct {
+	FilmID int
+	Data   []byte
+	UserID int
+}
+
+// SubtitleTrack is a WebVTT file, Language is a BCP 47 tag
+type SubtitleTrack struct {
+	Language string `json:"language"`
+	Label    string `json:"label"`
+	URL      string `json:"url"`
+}
+
+type SubtitlesUploadRequest struct {
+	FilmID   int
+	Language string
+	Label    string
+	Data     []byte
+	UserID   int
+}
+
+// FilmTranslation is the title and synopsis of a film in a BCP 47 locale
+type FilmTranslation struct {
+	Locale   string `json:"locale"`
+	Title    string `json:"title"`
+	Synopsis string `json:"synopsis"`
+}
+
+type FilmTranslationRequest struct {
+	FilmID   int    `param:"id" validate:"required"`
+	Locale   string `param:"locale" validate:"required"`
+	Title    string `json:"title" validate:"title"`
+	Synopsis string `json:"synopsis"`
+	UserID   int    `json:"-"`
+}
+
+type FilmCredit struct {
+	ID            int    `json:"id"`
+	PersonID      int    `json:"personId"`
+	Name          string `json:"name"`
+	Role          string `json:"role"`
+	CharacterName string `json:"characterName,omitempty"`
+}
+
+type FilmCreditCreateRequest struct {
+	FilmID        int    `param:"id" validate:"required"`
+	PersonID      int    `json:"personId" validate:"required"`
+	Role          string `json:"role" validate:"required,oneof=DIRECTOR WRITER ACTOR COMPOSER"`
+	CharacterName string `json:"characterName" validate:"max=255"`
+	Position      int    `json:"position"`
+	UserID        int    `json:"-"`
 }
 
 type FilmCreateRequest struct {
-	Title       string                     `json:"title" validate:"required"`
-	Director    string                     `json:"director"`
-	ReleaseDate entitiescustom.ReleaseDate `json:"release_date"`
+	Title       string                     `json:"title" validate:"title"`
+	Director    string                     `json:"director" validate:"max=100"`
+	ReleaseDate entitiescustom.ReleaseDate `json:"release_date" validate:"maxyearsahead=10"`
 	Synopsis    string                     `json:"synopsis"`
 	UserID      int                        `json:"-"`
 }
 
 type FilmUpdateRequest struct {
 	ID          int                        `param:"id" validate:"required"`
-	Title       string                     `json:"title" validate:"required"`
-	Director    string                     `json:"director"`
-	ReleaseDate entitiescustom.ReleaseDate `json:"release_date"`
+	Title       string                     `json:"title" validate:"title"`
+	Director    string                     `json:"director" validate:"max=100"`
+	ReleaseDate entitiescustom.ReleaseDate `json:"release_date" validate:"maxyearsahead=10"`
 	Synopsis    string                     `json:"synopsis"`
+	Version     int                        `json:"version"`
 	UserID      int                        `json:"-"`
 }
+
+type FilmPatchRequest struct {
+	ID        int
+	PatchType string
+	Patch     []byte
+	Version   int
+	UserID    int
+}
+
+// FilmPatchDocument is the representation of a film that patches are applied to
+type FilmPatchDocument struct {
+	Title       string                      `json:"title" validate:"title"`
+	Director    string                      `json:"director" validate:"max=100"`
+	ReleaseDate *entitiescustom.ReleaseDate `json:"release_date" validate:"omitempty,maxyearsahead=10"`
+	Synopsis    string                      `json:"synopsis"`
+}
+
+type FilmImportRequest struct {
+	Format     string
+	OnConflict string
+	Data       []byte
+	UserID     int
+}
+
+type FilmImportRow struct {
+	Title       string                      `json:"title" validate:"title"`
+	Director    string                      `json:"director" validate:"max=100"`
+	ReleaseDate *entitiescustom.ReleaseDate `json:"release_date" validate:"omitempty,maxyearsahead=10"`
+	Synopsis    string                      `json:"synopsis"`
+}
+
+type FilmExportRequest struct {
+	FilmFilter
+	Format string `query:"format"`
+}
+
+type FilmExportRow struct {
+	ID          int                         `json:"id"`
+	Title       string                      `json:"title"`
+	Director    string                      `json:"director"`
+	ReleaseDate *entitiescustom.ReleaseDate `json:"release_date"`
+	Synopsis    string                      `json:"synopsis"`
+}
+
+type FilmRatingRequest struct {
+	FilmID int `param:"id" validate:"required"`
+	Score  int `json:"score" validate:"required,min=1,max=10"`
+	UserID int `json:"-"`
+}
@@ -0,0 +1,30 @@
+package dto
+
+// GraphQLRequest is a query of the /graphql endpoint, as the GraphQL clients send it
+type GraphQLRequest struct {
+	Query         string                 `json:"query" validate:"required"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+	UserID        int                    `json:"-"`
+	// Locales are the negotiated languages by preference, for the films and the error messages
+	Locales []string `json:"-"`
+}
+
+type GraphQLResponse struct {
+	Data   interface{}    `json:"data,omitempty"`
+	Errors []GraphQLError `json:"errors,omitempty"`
+}
+
+// GraphQLError is an error of the GraphQL spec, the extensions of an API error hold its code, params
+// and field errors like a problem does
+type GraphQLError struct {
+	Message    string                 `json:"message"`
+	Locations  []GraphQLLocation      `json:"locations,omitempty"`
+	Path       []interface{}          `json:"path,omitempty"`
+	Extensions map[string]interface{} `json:"extensions,omitempty"`
+}
+
+type GraphQLLocation struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
@@ -0,0 +1,20 @@
+package dto
+
+type Job struct {
+	ID        int           `json:"id"`
+	Type      string        `json:"type"`
+	Status    string        `json:"status"`
+	Total     int           `json:"total"`
+	Processed int           `json:"processed"`
+	Succeeded int           `json:"succeeded"`
+	Skipped   int           `json:"skipped"`
+	Failed    int           `json:"failed"`
+	RowErrors []JobRowError `json:"rowErrors"`
+	Error     string        `json:"error,omitempty"`
+}
+
+type JobRowError struct {
+	Row    int                    `json:"row"`
+	Code   string                 `json:"code"`
+	Params map[string]interface{} `json:"params,omitempty"`
+}
@@ -0,0 +1,53 @@
+package dto
+
+import "time"
+
+type UserList struct {
+	ID          int             `json:"id"`
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	Public      bool            `json:"public"`
+	ShareURL    string          `json:"shareUrl,omitempty"`
+	EntryCount  int             `json:"entryCount"`
+	CreatedAt   time.Time       `json:"createdAt"`
+	UpdatedAt   time.Time       `json:"updatedAt"`
+	Entries     []UserListEntry `json:"entries,omitempty"`
+}
+
+// UserListEntry of a hidden film is only shown to the owner of the list, without its title, so
+// that they can remove it
+type UserListEntry struct {
+	FilmID   int       `json:"filmId"`
+	Title    string    `json:"title"`
+	Hidden   bool      `json:"hidden"`
+	Position int       `json:"position"`
+	AddedAt  time.Time `json:"addedAt"`
+}
+
+type UserListCreateRequest struct {
+	Name        string `json:"name" validate:"required,max=100"`
+	Description string `json:"description" validate:"max=1000"`
+	Public      bool   `json:"public"`
+	UserID      int    `json:"-"`
+}
+
+type UserListUpdateRequest struct {
+	ID          int    `param:"id" validate:"required"`
+	Name        string `json:"name" validate:"required,max=100"`
+	Description string `json:"description" validate:"max=1000"`
+	Public      bool   `json:"public"`
+	UserID      int    `json:"-"`
+}
+
+type UserListEntryRequest struct {
+	ListID int `param:"id" validate:"required"`
+	FilmID int `json:"filmId" validate:"required"`
+	UserID int `json:"-"`
+}
+
+// UserListOrderRequest gives the new order of the list, it must hold every film of the list once
+type UserListOrderRequest struct {
+	ListID  int   `param:"id" validate:"required"`
+	FilmIDs []int `json:"filmIds" validate:"required"`
+	UserID  int   `json:"-"`
+}
@@ -0,0 +1,8 @@
+package dto
+
+import "net/url"
+
+type MediaRequest struct {
+	Key   string
+	Query url.Values
+}
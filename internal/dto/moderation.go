@@ -0,0 +1,72 @@
+package dto
+
+import "time"
+
+type ReportCreateRequest struct {
+	TargetType string `json:"targetType" validate:"required,oneof=FILM USER REVIEW"`
+	TargetID   int    `json:"targetId" validate:"required"`
+	Reason     string `json:"reason" validate:"required,max=1000"`
+	UserID     int    `json:"-"`
+}
+
+type ReportSearchRequest struct {
+	Status   string `query:"status"`
+	Page     int    `query:"page"`
+	PageSize int    `query:"pageSize"`
+	UserID   int    `json:"-"`
+}
+
+type ReportsPaginated struct {
+	Reports  []Report `json:"reports"`
+	Count    int      `json:"count"`
+	Page     int      `json:"page"`
+	PageSize int      `json:"pageSize"`
+}
+
+type Report struct {
+	ID                int       `json:"id"`
+	ReporterID        int       `json:"reporterId"`
+	ReporterUsername  string    `json:"reporterUsername"`
+	TargetType        string    `json:"targetType"`
+	TargetID          int       `json:"targetId"`
+	Reason            string    `json:"reason"`
+	Status            string    `json:"status"`
+	ModeratorID       *int      `json:"moderatorId,omitempty"`
+	ModeratorUsername *string   `json:"moderatorUsername,omitempty"`
+	Resolution        *string   `json:"resolution,omitempty"`
+	CreatedAt         time.Time `json:"createdAt"`
+	UpdatedAt         time.Time `json:"updatedAt"`
+}
+
+type ReportResolveRequest struct {
+	ReportID int    `param:"id" validate:"required"`
+	Action   string `json:"action" validate:"required,oneof=DISMISS HIDE_CONTENT SUSPEND_USER"`
+	Note     string `json:"note" validate:"max=1000"`
+	UserID   int    `json:"-"`
+}
+
+type AuditSearchRequest struct {
+	ModeratorID int `query:"moderatorId"`
+	Page        int `query:"page"`
+	PageSize    int `query:"pageSize"`
+	UserID      int `json:"-"`
+}
+
+type AuditTrail struct {
+	Actions  []ModerationAction `json:"actions"`
+	Count    int                `json:"count"`
+	Page     int                `json:"page"`
+	PageSize int                `json:"pageSize"`
+}
+
+type ModerationAction struct {
+	ID                int       `json:"id"`
+	ModeratorID       int       `json:"moderatorId"`
+	ModeratorUsername string    `json:"moderatorUsername"`
+	ReportID          *int      `json:"reportId,omitempty"`
+	Action            string    `json:"action"`
+	TargetType        string    `json:"targetType"`
+	TargetID          int       `json:"targetId"`
+	Note              string    `json:"note,omitempty"`
+	CreatedAt         time.Time `json:"createdAt"`
+}
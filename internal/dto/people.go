@@ -0,0 +1,48 @@
+package dto
+
+import "KTOnlinePlatform/pkg/database/entities/entitiescustom"
+
+type PeopleSearchRequest struct {
+	Name     string `query:"name"`
+	Page     int    `query:"page"`
+	PageSize int    `query:"pageSize"`
+}
+
+type PeoplePaginated struct {
+	People   []Person `json:"people"`
+	Count    int      `json:"count"`
+	Page     int      `json:"page"`
+	PageSize int      `json:"pageSize"`
+}
+
+type Person struct {
+	ID        int    `json:"id"`
+	Name      string `json:"name"`
+	Biography string `json:"biography"`
+}
+
+type PersonCreateRequest struct {
+	Name      string `json:"name" validate:"required,max=255"`
+	Biography string `json:"biography"`
+	UserID    int    `json:"-"`
+}
+
+type PersonUpdateRequest struct {
+	ID        int    `param:"id" validate:"required"`
+	Name      string `json:"name" validate:"required,max=255"`
+	Biography string `json:"biography"`
+	UserID    int    `json:"-"`
+}
+
+type Filmography struct {
+	Person  Person             `json:"person"`
+	Credits []FilmographyEntry `json:"credits"`
+}
+
+type FilmographyEntry struct {
+	FilmID        int                         `json:"filmId"`
+	Title         string                      `json:"title"`
+	ReleaseDate   *entitiescustom.ReleaseDate `json:"release_date"`
+	Role          string                      `json:"role"`
+	CharacterName string                      `json:"characterName,omitempty"`
+}
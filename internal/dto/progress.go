@@ -0,0 +1,34 @@
+package dto
+
+import "time"
+
+type ProgressRequest struct {
+	FilmID          int  `param:"id" validate:"required"`
+	PositionSeconds int  `json:"positionSeconds" validate:"min=0,ltefield=DurationSeconds"`
+	DurationSeconds int  `json:"durationSeconds" validate:"required,min=1"`
+	Completed       bool `json:"completed"`
+	UserID          int  `json:"-"`
+}
+
+type HistorySearchRequest struct {
+	Sort     string `query:"sort"`
+	Page     int    `query:"page"`
+	PageSize int    `query:"pageSize"`
+	UserID   int    `json:"-"`
+}
+
+type ViewingHistory struct {
+	Entries  []ViewingHistoryEntry `json:"entries"`
+	Count    int                   `json:"count"`
+	Page     int                   `json:"page"`
+	PageSize int                   `json:"pageSize"`
+}
+
+type ViewingHistoryEntry struct {
+	FilmID          int       `json:"filmId"`
+	Title           string    `json:"title"`
+	PositionSeconds int       `json:"positionSeconds"`
+	DurationSeconds int       `json:"durationSeconds"`
+	Completed       bool      `json:"completed"`
+	WatchedAt       time.Time `json:"watchedAt"`
+}
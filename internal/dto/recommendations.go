@@ -0,0 +1,25 @@
+package dto
+
+type RecommendationsRequest struct {
+	Page     int `query:"page"`
+	PageSize int `query:"pageSize"`
+	UserID   int
+}
+
+type Recommendations struct {
+	Films    []Film `json:"films"`
+	Count    int    `json:"count"`
+	Page     int    `json:"page"`
+	PageSize int    `json:"pageSize"`
+	// Popular is set when the user has no history yet and gets the most popular films instead
+	Popular bool `json:"popular"`
+}
+
+type SimilarFilmsRequest struct {
+	FilmID   int `param:"id"`
+	PageSize int `query:"pageSize" validate:"min=0,max=20"`
+}
+
+type SimilarFilms struct {
+	Films []Film `json:"films"`
+}
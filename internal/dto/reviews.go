@@ -0,0 +1,89 @@
+package dto
+
+import "time"
+
+type ReviewSearchRequest struct {
+	FilmID   int    `param:"id"`
+	Sort     string `query:"sort"`
+	Page     int    `query:"page"`
+	PageSize int    `query:"pageSize"`
+}
+
+type ReviewsPaginated struct {
+	Reviews  []Review `json:"reviews"`
+	Count    int      `json:"count"`
+	Page     int      `json:"page"`
+	PageSize int      `json:"pageSize"`
+}
+
+type Review struct {
+	ID        int       `json:"id"`
+	FilmID    int       `json:"filmId"`
+	UserID    int       `json:"userId"`
+	Username  string    `json:"username,omitempty"`
+	Title     string    `json:"title"`
+	Body      string    `json:"body"`
+	Spoiler   bool      `json:"spoiler"`
+	LikeCount int       `json:"likeCount"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+type ReviewCreateRequest struct {
+	FilmID  int    `param:"id" validate:"required"`
+	Title   string `json:"title" validate:"required,max=255"`
+	Body    string `json:"body" validate:"required"`
+	Spoiler bool   `json:"spoiler"`
+	UserID  int    `json:"-"`
+}
+
+type ReviewUpdateRequest struct {
+	FilmID   int    `param:"id" validate:"required"`
+	ReviewID int    `param:"reviewId" validate:"required"`
+	Title    string `json:"title" validate:"required,max=255"`
+	Body     string `json:"body" validate:"required"`
+	Spoiler  bool   `json:"spoiler"`
+	UserID   int    `json:"-"`
+}
+
+type CommentSearchRequest struct {
+	FilmID   int `param:"id"`
+	ReviewID int `param:"reviewId"`
+	Page     int `query:"page"`
+	PageSize int `query:"pageSize"`
+}
+
+// CommentsPaginated pages through top level comments, each one carries its whole thread
+type CommentsPaginated struct {
+	Comments []Comment `json:"comments"`
+	Count    int       `json:"count"`
+	Page     int       `json:"page"`
+	PageSize int       `json:"pageSize"`
+}
+
+type Comment struct {
+	ID        int       `json:"id"`
+	ParentID  *int      `json:"parentId,omitempty"`
+	UserID    int       `json:"userId"`
+	Username  string    `json:"username"`
+	Body      string    `json:"body"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+	Replies   []Comment `json:"replies"`
+}
+
+type CommentCreateRequest struct {
+	FilmID   int    `param:"id" validate:"required"`
+	ReviewID int    `param:"reviewId" validate:"required"`
+	ParentID *int   `json:"parentId"`
+	Body     string `json:"body" validate:"required"`
+	UserID   int    `json:"-"`
+}
+
+type CommentUpdateRequest struct {
+	FilmID    int    `param:"id" validate:"required"`
+	ReviewID  int    `param:"reviewId" validate:"required"`
+	CommentID int    `param:"commentId" validate:"required"`
+	Body      string `json:"body" validate:"required"`
+	UserID    int    `json:"-"`
+}
@@ -0,0 +1,12 @@
+package dto
+
+type TrendingRequest struct {
+	Window   string `query:"window"`
+	Page     int    `query:"page"`
+	PageSize int    `query:"pageSize"`
+}
+
+type FeedRequest struct {
+	Page     int `query:"page"`
+	PageSize int `query:"pageSize"`
+}
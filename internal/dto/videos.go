@@ -0,0 +1,32 @@
+package dto
+
+import (
+	"io"
+	"net/url"
+)
+
+type VideoUploadRequest struct {
+	FilmID int
+	Source io.Reader
+	Size   int64
+	UserID int
+}
+
+type VideoRequest struct {
+	FilmID int `param:"id" validate:"required"`
+	UserID int `json:"-"`
+}
+
+type StreamRequest struct {
+	FilmID    int    `param:"id" validate:"required"`
+	Rendition string `param:"rendition"`
+	// Query holds the signature of a rendition playlist URL
+	Query url.Values `json:"-"`
+}
+
+// VideoAsset is the state of the latest upload, Renditions are the qualities being streamed
+type VideoAsset struct {
+	Status     string   `json:"status"`
+	Error      string   `json:"error,omitempty"`
+	Renditions []string `json:"renditions"`
+}
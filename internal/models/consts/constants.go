@@ -1,8 +1,194 @@
 package consts
 
+import "time"
+
 const (
 	BasicPaginationDefaultPageNumber = 1
 	BasicPaginationDefaultOffset     = 0
 
 	PaginationDefaultPageSize = 10
+
+	CursorDirectionNext = "next"
+	CursorDirectionPrev = "prev"
+
+	FilmSortTitle = "title"
+	FilmSortScore = "score"
+)
+
+const (
+	// RatingPriorWeight is how many votes at the catalog mean every film starts with
+	// when ranking by score, so a single 10 does not outrank hundreds of 9s
+	RatingPriorWeight = 10
+)
+
+const (
+	MergePatchContentType = "application/merge-patch+json"
+	JSONPatchContentType  = "application/json-patch+json"
+	// FilmPatchMaxSize is far above any patch of the few fields of a film
+	FilmPatchMaxSize = 64 << 10
+	// AnyVersion is the version of If-Match: *, the update applies to whatever version the film is at
+	AnyVersion = -1
+)
+
+const (
+	CSVContentType    = "text/csv"
+	NDJSONContentType = "application/x-ndjson"
+	JSONContentType   = "application/json"
+
+	ExportFormatCSV    = "csv"
+	ExportFormatJSON   = "json"
+	ExportFormatNDJSON = "ndjson"
+	ExportFlushEvery   = 100
+
+	ImportConflictSkip   = "skip"
+	ImportConflictUpdate = "update"
+	ImportConflictFail   = "fail"
+
+	FilmImportBatchSize = 100
+	// FilmImportMaxSize bounds the file read in memory, about 50 000 films with their synopsis
+	FilmImportMaxSize = 32 << 20
+)
+
+const (
+	JobTypeFilmImport = "FILM_IMPORT"
+
+	JobStatusPending   = "PENDING"
+	JobStatusRunning   = "RUNNING"
+	JobStatusCompleted = "COMPLETED"
+	JobStatusFailed    = "FAILED"
+)
+
+const (
+	CreditRoleDirector = "DIRECTOR"
+	CreditRoleWriter   = "WRITER"
+	CreditRoleActor    = "ACTOR"
+	CreditRoleComposer = "COMPOSER"
+)
+
+const (
+	ReviewSortRecent  = "recent"
+	ReviewSortHelpful = "helpful"
+)
+
+const (
+	UserRoleUser      = "USER"
+	UserRoleModerator = "MODERATOR"
+
+	ReportTargetFilm   = "FILM"
+	ReportTargetUser   = "USER"
+	ReportTargetReview = "REVIEW"
+
+	ReportStatusOpen     = "OPEN"
+	ReportStatusClaimed  = "CLAIMED"
+	ReportStatusResolved = "RESOLVED"
+
+	ModerationActionClaim       = "CLAIM"
+	ModerationActionDismiss     = "DISMISS"
+	ModerationActionHideContent = "HIDE_CONTENT"
+	ModerationActionSuspendUser = "SUSPEND_USER"
+)
+
+const (
+	// ListSharePath prefixes the share slug of a public list to build its shareable URL
+	ListSharePath    = "/api/v1/lists/"
+	ListShareSlugLen = 12
+)
+
+const (
+	HistorySortRecent   = "recent"
+	HistorySortContinue = "continue"
+
+	// heartbeats are kept in memory and written together every ProgressFlushInterval,
+	// or as soon as ProgressFlushSize users and films are waiting. While the writes fail,
+	// at most ProgressMaxPending of them are kept
+	ProgressFlushInterval = 10 * time.Second
+	ProgressFlushSize     = 500
+	ProgressMaxPending    = 20 * ProgressFlushSize
+)
+
+const (
+	// RecommendationMinRating is the lowest rating counted as the user liking the film
+	RecommendationMinRating = 7
+	// RecommendationMinCoOccurrence drops film pairs shared by too few users to mean anything
+	RecommendationMinCoOccurrence = 2
+	RecommendationRebuildInterval = time.Hour
+
+	SimilarFilmsMaxSize = 20
+)
+
+const (
+	TrendingWindowDay   = "day"
+	TrendingWindowWeek  = "week"
+	TrendingWindowMonth = "month"
+	// TrendingWindowAll is the all time ranking without decay behind the popular feed
+	TrendingWindowAll = "all"
+
+	TrendingViewWeight   = 1
+	TrendingRatingWeight = 2
+	TrendingListWeight   = 3
+
+	TrendingRebuildInterval = 15 * time.Minute
+)
+
+const (
+	PosterFormField = "poster"
+	// PosterMaxSize is the largest accepted upload, PosterMaxPixels keeps a small file from
+	// decoding into a huge image
+	PosterMaxSize     = 10 << 20
+	PosterMaxPixels   = 40_000_000
+	PosterJPEGQuality = 85
+
+	PosterSizeSmall  = "small"
+	PosterSizeMedium = "medium"
+	PosterSizeLarge  = "large"
+
+	// MediaPath serves the blobs to the holders of a signed URL
+	MediaPath    = "/media"
+	PosterURLTTL = 24 * time.Hour
+
+	BlobStoreLocal = "local"
+	BlobStoreS3    = "s3"
+)
+
+const (
+	JobTypeVideoTranscode = "VIDEO_TRANSCODE"
+
+	VideoStatusPending    = "PENDING"
+	VideoStatusProcessing = "PROCESSING"
+	VideoStatusReady      = "READY"
+	VideoStatusFailed     = "FAILED"
+	// an upload pending or processing for longer lost its job, in a restart most likely, and
+	// no longer holds the film. The job refreshes it after every rendition
+	VideoProcessingTimeout = 2 * time.Hour
+	// a replaced version keeps its blobs while the URLs signed for it are valid, up to twice
+	// StreamURLTTL as the expiry is rounded up
+	VideoRetiredVersionRetention = 2 * StreamURLTTL
+	VideoSweepInterval           = 30 * time.Minute
+
+	VideoFormField    = "video"
+	VideoMaxSize      = 8 << 30
+	HLSSegmentSeconds = 6
+	// segment URLs are signed when the player loads the playlist, they must outlive the film
+	StreamURLTTL = 6 * time.Hour
+
+	HLSContentType    = "application/vnd.apple.mpegurl"
+	MPEGTSContentType = "video/mp2t"
+)
+
+const (
+	SubtitlesFormField = "subtitles"
+	SubtitlesMaxSize   = 2 << 20
+	SubtitlesURLTTL    = 24 * time.Hour
+
+	WebVTTContentType = "text/vtt; charset=utf-8"
+)
+
+const (
+	// a query is measured before it runs, every field costs 1 and the fields under a list cost as
+	// many times as the list is expected to be long: the pageSize of the page it belongs to, or
+	// GraphQLListSize for the other lists. A page holds at most GraphQLMaxPageSize films
+	GraphQLMaxDepth      = 10
+	GraphQLMaxComplexity = 5000
+	GraphQLListSize      = 10
+	GraphQLMaxPageSize   = 100
 )
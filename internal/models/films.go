@@ -1,7 +1,23 @@
 package models
 
 type FilmPaginated struct {
-	ID    int
-	Title string
-	Qty   int
+	ID          int
+	Title       string
+	RatingSum   int
+	RatingCount int
+	PosterKey   string
+	Qty         int
+}
+
+type FilmFilter struct {
+	Title    string
+	Director string
+	Year     int
+}
+
+// FilmCursor is the position of a film in the list ordered by title then id
+type FilmCursor struct {
+	Title     string `json:"t"`
+	ID        int    `json:"i"`
+	Direction string `json:"d"`
 }
@@ -0,0 +1,11 @@
+package models
+
+import "KTOnlinePlatform/pkg/database/entities/entitiescustom"
+
+// JobProgress is the outcome of a chunk of work, added to the totals of a job
+type JobProgress struct {
+	Succeeded int
+	Skipped   int
+	Failed    int
+	RowErrors []entitiescustom.JobRowError
+}
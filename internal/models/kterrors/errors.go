@@ -3,6 +3,7 @@ package kterrors
 const (
 	UsernameAlreadyExistsError  = "USERNAME_ALREADY_EXISTS"
 	WrongLoginCredentialsError  = "WRONG_LOGIN_CREDENTIALS"
+	InvalidRefreshTokenError    = "INVALID_REFRESH_TOKEN"
 	InvalidPasswordError        = "INVALID_PASSWORD_ERROR"
 	NeedAtLeastLength           = "NEED_AT_LEAST_LENGTH"
 	NeedAtLeastOneUppercaseChar = "NEED_AT_LEAST_ONE_UPPERCASE_CHAR"
@@ -10,9 +11,98 @@ const (
 	NeedAtLeastOneNumber        = "NEED_AT_LEAST_ONE_NUMBER"
 	NeedAtLeastOneSpecialChar   = "NEED_AT_LEAST_ONE_SPECIAL_CHAR"
 	InvalidUsernameError        = "INVALID_USERNAME_ERROR"
+	ValidationError             = "VALIDATION_ERROR"
 
-	UserNotFoundError           = "USER_NOT_FOUND_ERROR"
-	UserCannotDeleteFilmError   = "USER_CANNOT_DELETE_FILM_ERROR"
-	FilmTitleAlreadyExistsError = "FILM_TITLE_ALREADY_EXISTS_ERROR"
-	UserCannotUpdateFilmError   = "USER_CANNOT_UPDATE_FILM_ERROR"
+	UserNotFoundError               = "USER_NOT_FOUND_ERROR"
+	UserCannotDeleteFilmError       = "USER_CANNOT_DELETE_FILM_ERROR"
+	FilmTitleAlreadyExistsError     = "FILM_TITLE_ALREADY_EXISTS_ERROR"
+	UserCannotUpdateFilmError       = "USER_CANNOT_UPDATE_FILM_ERROR"
+	InvalidCursorError              = "INVALID_CURSOR_ERROR"
+	InvalidFilmSortError            = "INVALID_FILM_SORT_ERROR"
+	CursorNotSupportedForSortError  = "CURSOR_NOT_SUPPORTED_FOR_SORT_ERROR"
+	FilmNotFoundError               = "FILM_NOT_FOUND_ERROR"
+	RatingNotFoundError             = "RATING_NOT_FOUND_ERROR"
+	ContentContainsBannedWordsError = "CONTENT_CONTAINS_BANNED_WORDS_ERROR"
+
+	UserCannotUpdatePersonError    = "USER_CANNOT_UPDATE_PERSON_ERROR"
+	UserCannotDeletePersonError    = "USER_CANNOT_DELETE_PERSON_ERROR"
+	PersonNotFoundError            = "PERSON_NOT_FOUND_ERROR"
+	FilmCreditAlreadyExistsError   = "FILM_CREDIT_ALREADY_EXISTS_ERROR"
+	CharacterNameOnlyForActorError = "CHARACTER_NAME_ONLY_FOR_ACTOR_ERROR"
+	FilmVersionMismatchError       = "FILM_VERSION_MISMATCH_ERROR"
+	FilmVersionRequiredError       = "FILM_VERSION_REQUIRED_ERROR"
+	InvalidFilmPatchError          = "INVALID_FILM_PATCH_ERROR"
+	UnsupportedPatchTypeError      = "UNSUPPORTED_PATCH_TYPE_ERROR"
+
+	ReviewNotFoundError          = "REVIEW_NOT_FOUND_ERROR"
+	ReviewAlreadyExistsError     = "REVIEW_ALREADY_EXISTS_ERROR"
+	UserCannotUpdateReviewError  = "USER_CANNOT_UPDATE_REVIEW_ERROR"
+	UserCannotDeleteReviewError  = "USER_CANNOT_DELETE_REVIEW_ERROR"
+	InvalidReviewSortError       = "INVALID_REVIEW_SORT_ERROR"
+	ReviewAlreadyLikedError      = "REVIEW_ALREADY_LIKED_ERROR"
+	ReviewNotLikedError          = "REVIEW_NOT_LIKED_ERROR"
+	CommentNotFoundError         = "COMMENT_NOT_FOUND_ERROR"
+	UserCannotUpdateCommentError = "USER_CANNOT_UPDATE_COMMENT_ERROR"
+	UserCannotDeleteCommentError = "USER_CANNOT_DELETE_COMMENT_ERROR"
+
+	UserNotModeratorError           = "USER_NOT_MODERATOR_ERROR"
+	UserSuspendedError              = "USER_SUSPENDED_ERROR"
+	ReportNotFoundError             = "REPORT_NOT_FOUND_ERROR"
+	ReportTargetNotFoundError       = "REPORT_TARGET_NOT_FOUND_ERROR"
+	ReportAlreadyExistsError        = "REPORT_ALREADY_EXISTS_ERROR"
+	ReportNotOpenError              = "REPORT_NOT_OPEN_ERROR"
+	ReportNotClaimedByUserError     = "REPORT_NOT_CLAIMED_BY_USER_ERROR"
+	ModerationActionNotAllowedError = "MODERATION_ACTION_NOT_ALLOWED_ERROR"
+	InvalidReportStatusError        = "INVALID_REPORT_STATUS_ERROR"
+
+	ListNotFoundError          = "LIST_NOT_FOUND_ERROR"
+	UserCannotAccessListError  = "USER_CANNOT_ACCESS_LIST_ERROR"
+	ListNameAlreadyExistsError = "LIST_NAME_ALREADY_EXISTS_ERROR"
+	FilmAlreadyInListError     = "FILM_ALREADY_IN_LIST_ERROR"
+	FilmNotInListError         = "FILM_NOT_IN_LIST_ERROR"
+	InvalidListOrderError      = "INVALID_LIST_ORDER_ERROR"
+
+	InvalidHistorySortError = "INVALID_HISTORY_SORT_ERROR"
+
+	InvalidTrendingWindowError = "INVALID_TRENDING_WINDOW_ERROR"
+
+	PosterRequiredError        = "POSTER_REQUIRED_ERROR"
+	PosterTooLargeError        = "POSTER_TOO_LARGE_ERROR"
+	UnsupportedPosterTypeError = "UNSUPPORTED_POSTER_TYPE_ERROR"
+	InvalidPosterError         = "INVALID_POSTER_ERROR"
+
+	InvalidMediaSignatureError = "INVALID_MEDIA_SIGNATURE_ERROR"
+	MediaURLExpiredError       = "MEDIA_URL_EXPIRED_ERROR"
+	MediaNotFoundError         = "MEDIA_NOT_FOUND_ERROR"
+
+	VideoRequiredError          = "VIDEO_REQUIRED_ERROR"
+	UnsupportedVideoTypeError   = "UNSUPPORTED_VIDEO_TYPE_ERROR"
+	VideoProcessingError        = "VIDEO_PROCESSING_ERROR"
+	VideoNotFoundError          = "VIDEO_NOT_FOUND_ERROR"
+	VideoRenditionNotFoundError = "VIDEO_RENDITION_NOT_FOUND_ERROR"
+	UserCannotAccessVideoError  = "USER_CANNOT_ACCESS_VIDEO_ERROR"
+
+	SubtitlesRequiredError          = "SUBTITLES_REQUIRED_ERROR"
+	SubtitlesTooLargeError          = "SUBTITLES_TOO_LARGE_ERROR"
+	UnsupportedSubtitlesFormatError = "UNSUPPORTED_SUBTITLES_FORMAT_ERROR"
+	InvalidSubtitlesError           = "INVALID_SUBTITLES_ERROR"
+	InvalidLanguageTagError         = "INVALID_LANGUAGE_TAG_ERROR"
+	SubtitleTrackNotFoundError      = "SUBTITLE_TRACK_NOT_FOUND_ERROR"
+
+	FilmTranslationNotFoundError = "FILM_TRANSLATION_NOT_FOUND_ERROR"
+
+	UnsupportedImportFormatError = "UNSUPPORTED_IMPORT_FORMAT_ERROR"
+	InvalidConflictPolicyError   = "INVALID_CONFLICT_POLICY_ERROR"
+	InvalidImportFileError       = "INVALID_IMPORT_FILE_ERROR"
+	InvalidImportRowError        = "INVALID_IMPORT_ROW_ERROR"
+	ImportBatchFailedError       = "IMPORT_BATCH_FAILED_ERROR"
+	UserCannotAccessJobError     = "USER_CANNOT_ACCESS_JOB_ERROR"
+	UnsupportedExportFormatError = "UNSUPPORTED_EXPORT_FORMAT_ERROR"
+	QueryTooDeepError            = "QUERY_TOO_DEEP_ERROR"
+	QueryTooComplexError         = "QUERY_TOO_COMPLEX_ERROR"
+	InvalidPaginationError       = "INVALID_PAGINATION_ERROR"
+	InvalidPathParamError        = "INVALID_PATH_PARAM_ERROR"
+	InvalidETagError             = "INVALID_ETAG_ERROR"
+	FilmPatchTooLargeError       = "FILM_PATCH_TOO_LARGE_ERROR"
+	ImportFileTooLargeError      = "IMPORT_FILE_TOO_LARGE_ERROR"
 )
@@ -0,0 +1,17 @@
+package kterrors
+
+import (
+	"KTOnlinePlatform/pkg/i18n"
+	"embed"
+)
+
+// DefaultLocale is used when the client accepts none of the locales of the catalog
+const DefaultLocale = "en"
+
+//go:embed messages/*.json
+var messagesFS embed.FS
+
+// NewMessages loads the message of every error code, one file per locale
+func NewMessages() (*i18n.Catalog, error) {
+	return i18n.LoadCatalog(messagesFS, "messages", DefaultLocale)
+}
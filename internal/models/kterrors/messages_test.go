@@ -0,0 +1,53 @@
+package kterrors
+
+import (
+	"KTOnlinePlatform/pkg/i18n"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// errorCodes parses errors.go so a new constant cannot be added without its messages
+func errorCodes(t *testing.T) []string {
+	file, err := parser.ParseFile(token.NewFileSet(), "errors.go", nil, 0)
+	assert.NoError(t, err)
+	var codes []string
+	for _, decl := range file.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || genDecl.Tok != token.CONST {
+			continue
+		}
+		for _, spec := range genDecl.Specs {
+			for _, value := range spec.(*ast.ValueSpec).Values {
+				code, err := strconv.Unquote(value.(*ast.BasicLit).Value)
+				assert.NoError(t, err)
+				codes = append(codes, code)
+			}
+		}
+	}
+	return codes
+}
+
+func TestMessages(t *testing.T) {
+	messages, err := NewMessages()
+	assert.NoError(t, err)
+	codes := errorCodes(t)
+	assert.NotEmpty(t, codes)
+	assert.Contains(t, messages.Locales(), DefaultLocale)
+
+	for _, locale := range messages.Locales() {
+		for _, code := range codes {
+			message, ok := messages.Template(locale, code)
+			if !assert.True(t, ok && message != "", "%s has no message for %s", locale, code) {
+				continue
+			}
+			// a translation must use the same params as the default message
+			defaultMessage, _ := messages.Template(DefaultLocale, code)
+			assert.Equal(t, i18n.Placeholders(defaultMessage), i18n.Placeholders(message), "%s: %s", locale, code)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package kterrors
+
+import "net/http"
+
+// Statuses is the HTTP status sent with each error code, whatever status the error was created with
+var Statuses = map[string]int{
+	WrongLoginCredentialsError: http.StatusUnauthorized,
+	InvalidRefreshTokenError:   http.StatusUnauthorized,
+
+	UserCannotDeleteFilmError:    http.StatusForbidden,
+	UserCannotUpdateFilmError:    http.StatusForbidden,
+	UserCannotUpdatePersonError:  http.StatusForbidden,
+	UserCannotDeletePersonError:  http.StatusForbidden,
+	UserCannotUpdateReviewError:  http.StatusForbidden,
+	UserCannotDeleteReviewError:  http.StatusForbidden,
+	UserCannotUpdateCommentError: http.StatusForbidden,
+	UserCannotDeleteCommentError: http.StatusForbidden,
+	UserNotModeratorError:        http.StatusForbidden,
+	UserSuspendedError:           http.StatusForbidden,
+	UserCannotAccessListError:    http.StatusForbidden,
+	InvalidMediaSignatureError:   http.StatusForbidden,
+	MediaURLExpiredError:         http.StatusForbidden,
+	UserCannotAccessVideoError:   http.StatusForbidden,
+	UserCannotAccessJobError:     http.StatusForbidden,
+
+	UserNotFoundError:            http.StatusNotFound,
+	FilmNotFoundError:            http.StatusNotFound,
+	RatingNotFoundError:          http.StatusNotFound,
+	PersonNotFoundError:          http.StatusNotFound,
+	ReviewNotFoundError:          http.StatusNotFound,
+	ReviewNotLikedError:          http.StatusNotFound,
+	CommentNotFoundError:         http.StatusNotFound,
+	ReportNotFoundError:          http.StatusNotFound,
+	ReportTargetNotFoundError:    http.StatusNotFound,
+	ListNotFoundError:            http.StatusNotFound,
+	FilmNotInListError:           http.StatusNotFound,
+	MediaNotFoundError:           http.StatusNotFound,
+	VideoNotFoundError:           http.StatusNotFound,
+	VideoRenditionNotFoundError:  http.StatusNotFound,
+	SubtitleTrackNotFoundError:   http.StatusNotFound,
+	FilmTranslationNotFoundError: http.StatusNotFound,
+
+	UsernameAlreadyExistsError:   http.StatusConflict,
+	FilmTitleAlreadyExistsError:  http.StatusConflict,
+	FilmCreditAlreadyExistsError: http.StatusConflict,
+	ReviewAlreadyExistsError:     http.StatusConflict,
+	ReviewAlreadyLikedError:      http.StatusConflict,
+	ReportAlreadyExistsError:     http.StatusConflict,
+	ReportNotOpenError:           http.StatusConflict,
+	ReportNotClaimedByUserError:  http.StatusConflict,
+	ListNameAlreadyExistsError:   http.StatusConflict,
+	FilmAlreadyInListError:       http.StatusConflict,
+	VideoProcessingError:         http.StatusConflict,
+	FilmVersionMismatchError:     http.StatusPreconditionFailed,
+	FilmVersionRequiredError:     http.StatusPreconditionRequired,
+
+	PosterTooLargeError:             http.StatusRequestEntityTooLarge,
+	SubtitlesTooLargeError:          http.StatusRequestEntityTooLarge,
+	UnsupportedPatchTypeError:       http.StatusUnsupportedMediaType,
+	UnsupportedPosterTypeError:      http.StatusUnsupportedMediaType,
+	UnsupportedVideoTypeError:       http.StatusUnsupportedMediaType,
+	UnsupportedSubtitlesFormatError: http.StatusUnsupportedMediaType,
+
+	InvalidPasswordError:            http.StatusUnprocessableEntity,
+	NeedAtLeastLength:               http.StatusUnprocessableEntity,
+	NeedAtLeastOneUppercaseChar:     http.StatusUnprocessableEntity,
+	NeedAtLeastOneLowercaseChar:     http.StatusUnprocessableEntity,
+	NeedAtLeastOneNumber:            http.StatusUnprocessableEntity,
+	NeedAtLeastOneSpecialChar:       http.StatusUnprocessableEntity,
+	InvalidUsernameError:            http.StatusUnprocessableEntity,
+	ValidationError:                 http.StatusUnprocessableEntity,
+	InvalidCursorError:              http.StatusUnprocessableEntity,
+	InvalidFilmSortError:            http.StatusUnprocessableEntity,
+	CursorNotSupportedForSortError:  http.StatusUnprocessableEntity,
+	ContentContainsBannedWordsError: http.StatusUnprocessableEntity,
+	CharacterNameOnlyForActorError:  http.StatusUnprocessableEntity,
+	InvalidFilmPatchError:           http.StatusUnprocessableEntity,
+	InvalidReviewSortError:          http.StatusUnprocessableEntity,
+	ModerationActionNotAllowedError: http.StatusUnprocessableEntity,
+	InvalidReportStatusError:        http.StatusUnprocessableEntity,
+	InvalidListOrderError:           http.StatusUnprocessableEntity,
+	InvalidHistorySortError:         http.StatusUnprocessableEntity,
+	InvalidTrendingWindowError:      http.StatusUnprocessableEntity,
+	PosterRequiredError:             http.StatusUnprocessableEntity,
+	InvalidPosterError:              http.StatusUnprocessableEntity,
+	VideoRequiredError:              http.StatusUnprocessableEntity,
+	SubtitlesRequiredError:          http.StatusUnprocessableEntity,
+	InvalidSubtitlesError:           http.StatusUnprocessableEntity,
+	InvalidLanguageTagError:         http.StatusUnprocessableEntity,
+	UnsupportedImportFormatError:    http.StatusUnprocessableEntity,
+	InvalidConflictPolicyError:      http.StatusUnprocessableEntity,
+	InvalidImportFileError:          http.StatusUnprocessableEntity,
+	InvalidImportRowError:           http.StatusUnprocessableEntity,
+	UnsupportedExportFormatError:    http.StatusUnprocessableEntity,
+	QueryTooDeepError:               http.StatusBadRequest,
+	QueryTooComplexError:            http.StatusBadRequest,
+	InvalidPaginationError:          http.StatusBadRequest,
+	InvalidPathParamError:           http.StatusBadRequest,
+	InvalidETagError:                http.StatusBadRequest,
+	FilmPatchTooLargeError:          http.StatusRequestEntityTooLarge,
+	ImportFileTooLargeError:         http.StatusRequestEntityTooLarge,
+
+	// reported in the rows of an import job, never as a response
+	ImportBatchFailedError: http.StatusInternalServerError,
+}
@@ -0,0 +1,16 @@
+package kterrors
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatuses(t *testing.T) {
+	for _, code := range errorCodes(t) {
+		status, ok := Statuses[code]
+		assert.True(t, ok, "%s has no status", code)
+		assert.NotEmpty(t, http.StatusText(status), code)
+	}
+}
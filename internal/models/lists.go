@@ -0,0 +1,25 @@
+package models
+
+import "time"
+
+// UserListSummary is a list with the number of films it holds
+type UserListSummary struct {
+	ID          int
+	Name        string
+	Description string
+	Public      bool
+	ShareSlug   string
+	EntryCount  int
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// UserListEntry is a list entry joined with its film
+type UserListEntry struct {
+	ListID    int
+	FilmID    int
+	Title     string
+	Hidden    bool
+	Position  int
+	CreatedAt time.Time
+}
@@ -0,0 +1,41 @@
+package models
+
+import "time"
+
+// ReportPaginated is a report joined with the usernames of the reporter and the claiming moderator
+type ReportPaginated struct {
+	ID                int
+	ReporterID        int
+	ReporterUsername  string
+	TargetType        string
+	TargetID          int
+	Reason            string
+	Status            string
+	ModeratorID       *int
+	ModeratorUsername *string
+	Resolution        *string
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+	Qty               int
+}
+
+// ModerationActionPaginated is an audit entry joined with the moderator's username
+type ModerationActionPaginated struct {
+	ID                int
+	ModeratorID       int
+	ModeratorUsername string
+	ReportID          *int
+	Action            string
+	TargetType        string
+	TargetID          int
+	Note              string
+	CreatedAt         time.Time
+	Qty               int
+}
+
+// ModerationEffect is what resolving a report does besides closing it, zero values do nothing
+type ModerationEffect struct {
+	HideFilmID    int
+	HideReviewID  int
+	SuspendUserID int
+}
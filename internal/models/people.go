@@ -0,0 +1,29 @@
+package models
+
+import "KTOnlinePlatform/pkg/database/entities/entitiescustom"
+
+type PersonPaginated struct {
+	ID   int
+	Name string
+	Qty  int
+}
+
+// FilmCredit is a credit joined with the name of the credited person
+type FilmCredit struct {
+	ID            int
+	FilmID        int
+	PersonID      int
+	Name          string
+	Role          string
+	CharacterName string
+	Position      int
+}
+
+// FilmographyEntry is a credit joined with the credited film
+type FilmographyEntry struct {
+	FilmID        int
+	Title         string
+	ReleaseDate   entitiescustom.ReleaseDate
+	Role          string
+	CharacterName string
+}
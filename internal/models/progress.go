@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+// ViewingHistoryEntry is the progress of a user on a film joined with the film
+type ViewingHistoryEntry struct {
+	FilmID          int
+	Title           string
+	PositionSeconds int
+	DurationSeconds int
+	Completed       bool
+	UpdatedAt       time.Time
+	Qty             int
+}
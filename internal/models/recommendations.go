@@ -0,0 +1,12 @@
+package models
+
+// RecommendedFilm is a film with the score it was ranked by
+type RecommendedFilm struct {
+	ID          int
+	Title       string
+	RatingSum   int
+	RatingCount int
+	PosterKey   string
+	Score       float64
+	Qty         int
+}
@@ -0,0 +1,30 @@
+package models
+
+import "time"
+
+// ReviewPaginated is a review joined with the author's username
+type ReviewPaginated struct {
+	ID        int
+	UserID    int
+	Username  string
+	Title     string
+	Body      string
+	Spoiler   bool
+	LikeCount int
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	Qty       int
+}
+
+// ReviewComment is a comment joined with the author's username
+type ReviewComment struct {
+	ID        int
+	ParentID  *int
+	RootID    *int
+	UserID    int
+	Username  string
+	Body      string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	Qty       int
+}
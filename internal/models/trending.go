@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// TrendingWindow is how far back the engagement of a ranking goes and how fast it fades.
+// A zero Period takes every event, a zero HalfLife never fades them
+type TrendingWindow struct {
+	Name     string
+	Period   time.Duration
+	HalfLife time.Duration
+}
+
+// TrendingWeights is what each kind of engagement is worth
+type TrendingWeights struct {
+	View   int
+	Rating int
+	List   int // the list entries, which are the favorites
+}
@@ -25,6 +25,23 @@ func (r *Repository) FindUser(ctx context.Context, username string) (user entiti
 	return user, nil
 }
 
+func (r *Repository) GetUser(ctx context.Context, ID int) (user entities.User, err error) {
+	err = r.db.WithContext(ctx).First(&user, ID).Error
+	if err != nil {
+		return user, err
+	}
+	return user, nil
+}
+
+func (r *Repository) IsUserSuspended(ctx context.Context, userID int) (bool, error) {
+	var user entities.User
+	err := r.db.WithContext(ctx).Select("suspended_at").Take(&user, userID).Error
+	if err != nil {
+		return false, err
+	}
+	return user.SuspendedAt != nil, nil
+}
+
 func (r *Repository) CreateUser(ctx context.Context, username, password string) error {
 	user := entities.User{
 		Username: username,
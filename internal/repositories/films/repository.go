@@ -2,9 +2,13 @@ package films
 
 import (
 	"KTOnlinePlatform/internal/models"
+	"KTOnlinePlatform/internal/models/consts"
 	"KTOnlinePlatform/pkg/database/entities"
 	"context"
+	"fmt"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"strings"
 )
 
 type Repository struct {
@@ -20,15 +24,129 @@ const (
 SELECT
 		f.id,
 		f.title,
+		f.rating_sum,
+		f.rating_count,
+		f.poster_key,
 			COUNT(*) OVER() AS qty
 		FROM films f
-		ORDER BY f.title
+		WHERE %s
+		ORDER BY f.title, f.id
 		LIMIT ? OFFSET ?
 `
+	// films are ranked by their Bayesian average: every film gets ? prior votes at the catalog mean
+	getFilmsPaginatedByScore = `
+WITH prior AS (
+		SELECT COALESCE(SUM(rating_sum)::float / NULLIF(SUM(rating_count), 0), 0) AS mean
+		FROM films
 )
+SELECT
+		f.id,
+		f.title,
+		f.rating_sum,
+		f.rating_count,
+		f.poster_key,
+			COUNT(*) OVER() AS qty
+		FROM films f, prior
+		WHERE %s
+		ORDER BY (? * prior.mean + f.rating_sum) / (? + f.rating_count) DESC, f.id
+		LIMIT ? OFFSET ?
+`
+	getFilmsAfter = `
+SELECT
+		f.id,
+		f.title,
+		f.rating_sum,
+		f.rating_count,
+		f.poster_key
+		FROM films f
+		WHERE %s AND (f.title, f.id) > (?, ?)
+		ORDER BY f.title, f.id
+		LIMIT ?
+`
+	getFilmsBefore = `
+SELECT
+		f.id,
+		f.title,
+		f.rating_sum,
+		f.rating_count,
+		f.poster_key
+		FROM films f
+		WHERE %s AND (f.title, f.id) < (?, ?)
+		ORDER BY f.title DESC, f.id DESC
+		LIMIT ?
+`
+	getFilmCredits = `
+SELECT
+		c.id,
+		c.person_id,
+		p.name,
+		c.role,
+		c.character_name,
+		c.position
+		FROM film_credits c
+		JOIN people p ON p.id = c.person_id
+		WHERE c.film_id = ?
+		ORDER BY c.role, c.position, p.name
+`
+	getFilmsCredits = `
+SELECT
+		c.id,
+		c.film_id,
+		c.person_id,
+		p.name,
+		c.role,
+		c.character_name,
+		c.position
+		FROM film_credits c
+		JOIN people p ON p.id = c.person_id
+		WHERE c.film_id IN ?
+		ORDER BY c.film_id, c.role, c.position, p.name
+`
+	countFilms = `
+SELECT
+		COUNT(*)
+		FROM films f
+		WHERE %s
+`
+	streamFilms = `
+SELECT
+		f.id,
+		f.title,
+		f.director,
+		f.release_date,
+		f.synopsis
+		FROM films f
+		WHERE %s
+		ORDER BY f.title, f.id
+`
+)
+
+func (r *Repository) GetFilmsPaginated(ctx context.Context, filter models.FilmFilter, sort string, pageSize int, offset int) (result []models.FilmPaginated, err error) {
+	where, args := filmFilterClause(filter)
+	query := getFilmsPaginated
+	if sort == consts.FilmSortScore {
+		query = getFilmsPaginatedByScore
+		args = append(args, consts.RatingPriorWeight, consts.RatingPriorWeight)
+	}
+	args = append(args, pageSize, offset)
+	err = r.db.WithContext(ctx).Raw(fmt.Sprintf(query, where), args...).Scan(&result).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
 
-func (r *Repository) GetFilmsPaginated(ctx context.Context, pageSize int, offset int) (result []models.FilmPaginated, err error) {
-	err = r.db.WithContext(ctx).Raw(getFilmsPaginated, pageSize, offset).Scan(&result).Error
+// GetFilmsKeyset returns up to limit films next to the cursor, in the cursor direction.
+// Films before the cursor come back in descending order.
+func (r *Repository) GetFilmsKeyset(ctx context.Context, filter models.FilmFilter, cursor models.FilmCursor, limit int) (result []models.FilmPaginated, err error) {
+	query := getFilmsAfter
+	if cursor.Direction == consts.CursorDirectionPrev {
+		query = getFilmsBefore
+	}
+	where, args := filmFilterClause(filter)
+	args = append(args, cursor.Title, cursor.ID, limit)
+	err = r.db.WithContext(ctx).Raw(fmt.Sprintf(query, where), args...).Scan(&result).Error
 	if err != nil {
 		return nil, err
 	}
@@ -36,6 +154,66 @@ func (r *Repository) GetFilmsPaginated(ctx context.Context, pageSize int, offset
 	return result, nil
 }
 
+func (r *Repository) CountFilms(ctx context.Context, filter models.FilmFilter) (count int, err error) {
+	where, args := filmFilterClause(filter)
+	err = r.db.WithContext(ctx).Raw(fmt.Sprintf(countFilms, where), args...).Scan(&count).Error
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// StreamFilms walks the filtered catalog row by row through a database cursor,
+// so memory does not grow with the size of the catalog
+func (r *Repository) StreamFilms(ctx context.Context, filter models.FilmFilter, fn func(film entities.Film) error) error {
+	where, args := filmFilterClause(filter)
+	db := r.db.WithContext(ctx)
+	rows, err := db.Raw(fmt.Sprintf(streamFilms, where), args...).Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		film := entities.Film{}
+		err = db.ScanRows(rows, &film)
+		if err != nil {
+			return err
+		}
+		err = fn(film)
+		if err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+func filmFilterClause(filter models.FilmFilter) (string, []interface{}) {
+	// hidden films were taken down by a moderator
+	conditions := []string{"NOT f.hidden"}
+	var args []interface{}
+	if filter.Title != "" {
+		// a title matches in any language
+		conditions = append(conditions, "(f.title ILIKE ? OR EXISTS (SELECT 1 FROM film_translations t WHERE t.film_id = f.id AND t.title ILIKE ?))")
+		args = append(args, containsPattern(filter.Title), containsPattern(filter.Title))
+	}
+	if filter.Director != "" {
+		conditions = append(conditions, "f.director ILIKE ?")
+		args = append(args, containsPattern(filter.Director))
+	}
+	if filter.Year != 0 {
+		conditions = append(conditions, "EXTRACT(YEAR FROM f.release_date) = ?")
+		args = append(args, filter.Year)
+	}
+	return strings.Join(conditions, " AND "), args
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+func containsPattern(value string) string {
+	return "%" + likeEscaper.Replace(value) + "%"
+}
+
 func (r *Repository) GetFilm(ctx context.Context, ID int) (film entities.Film, err error) {
 	err = r.db.WithContext(ctx).First(&film, ID).Error
 	if err != nil {
@@ -44,6 +222,15 @@ func (r *Repository) GetFilm(ctx context.Context, ID int) (film entities.Film, e
 	return film, nil
 }
 
+// GetFilms returns the films of the IDs found, in no particular order
+func (r *Repository) GetFilms(ctx context.Context, IDs []int) (films []entities.Film, err error) {
+	err = r.db.WithContext(ctx).Where("id IN ?", IDs).Find(&films).Error
+	if err != nil {
+		return nil, err
+	}
+	return films, nil
+}
+
 func (r *Repository) DeleteFilm(ctx context.Context, id int) error {
 	return r.db.WithContext(ctx).Delete(&entities.Film{}, id).Error
 }
@@ -52,11 +239,177 @@ func (r *Repository) CreateFilm(ctx context.Context, film entities.Film) error {
 	return r.db.WithContext(ctx).Create(&film).Error
 }
 
+// UpdateFilm only applies when the stored version still matches film.Version,
+// otherwise gorm.ErrRecordNotFound is returned
 func (r *Repository) UpdateFilm(ctx context.Context, film entities.Film) error {
-	return r.db.WithContext(ctx).Model(&film).Updates(entities.Film{
-		Title:       film.Title,
-		Director:    film.Director,
-		ReleaseDate: film.ReleaseDate,
-		Synopsis:    film.Synopsis,
-	}).Error
+	result := r.db.WithContext(ctx).Model(&film).
+		Where("version = ?", film.Version).
+		Updates(filmUpdateColumns(film))
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// SetFilmPoster points the film to its new poster, it is not an edit of the film so the version stays
+func (r *Repository) SetFilmPoster(ctx context.Context, filmID int, posterKey string) error {
+	result := r.db.WithContext(ctx).Model(&entities.Film{}).
+		Where("id = ?", filmID).
+		Update("poster_key", posterKey)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *Repository) FindFilmsByTitles(ctx context.Context, titles []string) (films []entities.Film, err error) {
+	err = r.db.WithContext(ctx).Where("title IN ?", titles).Find(&films).Error
+	if err != nil {
+		return nil, err
+	}
+	return films, nil
+}
+
+// SaveFilmsBatch creates and updates the given films inside a single transaction
+func (r *Repository) SaveFilmsBatch(ctx context.Context, created []entities.Film, updated []entities.Film) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if len(created) > 0 {
+			err := tx.Create(&created).Error
+			if err != nil {
+				return err
+			}
+		}
+		for _, film := range updated {
+			err := tx.Model(&film).Updates(filmUpdateColumns(film)).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func filmUpdateColumns(film entities.Film) map[string]interface{} {
+	return map[string]interface{}{
+		"title":        film.Title,
+		"director":     film.Director,
+		"release_date": film.ReleaseDate,
+		"synopsis":     film.Synopsis,
+		"version":      gorm.Expr("version + 1"),
+	}
+}
+
+func (r *Repository) GetFilmCredits(ctx context.Context, filmID int) (result []models.FilmCredit, err error) {
+	err = r.db.WithContext(ctx).Raw(getFilmCredits, filmID).Scan(&result).Error
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetFilmsCredits returns the credits of several films at once, grouped by film
+func (r *Repository) GetFilmsCredits(ctx context.Context, filmIDs []int) (result []models.FilmCredit, err error) {
+	err = r.db.WithContext(ctx).Raw(getFilmsCredits, filmIDs).Scan(&result).Error
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (r *Repository) CreateFilmCredit(ctx context.Context, credit entities.FilmCredit) error {
+	return r.db.WithContext(ctx).Create(&credit).Error
+}
+
+func (r *Repository) DeleteFilmCredit(ctx context.Context, filmID int, creditID int) error {
+	result := r.db.WithContext(ctx).Where("film_id = ?", filmID).Delete(&entities.FilmCredit{}, creditID)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *Repository) GetFilmSubtitles(ctx context.Context, filmID int) (subtitles []entities.FilmSubtitle, err error) {
+	err = r.db.WithContext(ctx).Where("film_id = ?", filmID).Order("language").Find(&subtitles).Error
+	if err != nil {
+		return nil, err
+	}
+	return subtitles, nil
+}
+
+// GetFilmsSubtitles returns the subtitle tracks of several films at once
+func (r *Repository) GetFilmsSubtitles(ctx context.Context, filmIDs []int) (subtitles []entities.FilmSubtitle, err error) {
+	err = r.db.WithContext(ctx).Where("film_id IN ?", filmIDs).Order("film_id, language").Find(&subtitles).Error
+	if err != nil {
+		return nil, err
+	}
+	return subtitles, nil
+}
+
+// SaveFilmSubtitle adds the track of a language or replaces it
+func (r *Repository) SaveFilmSubtitle(ctx context.Context, subtitle entities.FilmSubtitle) error {
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "film_id"}, {Name: "language"}},
+		DoUpdates: clause.AssignmentColumns([]string{"label", "blob_key", "updated_at"}),
+	}).Create(&subtitle).Error
+}
+
+// DeleteFilmSubtitle returns the deleted track, so its file can be removed
+func (r *Repository) DeleteFilmSubtitle(ctx context.Context, filmID int, language string) (subtitle entities.FilmSubtitle, err error) {
+	result := r.db.WithContext(ctx).Clauses(clause.Returning{}).
+		Where("film_id = ? AND language = ?", filmID, language).
+		Delete(&subtitle)
+	if result.Error != nil {
+		return subtitle, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return subtitle, gorm.ErrRecordNotFound
+	}
+	return subtitle, nil
+}
+
+func (r *Repository) GetFilmTranslations(ctx context.Context, filmID int) (translations []entities.FilmTranslation, err error) {
+	err = r.db.WithContext(ctx).Where("film_id = ?", filmID).Order("locale").Find(&translations).Error
+	if err != nil {
+		return nil, err
+	}
+	return translations, nil
+}
+
+// FindFilmTranslations returns the translations of the films in any of the locales
+func (r *Repository) FindFilmTranslations(ctx context.Context, filmIDs []int, locales []string) (translations []entities.FilmTranslation, err error) {
+	err = r.db.WithContext(ctx).Where("film_id IN ? AND locale IN ?", filmIDs, locales).Find(&translations).Error
+	if err != nil {
+		return nil, err
+	}
+	return translations, nil
+}
+
+// SaveFilmTranslation adds the translation of a locale or replaces it
+func (r *Repository) SaveFilmTranslation(ctx context.Context, translation entities.FilmTranslation) error {
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "film_id"}, {Name: "locale"}},
+		DoUpdates: clause.AssignmentColumns([]string{"title", "synopsis", "updated_at"}),
+	}).Create(&translation).Error
+}
+
+func (r *Repository) DeleteFilmTranslation(ctx context.Context, filmID int, locale string) error {
+	result := r.db.WithContext(ctx).
+		Where("film_id = ? AND locale = ?", filmID, locale).
+		Delete(&entities.FilmTranslation{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
 }
@@ -0,0 +1,47 @@
+package jobs
+
+import (
+	"KTOnlinePlatform/internal/models/consts"
+	"KTOnlinePlatform/pkg/database/entities"
+	"context"
+	"gorm.io/gorm"
+)
+
+type Repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) *Repository {
+	return &Repository{db: db}
+}
+
+func (r *Repository) CreateJob(ctx context.Context, job entities.Job) (entities.Job, error) {
+	err := r.db.WithContext(ctx).Create(&job).Error
+	if err != nil {
+		return entities.Job{}, err
+	}
+	return job, nil
+}
+
+func (r *Repository) GetJob(ctx context.Context, ID int) (job entities.Job, err error) {
+	err = r.db.WithContext(ctx).First(&job, ID).Error
+	if err != nil {
+		return job, err
+	}
+	return job, nil
+}
+
+func (r *Repository) UpdateJob(ctx context.Context, job entities.Job) error {
+	return r.db.WithContext(ctx).Save(&job).Error
+}
+
+// FailJobs marks every job in one of the statuses as failed with the message
+func (r *Repository) FailJobs(ctx context.Context, statuses []string, message string) (int64, error) {
+	result := r.db.WithContext(ctx).Model(&entities.Job{}).
+		Where("status IN ?", statuses).
+		Updates(map[string]interface{}{
+			"status": consts.JobStatusFailed,
+			"error":  message,
+		})
+	return result.RowsAffected, result.Error
+}
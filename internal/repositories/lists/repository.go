@@ -0,0 +1,157 @@
+package lists
+
+import (
+	"KTOnlinePlatform/internal/models"
+	"KTOnlinePlatform/pkg/database/entities"
+	"context"
+	"gorm.io/gorm"
+)
+
+type Repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) *Repository {
+	return &Repository{db: db}
+}
+
+const (
+	getUserLists = `
+SELECT
+		l.id,
+		l.name,
+		COALESCE(l.description, '') AS description,
+		l.public,
+		l.share_slug,
+		COUNT(e.film_id) AS entry_count,
+		l.created_at,
+		l.updated_at
+		FROM user_lists l
+		LEFT JOIN user_list_entries e ON e.list_id = l.id
+		WHERE l.user_id = ?
+		GROUP BY l.id
+		ORDER BY l.name, l.id
+`
+	// the entries of hidden films keep their position, the service decides who sees them
+	getListEntries = `
+SELECT
+		e.film_id,
+		f.title,
+		f.hidden,
+		e.position,
+		e.created_at
+		FROM user_list_entries e
+		JOIN films f ON f.id = e.film_id
+		WHERE e.list_id = ?
+		ORDER BY e.position, e.film_id
+`
+	getUserListsEntries = `
+SELECT
+		e.list_id,
+		e.film_id,
+		f.title,
+		f.hidden,
+		e.position,
+		e.created_at
+		FROM user_list_entries e
+		JOIN user_lists l ON l.id = e.list_id
+		JOIN films f ON f.id = e.film_id
+		WHERE l.user_id = ? AND e.list_id IN ?
+		ORDER BY e.list_id, e.position, e.film_id
+`
+	// the new entry goes last, the list row is locked so two additions never get the same position
+	addListEntry = `
+INSERT INTO user_list_entries (list_id, film_id, position)
+SELECT l.id, ?, COALESCE((SELECT MAX(position) FROM user_list_entries WHERE list_id = l.id), 0) + 1
+		FROM (SELECT id FROM user_lists WHERE id = ? FOR UPDATE) l
+`
+)
+
+func (r *Repository) GetUserLists(ctx context.Context, userID int) (result []models.UserListSummary, err error) {
+	err = r.db.WithContext(ctx).Raw(getUserLists, userID).Scan(&result).Error
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (r *Repository) GetList(ctx context.Context, ID int) (list entities.UserList, err error) {
+	err = r.db.WithContext(ctx).First(&list, ID).Error
+	if err != nil {
+		return list, err
+	}
+	return list, nil
+}
+
+func (r *Repository) GetListBySlug(ctx context.Context, slug string) (list entities.UserList, err error) {
+	err = r.db.WithContext(ctx).First(&list, "share_slug = ?", slug).Error
+	if err != nil {
+		return list, err
+	}
+	return list, nil
+}
+
+func (r *Repository) CreateList(ctx context.Context, list entities.UserList) error {
+	return r.db.WithContext(ctx).Create(&list).Error
+}
+
+func (r *Repository) UpdateList(ctx context.Context, list entities.UserList) error {
+	return r.db.WithContext(ctx).Model(&list).Updates(map[string]interface{}{
+		"name":        list.Name,
+		"description": list.Description,
+		"public":      list.Public,
+	}).Error
+}
+
+func (r *Repository) DeleteList(ctx context.Context, id int) error {
+	return r.db.WithContext(ctx).Delete(&entities.UserList{}, id).Error
+}
+
+func (r *Repository) GetListEntries(ctx context.Context, listID int) (result []models.UserListEntry, err error) {
+	err = r.db.WithContext(ctx).Raw(getListEntries, listID).Scan(&result).Error
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetUserListsEntries returns the entries of several lists of a user at once, grouped by list
+func (r *Repository) GetUserListsEntries(ctx context.Context, userID int, listIDs []int) (result []models.UserListEntry, err error) {
+	err = r.db.WithContext(ctx).Raw(getUserListsEntries, userID, listIDs).Scan(&result).Error
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (r *Repository) AddListEntry(ctx context.Context, listID int, filmID int) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return tx.Exec(addListEntry, filmID, listID).Error
+	})
+}
+
+func (r *Repository) RemoveListEntry(ctx context.Context, listID int, filmID int) error {
+	result := r.db.WithContext(ctx).Where("list_id = ? AND film_id = ?", listID, filmID).Delete(&entities.UserListEntry{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// ReorderListEntries numbers the entries from 1 in the order of filmIDs
+func (r *Repository) ReorderListEntries(ctx context.Context, listID int, filmIDs []int) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for i, filmID := range filmIDs {
+			err := tx.Model(&entities.UserListEntry{}).
+				Where("list_id = ? AND film_id = ?", listID, filmID).
+				Update("position", i+1).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
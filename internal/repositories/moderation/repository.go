@@ -0,0 +1,192 @@
+package moderation
+
+import (
+	"KTOnlinePlatform/internal/models"
+	"KTOnlinePlatform/internal/models/consts"
+	"KTOnlinePlatform/pkg/database/entities"
+	"KTOnlinePlatform/pkg/utils"
+	"context"
+	"fmt"
+	"gorm.io/gorm"
+)
+
+type Repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) *Repository {
+	return &Repository{db: db}
+}
+
+const (
+	getReportsPaginated = `
+SELECT
+		r.id,
+		r.reporter_id,
+		u.username AS reporter_username,
+		r.target_type,
+		r.target_id,
+		r.reason,
+		r.status,
+		r.moderator_id,
+		m.username AS moderator_username,
+		r.resolution,
+		r.created_at,
+		r.updated_at,
+			COUNT(*) OVER() AS qty
+		FROM reports r
+		JOIN users u ON u.id = r.reporter_id
+		LEFT JOIN users m ON m.id = r.moderator_id
+		WHERE r.status IN ?
+		ORDER BY r.created_at, r.id
+		LIMIT ? OFFSET ?
+`
+	getAuditTrail = `
+SELECT
+		a.id,
+		a.moderator_id,
+		u.username AS moderator_username,
+		a.report_id,
+		a.action,
+		a.target_type,
+		a.target_id,
+		COALESCE(a.note, '') AS note,
+		a.created_at,
+			COUNT(*) OVER() AS qty
+		FROM moderation_actions a
+		JOIN users u ON u.id = a.moderator_id
+		WHERE %s
+		ORDER BY a.created_at DESC, a.id DESC
+		LIMIT ? OFFSET ?
+`
+)
+
+// targetOwners gives the table holding each reportable target and the column of its author
+var targetOwners = map[string]struct{ table, column string }{
+	consts.ReportTargetFilm:   {"films", "user_id"},
+	consts.ReportTargetReview: {"reviews", "user_id"},
+	consts.ReportTargetUser:   {"users", "id"},
+}
+
+func (r *Repository) GetUser(ctx context.Context, ID int) (user entities.User, err error) {
+	err = r.db.WithContext(ctx).First(&user, ID).Error
+	if err != nil {
+		return user, err
+	}
+	return user, nil
+}
+
+// GetTargetOwner returns the user who wrote the reported content, a reported user owns itself
+func (r *Repository) GetTargetOwner(ctx context.Context, targetType string, targetID int) (int, error) {
+	owner, ok := targetOwners[targetType]
+	if !ok {
+		return 0, gorm.ErrRecordNotFound
+	}
+	var ownerIDs []int
+	err := r.db.WithContext(ctx).Table(owner.table).Where("id = ?", targetID).Pluck(owner.column, &ownerIDs).Error
+	if err != nil {
+		return 0, err
+	}
+	if len(ownerIDs) == 0 {
+		return 0, gorm.ErrRecordNotFound
+	}
+	return ownerIDs[0], nil
+}
+
+func (r *Repository) CreateReport(ctx context.Context, report entities.Report) error {
+	return r.db.WithContext(ctx).Create(&report).Error
+}
+
+func (r *Repository) GetReport(ctx context.Context, ID int) (report entities.Report, err error) {
+	err = r.db.WithContext(ctx).First(&report, ID).Error
+	if err != nil {
+		return report, err
+	}
+	return report, nil
+}
+
+func (r *Repository) GetReportsPaginated(ctx context.Context, statuses []string, pageSize int, offset int) (result []models.ReportPaginated, err error) {
+	err = r.db.WithContext(ctx).Raw(getReportsPaginated, statuses, pageSize, offset).Scan(&result).Error
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ClaimReport assigns an open report to the moderator, when another moderator claimed it
+// first no row matches and gorm.ErrRecordNotFound is returned
+func (r *Repository) ClaimReport(ctx context.Context, reportID int, action entities.ModerationAction) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&entities.Report{}).
+			Where("id = ? AND status = ?", reportID, consts.ReportStatusOpen).
+			Updates(map[string]interface{}{
+				"status":       consts.ReportStatusClaimed,
+				"moderator_id": action.ModeratorID,
+				"updated_at":   utils.TimeNowInUTC(),
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return tx.Create(&action).Error
+	})
+}
+
+// ResolveReport closes a report claimed by the moderator, applies the effect and records it
+// in the audit trail, all or nothing
+func (r *Repository) ResolveReport(ctx context.Context, effect models.ModerationEffect, action entities.ModerationAction) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := utils.TimeNowInUTC()
+		result := tx.Model(&entities.Report{}).
+			Where("id = ? AND status = ? AND moderator_id = ?", *action.ReportID, consts.ReportStatusClaimed, action.ModeratorID).
+			Updates(map[string]interface{}{
+				"status":     consts.ReportStatusResolved,
+				"resolution": action.Action,
+				"updated_at": now,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		if effect.HideFilmID != 0 {
+			err := tx.Model(&entities.Film{}).Where("id = ?", effect.HideFilmID).UpdateColumn("hidden", true).Error
+			if err != nil {
+				return err
+			}
+		}
+		if effect.HideReviewID != 0 {
+			err := tx.Model(&entities.Review{}).Where("id = ?", effect.HideReviewID).UpdateColumn("hidden", true).Error
+			if err != nil {
+				return err
+			}
+		}
+		if effect.SuspendUserID != 0 {
+			err := tx.Model(&entities.User{}).
+				Where("id = ? AND suspended_at IS NULL", effect.SuspendUserID).
+				UpdateColumn("suspended_at", now).Error
+			if err != nil {
+				return err
+			}
+		}
+		return tx.Create(&action).Error
+	})
+}
+
+func (r *Repository) GetAuditTrail(ctx context.Context, moderatorID int, pageSize int, offset int) (result []models.ModerationActionPaginated, err error) {
+	where := "TRUE"
+	var args []interface{}
+	if moderatorID != 0 {
+		where = "a.moderator_id = ?"
+		args = append(args, moderatorID)
+	}
+	args = append(args, pageSize, offset)
+	err = r.db.WithContext(ctx).Raw(fmt.Sprintf(getAuditTrail, where), args...).Scan(&result).Error
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -0,0 +1,92 @@
+package people
+
+import (
+	"KTOnlinePlatform/internal/models"
+	"KTOnlinePlatform/pkg/database/entities"
+	"context"
+	"gorm.io/gorm"
+	"strings"
+)
+
+type Repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) *Repository {
+	return &Repository{db: db}
+}
+
+const (
+	getPeoplePaginated = `
+SELECT
+		p.id,
+		p.name,
+			COUNT(*) OVER() AS qty
+		FROM people p
+		WHERE p.name ILIKE ?
+		ORDER BY p.name, p.id
+		LIMIT ? OFFSET ?
+`
+	getFilmography = `
+SELECT
+		f.id AS film_id,
+		f.title,
+		f.release_date,
+		c.role,
+		c.character_name
+		FROM film_credits c
+		JOIN films f ON f.id = c.film_id
+		WHERE c.person_id = ?
+		ORDER BY f.release_date DESC NULLS LAST, f.title, c.role
+`
+)
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+func (r *Repository) GetPeoplePaginated(ctx context.Context, name string, pageSize int, offset int) (result []models.PersonPaginated, err error) {
+	pattern := "%" + likeEscaper.Replace(name) + "%"
+	err = r.db.WithContext(ctx).Raw(getPeoplePaginated, pattern, pageSize, offset).Scan(&result).Error
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (r *Repository) GetPerson(ctx context.Context, ID int) (person entities.Person, err error) {
+	err = r.db.WithContext(ctx).First(&person, ID).Error
+	if err != nil {
+		return person, err
+	}
+	return person, nil
+}
+
+func (r *Repository) GetUser(ctx context.Context, ID int) (user entities.User, err error) {
+	err = r.db.WithContext(ctx).First(&user, ID).Error
+	if err != nil {
+		return user, err
+	}
+	return user, nil
+}
+
+func (r *Repository) CreatePerson(ctx context.Context, person entities.Person) error {
+	return r.db.WithContext(ctx).Create(&person).Error
+}
+
+func (r *Repository) UpdatePerson(ctx context.Context, person entities.Person) error {
+	return r.db.WithContext(ctx).Model(&person).Updates(map[string]interface{}{
+		"name":      person.Name,
+		"biography": person.Biography,
+	}).Error
+}
+
+func (r *Repository) DeletePerson(ctx context.Context, id int) error {
+	return r.db.WithContext(ctx).Delete(&entities.Person{}, id).Error
+}
+
+func (r *Repository) GetFilmography(ctx context.Context, personID int) (result []models.FilmographyEntry, err error) {
+	err = r.db.WithContext(ctx).Raw(getFilmography, personID).Scan(&result).Error
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -0,0 +1,89 @@
+package progress
+
+import (
+	"KTOnlinePlatform/internal/models"
+	"KTOnlinePlatform/internal/models/consts"
+	"KTOnlinePlatform/pkg/database/entities"
+	"context"
+	"fmt"
+	"github.com/samber/lo"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type Repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) *Repository {
+	return &Repository{db: db}
+}
+
+const (
+	filmExists = `
+SELECT EXISTS (SELECT 1 FROM films WHERE id = ? AND NOT hidden)
+`
+	getHistory = `
+SELECT
+		p.film_id,
+		f.title,
+		p.position_seconds,
+		p.duration_seconds,
+		p.completed,
+		p.updated_at,
+			COUNT(*) OVER() AS qty
+		FROM viewing_progress p
+		JOIN films f ON f.id = p.film_id
+		WHERE p.user_id = ? AND NOT f.hidden %s
+		ORDER BY p.updated_at DESC, p.film_id
+		LIMIT ? OFFSET ?
+`
+)
+
+// FilmExists tells whether the film can be watched, a hidden film cannot
+func (r *Repository) FilmExists(ctx context.Context, filmID int) (exists bool, err error) {
+	err = r.db.WithContext(ctx).Raw(filmExists, filmID).Scan(&exists).Error
+	return exists, err
+}
+
+// SaveProgress upserts a batch of heartbeats in one statement. Progress on films deleted
+// since the heartbeat is dropped, and a row is never overwritten by an older heartbeat
+func (r *Repository) SaveProgress(ctx context.Context, progress []entities.ViewingProgress) error {
+	db := r.db.WithContext(ctx)
+	var filmIDs []int
+	err := db.Model(&entities.Film{}).
+		Where("id IN ?", lo.Uniq(lo.Map(progress, func(item entities.ViewingProgress, index int) int {
+			return item.FilmID
+		}))).
+		Pluck("id", &filmIDs).Error
+	if err != nil {
+		return err
+	}
+	progress = lo.Filter(progress, func(item entities.ViewingProgress, index int) bool {
+		return lo.Contains(filmIDs, item.FilmID)
+	})
+	if len(progress) == 0 {
+		return nil
+	}
+	return db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "film_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"position_seconds", "duration_seconds", "completed", "updated_at"}),
+		Where: clause.Where{Exprs: []clause.Expression{
+			clause.Expr{SQL: "viewing_progress.updated_at <= excluded.updated_at"},
+		}},
+	}).Create(&progress).Error
+}
+
+// GetHistory lists the films watched by the user, last watched first. The continue
+// watching sort keeps only the films that were not finished
+func (r *Repository) GetHistory(ctx context.Context, userID int, sort string, pageSize int, offset int) (result []models.ViewingHistoryEntry, err error) {
+	condition := ""
+	if sort == consts.HistorySortContinue {
+		condition = "AND NOT p.completed"
+	}
+	err = r.db.WithContext(ctx).Raw(fmt.Sprintf(getHistory, condition), userID, pageSize, offset).Scan(&result).Error
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
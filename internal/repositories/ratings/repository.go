@@ -0,0 +1,82 @@
+package ratings
+
+import (
+	"KTOnlinePlatform/pkg/database/entities"
+	"context"
+	"errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type Repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) *Repository {
+	return &Repository{db: db}
+}
+
+// SaveRating inserts or replaces the user's score and moves the film aggregates by the difference.
+// The film row is locked first so concurrent votes on the same film apply their deltas one at a time.
+func (r *Repository) SaveRating(ctx context.Context, rating entities.FilmRating) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := lockFilm(tx, rating.FilmID)
+		if err != nil {
+			return err
+		}
+		existing := entities.FilmRating{}
+		err = tx.Where("film_id = ? AND user_id = ?", rating.FilmID, rating.UserID).Take(&existing).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			err = tx.Create(&rating).Error
+			if err != nil {
+				return err
+			}
+			return adjustAggregates(tx, rating.FilmID, rating.Score, 1)
+		}
+		if err != nil {
+			return err
+		}
+		err = tx.Model(&existing).
+			Where("film_id = ? AND user_id = ?", rating.FilmID, rating.UserID).
+			Update("score", rating.Score).Error
+		if err != nil {
+			return err
+		}
+		return adjustAggregates(tx, rating.FilmID, rating.Score-existing.Score, 0)
+	})
+}
+
+// DeleteRating removes the user's score and takes it out of the film aggregates
+func (r *Repository) DeleteRating(ctx context.Context, filmID int, userID int) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := lockFilm(tx, filmID)
+		if err != nil {
+			return err
+		}
+		existing := entities.FilmRating{}
+		err = tx.Clauses(clause.Returning{}).
+			Where("film_id = ? AND user_id = ?", filmID, userID).
+			Delete(&existing).Error
+		if err != nil {
+			return err
+		}
+		if existing.Score == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return adjustAggregates(tx, filmID, -existing.Score, -1)
+	})
+}
+
+func lockFilm(tx *gorm.DB, filmID int) error {
+	film := entities.Film{}
+	return tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").Take(&film, filmID).Error
+}
+
+func adjustAggregates(tx *gorm.DB, filmID int, scoreDelta int, countDelta int) error {
+	return tx.Model(&entities.Film{}).
+		Where("id = ?", filmID).
+		UpdateColumns(map[string]interface{}{
+			"rating_sum":   gorm.Expr("rating_sum + ?", scoreDelta),
+			"rating_count": gorm.Expr("rating_count + ?", countDelta),
+		}).Error
+}
@@ -0,0 +1,178 @@
+package recommendations
+
+import (
+	"KTOnlinePlatform/internal/models"
+	"KTOnlinePlatform/pkg/database/entities"
+	"context"
+	"gorm.io/gorm"
+)
+
+type Repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) *Repository {
+	return &Repository{db: db}
+}
+
+const (
+	// interactions is every film a user showed interest in: rated well, added to a list or watched to the end,
+	// the list entries standing for the favorites
+	interactions = `
+WITH interactions AS (
+		SELECT user_id, film_id FROM film_ratings WHERE score >= @minRating
+		UNION
+		SELECT l.user_id, e.film_id FROM user_list_entries e JOIN user_lists l ON l.id = e.list_id
+		UNION
+		SELECT user_id, film_id FROM viewing_progress WHERE completed
+)`
+
+	rebuildSimilarities = interactions + `,
+counts AS (
+		SELECT film_id, COUNT(*) AS n FROM interactions GROUP BY film_id
+)
+INSERT INTO film_similarities (film_id, similar_film_id, score)
+SELECT
+		a.film_id,
+		b.film_id,
+		COUNT(*) / sqrt(ca.n * cb.n)
+		FROM interactions a
+		JOIN interactions b ON b.user_id = a.user_id AND b.film_id <> a.film_id
+		JOIN counts ca ON ca.film_id = a.film_id
+		JOIN counts cb ON cb.film_id = b.film_id
+		GROUP BY a.film_id, b.film_id, ca.n, cb.n
+		HAVING COUNT(*) >= @minCoOccurrence
+`
+
+	hasInteractions = interactions + `
+SELECT EXISTS (SELECT 1 FROM interactions WHERE user_id = @userID)
+`
+
+	getRecommendations = interactions + `,
+seen AS (
+		SELECT film_id FROM interactions WHERE user_id = @userID
+		UNION
+		SELECT film_id FROM viewing_progress WHERE user_id = @userID
+)
+SELECT
+		f.id,
+		f.title,
+		f.rating_sum,
+		f.rating_count,
+		f.poster_key,
+		SUM(s.score) AS score,
+			COUNT(*) OVER() AS qty
+		FROM film_similarities s
+		JOIN films f ON f.id = s.similar_film_id
+		WHERE s.film_id IN (SELECT film_id FROM interactions WHERE user_id = @userID)
+		AND s.similar_film_id NOT IN (SELECT film_id FROM seen)
+		AND NOT f.hidden
+		GROUP BY f.id
+		ORDER BY score DESC, f.id
+		LIMIT @limit OFFSET @offset
+`
+
+	// getPopularFilms reads the ranking of the trending job so the popular films are the
+	// same everywhere
+	getPopularFilms = `
+SELECT
+		f.id,
+		f.title,
+		f.rating_sum,
+		f.rating_count,
+		f.poster_key,
+		t.score,
+			COUNT(*) OVER() AS qty
+		FROM film_trending t
+		JOIN films f ON f.id = t.film_id
+		WHERE t.time_window = @window AND NOT f.hidden
+		ORDER BY t.score DESC, f.id
+		LIMIT @limit OFFSET @offset
+`
+
+	getSimilarFilms = `
+SELECT
+		f.id,
+		f.title,
+		f.rating_sum,
+		f.rating_count,
+		f.poster_key,
+		COUNT(DISTINCT c.person_id) AS score
+		FROM film_credits c
+		JOIN film_credits src ON src.person_id = c.person_id AND src.film_id = @filmID
+		JOIN films f ON f.id = c.film_id
+		WHERE c.film_id <> @filmID AND NOT f.hidden
+		GROUP BY f.id
+		ORDER BY score DESC, f.rating_count DESC, f.id
+		LIMIT @limit
+`
+)
+
+// RebuildSimilarities replaces the whole similarity table in one transaction, readers keep
+// seeing the previous one until the new one is committed
+func (r *Repository) RebuildSimilarities(ctx context.Context, minRating int, minCoOccurrence int) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Exec("DELETE FROM film_similarities").Error
+		if err != nil {
+			return err
+		}
+		return tx.Exec(rebuildSimilarities, map[string]interface{}{
+			"minRating":       minRating,
+			"minCoOccurrence": minCoOccurrence,
+		}).Error
+	})
+}
+
+// HasInteractions tells whether the user showed interest in any film, the recommendations start from them
+func (r *Repository) HasInteractions(ctx context.Context, userID int, minRating int) (exists bool, err error) {
+	err = r.db.WithContext(ctx).Raw(hasInteractions, map[string]interface{}{
+		"userID":    userID,
+		"minRating": minRating,
+	}).Scan(&exists).Error
+	return exists, err
+}
+
+// GetRecommendations ranks the films the user has not seen by their summed similarity with the
+// films the user liked
+func (r *Repository) GetRecommendations(ctx context.Context, userID int, minRating int, pageSize int, offset int) (result []models.RecommendedFilm, err error) {
+	err = r.db.WithContext(ctx).Raw(getRecommendations, map[string]interface{}{
+		"userID":    userID,
+		"minRating": minRating,
+		"limit":     pageSize,
+		"offset":    offset,
+	}).Scan(&result).Error
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetPopularFilms ranks the films by their trending score over the window
+func (r *Repository) GetPopularFilms(ctx context.Context, window string, pageSize int, offset int) (result []models.RecommendedFilm, err error) {
+	err = r.db.WithContext(ctx).Raw(getPopularFilms, map[string]interface{}{
+		"window": window,
+		"limit":  pageSize,
+		"offset": offset,
+	}).Scan(&result).Error
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetSimilarFilms ranks the films by the number of people they share with the film
+func (r *Repository) GetSimilarFilms(ctx context.Context, filmID int, limit int) (result []models.RecommendedFilm, err error) {
+	err = r.db.WithContext(ctx).Raw(getSimilarFilms, map[string]interface{}{
+		"filmID": filmID,
+		"limit":  limit,
+	}).Scan(&result).Error
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (r *Repository) GetFilm(ctx context.Context, ID int) (film entities.Film, err error) {
+	err = r.db.WithContext(ctx).First(&film, ID).Error
+	return film, err
+}
@@ -0,0 +1,181 @@
+package reviews
+
+import (
+	"KTOnlinePlatform/internal/models"
+	"KTOnlinePlatform/internal/models/consts"
+	"KTOnlinePlatform/pkg/database/entities"
+	"context"
+	"fmt"
+	"gorm.io/gorm"
+)
+
+type Repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) *Repository {
+	return &Repository{db: db}
+}
+
+const (
+	getReviewsPaginated = `
+SELECT
+		r.id,
+		r.user_id,
+		u.username,
+		r.title,
+		r.body,
+		r.spoiler,
+		r.like_count,
+		r.created_at,
+		r.updated_at,
+			COUNT(*) OVER() AS qty
+		FROM reviews r
+		JOIN users u ON u.id = r.user_id
+		WHERE r.film_id = ? AND NOT r.hidden
+		ORDER BY %s
+		LIMIT ? OFFSET ?
+`
+	getRootCommentsPaginated = `
+SELECT
+		c.id,
+		c.parent_id,
+		c.root_id,
+		c.user_id,
+		u.username,
+		c.body,
+		c.created_at,
+		c.updated_at,
+			COUNT(*) OVER() AS qty
+		FROM review_comments c
+		JOIN users u ON u.id = c.user_id
+		WHERE c.review_id = ? AND c.parent_id IS NULL
+		ORDER BY c.created_at, c.id
+		LIMIT ? OFFSET ?
+`
+	getCommentReplies = `
+SELECT
+		c.id,
+		c.parent_id,
+		c.root_id,
+		c.user_id,
+		u.username,
+		c.body,
+		c.created_at,
+		c.updated_at
+		FROM review_comments c
+		JOIN users u ON u.id = c.user_id
+		WHERE c.root_id IN ?
+		ORDER BY c.created_at, c.id
+`
+)
+
+var reviewOrders = map[string]string{
+	consts.ReviewSortRecent:  "r.created_at DESC, r.id DESC",
+	consts.ReviewSortHelpful: "r.like_count DESC, r.created_at DESC, r.id DESC",
+}
+
+func (r *Repository) GetReviewsPaginated(ctx context.Context, filmID int, sort string, pageSize int, offset int) (result []models.ReviewPaginated, err error) {
+	order, ok := reviewOrders[sort]
+	if !ok {
+		order = reviewOrders[consts.ReviewSortRecent]
+	}
+	err = r.db.WithContext(ctx).Raw(fmt.Sprintf(getReviewsPaginated, order), filmID, pageSize, offset).Scan(&result).Error
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (r *Repository) GetReview(ctx context.Context, ID int) (review entities.Review, err error) {
+	err = r.db.WithContext(ctx).First(&review, ID).Error
+	if err != nil {
+		return review, err
+	}
+	return review, nil
+}
+
+func (r *Repository) CreateReview(ctx context.Context, review entities.Review) error {
+	return r.db.WithContext(ctx).Create(&review).Error
+}
+
+func (r *Repository) UpdateReview(ctx context.Context, review entities.Review) error {
+	return r.db.WithContext(ctx).Model(&review).Updates(map[string]interface{}{
+		"title":   review.Title,
+		"body":    review.Body,
+		"spoiler": review.Spoiler,
+	}).Error
+}
+
+func (r *Repository) DeleteReview(ctx context.Context, id int) error {
+	return r.db.WithContext(ctx).Delete(&entities.Review{}, id).Error
+}
+
+// LikeReview records the like and bumps like_count in the same transaction,
+// liking twice fails on the primary key
+func (r *Repository) LikeReview(ctx context.Context, reviewID int, userID int) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Create(&entities.ReviewLike{ReviewID: reviewID, UserID: userID}).Error
+		if err != nil {
+			return err
+		}
+		return adjustLikeCount(tx, reviewID, 1)
+	})
+}
+
+func (r *Repository) UnlikeReview(ctx context.Context, reviewID int, userID int) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("review_id = ? AND user_id = ?", reviewID, userID).Delete(&entities.ReviewLike{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return adjustLikeCount(tx, reviewID, -1)
+	})
+}
+
+func adjustLikeCount(tx *gorm.DB, reviewID int, delta int) error {
+	return tx.Model(&entities.Review{}).
+		Where("id = ?", reviewID).
+		UpdateColumn("like_count", gorm.Expr("like_count + ?", delta)).Error
+}
+
+func (r *Repository) GetRootCommentsPaginated(ctx context.Context, reviewID int, pageSize int, offset int) (result []models.ReviewComment, err error) {
+	err = r.db.WithContext(ctx).Raw(getRootCommentsPaginated, reviewID, pageSize, offset).Scan(&result).Error
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetCommentReplies returns every reply below the given top level comments, oldest first
+func (r *Repository) GetCommentReplies(ctx context.Context, rootIDs []int) (result []models.ReviewComment, err error) {
+	err = r.db.WithContext(ctx).Raw(getCommentReplies, rootIDs).Scan(&result).Error
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (r *Repository) GetComment(ctx context.Context, ID int) (comment entities.ReviewComment, err error) {
+	err = r.db.WithContext(ctx).First(&comment, ID).Error
+	if err != nil {
+		return comment, err
+	}
+	return comment, nil
+}
+
+func (r *Repository) CreateComment(ctx context.Context, comment entities.ReviewComment) error {
+	return r.db.WithContext(ctx).Create(&comment).Error
+}
+
+func (r *Repository) UpdateComment(ctx context.Context, comment entities.ReviewComment) error {
+	return r.db.WithContext(ctx).Model(&comment).Update("body", comment.Body).Error
+}
+
+// DeleteComment removes the comment along with its replies, the foreign keys cascade
+func (r *Repository) DeleteComment(ctx context.Context, id int) error {
+	return r.db.WithContext(ctx).Delete(&entities.ReviewComment{}, id).Error
+}
@@ -0,0 +1,105 @@
+package trending
+
+import (
+	"KTOnlinePlatform/internal/models"
+	"KTOnlinePlatform/pkg/utils"
+	"context"
+	"gorm.io/gorm"
+)
+
+type Repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) *Repository {
+	return &Repository{db: db}
+}
+
+const (
+	// every engagement counts for its weight halved each half life, views and ratings
+	// count again when they are updated, the list entries are the favorites
+	rebuildTrending = `
+INSERT INTO film_trending (time_window, film_id, score)
+SELECT
+		@window,
+		e.film_id,
+		SUM(CASE WHEN CAST(@halfLife AS DOUBLE PRECISION) = 0 THEN e.weight
+			ELSE e.weight * exp(-ln(2) * extract(epoch FROM CAST(@now AS TIMESTAMPTZ) - e.at) / CAST(@halfLife AS DOUBLE PRECISION)) END)
+		FROM (
+			SELECT film_id, updated_at AS at, @viewWeight AS weight FROM viewing_progress
+			UNION ALL
+			SELECT film_id, updated_at, @ratingWeight FROM film_ratings
+			UNION ALL
+			SELECT film_id, created_at, @listWeight FROM user_list_entries
+		) e
+		WHERE CAST(@period AS DOUBLE PRECISION) = 0
+		OR e.at >= CAST(@now AS TIMESTAMPTZ) - make_interval(secs => CAST(@period AS DOUBLE PRECISION))
+		GROUP BY e.film_id
+`
+
+	getTrending = `
+SELECT
+		f.id,
+		f.title,
+		f.rating_sum,
+		f.rating_count,
+		f.poster_key,
+			COUNT(*) OVER() AS qty
+		FROM film_trending t
+		JOIN films f ON f.id = t.film_id
+		WHERE t.time_window = ? AND NOT f.hidden
+		ORDER BY t.score DESC, f.id
+		LIMIT ? OFFSET ?
+`
+
+	getNewReleases = `
+SELECT
+		f.id,
+		f.title,
+		f.rating_sum,
+		f.rating_count,
+		f.poster_key,
+			COUNT(*) OVER() AS qty
+		FROM films f
+		WHERE f.release_date <= ? AND NOT f.hidden
+		ORDER BY f.release_date DESC, f.id
+		LIMIT ? OFFSET ?
+`
+)
+
+// RebuildTrending replaces the ranking of a window in one transaction, readers keep
+// seeing the previous one until the new one is committed
+func (r *Repository) RebuildTrending(ctx context.Context, window models.TrendingWindow, weights models.TrendingWeights) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Exec("DELETE FROM film_trending WHERE time_window = ?", window.Name).Error
+		if err != nil {
+			return err
+		}
+		return tx.Exec(rebuildTrending, map[string]interface{}{
+			"window":       window.Name,
+			"now":          utils.TimeNowInUTC(),
+			"period":       window.Period.Seconds(),
+			"halfLife":     window.HalfLife.Seconds(),
+			"viewWeight":   weights.View,
+			"ratingWeight": weights.Rating,
+			"listWeight":   weights.List,
+		}).Error
+	})
+}
+
+func (r *Repository) GetTrending(ctx context.Context, window string, pageSize int, offset int) (result []models.FilmPaginated, err error) {
+	err = r.db.WithContext(ctx).Raw(getTrending, window, pageSize, offset).Scan(&result).Error
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetNewReleases lists the films already released, latest first
+func (r *Repository) GetNewReleases(ctx context.Context, pageSize int, offset int) (result []models.FilmPaginated, err error) {
+	err = r.db.WithContext(ctx).Raw(getNewReleases, utils.TimeNowInUTC(), pageSize, offset).Scan(&result).Error
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
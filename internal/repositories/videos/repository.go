@@ -0,0 +1,128 @@
+package videos
+
+import (
+	"KTOnlinePlatform/internal/models/consts"
+	"KTOnlinePlatform/pkg/database/entities"
+	"KTOnlinePlatform/pkg/database/entities/entitiescustom"
+	"context"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+type Repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) *Repository {
+	return &Repository{db: db}
+}
+
+func (r *Repository) GetFilm(ctx context.Context, ID int) (film entities.Film, err error) {
+	err = r.db.WithContext(ctx).First(&film, ID).Error
+	if err != nil {
+		return film, err
+	}
+	return film, nil
+}
+
+func (r *Repository) GetVideoAsset(ctx context.Context, filmID int) (asset entities.VideoAsset, err error) {
+	err = r.db.WithContext(ctx).Where("film_id = ?", filmID).Take(&asset).Error
+	if err != nil {
+		return asset, err
+	}
+	return asset, nil
+}
+
+// SaveVideoAsset records a new upload of the film video, the version being streamed is kept.
+// An upload still transcoding is never replaced, gorm.ErrRecordNotFound is returned instead. One
+// not updated for consts.VideoProcessingTimeout lost its job and is replaced
+func (r *Repository) SaveVideoAsset(ctx context.Context, asset entities.VideoAsset) error {
+	result := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "film_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"status", "version", "error", "updated_at"}),
+		Where: clause.Where{Exprs: []clause.Expression{
+			clause.Expr{SQL: "video_assets.status NOT IN (?, ?) OR video_assets.updated_at < ?",
+				Vars: []interface{}{consts.VideoStatusPending, consts.VideoStatusProcessing,
+					time.Now().Add(-consts.VideoProcessingTimeout)}},
+		}},
+	}).Omit("ready_version", "renditions", "created_at").Create(&asset)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// SetVideoStatus only applies to the given version, gorm.ErrRecordNotFound is returned when
+// another upload replaced it
+func (r *Repository) SetVideoStatus(ctx context.Context, filmID int, version string, status string, message string) error {
+	return r.updateVersion(ctx, filmID, version, map[string]interface{}{
+		"status":     status,
+		"error":      message,
+		"updated_at": time.Now(),
+	})
+}
+
+// SetVideoReady makes the given version the one streamed, the version streamed until then is
+// retired in the same transaction
+func (r *Repository) SetVideoReady(ctx context.Context, filmID int, version string, renditions entitiescustom.VideoRenditions) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var asset entities.VideoAsset
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("film_id = ? AND version = ?", filmID, version).
+			Take(&asset).Error
+		if err != nil {
+			return err
+		}
+		if asset.ReadyVersion != "" && asset.ReadyVersion != version {
+			err = tx.Omit("retired_at").Create(&entities.VideoRetiredVersion{
+				FilmID:     filmID,
+				Version:    asset.ReadyVersion,
+				Renditions: asset.Renditions,
+			}).Error
+			if err != nil {
+				return err
+			}
+		}
+		return updateVersion(tx, filmID, version, map[string]interface{}{
+			"status":        consts.VideoStatusReady,
+			"ready_version": version,
+			"renditions":    renditions,
+			"error":         "",
+			"updated_at":    time.Now(),
+		})
+	})
+}
+
+// GetRetiredVideoVersions lists the versions retired before the given time
+func (r *Repository) GetRetiredVideoVersions(ctx context.Context, before time.Time) (result []entities.VideoRetiredVersion, err error) {
+	err = r.db.WithContext(ctx).Where("retired_at < ?", before).Order("id").Find(&result).Error
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (r *Repository) DeleteRetiredVideoVersion(ctx context.Context, ID int) error {
+	return r.db.WithContext(ctx).Delete(&entities.VideoRetiredVersion{}, ID).Error
+}
+
+func (r *Repository) updateVersion(ctx context.Context, filmID int, version string, values map[string]interface{}) error {
+	return updateVersion(r.db.WithContext(ctx), filmID, version, values)
+}
+
+func updateVersion(db *gorm.DB, filmID int, version string, values map[string]interface{}) error {
+	result := db.Model(&entities.VideoAsset{}).
+		Where("film_id = ? AND version = ?", filmID, version).
+		Updates(values)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
@@ -60,6 +60,34 @@ func (_m *Repository) FindUser(ctx context.Context, username string) (entities.U
 	return r0, r1
 }
 
+// GetUser provides a mock function with given fields: ctx, ID
+func (_m *Repository) GetUser(ctx context.Context, ID int) (entities.User, error) {
+	ret := _m.Called(ctx, ID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUser")
+	}
+
+	var r0 entities.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (entities.User, error)); ok {
+		return rf(ctx, ID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) entities.User); ok {
+		r0 = rf(ctx, ID)
+	} else {
+		r0 = ret.Get(0).(entities.User)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, ID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewRepository creates a new instance of Repository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewRepository(t interface {
@@ -41,6 +41,41 @@ func (_m *TokensGeneration) GenerateAuthTokens(userID int, username string) (dto
 	return r0, r1
 }
 
+// ParseRefreshToken provides a mock function with given fields: refreshToken
+func (_m *TokensGeneration) ParseRefreshToken(refreshToken string) (int, string, error) {
+	ret := _m.Called(refreshToken)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ParseRefreshToken")
+	}
+
+	var r0 int
+	var r1 string
+	var r2 error
+	if rf, ok := ret.Get(0).(func(string) (int, string, error)); ok {
+		return rf(refreshToken)
+	}
+	if rf, ok := ret.Get(0).(func(string) int); ok {
+		r0 = rf(refreshToken)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) string); ok {
+		r1 = rf(refreshToken)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	if rf, ok := ret.Get(2).(func(string) error); ok {
+		r2 = rf(refreshToken)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // NewTokensGeneration creates a new instance of TokensGeneration. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewTokensGeneration(t interface {
@@ -7,21 +7,35 @@ import (
 	"KTOnlinePlatform/pkg/database/entities"
 	"KTOnlinePlatform/pkg/logger"
 	"context"
+	"fmt"
 	"golang.org/x/crypto/bcrypt"
+	"net/http"
 	"regexp"
 )
 
 const (
-	pwMinLength = 8
+	pwMinLength   = 8
+	passwordField = "password"
 )
 
+// passwordRules orders the rules of the password policy in the field errors
+var passwordRules = []string{
+	kterrors.NeedAtLeastLength,
+	kterrors.NeedAtLeastOneUppercaseChar,
+	kterrors.NeedAtLeastOneLowercaseChar,
+	kterrors.NeedAtLeastOneNumber,
+	kterrors.NeedAtLeastOneSpecialChar,
+}
+
 type Repository interface {
 	FindUser(ctx context.Context, username string) (entities.User, error)
+	GetUser(ctx context.Context, ID int) (entities.User, error)
 	CreateUser(ctx context.Context, username, password string) error
 }
 
 type TokensGeneration interface {
 	GenerateAuthTokens(userID int, username string) (dto.JWTTokens, error)
+	ParseRefreshToken(refreshToken string) (int, string, error)
 }
 
 type Service struct {
@@ -50,6 +64,9 @@ func (s *Service) Login(ctx context.Context, request dto.Login) (dto.JWTTokens,
 		logger.Error().Err(err).Msg("passwords do not match")
 		return dto.JWTTokens{}, customerror.NewCustomError(kterrors.WrongLoginCredentialsError)
 	}
+	if user.SuspendedAt != nil {
+		return dto.JWTTokens{}, customerror.NewCustomErrorWithHttpCode(kterrors.UserSuspendedError, http.StatusForbidden)
+	}
 	jwtTokens, err := s.tg.GenerateAuthTokens(user.ID, user.Username)
 	if err != nil {
 		return dto.JWTTokens{}, err
@@ -57,6 +74,47 @@ func (s *Service) Login(ctx context.Context, request dto.Login) (dto.JWTTokens,
 	return jwtTokens, nil
 }
 
+// Refresh exchanges a refresh token for new tokens, as long as its user can still log in
+func (s *Service) Refresh(ctx context.Context, request dto.RefreshRequest) (dto.JWTTokens, error) {
+	logger.Debug().Msg("Refresh service")
+	userID, username, err := s.tg.ParseRefreshToken(request.RefreshToken)
+	if err != nil {
+		logger.Error().Err(err).Msg("invalid refresh token")
+		return dto.JWTTokens{}, customerror.NewCustomErrorWithHttpCode(kterrors.InvalidRefreshTokenError, http.StatusUnauthorized)
+	}
+	user, err := s.repo.FindUser(ctx, username)
+	if err != nil {
+		if customerror.IsNotFoundError(err) {
+			return dto.JWTTokens{}, customerror.NewCustomErrorWithHttpCode(kterrors.InvalidRefreshTokenError, http.StatusUnauthorized)
+		}
+		return dto.JWTTokens{}, err
+	}
+	// the username may belong to another user since the token was issued
+	if user.ID != userID {
+		return dto.JWTTokens{}, customerror.NewCustomErrorWithHttpCode(kterrors.InvalidRefreshTokenError, http.StatusUnauthorized)
+	}
+	if user.SuspendedAt != nil {
+		return dto.JWTTokens{}, customerror.NewCustomErrorWithHttpCode(kterrors.UserSuspendedError, http.StatusForbidden)
+	}
+	return s.tg.GenerateAuthTokens(user.ID, user.Username)
+}
+
+// GetUser returns the user an access token was issued to
+func (s *Service) GetUser(ctx context.Context, userID int) (dto.User, error) {
+	user, err := s.repo.GetUser(ctx, userID)
+	if err != nil {
+		if customerror.IsNotFoundError(err) {
+			return dto.User{}, customerror.NewCustomErrorWithHttpCode(kterrors.UserNotFoundError, http.StatusNotFound)
+		}
+		return dto.User{}, err
+	}
+	return dto.User{
+		ID:       user.ID,
+		Username: user.Username,
+		Role:     user.Role,
+	}, nil
+}
+
 func (s *Service) CreateUser(ctx context.Context, request dto.CreateUserRequest) error {
 	if err := validateUsername(request.Username); err != nil {
 		return err
@@ -116,11 +174,30 @@ func validate(password string) error {
 	}
 
 	if len(errorMap) > 0 {
-		return customerror.NewI18nErrorWithParams(kterrors.InvalidPasswordError, errorMap)
+		invalidPasswordError := customerror.NewValidationError(kterrors.InvalidPasswordError, passwordFieldErrors(errorMap))
+		invalidPasswordError.Params = errorMap
+		return invalidPasswordError
 	}
 	return nil
 }
 
+// passwordFieldErrors lists the broken rules of the policy like the validation of the other fields
+func passwordFieldErrors(errorMap map[string]interface{}) []customerror.FieldError {
+	fields := make([]customerror.FieldError, 0, len(errorMap))
+	for _, rule := range passwordRules {
+		value, ok := errorMap[rule]
+		if !ok {
+			continue
+		}
+		field := customerror.FieldError{Field: passwordField, Rule: rule}
+		if value != true {
+			field.Param = fmt.Sprint(value)
+		}
+		fields = append(fields, field)
+	}
+	return fields
+}
+
 func matchRegexp(regex, src string) bool {
 	matched, err := regexp.MatchString(regex, src)
 	if err != nil {
@@ -8,6 +8,7 @@ import (
 	"errors"
 	"gorm.io/gorm"
 	"testing"
+	"time"
 
 	"KTOnlinePlatform/internal/dto"
 	"KTOnlinePlatform/internal/models/kterrors"
@@ -78,6 +79,24 @@ func TestLogin(t *testing.T) {
 			expectedError: kterrors.WrongLoginCredentialsError,
 			expectedToken: dto.JWTTokens{},
 		},
+		{
+			name:     "Suspended user",
+			username: "validuser",
+			password: "ValidPassword123!",
+			setupMocks: func(repo *mocks.Repository, tokenGen *mocks.TokensGeneration) {
+				hashedPassword, _ := bcrypt.GenerateFromPassword([]byte("ValidPassword123!"), bcrypt.DefaultCost)
+				suspendedAt := time.Now()
+				user := entities.User{
+					ID:          1,
+					Username:    "validuser",
+					Password:    string(hashedPassword),
+					SuspendedAt: &suspendedAt,
+				}
+				repo.On("FindUser", mock.Anything, "validuser").Return(user, nil)
+			},
+			expectedError: kterrors.UserSuspendedError,
+			expectedToken: dto.JWTTokens{},
+		},
 		{
 			name:     "Token generation error",
 			username: "validuser",
@@ -148,6 +167,143 @@ func TestLogin(t *testing.T) {
 	}
 }
 
+func TestGetUser(t *testing.T) {
+	logger.InitializeForTest()
+	testCases := []struct {
+		name           string
+		setupMocks     func(*mocks.Repository)
+		expectedError  string
+		expectedResult dto.User
+	}{
+		{
+			name: "Success",
+			setupMocks: func(repo *mocks.Repository) {
+				repo.On("GetUser", mock.Anything, 1).Return(entities.User{ID: 1, Username: "validuser", Password: "hash", Role: "ADMIN"}, nil)
+			},
+			expectedResult: dto.User{ID: 1, Username: "validuser", Role: "ADMIN"},
+		},
+		{
+			name: "User deleted",
+			setupMocks: func(repo *mocks.Repository) {
+				repo.On("GetUser", mock.Anything, 1).Return(entities.User{}, gorm.ErrRecordNotFound)
+			},
+			expectedError: kterrors.UserNotFoundError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := new(mocks.Repository)
+			service := authentication.NewService(mockRepo, new(mocks.TokensGeneration))
+			tc.setupMocks(mockRepo)
+
+			result, err := service.GetUser(context.Background(), 1)
+
+			if tc.expectedError != "" {
+				assert.Error(t, err)
+				if customErr, ok := err.(*customerror.CustomError); ok {
+					assert.Equal(t, tc.expectedError, customErr.Code)
+				} else {
+					assert.Contains(t, err.Error(), tc.expectedError)
+				}
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.expectedResult, result)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRefresh(t *testing.T) {
+	logger.InitializeForTest()
+	tokens := dto.JWTTokens{
+		AccessToken:  "access-token",
+		RefreshToken: "refresh-token",
+	}
+	suspendedAt := time.Now()
+	testCases := []struct {
+		name          string
+		setupMocks    func(*mocks.Repository, *mocks.TokensGeneration)
+		expectedError string
+		expectedToken dto.JWTTokens
+	}{
+		{
+			name: "Success",
+			setupMocks: func(repo *mocks.Repository, tokenGen *mocks.TokensGeneration) {
+				tokenGen.On("ParseRefreshToken", "old-refresh-token").Return(1, "validuser", nil)
+				repo.On("FindUser", mock.Anything, "validuser").Return(entities.User{ID: 1, Username: "validuser"}, nil)
+				tokenGen.On("GenerateAuthTokens", 1, "validuser").Return(tokens, nil)
+			},
+			expectedToken: tokens,
+		},
+		{
+			name: "Invalid token",
+			setupMocks: func(repo *mocks.Repository, tokenGen *mocks.TokensGeneration) {
+				tokenGen.On("ParseRefreshToken", "old-refresh-token").Return(0, "", errors.New("token is expired"))
+			},
+			expectedError: kterrors.InvalidRefreshTokenError,
+		},
+		{
+			name: "User deleted",
+			setupMocks: func(repo *mocks.Repository, tokenGen *mocks.TokensGeneration) {
+				tokenGen.On("ParseRefreshToken", "old-refresh-token").Return(1, "validuser", nil)
+				repo.On("FindUser", mock.Anything, "validuser").Return(entities.User{}, gorm.ErrRecordNotFound)
+			},
+			expectedError: kterrors.InvalidRefreshTokenError,
+		},
+		{
+			name: "Username of another user",
+			setupMocks: func(repo *mocks.Repository, tokenGen *mocks.TokensGeneration) {
+				tokenGen.On("ParseRefreshToken", "old-refresh-token").Return(1, "validuser", nil)
+				repo.On("FindUser", mock.Anything, "validuser").Return(entities.User{ID: 2, Username: "validuser"}, nil)
+			},
+			expectedError: kterrors.InvalidRefreshTokenError,
+		},
+		{
+			name: "Suspended user",
+			setupMocks: func(repo *mocks.Repository, tokenGen *mocks.TokensGeneration) {
+				tokenGen.On("ParseRefreshToken", "old-refresh-token").Return(1, "validuser", nil)
+				repo.On("FindUser", mock.Anything, "validuser").Return(entities.User{ID: 1, Username: "validuser", SuspendedAt: &suspendedAt}, nil)
+			},
+			expectedError: kterrors.UserSuspendedError,
+		},
+		{
+			name: "Repository error",
+			setupMocks: func(repo *mocks.Repository, tokenGen *mocks.TokensGeneration) {
+				tokenGen.On("ParseRefreshToken", "old-refresh-token").Return(1, "validuser", nil)
+				repo.On("FindUser", mock.Anything, "validuser").Return(entities.User{}, errors.New("database error"))
+			},
+			expectedError: "database error",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := new(mocks.Repository)
+			mockTokenGen := new(mocks.TokensGeneration)
+			service := authentication.NewService(mockRepo, mockTokenGen)
+			tc.setupMocks(mockRepo, mockTokenGen)
+
+			result, err := service.Refresh(context.Background(), dto.RefreshRequest{RefreshToken: "old-refresh-token"})
+
+			if tc.expectedError != "" {
+				assert.Error(t, err)
+				if customErr, ok := err.(*customerror.CustomError); ok {
+					assert.Equal(t, tc.expectedError, customErr.Code)
+				} else {
+					assert.Contains(t, err.Error(), tc.expectedError)
+				}
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.expectedToken, result)
+			mockRepo.AssertExpectations(t)
+			mockTokenGen.AssertExpectations(t)
+		})
+	}
+}
+
 func TestCreateUser(t *testing.T) {
 	testCases := []struct {
 		name          string
@@ -273,3 +429,24 @@ func TestCreateUser(t *testing.T) {
 		})
 	}
 }
+
+func TestCreateUserPasswordPolicyFields(t *testing.T) {
+	logger.InitializeForTest()
+	service := authentication.NewService(new(mocks.Repository), new(mocks.TokensGeneration))
+
+	err := service.CreateUser(context.Background(), dto.CreateUserRequest{Login: dto.Login{
+		Username: "validuser",
+		Password: "short",
+	}})
+
+	customErr, ok := err.(*customerror.CustomError)
+	assert.True(t, ok)
+	assert.Equal(t, kterrors.InvalidPasswordError, customErr.Code)
+	assert.Equal(t, []customerror.FieldError{
+		{Field: "password", Rule: kterrors.NeedAtLeastLength, Param: "8"},
+		{Field: "password", Rule: kterrors.NeedAtLeastOneUppercaseChar},
+		{Field: "password", Rule: kterrors.NeedAtLeastOneNumber},
+		{Field: "password", Rule: kterrors.NeedAtLeastOneSpecialChar},
+	}, customErr.Fields)
+	assert.Equal(t, 8, customErr.Params[kterrors.NeedAtLeastLength])
+}
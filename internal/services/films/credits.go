@@ -0,0 +1,66 @@
+package films
+
+import (
+	"KTOnlinePlatform/internal/dto"
+	"KTOnlinePlatform/internal/models"
+	"KTOnlinePlatform/internal/models/consts"
+	"KTOnlinePlatform/internal/models/kterrors"
+	"KTOnlinePlatform/pkg/customerror"
+	"KTOnlinePlatform/pkg/database/entities"
+	"context"
+	"github.com/samber/lo"
+)
+
+// AddFilmCredit credits a person on a film, only the creator of the film can do it
+func (s *Service) AddFilmCredit(ctx context.Context, request dto.FilmCreditCreateRequest) error {
+	if request.CharacterName != "" && request.Role != consts.CreditRoleActor {
+		return customerror.NewCustomError(kterrors.CharacterNameOnlyForActorError)
+	}
+	film, err := s.getFilm(ctx, request.FilmID)
+	if err != nil {
+		return err
+	}
+	if film.UserID != request.UserID {
+		return customerror.NewCustomError(kterrors.UserCannotUpdateFilmError)
+	}
+	err = s.repo.CreateFilmCredit(ctx, entities.FilmCredit{
+		FilmID:        request.FilmID,
+		PersonID:      request.PersonID,
+		Role:          request.Role,
+		CharacterName: request.CharacterName,
+		Position:      request.Position,
+	})
+	if err != nil {
+		if customerror.IsUniqueViolation(err) {
+			return customerror.NewCustomError(kterrors.FilmCreditAlreadyExistsError)
+		}
+		if customerror.IsForeignKeyViolation(err) {
+			return customerror.NewCustomError(kterrors.PersonNotFoundError)
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *Service) DeleteFilmCredit(ctx context.Context, filmID int, creditID int, userID int) error {
+	film, err := s.getFilm(ctx, filmID)
+	if err != nil {
+		return err
+	}
+	if film.UserID != userID {
+		return customerror.NewCustomError(kterrors.UserCannotUpdateFilmError)
+	}
+	return s.repo.DeleteFilmCredit(ctx, filmID, creditID)
+}
+
+func toFilmCredits(credits []models.FilmCredit) []dto.FilmCredit {
+	return lo.Map(credits, func(item models.FilmCredit, index int) dto.FilmCredit {
+		return dto.FilmCredit{
+			ID:            item.ID,
+			PersonID:      item.PersonID,
+			Name:          item.Name,
+			Role:          item.Role,
+			CharacterName: item.CharacterName,
+		}
+	})
+}
@@ -0,0 +1,188 @@
+package films
+
+import (
+	"KTOnlinePlatform/internal/dto"
+	"KTOnlinePlatform/internal/models/consts"
+	"KTOnlinePlatform/internal/models/kterrors"
+	"KTOnlinePlatform/pkg/customerror"
+	"KTOnlinePlatform/pkg/database/entities"
+	"KTOnlinePlatform/pkg/database/entities/entitiescustom"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+)
+
+var exportContentTypes = map[string]string{
+	consts.ExportFormatCSV:    consts.CSVContentType,
+	consts.ExportFormatJSON:   consts.JSONContentType,
+	consts.ExportFormatNDJSON: consts.NDJSONContentType,
+}
+
+var exportCSVHeader = []string{"id", "title", "director", "release_date", "synopsis"}
+
+// ExportContentType returns the content type of an export format, it fails for unknown formats
+func (s *Service) ExportContentType(format string) (string, error) {
+	contentType, ok := exportContentTypes[format]
+	if !ok {
+		return "", customerror.NewI18nErrorWithParams(
+			kterrors.UnsupportedExportFormatError,
+			map[string]interface{}{"format": format})
+	}
+	return contentType, nil
+}
+
+// ExportFilms streams the filtered catalog to w, flushing regularly when w supports it. Nothing
+// is written before the first film is read, so an export failing to start leaves w untouched
+func (s *Service) ExportFilms(ctx context.Context, request dto.FilmExportRequest, w io.Writer) error {
+	if _, err := s.ExportContentType(request.Format); err != nil {
+		return err
+	}
+	writer := newExportWriter(request.Format, w)
+	started := false
+
+	flusher, _ := w.(http.Flusher)
+	count := 0
+	err := s.repo.StreamFilms(ctx, toFilmFilter(request.FilmFilter), func(film entities.Film) error {
+		if !started {
+			started = true
+			err := writer.begin()
+			if err != nil {
+				return err
+			}
+		}
+		err := writer.write(toExportRow(film))
+		if err != nil {
+			return err
+		}
+		count++
+		if count%consts.ExportFlushEvery == 0 && flusher != nil {
+			err = writer.flush()
+			if err != nil {
+				return err
+			}
+			flusher.Flush()
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if !started {
+		err = writer.begin()
+		if err != nil {
+			return err
+		}
+	}
+	return writer.end()
+}
+
+func toExportRow(film entities.Film) dto.FilmExportRow {
+	row := dto.FilmExportRow{
+		ID:       film.ID,
+		Title:    film.Title,
+		Director: film.Director,
+		Synopsis: film.Synopsis,
+	}
+	if !film.ReleaseDate.IsZero() {
+		releaseDate := film.ReleaseDate
+		row.ReleaseDate = &releaseDate
+	}
+	return row
+}
+
+type exportWriter interface {
+	begin() error
+	write(row dto.FilmExportRow) error
+	flush() error
+	end() error
+}
+
+func newExportWriter(format string, w io.Writer) exportWriter {
+	switch format {
+	case consts.ExportFormatCSV:
+		return &csvExportWriter{writer: csv.NewWriter(w)}
+	case consts.ExportFormatJSON:
+		return &jsonExportWriter{w: w, encoder: json.NewEncoder(w)}
+	default:
+		return &ndjsonExportWriter{encoder: json.NewEncoder(w)}
+	}
+}
+
+type csvExportWriter struct {
+	writer *csv.Writer
+}
+
+func (c *csvExportWriter) begin() error {
+	return c.writer.Write(exportCSVHeader)
+}
+
+func (c *csvExportWriter) write(row dto.FilmExportRow) error {
+	releaseDate := ""
+	if row.ReleaseDate != nil {
+		releaseDate = row.ReleaseDate.Format(entitiescustom.ReleaseDateFormat)
+	}
+	return c.writer.Write([]string{strconv.Itoa(row.ID), row.Title, row.Director, releaseDate, row.Synopsis})
+}
+
+func (c *csvExportWriter) flush() error {
+	c.writer.Flush()
+	return c.writer.Error()
+}
+
+func (c *csvExportWriter) end() error {
+	return c.flush()
+}
+
+// jsonExportWriter writes a single array, one element at a time
+type jsonExportWriter struct {
+	w       io.Writer
+	encoder *json.Encoder
+	written bool
+}
+
+func (j *jsonExportWriter) begin() error {
+	_, err := io.WriteString(j.w, "[")
+	return err
+}
+
+func (j *jsonExportWriter) write(row dto.FilmExportRow) error {
+	if j.written {
+		if _, err := io.WriteString(j.w, ","); err != nil {
+			return err
+		}
+	}
+	j.written = true
+	return j.encoder.Encode(row)
+}
+
+func (j *jsonExportWriter) flush() error {
+	return nil
+}
+
+func (j *jsonExportWriter) end() error {
+	_, err := io.WriteString(j.w, "]\n")
+	return err
+}
+
+type ndjsonExportWriter struct {
+	encoder *json.Encoder
+}
+
+func (n *ndjsonExportWriter) begin() error {
+	return nil
+}
+
+func (n *ndjsonExportWriter) write(row dto.FilmExportRow) error {
+	return n.encoder.Encode(row)
+}
+
+func (n *ndjsonExportWriter) flush() error {
+	return nil
+}
+
+func (n *ndjsonExportWriter) end() error {
+	return nil
+}
@@ -16,6 +16,34 @@ type Repository struct {
 	mock.Mock
 }
 
+// CountFilms provides a mock function with given fields: ctx, filter
+func (_m *Repository) CountFilms(ctx context.Context, filter models.FilmFilter) (int, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountFilms")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.FilmFilter) (int, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.FilmFilter) int); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.FilmFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreateFilm provides a mock function with given fields: ctx, film
 func (_m *Repository) CreateFilm(ctx context.Context, film entities.Film) error {
 	ret := _m.Called(ctx, film)
@@ -34,6 +62,24 @@ func (_m *Repository) CreateFilm(ctx context.Context, film entities.Film) error
 	return r0
 }
 
+// CreateFilmCredit provides a mock function with given fields: ctx, credit
+func (_m *Repository) CreateFilmCredit(ctx context.Context, credit entities.FilmCredit) error {
+	ret := _m.Called(ctx, credit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateFilmCredit")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entities.FilmCredit) error); ok {
+		r0 = rf(ctx, credit)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeleteFilm provides a mock function with given fields: ctx, id
 func (_m *Repository) DeleteFilm(ctx context.Context, id int) error {
 	ret := _m.Called(ctx, id)
@@ -52,6 +98,100 @@ func (_m *Repository) DeleteFilm(ctx context.Context, id int) error {
 	return r0
 }
 
+// DeleteFilmCredit provides a mock function with given fields: ctx, filmID, creditID
+func (_m *Repository) DeleteFilmCredit(ctx context.Context, filmID int, creditID int) error {
+	ret := _m.Called(ctx, filmID, creditID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteFilmCredit")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) error); ok {
+		r0 = rf(ctx, filmID, creditID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteFilmSubtitle provides a mock function with given fields: ctx, filmID, language
+func (_m *Repository) DeleteFilmSubtitle(ctx context.Context, filmID int, language string) (entities.FilmSubtitle, error) {
+	ret := _m.Called(ctx, filmID, language)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteFilmSubtitle")
+	}
+
+	var r0 entities.FilmSubtitle
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, string) (entities.FilmSubtitle, error)); ok {
+		return rf(ctx, filmID, language)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, string) entities.FilmSubtitle); ok {
+		r0 = rf(ctx, filmID, language)
+	} else {
+		r0 = ret.Get(0).(entities.FilmSubtitle)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, string) error); ok {
+		r1 = rf(ctx, filmID, language)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteFilmTranslation provides a mock function with given fields: ctx, filmID, locale
+func (_m *Repository) DeleteFilmTranslation(ctx context.Context, filmID int, locale string) error {
+	ret := _m.Called(ctx, filmID, locale)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteFilmTranslation")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, string) error); ok {
+		r0 = rf(ctx, filmID, locale)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FindFilmTranslations provides a mock function with given fields: ctx, filmIDs, locales
+func (_m *Repository) FindFilmTranslations(ctx context.Context, filmIDs []int, locales []string) ([]entities.FilmTranslation, error) {
+	ret := _m.Called(ctx, filmIDs, locales)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindFilmTranslations")
+	}
+
+	var r0 []entities.FilmTranslation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []int, []string) ([]entities.FilmTranslation, error)); ok {
+		return rf(ctx, filmIDs, locales)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []int, []string) []entities.FilmTranslation); ok {
+		r0 = rf(ctx, filmIDs, locales)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entities.FilmTranslation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []int, []string) error); ok {
+		r1 = rf(ctx, filmIDs, locales)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetFilm provides a mock function with given fields: ctx, ID
 func (_m *Repository) GetFilm(ctx context.Context, ID int) (entities.Film, error) {
 	ret := _m.Called(ctx, ID)
@@ -80,9 +220,189 @@ func (_m *Repository) GetFilm(ctx context.Context, ID int) (entities.Film, error
 	return r0, r1
 }
 
-// GetFilmsPaginated provides a mock function with given fields: ctx, pageSize, offset
-func (_m *Repository) GetFilmsPaginated(ctx context.Context, pageSize int, offset int) ([]models.FilmPaginated, error) {
-	ret := _m.Called(ctx, pageSize, offset)
+// GetFilmCredits provides a mock function with given fields: ctx, filmID
+func (_m *Repository) GetFilmCredits(ctx context.Context, filmID int) ([]models.FilmCredit, error) {
+	ret := _m.Called(ctx, filmID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFilmCredits")
+	}
+
+	var r0 []models.FilmCredit
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]models.FilmCredit, error)); ok {
+		return rf(ctx, filmID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []models.FilmCredit); ok {
+		r0 = rf(ctx, filmID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.FilmCredit)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, filmID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetFilmSubtitles provides a mock function with given fields: ctx, filmID
+func (_m *Repository) GetFilmSubtitles(ctx context.Context, filmID int) ([]entities.FilmSubtitle, error) {
+	ret := _m.Called(ctx, filmID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFilmSubtitles")
+	}
+
+	var r0 []entities.FilmSubtitle
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]entities.FilmSubtitle, error)); ok {
+		return rf(ctx, filmID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []entities.FilmSubtitle); ok {
+		r0 = rf(ctx, filmID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entities.FilmSubtitle)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, filmID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetFilmTranslations provides a mock function with given fields: ctx, filmID
+func (_m *Repository) GetFilmTranslations(ctx context.Context, filmID int) ([]entities.FilmTranslation, error) {
+	ret := _m.Called(ctx, filmID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFilmTranslations")
+	}
+
+	var r0 []entities.FilmTranslation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]entities.FilmTranslation, error)); ok {
+		return rf(ctx, filmID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []entities.FilmTranslation); ok {
+		r0 = rf(ctx, filmID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entities.FilmTranslation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, filmID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetFilms provides a mock function with given fields: ctx, IDs
+func (_m *Repository) GetFilms(ctx context.Context, IDs []int) ([]entities.Film, error) {
+	ret := _m.Called(ctx, IDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFilms")
+	}
+
+	var r0 []entities.Film
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []int) ([]entities.Film, error)); ok {
+		return rf(ctx, IDs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []int) []entities.Film); ok {
+		r0 = rf(ctx, IDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entities.Film)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []int) error); ok {
+		r1 = rf(ctx, IDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetFilmsCredits provides a mock function with given fields: ctx, filmIDs
+func (_m *Repository) GetFilmsCredits(ctx context.Context, filmIDs []int) ([]models.FilmCredit, error) {
+	ret := _m.Called(ctx, filmIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFilmsCredits")
+	}
+
+	var r0 []models.FilmCredit
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []int) ([]models.FilmCredit, error)); ok {
+		return rf(ctx, filmIDs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []int) []models.FilmCredit); ok {
+		r0 = rf(ctx, filmIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.FilmCredit)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []int) error); ok {
+		r1 = rf(ctx, filmIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetFilmsKeyset provides a mock function with given fields: ctx, filter, cursor, limit
+func (_m *Repository) GetFilmsKeyset(ctx context.Context, filter models.FilmFilter, cursor models.FilmCursor, limit int) ([]models.FilmPaginated, error) {
+	ret := _m.Called(ctx, filter, cursor, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFilmsKeyset")
+	}
+
+	var r0 []models.FilmPaginated
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.FilmFilter, models.FilmCursor, int) ([]models.FilmPaginated, error)); ok {
+		return rf(ctx, filter, cursor, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.FilmFilter, models.FilmCursor, int) []models.FilmPaginated); ok {
+		r0 = rf(ctx, filter, cursor, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.FilmPaginated)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.FilmFilter, models.FilmCursor, int) error); ok {
+		r1 = rf(ctx, filter, cursor, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetFilmsPaginated provides a mock function with given fields: ctx, filter, sort, pageSize, offset
+func (_m *Repository) GetFilmsPaginated(ctx context.Context, filter models.FilmFilter, sort string, pageSize int, offset int) ([]models.FilmPaginated, error) {
+	ret := _m.Called(ctx, filter, sort, pageSize, offset)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetFilmsPaginated")
@@ -90,19 +410,19 @@ func (_m *Repository) GetFilmsPaginated(ctx context.Context, pageSize int, offse
 
 	var r0 []models.FilmPaginated
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, int, int) ([]models.FilmPaginated, error)); ok {
-		return rf(ctx, pageSize, offset)
+	if rf, ok := ret.Get(0).(func(context.Context, models.FilmFilter, string, int, int) ([]models.FilmPaginated, error)); ok {
+		return rf(ctx, filter, sort, pageSize, offset)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, int, int) []models.FilmPaginated); ok {
-		r0 = rf(ctx, pageSize, offset)
+	if rf, ok := ret.Get(0).(func(context.Context, models.FilmFilter, string, int, int) []models.FilmPaginated); ok {
+		r0 = rf(ctx, filter, sort, pageSize, offset)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]models.FilmPaginated)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
-		r1 = rf(ctx, pageSize, offset)
+	if rf, ok := ret.Get(1).(func(context.Context, models.FilmFilter, string, int, int) error); ok {
+		r1 = rf(ctx, filter, sort, pageSize, offset)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -110,6 +430,108 @@ func (_m *Repository) GetFilmsPaginated(ctx context.Context, pageSize int, offse
 	return r0, r1
 }
 
+// GetFilmsSubtitles provides a mock function with given fields: ctx, filmIDs
+func (_m *Repository) GetFilmsSubtitles(ctx context.Context, filmIDs []int) ([]entities.FilmSubtitle, error) {
+	ret := _m.Called(ctx, filmIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFilmsSubtitles")
+	}
+
+	var r0 []entities.FilmSubtitle
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []int) ([]entities.FilmSubtitle, error)); ok {
+		return rf(ctx, filmIDs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []int) []entities.FilmSubtitle); ok {
+		r0 = rf(ctx, filmIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entities.FilmSubtitle)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []int) error); ok {
+		r1 = rf(ctx, filmIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SaveFilmSubtitle provides a mock function with given fields: ctx, subtitle
+func (_m *Repository) SaveFilmSubtitle(ctx context.Context, subtitle entities.FilmSubtitle) error {
+	ret := _m.Called(ctx, subtitle)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveFilmSubtitle")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entities.FilmSubtitle) error); ok {
+		r0 = rf(ctx, subtitle)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SaveFilmTranslation provides a mock function with given fields: ctx, translation
+func (_m *Repository) SaveFilmTranslation(ctx context.Context, translation entities.FilmTranslation) error {
+	ret := _m.Called(ctx, translation)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveFilmTranslation")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entities.FilmTranslation) error); ok {
+		r0 = rf(ctx, translation)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetFilmPoster provides a mock function with given fields: ctx, filmID, posterKey
+func (_m *Repository) SetFilmPoster(ctx context.Context, filmID int, posterKey string) error {
+	ret := _m.Called(ctx, filmID, posterKey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetFilmPoster")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, string) error); ok {
+		r0 = rf(ctx, filmID, posterKey)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// StreamFilms provides a mock function with given fields: ctx, filter, fn
+func (_m *Repository) StreamFilms(ctx context.Context, filter models.FilmFilter, fn func(entities.Film) error) error {
+	ret := _m.Called(ctx, filter, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StreamFilms")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.FilmFilter, func(entities.Film) error) error); ok {
+		r0 = rf(ctx, filter, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateFilm provides a mock function with given fields: ctx, film
 func (_m *Repository) UpdateFilm(ctx context.Context, film entities.Film) error {
 	ret := _m.Called(ctx, film)
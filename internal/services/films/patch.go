@@ -0,0 +1,126 @@
+package films
+
+import (
+	"KTOnlinePlatform/internal/dto"
+	"KTOnlinePlatform/internal/models/consts"
+	"KTOnlinePlatform/internal/models/kterrors"
+	"KTOnlinePlatform/pkg/customerror"
+	"KTOnlinePlatform/pkg/database/entities"
+	"KTOnlinePlatform/pkg/database/entities/entitiescustom"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	"github.com/go-playground/validator/v10"
+	"net/http"
+)
+
+// PatchFilm applies a JSON Merge Patch (RFC 7386) or a JSON Patch (RFC 6902) to a film.
+// The If-Match version is required like for the updates, the patch applies to that version only.
+func (s *Service) PatchFilm(ctx context.Context, request dto.FilmPatchRequest) error {
+	if request.Version == 0 {
+		return customerror.NewCustomErrorWithHttpCode(kterrors.FilmVersionRequiredError, http.StatusPreconditionRequired)
+	}
+	film, err := s.getFilm(ctx, request.ID)
+	if err != nil {
+		return err
+	}
+	if film.UserID != request.UserID {
+		return customerror.NewCustomError(kterrors.UserCannotUpdateFilmError)
+	}
+	if request.Version != consts.AnyVersion && film.Version != request.Version {
+		return newVersionMismatchError()
+	}
+
+	document, err := applyPatch(toPatchDocument(film), request.PatchType, request.Patch)
+	if err != nil {
+		return err
+	}
+	err = s.validate.Struct(document)
+	var validationErrors validator.ValidationErrors
+	if errors.As(err, &validationErrors) {
+		return customerror.NewValidationError(kterrors.ValidationError, customerror.FieldErrors(validationErrors))
+	}
+	if err != nil {
+		return err
+	}
+	err = s.filter.Check(document.Title, document.Synopsis)
+	if err != nil {
+		return err
+	}
+
+	film.Title = document.Title
+	film.Director = document.Director
+	film.ReleaseDate = entitiescustom.ReleaseDate{}
+	if document.ReleaseDate != nil {
+		film.ReleaseDate = *document.ReleaseDate
+	}
+	film.Synopsis = document.Synopsis
+	err = s.repo.UpdateFilm(ctx, film)
+	if err != nil {
+		if customerror.IsUniqueViolation(err) {
+			return customerror.NewCustomError(kterrors.FilmTitleAlreadyExistsError)
+		}
+		if customerror.IsNotFoundError(err) {
+			return s.updateMissError(ctx, film.ID)
+		}
+		return err
+	}
+	return nil
+}
+
+func toPatchDocument(film entities.Film) dto.FilmPatchDocument {
+	document := dto.FilmPatchDocument{
+		Title:    film.Title,
+		Director: film.Director,
+		Synopsis: film.Synopsis,
+	}
+	if !film.ReleaseDate.IsZero() {
+		releaseDate := film.ReleaseDate
+		document.ReleaseDate = &releaseDate
+	}
+	return document
+}
+
+func applyPatch(document dto.FilmPatchDocument, patchType string, patch []byte) (dto.FilmPatchDocument, error) {
+	original, err := json.Marshal(document)
+	if err != nil {
+		return dto.FilmPatchDocument{}, err
+	}
+
+	var patched []byte
+	switch patchType {
+	case consts.MergePatchContentType:
+		patched, err = jsonpatch.MergePatch(original, patch)
+	case consts.JSONPatchContentType:
+		var operations jsonpatch.Patch
+		operations, err = jsonpatch.DecodePatch(patch)
+		if err == nil {
+			patched, err = operations.Apply(original)
+		}
+	default:
+		return dto.FilmPatchDocument{}, customerror.NewI18nErrorWithParams(
+			kterrors.UnsupportedPatchTypeError,
+			map[string]interface{}{"patchType": patchType})
+	}
+	if err != nil {
+		return dto.FilmPatchDocument{}, newInvalidPatchError(err)
+	}
+
+	// a fresh document, so that removed or null members end up cleared
+	result := dto.FilmPatchDocument{}
+	decoder := json.NewDecoder(bytes.NewReader(patched))
+	decoder.DisallowUnknownFields()
+	err = decoder.Decode(&result)
+	if err != nil {
+		return dto.FilmPatchDocument{}, newInvalidPatchError(err)
+	}
+	return result, nil
+}
+
+func newInvalidPatchError(err error) error {
+	return customerror.NewI18nErrorWithParams(
+		kterrors.InvalidFilmPatchError,
+		map[string]interface{}{"reason": err.Error()})
+}
@@ -0,0 +1,138 @@
+package films
+
+import (
+	"KTOnlinePlatform/internal/dto"
+	"KTOnlinePlatform/internal/models/consts"
+	"KTOnlinePlatform/internal/models/kterrors"
+	"KTOnlinePlatform/pkg/customerror"
+	"KTOnlinePlatform/pkg/imaging"
+	"KTOnlinePlatform/pkg/logger"
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"github.com/samber/lo"
+	_ "golang.org/x/image/webp"
+	"image"
+	_ "image/jpeg"
+	_ "image/png"
+	"net/http"
+)
+
+const (
+	posterKeyFormat   = "posters/%d/%s"
+	posterVersionLen  = 8
+	posterContentType = "image/jpeg"
+)
+
+type posterSize struct {
+	name  string
+	width int
+}
+
+var posterSizes = []posterSize{
+	{name: consts.PosterSizeSmall, width: 185},
+	{name: consts.PosterSizeMedium, width: 342},
+	{name: consts.PosterSizeLarge, width: 780},
+}
+
+// the type is sniffed from the content, the name and the header sent by the client are ignored
+var posterTypes = []string{"image/jpeg", "image/png", "image/webp"}
+
+// UploadPoster lets the creator of a film replace its poster. The upload is decoded and
+// re-encoded into every thumbnail size, under a new key so clients never get a stale cached image
+func (s *Service) UploadPoster(ctx context.Context, request dto.PosterUploadRequest) (dto.Poster, error) {
+	film, err := s.getFilm(ctx, request.FilmID)
+	if err != nil {
+		return dto.Poster{}, err
+	}
+	if film.UserID != request.UserID {
+		return dto.Poster{}, customerror.NewCustomError(kterrors.UserCannotUpdateFilmError)
+	}
+	img, err := decodePoster(request.Data)
+	if err != nil {
+		return dto.Poster{}, err
+	}
+
+	version := make([]byte, posterVersionLen)
+	_, err = rand.Read(version)
+	if err != nil {
+		return dto.Poster{}, err
+	}
+	posterKey := fmt.Sprintf(posterKeyFormat, film.ID, hex.EncodeToString(version))
+	for _, size := range posterSizes {
+		var data []byte
+		data, err = imaging.EncodeJPEG(imaging.Thumbnail(img, size.width), consts.PosterJPEGQuality)
+		if err == nil {
+			err = s.blobs.Put(ctx, posterBlobKey(posterKey, size.name), posterContentType, bytes.NewReader(data), int64(len(data)))
+		}
+		if err != nil {
+			s.deletePoster(ctx, posterKey)
+			return dto.Poster{}, err
+		}
+	}
+
+	err = s.repo.SetFilmPoster(ctx, film.ID, posterKey)
+	if err != nil {
+		s.deletePoster(ctx, posterKey)
+		return dto.Poster{}, err
+	}
+	s.deletePoster(ctx, film.PosterKey)
+	return *s.Poster(posterKey), nil
+}
+
+func decodePoster(data []byte) (image.Image, error) {
+	if len(data) == 0 {
+		return nil, customerror.NewCustomError(kterrors.PosterRequiredError)
+	}
+	if len(data) > consts.PosterMaxSize {
+		return nil, customerror.NewCustomErrorWithHttpCode(kterrors.PosterTooLargeError, http.StatusRequestEntityTooLarge)
+	}
+	contentType := http.DetectContentType(data)
+	if !lo.Contains(posterTypes, contentType) {
+		return nil, customerror.NewCustomErrorWithHttpCode(kterrors.UnsupportedPosterTypeError, http.StatusUnsupportedMediaType)
+	}
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, customerror.NewCustomError(kterrors.InvalidPosterError)
+	}
+	if config.Width*config.Height > consts.PosterMaxPixels {
+		return nil, customerror.NewCustomErrorWithHttpCode(kterrors.PosterTooLargeError, http.StatusRequestEntityTooLarge)
+	}
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, customerror.NewCustomError(kterrors.InvalidPosterError)
+	}
+	return img, nil
+}
+
+// deletePoster is best effort, a leftover thumbnail is only wasted space
+func (s *Service) deletePoster(ctx context.Context, posterKey string) {
+	if posterKey == "" {
+		return
+	}
+	for _, size := range posterSizes {
+		err := s.blobs.Delete(ctx, posterBlobKey(posterKey, size.name))
+		if err != nil {
+			logger.Warn().Err(err).Str("poster", posterKey).Msg("delete poster failed")
+		}
+	}
+}
+
+// Poster signs the thumbnail URLs of a poster, nil for a film without one. Posters are not bound
+// to a user so they can be cached and shared
+func (s *Service) Poster(posterKey string) *dto.Poster {
+	if posterKey == "" {
+		return nil
+	}
+	return &dto.Poster{
+		Small:  s.media.URL(posterBlobKey(posterKey, consts.PosterSizeSmall), consts.PosterURLTTL),
+		Medium: s.media.URL(posterBlobKey(posterKey, consts.PosterSizeMedium), consts.PosterURLTTL),
+		Large:  s.media.URL(posterBlobKey(posterKey, consts.PosterSizeLarge), consts.PosterURLTTL),
+	}
+}
+
+func posterBlobKey(posterKey string, size string) string {
+	return posterKey + "/" + size + ".jpg"
+}
@@ -0,0 +1,141 @@
+package films
+
+import (
+	"KTOnlinePlatform/internal/dto"
+	"KTOnlinePlatform/internal/models/kterrors"
+	"KTOnlinePlatform/internal/services/films/mocks"
+	"KTOnlinePlatform/pkg/blobstore"
+	"KTOnlinePlatform/pkg/cursor"
+	"KTOnlinePlatform/pkg/customerror"
+	"KTOnlinePlatform/pkg/database/entities"
+	"KTOnlinePlatform/pkg/logger"
+	"KTOnlinePlatform/pkg/mediasign"
+	"KTOnlinePlatform/pkg/wordfilter"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"image"
+	"image/png"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func testPNG(width int, height int) []byte {
+	var buf bytes.Buffer
+	_ = png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, width, height)))
+	return buf.Bytes()
+}
+
+func TestUploadPoster(t *testing.T) {
+	logger.InitializeForTest()
+
+	testCases := []struct {
+		name          string
+		data          []byte
+		mockBehavior  func(*mocks.Repository)
+		expectedError error
+	}{
+		{
+			name: "Poster replaces the previous one",
+			data: testPNG(600, 900),
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("GetFilm", mock.Anything, 1).Return(entities.Film{ID: 1, UserID: 100, PosterKey: "posters/1/old"}, nil)
+				mr.On("SetFilmPoster", mock.Anything, 1, mock.MatchedBy(func(key string) bool {
+					return strings.HasPrefix(key, "posters/1/") && key != "posters/1/old"
+				})).Return(nil)
+			},
+		},
+		{
+			name: "User is not the creator",
+			data: testPNG(600, 900),
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("GetFilm", mock.Anything, 1).Return(entities.Film{ID: 1, UserID: 200}, nil)
+			},
+			expectedError: customerror.NewCustomError(kterrors.UserCannotUpdateFilmError),
+		},
+		{
+			name: "Not an image",
+			data: []byte("%PDF-1.4 not a poster"),
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("GetFilm", mock.Anything, 1).Return(entities.Film{ID: 1, UserID: 100}, nil)
+			},
+			expectedError: customerror.NewCustomErrorWithHttpCode(kterrors.UnsupportedPosterTypeError, http.StatusUnsupportedMediaType),
+		},
+		{
+			name: "Truncated image",
+			data: testPNG(600, 900)[:100],
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("GetFilm", mock.Anything, 1).Return(entities.Film{ID: 1, UserID: 100}, nil)
+			},
+			expectedError: customerror.NewCustomError(kterrors.InvalidPosterError),
+		},
+		{
+			name: "Empty upload",
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("GetFilm", mock.Anything, 1).Return(entities.Film{ID: 1, UserID: 100}, nil)
+			},
+			expectedError: customerror.NewCustomError(kterrors.PosterRequiredError),
+		},
+		{
+			name: "Thumbnails are removed when the film cannot be updated",
+			data: testPNG(600, 900),
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("GetFilm", mock.Anything, 1).Return(entities.Film{ID: 1, UserID: 100}, nil)
+				mr.On("SetFilmPoster", mock.Anything, 1, mock.Anything).Return(errors.New("connection refused"))
+			},
+			expectedError: errors.New("connection refused"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			store := blobstore.NewLocalStore(dir)
+			for _, size := range posterSizes {
+				_ = store.Put(context.Background(), posterBlobKey("posters/1/old", size.name), "image/jpeg", strings.NewReader("old"), 3)
+			}
+			mockRepo := mocks.NewRepository(t)
+			tc.mockBehavior(mockRepo)
+			service := NewService(mockRepo, cursor.NewSigner(testCursorSecret), wordfilter.NewFilter(testBannedWords), store, mediasign.NewSigner(testMediaKeys, testMediaURL))
+
+			poster, err := service.UploadPoster(context.Background(), dto.PosterUploadRequest{FilmID: 1, Data: tc.data, UserID: 100})
+
+			files, _ := filepath.Glob(filepath.Join(dir, "posters", "1", "*", "*.jpg"))
+			assert.Len(t, files, len(posterSizes))
+			if tc.expectedError != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tc.expectedError.Error(), err.Error())
+				return
+			}
+			assert.NoError(t, err)
+			assert.True(t, strings.HasPrefix(poster.Small, testMediaURL+"/posters/1/"))
+			assert.Contains(t, poster.Small, "small.jpg?exp=")
+			_, err = os.Stat(filepath.Join(dir, "posters", "1", "old", "small.jpg"))
+			assert.True(t, os.IsNotExist(err))
+		})
+	}
+}
+
+// testHugePNGHeader is a valid PNG header announcing an image too large to decode
+func testHugePNGHeader() []byte {
+	data := testPNG(1, 1)
+	// the IHDR chunk data starts at 16: width, height, then 5 more bytes before its checksum
+	binary.BigEndian.PutUint32(data[16:], 10000)
+	binary.BigEndian.PutUint32(data[20:], 10000)
+	binary.BigEndian.PutUint32(data[29:], crc32.ChecksumIEEE(data[12:29]))
+	return data
+}
+
+func TestDecodePosterTooLarge(t *testing.T) {
+	_, err := decodePoster(testHugePNGHeader())
+
+	assert.Equal(t, customerror.NewCustomErrorWithHttpCode(kterrors.PosterTooLargeError, http.StatusRequestEntityTooLarge).Error(), err.Error())
+}
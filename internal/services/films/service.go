@@ -7,88 +7,342 @@ import (
 	"KTOnlinePlatform/internal/models/kterrors"
 	"KTOnlinePlatform/pkg/customerror"
 	"KTOnlinePlatform/pkg/database/entities"
+	"KTOnlinePlatform/pkg/utils"
+	"KTOnlinePlatform/pkg/validation"
 	"context"
+	"github.com/go-playground/validator/v10"
 	"github.com/samber/lo"
+	"io"
+	"net/http"
+	"slices"
+	"time"
 )
 
 type Repository interface {
-	GetFilmsPaginated(ctx context.Context, pageSize int, offset int) ([]models.FilmPaginated, error)
+	GetFilmsPaginated(ctx context.Context, filter models.FilmFilter, sort string, pageSize int, offset int) ([]models.FilmPaginated, error)
+	GetFilmsKeyset(ctx context.Context, filter models.FilmFilter, cursor models.FilmCursor, limit int) ([]models.FilmPaginated, error)
+	CountFilms(ctx context.Context, filter models.FilmFilter) (int, error)
+	StreamFilms(ctx context.Context, filter models.FilmFilter, fn func(film entities.Film) error) error
 	GetFilm(ctx context.Context, ID int) (entities.Film, error)
+	GetFilms(ctx context.Context, IDs []int) ([]entities.Film, error)
 	DeleteFilm(ctx context.Context, id int) error
 	CreateFilm(ctx context.Context, film entities.Film) error
 	UpdateFilm(ctx context.Context, film entities.Film) error
+	GetFilmCredits(ctx context.Context, filmID int) ([]models.FilmCredit, error)
+	GetFilmsCredits(ctx context.Context, filmIDs []int) ([]models.FilmCredit, error)
+	CreateFilmCredit(ctx context.Context, credit entities.FilmCredit) error
+	DeleteFilmCredit(ctx context.Context, filmID int, creditID int) error
+	SetFilmPoster(ctx context.Context, filmID int, posterKey string) error
+	GetFilmSubtitles(ctx context.Context, filmID int) ([]entities.FilmSubtitle, error)
+	GetFilmsSubtitles(ctx context.Context, filmIDs []int) ([]entities.FilmSubtitle, error)
+	SaveFilmSubtitle(ctx context.Context, subtitle entities.FilmSubtitle) error
+	DeleteFilmSubtitle(ctx context.Context, filmID int, language string) (entities.FilmSubtitle, error)
+	GetFilmTranslations(ctx context.Context, filmID int) ([]entities.FilmTranslation, error)
+	FindFilmTranslations(ctx context.Context, filmIDs []int, locales []string) ([]entities.FilmTranslation, error)
+	SaveFilmTranslation(ctx context.Context, translation entities.FilmTranslation) error
+	DeleteFilmTranslation(ctx context.Context, filmID int, locale string) error
+}
+
+// CursorSigner makes pagination cursors opaque and tamper-proof
+type CursorSigner interface {
+	Encode(v interface{}) (string, error)
+	Decode(token string, v interface{}) error
+}
+
+// ContentFilter rejects user written text containing banned words
+type ContentFilter interface {
+	Check(texts ...string) error
+}
+
+// BlobStore keeps the uploaded media
+type BlobStore interface {
+	Put(ctx context.Context, key string, contentType string, body io.Reader, size int64) error
+	Delete(ctx context.Context, key string) error
+}
+
+// MediaSigner builds the expiring URLs clients download the media from, userID 0 is any user
+type MediaSigner interface {
+	URL(key string, ttl time.Duration) string
 }
 
 type Service struct {
-	repo Repository
+	repo     Repository
+	cursors  CursorSigner
+	filter   ContentFilter
+	blobs    BlobStore
+	media    MediaSigner
+	validate *validator.Validate
 }
 
-func NewService(repo Repository) *Service {
+func NewService(repo Repository, cursors CursorSigner, filter ContentFilter, blobs BlobStore, media MediaSigner) *Service {
 	return &Service{
-		repo: repo,
+		repo:     repo,
+		cursors:  cursors,
+		filter:   filter,
+		blobs:    blobs,
+		media:    media,
+		validate: validation.New(),
 	}
 }
 
+// GetFilmPaginated pages with the cursor when one is given, otherwise with page and pageSize
 func (s *Service) GetFilmPaginated(ctx context.Context, request dto.FilmSearchRequest) (dto.FilmsPaginated, error) {
-	offset := calculateOffset(request.Page, request.PageSize)
-	result, err := s.repo.GetFilmsPaginated(ctx, request.PageSize, offset)
+	if request.Sort == "" {
+		request.Sort = consts.FilmSortTitle
+	}
+	if request.Sort != consts.FilmSortTitle && request.Sort != consts.FilmSortScore {
+		return dto.FilmsPaginated{}, customerror.NewI18nErrorWithParams(
+			kterrors.InvalidFilmSortError,
+			map[string]interface{}{"sort": request.Sort})
+	}
+	// cursors hold a title, scores move with every vote so they cannot be keyset positions
+	keysetAllowed := request.Sort == consts.FilmSortTitle
+	if request.Cursor != "" {
+		if !keysetAllowed {
+			return dto.FilmsPaginated{}, customerror.NewCustomError(kterrors.CursorNotSupportedForSortError)
+		}
+		return s.getFilmsKeyset(ctx, request)
+	}
+	filter := toFilmFilter(request.FilmFilter)
+	offset := utils.CalculateOffset(request.Page, request.PageSize)
+	result, err := s.repo.GetFilmsPaginated(ctx, filter, request.Sort, request.PageSize, offset)
 	if err != nil {
 		return dto.FilmsPaginated{}, err
 	}
+	response := dto.FilmsPaginated{
+		Page:     request.Page,
+		PageSize: request.PageSize,
+	}
 	if len(result) == 0 {
-		return dto.FilmsPaginated{
-			Page:     request.Page,
-			PageSize: request.PageSize,
-		}, nil
+		if offset == 0 {
+			return response, nil
+		}
+		// past the last page the window count is gone with the rows
+		response.Count, err = s.repo.CountFilms(ctx, filter)
+		if err != nil {
+			return dto.FilmsPaginated{}, err
+		}
+		return response, nil
+	}
+	response.Films = s.toFilms(result)
+	err = s.localizeFilms(ctx, response.Films, request.Locales)
+	if err != nil {
+		return dto.FilmsPaginated{}, err
+	}
+	response.Count = result[0].Qty
+	if offset > 0 && keysetAllowed {
+		response.PrevCursor, err = s.encodeCursor(result[0], consts.CursorDirectionPrev)
+		if err != nil {
+			return dto.FilmsPaginated{}, err
+		}
+	}
+	if offset+len(result) < response.Count && keysetAllowed {
+		response.NextCursor, err = s.encodeCursor(result[len(result)-1], consts.CursorDirectionNext)
+		if err != nil {
+			return dto.FilmsPaginated{}, err
+		}
+	}
+	return response, nil
+}
+
+func (s *Service) getFilmsKeyset(ctx context.Context, request dto.FilmSearchRequest) (dto.FilmsPaginated, error) {
+	cursor := models.FilmCursor{}
+	err := s.cursors.Decode(request.Cursor, &cursor)
+	if err != nil || (cursor.Direction != consts.CursorDirectionNext && cursor.Direction != consts.CursorDirectionPrev) {
+		return dto.FilmsPaginated{}, customerror.NewCustomError(kterrors.InvalidCursorError)
+	}
+	filter := toFilmFilter(request.FilmFilter)
+	// one extra row tells whether another page follows in the cursor direction
+	result, err := s.repo.GetFilmsKeyset(ctx, filter, cursor, request.PageSize+1)
+	if err != nil {
+		return dto.FilmsPaginated{}, err
+	}
+	hasMore := len(result) > request.PageSize
+	if hasMore {
+		result = result[:request.PageSize]
+	}
+	if cursor.Direction == consts.CursorDirectionPrev {
+		slices.Reverse(result)
+	}
+
+	response := dto.FilmsPaginated{
+		Films:    s.toFilms(result),
+		PageSize: request.PageSize,
+	}
+	err = s.localizeFilms(ctx, response.Films, request.Locales)
+	if err != nil {
+		return dto.FilmsPaginated{}, err
+	}
+	if len(result) > 0 {
+		if cursor.Direction == consts.CursorDirectionNext || hasMore {
+			response.PrevCursor, err = s.encodeCursor(result[0], consts.CursorDirectionPrev)
+			if err != nil {
+				return dto.FilmsPaginated{}, err
+			}
+		}
+		if cursor.Direction == consts.CursorDirectionPrev || hasMore {
+			response.NextCursor, err = s.encodeCursor(result[len(result)-1], consts.CursorDirectionNext)
+			if err != nil {
+				return dto.FilmsPaginated{}, err
+			}
+		}
+	}
+	if request.IncludeTotal {
+		response.Count, err = s.repo.CountFilms(ctx, filter)
+		if err != nil {
+			return dto.FilmsPaginated{}, err
+		}
 	}
-	films := lo.Map(result, func(item models.FilmPaginated, index int) dto.Film {
+	return response, nil
+}
+
+func (s *Service) encodeCursor(film models.FilmPaginated, direction string) (string, error) {
+	return s.cursors.Encode(models.FilmCursor{
+		Title:     film.Title,
+		ID:        film.ID,
+		Direction: direction,
+	})
+}
+
+func (s *Service) toFilms(result []models.FilmPaginated) []dto.Film {
+	return lo.Map(result, func(item models.FilmPaginated, index int) dto.Film {
 		return dto.Film{
-			ID:    item.ID,
-			Title: item.Title,
+			ID:            item.ID,
+			Title:         item.Title,
+			AverageRating: utils.AverageRating(item.RatingSum, item.RatingCount),
+			VoteCount:     item.RatingCount,
+			Poster:        s.Poster(item.PosterKey),
 		}
 	})
-	return dto.FilmsPaginated{
-		Films:    films,
-		Count:    result[0].Qty,
-		Page:     request.Page,
-		PageSize: request.PageSize,
-	}, nil
 }
 
-func calculateOffset(page, size int) int {
-	offset := (page - 1) * size
-	if offset < 0 {
-		offset = consts.BasicPaginationDefaultOffset
+func toFilmFilter(filter dto.FilmFilter) models.FilmFilter {
+	return models.FilmFilter{
+		Title:    filter.Title,
+		Director: filter.Director,
+		Year:     filter.Year,
 	}
-	return offset
 }
 
-func (s *Service) GetFilmDetail(ctx context.Context, ID int) (dto.FilmDetail, error) {
-	film, err := s.repo.GetFilm(ctx, ID)
+// GetFilmDetail returns the title and synopsis in the first of the locales the film is translated to
+func (s *Service) GetFilmDetail(ctx context.Context, ID int, locales []string) (dto.FilmDetail, error) {
+	film, err := s.getFilm(ctx, ID)
 	if err != nil {
 		return dto.FilmDetail{}, err
 	}
+	if film.Hidden {
+		return dto.FilmDetail{}, newFilmNotFoundError()
+	}
+	credits, err := s.repo.GetFilmCredits(ctx, ID)
+	if err != nil {
+		return dto.FilmDetail{}, err
+	}
+	filmSubtitles, err := s.repo.GetFilmSubtitles(ctx, ID)
+	if err != nil {
+		return dto.FilmDetail{}, err
+	}
+	translation, err := s.findTranslation(ctx, film.ID, locales)
+	if err != nil {
+		return dto.FilmDetail{}, err
+	}
+	return s.toFilmDetail(film, credits, filmSubtitles, translation), nil
+}
+
+// GetFilmDetails returns the details of several films with one query per kind of data, the films
+// hidden or not found are left out of the map
+func (s *Service) GetFilmDetails(ctx context.Context, IDs []int, locales []string) (map[int]dto.FilmDetail, error) {
+	details := make(map[int]dto.FilmDetail, len(IDs))
+	films, err := s.repo.GetFilms(ctx, lo.Uniq(IDs))
+	if err != nil {
+		return nil, err
+	}
+	films = lo.Reject(films, func(item entities.Film, index int) bool {
+		return item.Hidden
+	})
+	if len(films) == 0 {
+		return details, nil
+	}
+	filmIDs := lo.Map(films, func(item entities.Film, index int) int {
+		return item.ID
+	})
+	credits, err := s.repo.GetFilmsCredits(ctx, filmIDs)
+	if err != nil {
+		return nil, err
+	}
+	filmSubtitles, err := s.repo.GetFilmsSubtitles(ctx, filmIDs)
+	if err != nil {
+		return nil, err
+	}
+	var translations []entities.FilmTranslation
+	if len(locales) > 0 {
+		translations, err = s.repo.FindFilmTranslations(ctx, filmIDs, locales)
+		if err != nil {
+			return nil, err
+		}
+	}
+	creditsByFilm := lo.GroupBy(credits, func(item models.FilmCredit) int {
+		return item.FilmID
+	})
+	subtitlesByFilm := lo.GroupBy(filmSubtitles, func(item entities.FilmSubtitle) int {
+		return item.FilmID
+	})
+	translationsByFilm := lo.GroupBy(translations, func(item entities.FilmTranslation) int {
+		return item.FilmID
+	})
+	for _, film := range films {
+		translation := pickTranslation(translationsByFilm[film.ID], locales)
+		details[film.ID] = s.toFilmDetail(film, creditsByFilm[film.ID], subtitlesByFilm[film.ID], translation)
+	}
+	return details, nil
+}
+
+func (s *Service) toFilmDetail(film entities.Film, credits []models.FilmCredit, filmSubtitles []entities.FilmSubtitle, translation entities.FilmTranslation) dto.FilmDetail {
 	return dto.FilmDetail{
-		ID:          film.ID,
-		Title:       film.Title,
-		Director:    film.Director,
-		ReleaseDate: film.ReleaseDate,
-		Synopsis:    film.Synopsis,
-	}, nil
+		ID:            film.ID,
+		Title:         lo.CoalesceOrEmpty(translation.Title, film.Title),
+		Director:      film.Director,
+		ReleaseDate:   film.ReleaseDate,
+		Synopsis:      lo.CoalesceOrEmpty(translation.Synopsis, film.Synopsis),
+		Locale:        translation.Locale,
+		Version:       film.Version,
+		AverageRating: utils.AverageRating(film.RatingSum, film.RatingCount),
+		VoteCount:     film.RatingCount,
+		Poster:        s.Poster(film.PosterKey),
+		Credits:       toFilmCredits(credits),
+		Subtitles:     s.toSubtitleTracks(filmSubtitles),
+	}
 }
 
+// DeleteFilm lets the creator delete a film, its credits, ratings, reviews, list entries and
+// subtitle tracks go with it through the foreign key cascades, its files are removed from the blob store after
 func (s *Service) DeleteFilm(ctx context.Context, filmID int, userID int) error {
-	film, err := s.repo.GetFilm(ctx, filmID)
+	film, err := s.getFilm(ctx, filmID)
 	if err != nil {
 		return err
 	}
 	if film.UserID != userID {
 		return customerror.NewCustomError(kterrors.UserCannotDeleteFilmError)
 	}
-	return s.repo.DeleteFilm(ctx, filmID)
+	filmSubtitles, err := s.repo.GetFilmSubtitles(ctx, filmID)
+	if err != nil {
+		return err
+	}
+	err = s.repo.DeleteFilm(ctx, filmID)
+	if err != nil {
+		return err
+	}
+	s.deletePoster(ctx, film.PosterKey)
+	for _, subtitle := range filmSubtitles {
+		s.deleteSubtitles(ctx, subtitle.BlobKey)
+	}
+	return nil
 }
 
 func (s *Service) CreateFilm(ctx context.Context, request dto.FilmCreateRequest) error {
+	err := s.filter.Check(request.Title, request.Synopsis)
+	if err != nil {
+		return err
+	}
 	film := entities.Film{
 		Title:       request.Title,
 		Director:    request.Director,
@@ -96,7 +350,7 @@ func (s *Service) CreateFilm(ctx context.Context, request dto.FilmCreateRequest)
 		Synopsis:    request.Synopsis,
 		UserID:      request.UserID,
 	}
-	err := s.repo.CreateFilm(ctx, film)
+	err = s.repo.CreateFilm(ctx, film)
 	if err != nil {
 		if customerror.IsUniqueViolation(err) {
 			return customerror.NewCustomError(kterrors.FilmTitleAlreadyExistsError)
@@ -107,13 +361,23 @@ func (s *Service) CreateFilm(ctx context.Context, request dto.FilmCreateRequest)
 }
 
 func (s *Service) UpdateFilm(ctx context.Context, request dto.FilmUpdateRequest) error {
-	film, err := s.repo.GetFilm(ctx, request.ID)
+	if request.Version == 0 {
+		return customerror.NewCustomErrorWithHttpCode(kterrors.FilmVersionRequiredError, http.StatusPreconditionRequired)
+	}
+	film, err := s.getFilm(ctx, request.ID)
 	if err != nil {
 		return err
 	}
 	if film.UserID != request.UserID {
 		return customerror.NewCustomError(kterrors.UserCannotUpdateFilmError)
 	}
+	if request.Version != consts.AnyVersion && film.Version != request.Version {
+		return newVersionMismatchError()
+	}
+	err = s.filter.Check(request.Title, request.Synopsis)
+	if err != nil {
+		return err
+	}
 	film.Title = request.Title
 	film.Director = request.Director
 	film.ReleaseDate = request.ReleaseDate
@@ -123,7 +387,37 @@ func (s *Service) UpdateFilm(ctx context.Context, request dto.FilmUpdateRequest)
 		if customerror.IsUniqueViolation(err) {
 			return customerror.NewCustomError(kterrors.FilmTitleAlreadyExistsError)
 		}
+		if customerror.IsNotFoundError(err) {
+			return s.updateMissError(ctx, film.ID)
+		}
 		return err
 	}
 	return nil
 }
+
+// updateMissError tells why no row matched an update of a film read just before: the film was
+// deleted in between, or another update bumped its version first
+func (s *Service) updateMissError(ctx context.Context, filmID int) error {
+	_, err := s.getFilm(ctx, filmID)
+	if err != nil {
+		return err
+	}
+	return newVersionMismatchError()
+}
+
+func newVersionMismatchError() error {
+	return customerror.NewCustomErrorWithHttpCode(kterrors.FilmVersionMismatchError, http.StatusPreconditionFailed)
+}
+
+func newFilmNotFoundError() error {
+	return customerror.NewCustomErrorWithHttpCode(kterrors.FilmNotFoundError, http.StatusNotFound)
+}
+
+// getFilm reports a missing film as a 404 rather than the raw database error
+func (s *Service) getFilm(ctx context.Context, ID int) (entities.Film, error) {
+	film, err := s.repo.GetFilm(ctx, ID)
+	if customerror.IsNotFoundError(err) {
+		return film, newFilmNotFoundError()
+	}
+	return film, err
+}
@@ -4,9 +4,11 @@ import (
 	"KTOnlinePlatform/internal/services/films/mocks"
 	"KTOnlinePlatform/pkg/database/entities/entitiescustom"
 	"KTOnlinePlatform/pkg/logger"
+	"bytes"
 	"context"
 	"errors"
 	"gorm.io/gorm"
+	"net/http"
 	"testing"
 	"time"
 
@@ -15,11 +17,32 @@ import (
 
 	"KTOnlinePlatform/internal/dto"
 	"KTOnlinePlatform/internal/models"
+	"KTOnlinePlatform/internal/models/consts"
 	"KTOnlinePlatform/internal/models/kterrors"
+	"KTOnlinePlatform/pkg/blobstore"
+	"KTOnlinePlatform/pkg/cursor"
 	"KTOnlinePlatform/pkg/customerror"
 	"KTOnlinePlatform/pkg/database/entities"
+	"KTOnlinePlatform/pkg/mediasign"
+	"KTOnlinePlatform/pkg/wordfilter"
 )
 
+const (
+	testCursorSecret = "test-cursor-secret"
+	testMediaURL     = "http://localhost/media"
+)
+
+var (
+	testBannedWords = []string{"scam"}
+	testMediaKeys   = []string{"test-media-key"}
+)
+
+// testCursor signs a cursor the way the service under test does
+func testCursor(title string, id int, direction string) string {
+	token, _ := cursor.NewSigner(testCursorSecret).Encode(models.FilmCursor{Title: title, ID: id, Direction: direction})
+	return token
+}
+
 // setupMockRepository creates a mock repository for testing
 func setupMockRepository(t *testing.T) *mocks.Repository {
 	return mocks.NewRepository(t)
@@ -38,7 +61,7 @@ func TestGetFilmPaginated(t *testing.T) {
 		{
 			name: "Successful pagination with results",
 			mockBehavior: func(mr *mocks.Repository) {
-				mr.On("GetFilmsPaginated", mock.Anything, 10, 0).Return(
+				mr.On("GetFilmsPaginated", mock.Anything, models.FilmFilter{}, consts.FilmSortTitle, 10, 0).Return(
 					[]models.FilmPaginated{
 						{ID: 1, Title: "Film 1", Qty: 2},
 						{ID: 2, Title: "Film 2", Qty: 2},
@@ -58,10 +81,184 @@ func TestGetFilmPaginated(t *testing.T) {
 				PageSize: 10,
 			},
 		},
+		{
+			name: "Filters are passed to the repository",
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("GetFilmsPaginated", mock.Anything, models.FilmFilter{Director: "nolan", Year: 2010}, consts.FilmSortTitle, 10, 10).Return(
+					[]models.FilmPaginated{
+						{ID: 3, Title: "Inception", Qty: 11},
+					}, nil)
+			},
+			inputRequest: dto.FilmSearchRequest{
+				FilmFilter: dto.FilmFilter{Director: "nolan", Year: 2010},
+				Page:       2,
+				PageSize:   10,
+			},
+			expectedResult: dto.FilmsPaginated{
+				Films: []dto.Film{
+					{ID: 3, Title: "Inception"},
+				},
+				Count:      11,
+				Page:       2,
+				PageSize:   10,
+				PrevCursor: testCursor("Inception", 3, consts.CursorDirectionPrev),
+			},
+		},
+		{
+			name: "First page links to the next one",
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("GetFilmsPaginated", mock.Anything, models.FilmFilter{}, consts.FilmSortTitle, 2, 0).Return(
+					[]models.FilmPaginated{
+						{ID: 1, Title: "Film 1", Qty: 5},
+						{ID: 2, Title: "Film 2", Qty: 5},
+					}, nil)
+			},
+			inputRequest: dto.FilmSearchRequest{
+				Page:     1,
+				PageSize: 2,
+			},
+			expectedResult: dto.FilmsPaginated{
+				Films: []dto.Film{
+					{ID: 1, Title: "Film 1"},
+					{ID: 2, Title: "Film 2"},
+				},
+				Count:      5,
+				Page:       1,
+				PageSize:   2,
+				NextCursor: testCursor("Film 2", 2, consts.CursorDirectionNext),
+			},
+		},
+		{
+			name: "Page past the end still counts the films",
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("GetFilmsPaginated", mock.Anything, models.FilmFilter{}, consts.FilmSortTitle, 10, 40).Return(
+					[]models.FilmPaginated{}, nil)
+				mr.On("CountFilms", mock.Anything, models.FilmFilter{}).Return(12, nil)
+			},
+			inputRequest: dto.FilmSearchRequest{
+				Page:     5,
+				PageSize: 10,
+			},
+			expectedResult: dto.FilmsPaginated{
+				Count:    12,
+				Page:     5,
+				PageSize: 10,
+			},
+		},
+		{
+			name: "Keyset page after a cursor",
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("GetFilmsKeyset", mock.Anything, models.FilmFilter{},
+					models.FilmCursor{Title: "Film 2", ID: 2, Direction: consts.CursorDirectionNext}, 3).Return(
+					[]models.FilmPaginated{
+						{ID: 3, Title: "Film 3"},
+						{ID: 4, Title: "Film 4"},
+						{ID: 5, Title: "Film 5"},
+					}, nil)
+			},
+			inputRequest: dto.FilmSearchRequest{
+				Page:     1,
+				PageSize: 2,
+				Cursor:   testCursor("Film 2", 2, consts.CursorDirectionNext),
+			},
+			expectedResult: dto.FilmsPaginated{
+				Films: []dto.Film{
+					{ID: 3, Title: "Film 3"},
+					{ID: 4, Title: "Film 4"},
+				},
+				PageSize:   2,
+				PrevCursor: testCursor("Film 3", 3, consts.CursorDirectionPrev),
+				NextCursor: testCursor("Film 4", 4, consts.CursorDirectionNext),
+			},
+		},
+		{
+			name: "Keyset page before a cursor reaching the start, with total",
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("GetFilmsKeyset", mock.Anything, models.FilmFilter{},
+					models.FilmCursor{Title: "Film 3", ID: 3, Direction: consts.CursorDirectionPrev}, 3).Return(
+					[]models.FilmPaginated{
+						{ID: 2, Title: "Film 2"},
+						{ID: 1, Title: "Film 1"},
+					}, nil)
+				mr.On("CountFilms", mock.Anything, models.FilmFilter{}).Return(5, nil)
+			},
+			inputRequest: dto.FilmSearchRequest{
+				Page:         1,
+				PageSize:     2,
+				Cursor:       testCursor("Film 3", 3, consts.CursorDirectionPrev),
+				IncludeTotal: true,
+			},
+			expectedResult: dto.FilmsPaginated{
+				Films: []dto.Film{
+					{ID: 1, Title: "Film 1"},
+					{ID: 2, Title: "Film 2"},
+				},
+				Count:      5,
+				PageSize:   2,
+				NextCursor: testCursor("Film 2", 2, consts.CursorDirectionNext),
+			},
+		},
+		{
+			name:         "Forged cursor",
+			mockBehavior: func(mr *mocks.Repository) {},
+			inputRequest: dto.FilmSearchRequest{
+				Page:     1,
+				PageSize: 2,
+				Cursor:   "eyJ0IjoiRmlsbSAyIiwiaSI6MiwiZCI6Im5leHQifQ.c2lnbmF0dXJl",
+			},
+			expectedError: customerror.NewCustomError(kterrors.InvalidCursorError),
+		},
+		{
+			name: "Sorted by score with averages and no cursors",
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("GetFilmsPaginated", mock.Anything, models.FilmFilter{}, consts.FilmSortScore, 2, 0).Return(
+					[]models.FilmPaginated{
+						{ID: 7, Title: "Heat", RatingSum: 26, RatingCount: 3, Qty: 5},
+						{ID: 2, Title: "Film 2", Qty: 5},
+					}, nil)
+			},
+			inputRequest: dto.FilmSearchRequest{
+				Page:     1,
+				PageSize: 2,
+				Sort:     consts.FilmSortScore,
+			},
+			expectedResult: dto.FilmsPaginated{
+				Films: []dto.Film{
+					{ID: 7, Title: "Heat", AverageRating: 8.7, VoteCount: 3},
+					{ID: 2, Title: "Film 2"},
+				},
+				Count:    5,
+				Page:     1,
+				PageSize: 2,
+			},
+		},
+		{
+			name:         "Cursor with score sort",
+			mockBehavior: func(mr *mocks.Repository) {},
+			inputRequest: dto.FilmSearchRequest{
+				Page:     1,
+				PageSize: 2,
+				Sort:     consts.FilmSortScore,
+				Cursor:   testCursor("Film 2", 2, consts.CursorDirectionNext),
+			},
+			expectedError: customerror.NewCustomError(kterrors.CursorNotSupportedForSortError),
+		},
+		{
+			name:         "Unknown sort",
+			mockBehavior: func(mr *mocks.Repository) {},
+			inputRequest: dto.FilmSearchRequest{
+				Page:     1,
+				PageSize: 10,
+				Sort:     "popularity",
+			},
+			expectedError: customerror.NewI18nErrorWithParams(
+				kterrors.InvalidFilmSortError,
+				map[string]interface{}{"sort": "popularity"}),
+		},
 		{
 			name: "Empty result set",
 			mockBehavior: func(mr *mocks.Repository) {
-				mr.On("GetFilmsPaginated", mock.Anything, 10, 0).Return(
+				mr.On("GetFilmsPaginated", mock.Anything, models.FilmFilter{}, consts.FilmSortTitle, 10, 0).Return(
 					[]models.FilmPaginated{}, nil)
 			},
 			inputRequest: dto.FilmSearchRequest{
@@ -76,7 +273,7 @@ func TestGetFilmPaginated(t *testing.T) {
 		{
 			name: "Repository error",
 			mockBehavior: func(mr *mocks.Repository) {
-				mr.On("GetFilmsPaginated", mock.Anything, 10, 0).Return(
+				mr.On("GetFilmsPaginated", mock.Anything, models.FilmFilter{}, consts.FilmSortTitle, 10, 0).Return(
 					nil, errors.New("database error"))
 			},
 			inputRequest: dto.FilmSearchRequest{
@@ -94,7 +291,7 @@ func TestGetFilmPaginated(t *testing.T) {
 			tc.mockBehavior(mockRepo)
 
 			// Create service with mock repository
-			service := NewService(mockRepo)
+			service := NewService(mockRepo, cursor.NewSigner(testCursorSecret), wordfilter.NewFilter(testBannedWords), blobstore.NewLocalStore(t.TempDir()), mediasign.NewSigner(testMediaKeys, testMediaURL))
 
 			// Execute method
 			result, err := service.GetFilmPaginated(context.Background(), tc.inputRequest)
@@ -136,7 +333,15 @@ func TestGetFilmDetail(t *testing.T) {
 						Director:    "Test Director",
 						ReleaseDate: entitiescustom.ReleaseDate{Time: time.Now()},
 						Synopsis:    "Test Synopsis",
+						Version:     3,
 					}, nil)
+				mr.On("GetFilmCredits", mock.Anything, 1).Return(
+					[]models.FilmCredit{
+						{ID: 7, PersonID: 4, Name: "Test Director", Role: consts.CreditRoleDirector},
+						{ID: 8, PersonID: 5, Name: "Test Actor", Role: consts.CreditRoleActor, CharacterName: "Hero"},
+					}, nil)
+				mr.On("GetFilmSubtitles", mock.Anything, 1).Return(
+					[]entities.FilmSubtitle{{FilmID: 1, Language: "pt-BR", Label: "Português", BlobKey: "subtitles/1/abc/pt-BR.vtt"}}, nil)
 			},
 			expectedResult: dto.FilmDetail{
 				ID:          1,
@@ -144,6 +349,12 @@ func TestGetFilmDetail(t *testing.T) {
 				Director:    "Test Director",
 				ReleaseDate: entitiescustom.ReleaseDate{Time: time.Now()},
 				Synopsis:    "Test Synopsis",
+				Version:     3,
+				Credits: []dto.FilmCredit{
+					{ID: 7, PersonID: 4, Name: "Test Director", Role: consts.CreditRoleDirector},
+					{ID: 8, PersonID: 5, Name: "Test Actor", Role: consts.CreditRoleActor, CharacterName: "Hero"},
+				},
+				Subtitles: []dto.SubtitleTrack{{Language: "pt-BR", Label: "Português"}},
 			},
 		},
 		{
@@ -151,9 +362,18 @@ func TestGetFilmDetail(t *testing.T) {
 			filmID: 999,
 			mockBehavior: func(mr *mocks.Repository) {
 				mr.On("GetFilm", mock.Anything, 999).Return(
-					entities.Film{}, errors.New("film not found"))
+					entities.Film{}, gorm.ErrRecordNotFound)
 			},
-			expectedError: errors.New("film not found"),
+			expectedError: customerror.NewCustomErrorWithHttpCode(kterrors.FilmNotFoundError, http.StatusNotFound),
+		},
+		{
+			name:   "Database error",
+			filmID: 1,
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("GetFilm", mock.Anything, 1).Return(
+					entities.Film{}, errors.New("connection refused"))
+			},
+			expectedError: errors.New("connection refused"),
 		},
 	}
 
@@ -162,9 +382,9 @@ func TestGetFilmDetail(t *testing.T) {
 			mockRepo := setupMockRepository(t)
 			tc.mockBehavior(mockRepo)
 
-			service := NewService(mockRepo)
+			service := NewService(mockRepo, cursor.NewSigner(testCursorSecret), wordfilter.NewFilter(testBannedWords), blobstore.NewLocalStore(t.TempDir()), mediasign.NewSigner(testMediaKeys, testMediaURL))
 
-			result, err := service.GetFilmDetail(context.Background(), tc.filmID)
+			result, err := service.GetFilmDetail(context.Background(), tc.filmID, nil)
 
 			if tc.expectedError != nil {
 				assert.Error(t, err)
@@ -175,6 +395,97 @@ func TestGetFilmDetail(t *testing.T) {
 			assert.NoError(t, err)
 			assert.Equal(t, tc.expectedResult.ID, result.ID)
 			assert.Equal(t, tc.expectedResult.Title, result.Title)
+			assert.Equal(t, tc.expectedResult.Version, result.Version)
+			assert.Equal(t, tc.expectedResult.Credits, result.Credits)
+			for i, track := range result.Subtitles {
+				assert.Equal(t, tc.expectedResult.Subtitles[i].Language, track.Language)
+				assert.Equal(t, tc.expectedResult.Subtitles[i].Label, track.Label)
+				assert.Contains(t, track.URL, testMediaURL+"/subtitles/1/abc/"+track.Language+".vtt?exp=")
+			}
+			assert.Len(t, result.Subtitles, len(tc.expectedResult.Subtitles))
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGetFilmDetails(t *testing.T) {
+	logger.InitializeForTest()
+
+	testCases := []struct {
+		name           string
+		filmIDs        []int
+		locales        []string
+		mockBehavior   func(*mocks.Repository)
+		expectedTitles map[int]string
+		expectedCredit map[int]int
+		expectedError  error
+	}{
+		{
+			name:    "Batched details",
+			filmIDs: []int{1, 2, 1, 3},
+			locales: []string{"fr"},
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("GetFilms", mock.Anything, []int{1, 2, 3}).Return(
+					[]entities.Film{
+						{ID: 1, Title: "First"},
+						{ID: 2, Title: "Second"},
+						{ID: 3, Title: "Hidden", Hidden: true},
+					}, nil).Once()
+				mr.On("GetFilmsCredits", mock.Anything, []int{1, 2}).Return(
+					[]models.FilmCredit{
+						{ID: 7, FilmID: 1, PersonID: 4, Name: "Test Director", Role: consts.CreditRoleDirector},
+						{ID: 8, FilmID: 2, PersonID: 5, Name: "Test Actor", Role: consts.CreditRoleActor},
+						{ID: 9, FilmID: 2, PersonID: 6, Name: "Other Actor", Role: consts.CreditRoleActor},
+					}, nil).Once()
+				mr.On("GetFilmsSubtitles", mock.Anything, []int{1, 2}).Return(
+					[]entities.FilmSubtitle{}, nil).Once()
+				mr.On("FindFilmTranslations", mock.Anything, []int{1, 2}, []string{"fr"}).Return(
+					[]entities.FilmTranslation{{FilmID: 2, Locale: "fr", Title: "Deuxième"}}, nil).Once()
+			},
+			expectedTitles: map[int]string{1: "First", 2: "Deuxième"},
+			expectedCredit: map[int]int{1: 1, 2: 2},
+		},
+		{
+			name:    "No film found",
+			filmIDs: []int{999},
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("GetFilms", mock.Anything, []int{999}).Return([]entities.Film{}, nil)
+			},
+			expectedTitles: map[int]string{},
+			expectedCredit: map[int]int{},
+		},
+		{
+			name:    "Database error",
+			filmIDs: []int{1},
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("GetFilms", mock.Anything, []int{1}).Return(nil, errors.New("connection refused"))
+			},
+			expectedError: errors.New("connection refused"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := setupMockRepository(t)
+			tc.mockBehavior(mockRepo)
+
+			service := NewService(mockRepo, cursor.NewSigner(testCursorSecret), wordfilter.NewFilter(testBannedWords), blobstore.NewLocalStore(t.TempDir()), mediasign.NewSigner(testMediaKeys, testMediaURL))
+
+			result, err := service.GetFilmDetails(context.Background(), tc.filmIDs, tc.locales)
+
+			if tc.expectedError != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tc.expectedError.Error(), err.Error())
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Len(t, result, len(tc.expectedTitles))
+			for filmID, title := range tc.expectedTitles {
+				assert.Equal(t, title, result[filmID].Title)
+				assert.Len(t, result[filmID].Credits, tc.expectedCredit[filmID])
+			}
 
 			mockRepo.AssertExpectations(t)
 		})
@@ -201,6 +512,8 @@ func TestDeleteFilm(t *testing.T) {
 						ID:     1,
 						UserID: 100,
 					}, nil)
+				mr.On("GetFilmSubtitles", mock.Anything, 1).Return(
+					[]entities.FilmSubtitle{{FilmID: 1, Language: "fr", BlobKey: "subtitles/1/abc/fr.vtt"}}, nil)
 				mr.On("DeleteFilm", mock.Anything, 1).Return(nil)
 			},
 		},
@@ -234,7 +547,7 @@ func TestDeleteFilm(t *testing.T) {
 			mockRepo := setupMockRepository(t)
 			tc.mockBehavior(mockRepo)
 
-			service := NewService(mockRepo)
+			service := NewService(mockRepo, cursor.NewSigner(testCursorSecret), wordfilter.NewFilter(testBannedWords), blobstore.NewLocalStore(t.TempDir()), mediasign.NewSigner(testMediaKeys, testMediaURL))
 
 			err := service.DeleteFilm(context.Background(), tc.filmID, tc.userID)
 
@@ -302,6 +615,18 @@ func TestCreateFilm(t *testing.T) {
 			},
 			expectedError: errors.New("database error"),
 		},
+		{
+			name: "Banned word in the synopsis",
+			request: dto.FilmCreateRequest{
+				Title:    "Another Film",
+				Synopsis: "Total scam of a film",
+				UserID:   100,
+			},
+			mockBehavior: func(mr *mocks.Repository) {},
+			expectedError: customerror.NewI18nErrorWithParams(
+				kterrors.ContentContainsBannedWordsError,
+				map[string]interface{}{"words": []string{"scam"}}),
+		},
 	}
 
 	for _, tc := range testCases {
@@ -309,7 +634,7 @@ func TestCreateFilm(t *testing.T) {
 			mockRepo := setupMockRepository(t)
 			tc.mockBehavior(mockRepo)
 
-			service := NewService(mockRepo)
+			service := NewService(mockRepo, cursor.NewSigner(testCursorSecret), wordfilter.NewFilter(testBannedWords), blobstore.NewLocalStore(t.TempDir()), mediasign.NewSigner(testMediaKeys, testMediaURL))
 
 			err := service.CreateFilm(context.Background(), tc.request)
 
@@ -341,13 +666,15 @@ func TestUpdateFilm(t *testing.T) {
 				Director:    "Updated Director",
 				ReleaseDate: entitiescustom.ReleaseDate{Time: time.Now()},
 				Synopsis:    "Updated Synopsis",
+				Version:     1,
 				UserID:      100,
 			},
 			mockBehavior: func(mr *mocks.Repository) {
 				mr.On("GetFilm", mock.Anything, 1).Return(
 					entities.Film{
-						ID:     1,
-						UserID: 100,
+						ID:      1,
+						UserID:  100,
+						Version: 1,
 					}, nil)
 				mr.On("UpdateFilm", mock.Anything, mock.AnythingOfType("entities.Film")).Return(nil)
 			},
@@ -360,13 +687,15 @@ func TestUpdateFilm(t *testing.T) {
 				Director:    "Updated Director",
 				ReleaseDate: entitiescustom.ReleaseDate{Time: time.Now()},
 				Synopsis:    "Updated Synopsis",
+				Version:     1,
 				UserID:      200,
 			},
 			mockBehavior: func(mr *mocks.Repository) {
 				mr.On("GetFilm", mock.Anything, 1).Return(
 					entities.Film{
-						ID:     1,
-						UserID: 100,
+						ID:      1,
+						UserID:  100,
+						Version: 1,
 					}, nil)
 			},
 			expectedError: customerror.NewCustomError(kterrors.UserCannotUpdateFilmError),
@@ -379,19 +708,130 @@ func TestUpdateFilm(t *testing.T) {
 				Director:    "Updated Director",
 				ReleaseDate: entitiescustom.ReleaseDate{Time: time.Now()},
 				Synopsis:    "Updated Synopsis",
+				Version:     1,
 				UserID:      100,
 			},
 			mockBehavior: func(mr *mocks.Repository) {
 				mr.On("GetFilm", mock.Anything, 1).Return(
 					entities.Film{
-						ID:     1,
-						UserID: 100,
+						ID:      1,
+						UserID:  100,
+						Version: 1,
 					}, nil)
 				mr.On("UpdateFilm", mock.Anything, mock.AnythingOfType("entities.Film")).Return(
 					gorm.ErrDuplicatedKey)
 			},
 			expectedError: customerror.NewCustomError(kterrors.FilmTitleAlreadyExistsError),
 		},
+		{
+			name: "Banned word in the title",
+			request: dto.FilmUpdateRequest{
+				ID:      1,
+				Title:   "The Great SCAM",
+				Version: 1,
+				UserID:  100,
+			},
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("GetFilm", mock.Anything, 1).Return(
+					entities.Film{
+						ID:      1,
+						UserID:  100,
+						Version: 1,
+					}, nil)
+			},
+			expectedError: customerror.NewI18nErrorWithParams(
+				kterrors.ContentContainsBannedWordsError,
+				map[string]interface{}{"words": []string{"scam"}}),
+		},
+		{
+			name: "Missing version",
+			request: dto.FilmUpdateRequest{
+				ID:     1,
+				Title:  "Updated Film",
+				UserID: 100,
+			},
+			mockBehavior:  func(mr *mocks.Repository) {},
+			expectedError: customerror.NewCustomErrorWithHttpCode(kterrors.FilmVersionRequiredError, http.StatusPreconditionRequired),
+		},
+		{
+			name: "Stale version",
+			request: dto.FilmUpdateRequest{
+				ID:      1,
+				Title:   "Updated Film",
+				Version: 1,
+				UserID:  100,
+			},
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("GetFilm", mock.Anything, 1).Return(
+					entities.Film{
+						ID:      1,
+						UserID:  100,
+						Version: 2,
+					}, nil)
+			},
+			expectedError: customerror.NewCustomErrorWithHttpCode(kterrors.FilmVersionMismatchError, http.StatusPreconditionFailed),
+		},
+		{
+			name: "Concurrent update bumped the version",
+			request: dto.FilmUpdateRequest{
+				ID:      1,
+				Title:   "Updated Film",
+				Version: 1,
+				UserID:  100,
+			},
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("GetFilm", mock.Anything, 1).Return(
+					entities.Film{
+						ID:      1,
+						UserID:  100,
+						Version: 1,
+					}, nil)
+				mr.On("UpdateFilm", mock.Anything, mock.AnythingOfType("entities.Film")).Return(
+					gorm.ErrRecordNotFound)
+			},
+			expectedError: customerror.NewCustomErrorWithHttpCode(kterrors.FilmVersionMismatchError, http.StatusPreconditionFailed),
+		},
+		{
+			name: "Film deleted before the update",
+			request: dto.FilmUpdateRequest{
+				ID:      1,
+				Title:   "Updated Film",
+				Version: 1,
+				UserID:  100,
+			},
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("GetFilm", mock.Anything, 1).Return(
+					entities.Film{
+						ID:      1,
+						UserID:  100,
+						Version: 1,
+					}, nil).Once()
+				mr.On("GetFilm", mock.Anything, 1).Return(entities.Film{}, gorm.ErrRecordNotFound).Once()
+				mr.On("UpdateFilm", mock.Anything, mock.AnythingOfType("entities.Film")).Return(
+					gorm.ErrRecordNotFound)
+			},
+			expectedError: customerror.NewCustomErrorWithHttpCode(kterrors.FilmNotFoundError, http.StatusNotFound),
+		},
+		{
+			name: "Any version",
+			request: dto.FilmUpdateRequest{
+				ID:      1,
+				Title:   "Updated Film",
+				Version: consts.AnyVersion,
+				UserID:  100,
+			},
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("GetFilm", mock.Anything, 1).Return(
+					entities.Film{
+						ID:      1,
+						UserID:  100,
+						Version: 5,
+					}, nil)
+				mr.On("UpdateFilm", mock.Anything, mock.MatchedBy(func(film entities.Film) bool {
+					return film.Version == 5
+				})).Return(nil)
+			},
+		},
 		{
 			name: "Film not found",
 			request: dto.FilmUpdateRequest{
@@ -400,6 +840,7 @@ func TestUpdateFilm(t *testing.T) {
 				Director:    "Updated Director",
 				ReleaseDate: entitiescustom.ReleaseDate{Time: time.Now()},
 				Synopsis:    "Updated Synopsis",
+				Version:     1,
 				UserID:      100,
 			},
 			mockBehavior: func(mr *mocks.Repository) {
@@ -415,7 +856,7 @@ func TestUpdateFilm(t *testing.T) {
 			mockRepo := setupMockRepository(t)
 			tc.mockBehavior(mockRepo)
 
-			service := NewService(mockRepo)
+			service := NewService(mockRepo, cursor.NewSigner(testCursorSecret), wordfilter.NewFilter(testBannedWords), blobstore.NewLocalStore(t.TempDir()), mediasign.NewSigner(testMediaKeys, testMediaURL))
 
 			err := service.UpdateFilm(context.Background(), tc.request)
 
@@ -430,3 +871,432 @@ func TestUpdateFilm(t *testing.T) {
 		})
 	}
 }
+
+func TestPatchFilm(t *testing.T) {
+	logger.InitializeForTest()
+	releaseDate := entitiescustom.ReleaseDate{Time: time.Date(2010, 7, 16, 0, 0, 0, 0, time.UTC)}
+	storedFilm := entities.Film{
+		ID:          1,
+		Title:       "Inception",
+		Director:    "Christopher Nolan",
+		ReleaseDate: releaseDate,
+		Synopsis:    "Dreams within dreams",
+		UserID:      100,
+		Version:     2,
+	}
+	testCases := []struct {
+		name          string
+		request       dto.FilmPatchRequest
+		mockBehavior  func(*mocks.Repository)
+		expectedError error
+	}{
+		{
+			name: "Merge patch clears nullable fields",
+			request: dto.FilmPatchRequest{
+				ID:        1,
+				PatchType: consts.MergePatchContentType,
+				Patch:     []byte(`{"director": null, "release_date": null}`),
+				Version:   2,
+				UserID:    100,
+			},
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("GetFilm", mock.Anything, 1).Return(storedFilm, nil)
+				mr.On("UpdateFilm", mock.Anything, entities.Film{
+					ID:       1,
+					Title:    "Inception",
+					Synopsis: "Dreams within dreams",
+					UserID:   100,
+					Version:  2,
+				}).Return(nil)
+			},
+		},
+		{
+			name: "JSON patch replaces the title",
+			request: dto.FilmPatchRequest{
+				ID:        1,
+				PatchType: consts.JSONPatchContentType,
+				Patch:     []byte(`[{"op": "test", "path": "/title", "value": "Inception"}, {"op": "replace", "path": "/title", "value": "Inception (2010)"}]`),
+				Version:   2,
+				UserID:    100,
+			},
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("GetFilm", mock.Anything, 1).Return(storedFilm, nil)
+				mr.On("UpdateFilm", mock.Anything, entities.Film{
+					ID:          1,
+					Title:       "Inception (2010)",
+					Director:    "Christopher Nolan",
+					ReleaseDate: releaseDate,
+					Synopsis:    "Dreams within dreams",
+					UserID:      100,
+					Version:     2,
+				}).Return(nil)
+			},
+		},
+		{
+			name: "Clearing the title fails validation",
+			request: dto.FilmPatchRequest{
+				ID:        1,
+				PatchType: consts.MergePatchContentType,
+				Patch:     []byte(`{"title": null}`),
+				Version:   2,
+				UserID:    100,
+			},
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("GetFilm", mock.Anything, 1).Return(storedFilm, nil)
+			},
+			expectedError: customerror.NewValidationError(kterrors.ValidationError, []customerror.FieldError{{Field: "title", Rule: "title"}}),
+		},
+		{
+			name: "Unknown member is rejected",
+			request: dto.FilmPatchRequest{
+				ID:        1,
+				PatchType: consts.MergePatchContentType,
+				Patch:     []byte(`{"user_id": 200}`),
+				Version:   2,
+				UserID:    100,
+			},
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("GetFilm", mock.Anything, 1).Return(storedFilm, nil)
+			},
+			expectedError: customerror.NewI18nErrorWithParams(kterrors.InvalidFilmPatchError,
+				map[string]interface{}{"reason": `json: unknown field "user_id"`}),
+		},
+		{
+			name: "Failing test operation",
+			request: dto.FilmPatchRequest{
+				ID:        1,
+				PatchType: consts.JSONPatchContentType,
+				Patch:     []byte(`[{"op": "test", "path": "/title", "value": "Memento"}]`),
+				Version:   2,
+				UserID:    100,
+			},
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("GetFilm", mock.Anything, 1).Return(storedFilm, nil)
+			},
+			expectedError: customerror.NewI18nErrorWithParams(kterrors.InvalidFilmPatchError,
+				map[string]interface{}{"reason": "testing value /title failed: test failed"}),
+		},
+		{
+			name: "Unsupported patch type",
+			request: dto.FilmPatchRequest{
+				ID:        1,
+				PatchType: "application/json",
+				Patch:     []byte(`{"title": "Memento"}`),
+				Version:   2,
+				UserID:    100,
+			},
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("GetFilm", mock.Anything, 1).Return(storedFilm, nil)
+			},
+			expectedError: customerror.NewI18nErrorWithParams(kterrors.UnsupportedPatchTypeError,
+				map[string]interface{}{"patchType": "application/json"}),
+		},
+		{
+			name: "Unauthorized film patch",
+			request: dto.FilmPatchRequest{
+				ID:        1,
+				PatchType: consts.MergePatchContentType,
+				Patch:     []byte(`{"title": "Memento"}`),
+				Version:   2,
+				UserID:    200,
+			},
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("GetFilm", mock.Anything, 1).Return(storedFilm, nil)
+			},
+			expectedError: customerror.NewCustomError(kterrors.UserCannotUpdateFilmError),
+		},
+		{
+			name: "Missing If-Match version",
+			request: dto.FilmPatchRequest{
+				ID:        1,
+				PatchType: consts.MergePatchContentType,
+				Patch:     []byte(`{"title": "Memento"}`),
+				UserID:    100,
+			},
+			mockBehavior:  func(mr *mocks.Repository) {},
+			expectedError: customerror.NewCustomErrorWithHttpCode(kterrors.FilmVersionRequiredError, http.StatusPreconditionRequired),
+		},
+		{
+			name: "Any If-Match version",
+			request: dto.FilmPatchRequest{
+				ID:        1,
+				PatchType: consts.MergePatchContentType,
+				Patch:     []byte(`{"title": "Memento"}`),
+				Version:   consts.AnyVersion,
+				UserID:    100,
+			},
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("GetFilm", mock.Anything, 1).Return(storedFilm, nil)
+				mr.On("UpdateFilm", mock.Anything, mock.MatchedBy(func(film entities.Film) bool {
+					return film.Title == "Memento" && film.Version == storedFilm.Version
+				})).Return(nil)
+			},
+		},
+		{
+			name: "Film deleted before the patch",
+			request: dto.FilmPatchRequest{
+				ID:        1,
+				PatchType: consts.MergePatchContentType,
+				Patch:     []byte(`{"title": "Memento"}`),
+				Version:   2,
+				UserID:    100,
+			},
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("GetFilm", mock.Anything, 1).Return(storedFilm, nil).Once()
+				mr.On("GetFilm", mock.Anything, 1).Return(entities.Film{}, gorm.ErrRecordNotFound).Once()
+				mr.On("UpdateFilm", mock.Anything, mock.AnythingOfType("entities.Film")).Return(gorm.ErrRecordNotFound)
+			},
+			expectedError: customerror.NewCustomErrorWithHttpCode(kterrors.FilmNotFoundError, http.StatusNotFound),
+		},
+		{
+			name: "Stale If-Match version",
+			request: dto.FilmPatchRequest{
+				ID:        1,
+				PatchType: consts.MergePatchContentType,
+				Patch:     []byte(`{"title": "Memento"}`),
+				Version:   1,
+				UserID:    100,
+			},
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("GetFilm", mock.Anything, 1).Return(storedFilm, nil)
+			},
+			expectedError: customerror.NewCustomErrorWithHttpCode(kterrors.FilmVersionMismatchError, http.StatusPreconditionFailed),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := setupMockRepository(t)
+			tc.mockBehavior(mockRepo)
+
+			service := NewService(mockRepo, cursor.NewSigner(testCursorSecret), wordfilter.NewFilter(testBannedWords), blobstore.NewLocalStore(t.TempDir()), mediasign.NewSigner(testMediaKeys, testMediaURL))
+
+			err := service.PatchFilm(context.Background(), tc.request)
+
+			if tc.expectedError != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tc.expectedError.Error(), err.Error())
+				return
+			}
+
+			assert.NoError(t, err)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestExportFilms(t *testing.T) {
+	logger.InitializeForTest()
+	catalog := []entities.Film{
+		{
+			ID:          1,
+			Title:       "Inception",
+			Director:    "Christopher Nolan",
+			ReleaseDate: entitiescustom.ReleaseDate{Time: time.Date(2010, 7, 16, 0, 0, 0, 0, time.UTC)},
+			Synopsis:    "Dreams, within dreams",
+		},
+		{
+			ID:    2,
+			Title: "Untitled",
+		},
+	}
+	streamCatalog := func(mr *mocks.Repository, filter models.FilmFilter) {
+		mr.On("StreamFilms", mock.Anything, filter, mock.Anything).
+			Run(func(args mock.Arguments) {
+				fn := args.Get(2).(func(film entities.Film) error)
+				for _, film := range catalog {
+					_ = fn(film)
+				}
+			}).
+			Return(nil)
+	}
+
+	testCases := []struct {
+		name           string
+		request        dto.FilmExportRequest
+		mockBehavior   func(*mocks.Repository)
+		expectedOutput string
+		expectedError  error
+	}{
+		{
+			name:    "CSV export",
+			request: dto.FilmExportRequest{Format: consts.ExportFormatCSV},
+			mockBehavior: func(mr *mocks.Repository) {
+				streamCatalog(mr, models.FilmFilter{})
+			},
+			expectedOutput: "id,title,director,release_date,synopsis\n" +
+				"1,Inception,Christopher Nolan,2010-07-16,\"Dreams, within dreams\"\n" +
+				"2,Untitled,,,\n",
+		},
+		{
+			name:    "JSON export with filters",
+			request: dto.FilmExportRequest{FilmFilter: dto.FilmFilter{Title: "in"}, Format: consts.ExportFormatJSON},
+			mockBehavior: func(mr *mocks.Repository) {
+				streamCatalog(mr, models.FilmFilter{Title: "in"})
+			},
+			expectedOutput: `[{"id":1,"title":"Inception","director":"Christopher Nolan","release_date":"2010-07-16","synopsis":"Dreams, within dreams"}` + "\n" +
+				`,{"id":2,"title":"Untitled","director":"","release_date":null,"synopsis":""}` + "\n" +
+				"]\n",
+		},
+		{
+			name:    "NDJSON export",
+			request: dto.FilmExportRequest{Format: consts.ExportFormatNDJSON},
+			mockBehavior: func(mr *mocks.Repository) {
+				streamCatalog(mr, models.FilmFilter{})
+			},
+			expectedOutput: `{"id":1,"title":"Inception","director":"Christopher Nolan","release_date":"2010-07-16","synopsis":"Dreams, within dreams"}` + "\n" +
+				`{"id":2,"title":"Untitled","director":"","release_date":null,"synopsis":""}` + "\n",
+		},
+		{
+			name:    "Empty JSON export",
+			request: dto.FilmExportRequest{Format: consts.ExportFormatJSON},
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("StreamFilms", mock.Anything, models.FilmFilter{}, mock.Anything).Return(nil)
+			},
+			expectedOutput: "[]\n",
+		},
+		{
+			name:    "Query failing before the first film writes nothing",
+			request: dto.FilmExportRequest{Format: consts.ExportFormatJSON},
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("StreamFilms", mock.Anything, models.FilmFilter{}, mock.Anything).Return(errors.New("statement timeout"))
+			},
+			expectedError: errors.New("statement timeout"),
+		},
+		{
+			name:         "Unsupported format",
+			request:      dto.FilmExportRequest{Format: "xml"},
+			mockBehavior: func(mr *mocks.Repository) {},
+			expectedError: customerror.NewI18nErrorWithParams(kterrors.UnsupportedExportFormatError,
+				map[string]interface{}{"format": "xml"}),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := setupMockRepository(t)
+			tc.mockBehavior(mockRepo)
+
+			service := NewService(mockRepo, cursor.NewSigner(testCursorSecret), wordfilter.NewFilter(testBannedWords), blobstore.NewLocalStore(t.TempDir()), mediasign.NewSigner(testMediaKeys, testMediaURL))
+
+			output := &bytes.Buffer{}
+			err := service.ExportFilms(context.Background(), tc.request, output)
+
+			if tc.expectedError != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tc.expectedError.Error(), err.Error())
+				assert.Empty(t, output.String())
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedOutput, output.String())
+		})
+	}
+}
+
+func TestAddFilmCredit(t *testing.T) {
+	logger.InitializeForTest()
+
+	testCases := []struct {
+		name          string
+		request       dto.FilmCreditCreateRequest
+		mockBehavior  func(*mocks.Repository)
+		expectedError error
+	}{
+		{
+			name: "Successful actor credit",
+			request: dto.FilmCreditCreateRequest{
+				FilmID:        1,
+				PersonID:      4,
+				Role:          consts.CreditRoleActor,
+				CharacterName: "Cobb",
+				UserID:        100,
+			},
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("GetFilm", mock.Anything, 1).Return(entities.Film{ID: 1, UserID: 100}, nil)
+				mr.On("CreateFilmCredit", mock.Anything, entities.FilmCredit{
+					FilmID:        1,
+					PersonID:      4,
+					Role:          consts.CreditRoleActor,
+					CharacterName: "Cobb",
+				}).Return(nil)
+			},
+		},
+		{
+			name: "Character name on a director credit",
+			request: dto.FilmCreditCreateRequest{
+				FilmID:        1,
+				PersonID:      4,
+				Role:          consts.CreditRoleDirector,
+				CharacterName: "Cobb",
+				UserID:        100,
+			},
+			mockBehavior:  func(mr *mocks.Repository) {},
+			expectedError: customerror.NewCustomError(kterrors.CharacterNameOnlyForActorError),
+		},
+		{
+			name: "Unauthorized credit",
+			request: dto.FilmCreditCreateRequest{
+				FilmID:   1,
+				PersonID: 4,
+				Role:     consts.CreditRoleComposer,
+				UserID:   200,
+			},
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("GetFilm", mock.Anything, 1).Return(entities.Film{ID: 1, UserID: 100}, nil)
+			},
+			expectedError: customerror.NewCustomError(kterrors.UserCannotUpdateFilmError),
+		},
+		{
+			name: "Unknown person",
+			request: dto.FilmCreditCreateRequest{
+				FilmID:   1,
+				PersonID: 999,
+				Role:     consts.CreditRoleWriter,
+				UserID:   100,
+			},
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("GetFilm", mock.Anything, 1).Return(entities.Film{ID: 1, UserID: 100}, nil)
+				mr.On("CreateFilmCredit", mock.Anything, mock.AnythingOfType("entities.FilmCredit")).Return(
+					gorm.ErrForeignKeyViolated)
+			},
+			expectedError: customerror.NewCustomError(kterrors.PersonNotFoundError),
+		},
+		{
+			name: "Duplicate credit",
+			request: dto.FilmCreditCreateRequest{
+				FilmID:   1,
+				PersonID: 4,
+				Role:     consts.CreditRoleWriter,
+				UserID:   100,
+			},
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("GetFilm", mock.Anything, 1).Return(entities.Film{ID: 1, UserID: 100}, nil)
+				mr.On("CreateFilmCredit", mock.Anything, mock.AnythingOfType("entities.FilmCredit")).Return(
+					gorm.ErrDuplicatedKey)
+			},
+			expectedError: customerror.NewCustomError(kterrors.FilmCreditAlreadyExistsError),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := setupMockRepository(t)
+			tc.mockBehavior(mockRepo)
+
+			service := NewService(mockRepo, cursor.NewSigner(testCursorSecret), wordfilter.NewFilter(testBannedWords), blobstore.NewLocalStore(t.TempDir()), mediasign.NewSigner(testMediaKeys, testMediaURL))
+
+			err := service.AddFilmCredit(context.Background(), tc.request)
+
+			if tc.expectedError != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tc.expectedError.Error(), err.Error())
+				return
+			}
+
+			assert.NoError(t, err)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,161 @@
+package films
+
+import (
+	"KTOnlinePlatform/internal/dto"
+	"KTOnlinePlatform/internal/models/consts"
+	"KTOnlinePlatform/internal/models/kterrors"
+	"KTOnlinePlatform/pkg/customerror"
+	"KTOnlinePlatform/pkg/database/entities"
+	"KTOnlinePlatform/pkg/logger"
+	"KTOnlinePlatform/pkg/subtitles"
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/samber/lo"
+	"golang.org/x/text/language"
+	"golang.org/x/text/language/display"
+	"net/http"
+)
+
+// subtitles are stored under a new key at every upload, like the posters
+const subtitlesKeyFormat = "subtitles/%d/%s/%s.vtt"
+
+// UploadSubtitles lets the creator of a film add the subtitles of a language or replace them.
+// SRT files are converted, every track is served as WebVTT
+func (s *Service) UploadSubtitles(ctx context.Context, request dto.SubtitlesUploadRequest) (dto.SubtitleTrack, error) {
+	film, err := s.getFilm(ctx, request.FilmID)
+	if err != nil {
+		return dto.SubtitleTrack{}, err
+	}
+	if film.UserID != request.UserID {
+		return dto.SubtitleTrack{}, customerror.NewCustomError(kterrors.UserCannotUpdateFilmError)
+	}
+	tag, err := parseLanguage(request.Language)
+	if err != nil {
+		return dto.SubtitleTrack{}, err
+	}
+	data, err := toWebVTT(request.Data)
+	if err != nil {
+		return dto.SubtitleTrack{}, err
+	}
+	if request.Label == "" {
+		request.Label = display.Self.Name(tag)
+	}
+
+	existing, err := s.repo.GetFilmSubtitles(ctx, film.ID)
+	if err != nil {
+		return dto.SubtitleTrack{}, err
+	}
+	version := make([]byte, posterVersionLen)
+	_, err = rand.Read(version)
+	if err != nil {
+		return dto.SubtitleTrack{}, err
+	}
+	subtitle := entities.FilmSubtitle{
+		FilmID:   film.ID,
+		Language: tag.String(),
+		Label:    request.Label,
+		BlobKey:  fmt.Sprintf(subtitlesKeyFormat, film.ID, hex.EncodeToString(version), tag.String()),
+	}
+	err = s.blobs.Put(ctx, subtitle.BlobKey, consts.WebVTTContentType, bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return dto.SubtitleTrack{}, err
+	}
+	err = s.repo.SaveFilmSubtitle(ctx, subtitle)
+	if err != nil {
+		s.deleteSubtitles(ctx, subtitle.BlobKey)
+		return dto.SubtitleTrack{}, err
+	}
+	previous, found := lo.Find(existing, func(item entities.FilmSubtitle) bool {
+		return item.Language == subtitle.Language
+	})
+	if found {
+		s.deleteSubtitles(ctx, previous.BlobKey)
+	}
+	return s.toSubtitleTrack(subtitle), nil
+}
+
+func (s *Service) DeleteSubtitles(ctx context.Context, filmID int, lang string, userID int) error {
+	film, err := s.getFilm(ctx, filmID)
+	if err != nil {
+		return err
+	}
+	if film.UserID != userID {
+		return customerror.NewCustomError(kterrors.UserCannotUpdateFilmError)
+	}
+	tag, err := parseLanguage(lang)
+	if err != nil {
+		return err
+	}
+	subtitle, err := s.repo.DeleteFilmSubtitle(ctx, filmID, tag.String())
+	if err != nil {
+		if customerror.IsNotFoundError(err) {
+			return customerror.NewCustomErrorWithHttpCode(kterrors.SubtitleTrackNotFoundError, http.StatusNotFound)
+		}
+		return err
+	}
+	s.deleteSubtitles(ctx, subtitle.BlobKey)
+	return nil
+}
+
+// parseLanguage accepts any well formed BCP 47 tag and returns it canonicalized, "EN-us" is "en-US"
+func parseLanguage(lang string) (language.Tag, error) {
+	tag, err := language.Parse(lang)
+	if err != nil || tag == language.Und {
+		return language.Und, customerror.NewI18nErrorWithParams(
+			kterrors.InvalidLanguageTagError,
+			map[string]interface{}{"language": lang})
+	}
+	return tag, nil
+}
+
+// toWebVTT validates the cues of the upload and rewrites them as WebVTT
+func toWebVTT(data []byte) ([]byte, error) {
+	if len(data) == 0 {
+		return nil, customerror.NewCustomError(kterrors.SubtitlesRequiredError)
+	}
+	if len(data) > consts.SubtitlesMaxSize {
+		return nil, customerror.NewCustomErrorWithHttpCode(kterrors.SubtitlesTooLargeError, http.StatusRequestEntityTooLarge)
+	}
+	format := subtitles.Detect(data)
+	if format == "" {
+		return nil, customerror.NewCustomErrorWithHttpCode(kterrors.UnsupportedSubtitlesFormatError, http.StatusUnsupportedMediaType)
+	}
+	cues, err := subtitles.Parse(data, format)
+	if err != nil {
+		var parseErr *subtitles.ParseError
+		if errors.As(err, &parseErr) {
+			return nil, customerror.NewI18nErrorWithParams(
+				kterrors.InvalidSubtitlesError,
+				map[string]interface{}{"line": parseErr.Line, "reason": parseErr.Reason})
+		}
+		return nil, err
+	}
+	return subtitles.WriteWebVTT(cues), nil
+}
+
+// deleteSubtitles is best effort, like deletePoster
+func (s *Service) deleteSubtitles(ctx context.Context, blobKey string) {
+	err := s.blobs.Delete(ctx, blobKey)
+	if err != nil {
+		logger.Warn().Err(err).Str("subtitles", blobKey).Msg("delete subtitles failed")
+	}
+}
+
+// toSubtitleTrack signs the URL of the track, subtitles are shared like the posters
+func (s *Service) toSubtitleTrack(subtitle entities.FilmSubtitle) dto.SubtitleTrack {
+	return dto.SubtitleTrack{
+		Language: subtitle.Language,
+		Label:    subtitle.Label,
+		URL:      s.media.URL(subtitle.BlobKey, consts.SubtitlesURLTTL),
+	}
+}
+
+func (s *Service) toSubtitleTracks(result []entities.FilmSubtitle) []dto.SubtitleTrack {
+	return lo.Map(result, func(item entities.FilmSubtitle, index int) dto.SubtitleTrack {
+		return s.toSubtitleTrack(item)
+	})
+}
@@ -0,0 +1,185 @@
+package films
+
+import (
+	"KTOnlinePlatform/internal/dto"
+	"KTOnlinePlatform/internal/models/kterrors"
+	"KTOnlinePlatform/internal/services/films/mocks"
+	"KTOnlinePlatform/pkg/blobstore"
+	"KTOnlinePlatform/pkg/cursor"
+	"KTOnlinePlatform/pkg/customerror"
+	"KTOnlinePlatform/pkg/database/entities"
+	"KTOnlinePlatform/pkg/logger"
+	"KTOnlinePlatform/pkg/mediasign"
+	"KTOnlinePlatform/pkg/wordfilter"
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+const testSRT = "1\r\n00:00:01,000 --> 00:00:03,000\r\nBonjour & bienvenue\r\n\r\n2\r\n00:00:04,000 --> 00:00:05,500\r\nAu revoir\r\n"
+
+func TestUploadSubtitles(t *testing.T) {
+	logger.InitializeForTest()
+	previous := entities.FilmSubtitle{FilmID: 1, Language: "fr-CA", BlobKey: "subtitles/1/old/fr-CA.vtt"}
+
+	testCases := []struct {
+		name          string
+		request       dto.SubtitlesUploadRequest
+		mockBehavior  func(*mocks.Repository)
+		expectedTrack dto.SubtitleTrack
+		expectedVTT   string
+		expectedError error
+	}{
+		{
+			name:    "SRT is converted and replaces the track of the language",
+			request: dto.SubtitlesUploadRequest{FilmID: 1, Language: "FR-ca", Data: []byte(testSRT), UserID: 100},
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("GetFilm", mock.Anything, 1).Return(entities.Film{ID: 1, UserID: 100}, nil)
+				mr.On("GetFilmSubtitles", mock.Anything, 1).Return([]entities.FilmSubtitle{previous}, nil)
+				mr.On("SaveFilmSubtitle", mock.Anything, mock.MatchedBy(func(subtitle entities.FilmSubtitle) bool {
+					return subtitle.Language == "fr-CA" && subtitle.Label == "français canadien" &&
+						strings.HasSuffix(subtitle.BlobKey, "/fr-CA.vtt") && subtitle.BlobKey != previous.BlobKey
+				})).Return(nil)
+			},
+			expectedTrack: dto.SubtitleTrack{Language: "fr-CA", Label: "français canadien"},
+			expectedVTT:   "WEBVTT\n\n00:00:01.000 --> 00:00:03.000\nBonjour &amp; bienvenue\n\n00:00:04.000 --> 00:00:05.500\nAu revoir\n",
+		},
+		{
+			name: "WebVTT keeps its label",
+			request: dto.SubtitlesUploadRequest{FilmID: 1, Language: "en", Label: "English SDH", UserID: 100,
+				Data: []byte("WEBVTT\n\n00:01.000 --> 00:02.000 line:0\n[door slams]\n")},
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("GetFilm", mock.Anything, 1).Return(entities.Film{ID: 1, UserID: 100}, nil)
+				mr.On("GetFilmSubtitles", mock.Anything, 1).Return([]entities.FilmSubtitle{previous}, nil)
+				mr.On("SaveFilmSubtitle", mock.Anything, mock.Anything).Return(nil)
+			},
+			expectedTrack: dto.SubtitleTrack{Language: "en", Label: "English SDH"},
+			expectedVTT:   "WEBVTT\n\n00:00:01.000 --> 00:00:02.000 line:0\n[door slams]\n",
+		},
+		{
+			name:    "Malformed cue timings",
+			request: dto.SubtitlesUploadRequest{FilmID: 1, Language: "en", Data: []byte("1\n00:00:03,000 --> 00:00:01,000\nHi\n"), UserID: 100},
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("GetFilm", mock.Anything, 1).Return(entities.Film{ID: 1, UserID: 100}, nil)
+			},
+			expectedError: customerror.NewI18nErrorWithParams(kterrors.InvalidSubtitlesError,
+				map[string]interface{}{"line": 2, "reason": "cue ends at 00:00:01.000 before it starts at 00:00:03.000"}),
+		},
+		{
+			name:    "Invalid language tag",
+			request: dto.SubtitlesUploadRequest{FilmID: 1, Language: "english!", Data: []byte(testSRT), UserID: 100},
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("GetFilm", mock.Anything, 1).Return(entities.Film{ID: 1, UserID: 100}, nil)
+			},
+			expectedError: customerror.NewI18nErrorWithParams(kterrors.InvalidLanguageTagError,
+				map[string]interface{}{"language": "english!"}),
+		},
+		{
+			name:    "Unsupported format",
+			request: dto.SubtitlesUploadRequest{FilmID: 1, Language: "en", Data: []byte("<tt xmlns=\"http://www.w3.org/ns/ttml\"/>"), UserID: 100},
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("GetFilm", mock.Anything, 1).Return(entities.Film{ID: 1, UserID: 100}, nil)
+			},
+			expectedError: customerror.NewCustomErrorWithHttpCode(kterrors.UnsupportedSubtitlesFormatError, http.StatusUnsupportedMediaType),
+		},
+		{
+			name:    "User is not the creator",
+			request: dto.SubtitlesUploadRequest{FilmID: 1, Language: "en", Data: []byte(testSRT), UserID: 100},
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("GetFilm", mock.Anything, 1).Return(entities.Film{ID: 1, UserID: 200}, nil)
+			},
+			expectedError: customerror.NewCustomError(kterrors.UserCannotUpdateFilmError),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			store := blobstore.NewLocalStore(dir)
+			_ = store.Put(context.Background(), previous.BlobKey, "text/vtt", strings.NewReader("WEBVTT\n"), 7)
+			mockRepo := mocks.NewRepository(t)
+			tc.mockBehavior(mockRepo)
+			service := NewService(mockRepo, cursor.NewSigner(testCursorSecret), wordfilter.NewFilter(testBannedWords), store, mediasign.NewSigner(testMediaKeys, testMediaURL))
+
+			track, err := service.UploadSubtitles(context.Background(), tc.request)
+
+			files, _ := filepath.Glob(filepath.Join(dir, "subtitles", "1", "*", "*.vtt"))
+			if tc.expectedError != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tc.expectedError.Error(), err.Error())
+				assert.Equal(t, []string{filepath.Join(dir, filepath.FromSlash(previous.BlobKey))}, files)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedTrack.Language, track.Language)
+			assert.Equal(t, tc.expectedTrack.Label, track.Label)
+			assert.True(t, strings.HasPrefix(track.URL, testMediaURL+"/subtitles/1/"))
+			if track.Language == previous.Language {
+				assert.Len(t, files, 1)
+			}
+			key, _, _ := strings.Cut(strings.TrimPrefix(track.URL, testMediaURL+"/"), "?")
+			data, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(key)))
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedVTT, string(data))
+		})
+	}
+}
+
+func TestDeleteSubtitles(t *testing.T) {
+	logger.InitializeForTest()
+
+	testCases := []struct {
+		name          string
+		language      string
+		mockBehavior  func(*mocks.Repository)
+		expectedError error
+	}{
+		{
+			name:     "Track and file are deleted",
+			language: "pt-br",
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("GetFilm", mock.Anything, 1).Return(entities.Film{ID: 1, UserID: 100}, nil)
+				mr.On("DeleteFilmSubtitle", mock.Anything, 1, "pt-BR").Return(
+					entities.FilmSubtitle{FilmID: 1, Language: "pt-BR", BlobKey: "subtitles/1/abc/pt-BR.vtt"}, nil)
+			},
+		},
+		{
+			name:     "No track in that language",
+			language: "de",
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("GetFilm", mock.Anything, 1).Return(entities.Film{ID: 1, UserID: 100}, nil)
+				mr.On("DeleteFilmSubtitle", mock.Anything, 1, "de").Return(entities.FilmSubtitle{}, gorm.ErrRecordNotFound)
+			},
+			expectedError: customerror.NewCustomErrorWithHttpCode(kterrors.SubtitleTrackNotFoundError, http.StatusNotFound),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			store := blobstore.NewLocalStore(dir)
+			_ = store.Put(context.Background(), "subtitles/1/abc/pt-BR.vtt", "text/vtt", strings.NewReader("WEBVTT\n"), 7)
+			mockRepo := mocks.NewRepository(t)
+			tc.mockBehavior(mockRepo)
+			service := NewService(mockRepo, cursor.NewSigner(testCursorSecret), wordfilter.NewFilter(testBannedWords), store, mediasign.NewSigner(testMediaKeys, testMediaURL))
+
+			err := service.DeleteSubtitles(context.Background(), 1, tc.language, 100)
+
+			if tc.expectedError != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tc.expectedError.Error(), err.Error())
+				return
+			}
+			assert.NoError(t, err)
+			_, err = os.Stat(filepath.Join(dir, "subtitles", "1", "abc", "pt-BR.vtt"))
+			assert.True(t, os.IsNotExist(err))
+		})
+	}
+}
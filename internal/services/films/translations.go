@@ -0,0 +1,139 @@
+package films
+
+import (
+	"KTOnlinePlatform/internal/dto"
+	"KTOnlinePlatform/internal/models/kterrors"
+	"KTOnlinePlatform/pkg/customerror"
+	"KTOnlinePlatform/pkg/database/entities"
+	"context"
+	"github.com/samber/lo"
+	"net/http"
+)
+
+func (s *Service) GetFilmTranslations(ctx context.Context, filmID int) ([]dto.FilmTranslation, error) {
+	film, err := s.getFilm(ctx, filmID)
+	if err != nil {
+		return nil, err
+	}
+	if film.Hidden {
+		return nil, newFilmNotFoundError()
+	}
+	translations, err := s.repo.GetFilmTranslations(ctx, filmID)
+	if err != nil {
+		return nil, err
+	}
+	return lo.Map(translations, func(item entities.FilmTranslation, index int) dto.FilmTranslation {
+		return toFilmTranslation(item)
+	}), nil
+}
+
+// SaveFilmTranslation lets the creator add the translation of a locale or replace it
+func (s *Service) SaveFilmTranslation(ctx context.Context, request dto.FilmTranslationRequest) (dto.FilmTranslation, error) {
+	film, err := s.getFilm(ctx, request.FilmID)
+	if err != nil {
+		return dto.FilmTranslation{}, err
+	}
+	if film.UserID != request.UserID {
+		return dto.FilmTranslation{}, customerror.NewCustomError(kterrors.UserCannotUpdateFilmError)
+	}
+	tag, err := parseLanguage(request.Locale)
+	if err != nil {
+		return dto.FilmTranslation{}, err
+	}
+	err = s.filter.Check(request.Title, request.Synopsis)
+	if err != nil {
+		return dto.FilmTranslation{}, err
+	}
+	translation := entities.FilmTranslation{
+		FilmID:   film.ID,
+		Locale:   tag.String(),
+		Title:    request.Title,
+		Synopsis: request.Synopsis,
+	}
+	err = s.repo.SaveFilmTranslation(ctx, translation)
+	if err != nil {
+		return dto.FilmTranslation{}, err
+	}
+	return toFilmTranslation(translation), nil
+}
+
+func (s *Service) DeleteFilmTranslation(ctx context.Context, filmID int, locale string, userID int) error {
+	film, err := s.getFilm(ctx, filmID)
+	if err != nil {
+		return err
+	}
+	if film.UserID != userID {
+		return customerror.NewCustomError(kterrors.UserCannotUpdateFilmError)
+	}
+	tag, err := parseLanguage(locale)
+	if err != nil {
+		return err
+	}
+	err = s.repo.DeleteFilmTranslation(ctx, filmID, tag.String())
+	if err != nil {
+		if customerror.IsNotFoundError(err) {
+			return customerror.NewCustomErrorWithHttpCode(kterrors.FilmTranslationNotFoundError, http.StatusNotFound)
+		}
+		return err
+	}
+	return nil
+}
+
+// findTranslation returns the translation of the film in the first locale available,
+// an empty one when the original text is to be shown
+func (s *Service) findTranslation(ctx context.Context, filmID int, locales []string) (entities.FilmTranslation, error) {
+	if len(locales) == 0 {
+		return entities.FilmTranslation{}, nil
+	}
+	translations, err := s.repo.FindFilmTranslations(ctx, []int{filmID}, locales)
+	if err != nil {
+		return entities.FilmTranslation{}, err
+	}
+	return pickTranslation(translations, locales), nil
+}
+
+// localizeFilms replaces the titles of a page with their translation in the first locale
+// available, the page keeps the order of the original titles so cursors hold whatever the language
+func (s *Service) localizeFilms(ctx context.Context, films []dto.Film, locales []string) error {
+	if len(locales) == 0 || len(films) == 0 {
+		return nil
+	}
+	filmIDs := lo.Map(films, func(item dto.Film, index int) int {
+		return item.ID
+	})
+	translations, err := s.repo.FindFilmTranslations(ctx, filmIDs, locales)
+	if err != nil {
+		return err
+	}
+	byFilm := lo.GroupBy(translations, func(item entities.FilmTranslation) int {
+		return item.FilmID
+	})
+	for i := range films {
+		translation := pickTranslation(byFilm[films[i].ID], locales)
+		if translation.Title != "" {
+			films[i].Title = translation.Title
+			films[i].Locale = translation.Locale
+		}
+	}
+	return nil
+}
+
+func pickTranslation(translations []entities.FilmTranslation, locales []string) entities.FilmTranslation {
+	for _, locale := range locales {
+		translation, found := lo.Find(translations, func(item entities.FilmTranslation) bool {
+			return item.Locale == locale
+		})
+		if found {
+			return translation
+		}
+	}
+	return entities.FilmTranslation{}
+}
+
+func toFilmTranslation(translation entities.FilmTranslation) dto.FilmTranslation {
+	return dto.FilmTranslation{
+		Locale:   translation.Locale,
+		Title:    translation.Title,
+		Synopsis: translation.Synopsis,
+	}
+}
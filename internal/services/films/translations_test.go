@@ -0,0 +1,189 @@
+package films
+
+import (
+	"KTOnlinePlatform/internal/dto"
+	"KTOnlinePlatform/internal/models"
+	"KTOnlinePlatform/internal/models/kterrors"
+	"KTOnlinePlatform/internal/services/films/mocks"
+	"KTOnlinePlatform/pkg/blobstore"
+	"KTOnlinePlatform/pkg/cursor"
+	"KTOnlinePlatform/pkg/customerror"
+	"KTOnlinePlatform/pkg/database/entities"
+	"KTOnlinePlatform/pkg/logger"
+	"KTOnlinePlatform/pkg/mediasign"
+	"KTOnlinePlatform/pkg/wordfilter"
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func newTranslationTestService(t *testing.T, mockRepo *mocks.Repository) *Service {
+	return NewService(mockRepo, cursor.NewSigner(testCursorSecret), wordfilter.NewFilter(testBannedWords),
+		blobstore.NewLocalStore(t.TempDir()), mediasign.NewSigner(testMediaKeys, testMediaURL))
+}
+
+func TestGetFilmDetailLocalized(t *testing.T) {
+	logger.InitializeForTest()
+
+	testCases := []struct {
+		name             string
+		locales          []string
+		translations     []entities.FilmTranslation
+		expectedTitle    string
+		expectedSynopsis string
+		expectedLocale   string
+	}{
+		{
+			name:    "First locale with a translation wins",
+			locales: []string{"fr-CA", "fr", "de"},
+			translations: []entities.FilmTranslation{
+				{FilmID: 1, Locale: "de", Title: "Die Reise", Synopsis: "Eine Reise"},
+				{FilmID: 1, Locale: "fr", Title: "Le Voyage", Synopsis: "Un voyage"},
+			},
+			expectedTitle:    "Le Voyage",
+			expectedSynopsis: "Un voyage",
+			expectedLocale:   "fr",
+		},
+		{
+			name:             "Untranslated synopsis falls back to the original",
+			locales:          []string{"it"},
+			translations:     []entities.FilmTranslation{{FilmID: 1, Locale: "it", Title: "Il Viaggio"}},
+			expectedTitle:    "Il Viaggio",
+			expectedSynopsis: "A journey",
+			expectedLocale:   "it",
+		},
+		{
+			name:             "No translation in any locale",
+			locales:          []string{"ja"},
+			expectedTitle:    "The Journey",
+			expectedSynopsis: "A journey",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := mocks.NewRepository(t)
+			mockRepo.On("GetFilm", mock.Anything, 1).Return(entities.Film{ID: 1, Title: "The Journey", Synopsis: "A journey"}, nil)
+			mockRepo.On("GetFilmCredits", mock.Anything, 1).Return([]models.FilmCredit{}, nil)
+			mockRepo.On("GetFilmSubtitles", mock.Anything, 1).Return([]entities.FilmSubtitle{}, nil)
+			mockRepo.On("FindFilmTranslations", mock.Anything, []int{1}, tc.locales).Return(tc.translations, nil)
+			service := newTranslationTestService(t, mockRepo)
+
+			result, err := service.GetFilmDetail(context.Background(), 1, tc.locales)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedTitle, result.Title)
+			assert.Equal(t, tc.expectedSynopsis, result.Synopsis)
+			assert.Equal(t, tc.expectedLocale, result.Locale)
+		})
+	}
+}
+
+func TestGetFilmPaginatedLocalized(t *testing.T) {
+	logger.InitializeForTest()
+	mockRepo := mocks.NewRepository(t)
+	mockRepo.On("GetFilmsPaginated", mock.Anything, models.FilmFilter{Title: "reise"}, "title", 10, 0).Return([]models.FilmPaginated{
+		{ID: 1, Title: "The Journey", Qty: 2},
+		{ID: 2, Title: "The Return", Qty: 2},
+	}, nil)
+	mockRepo.On("FindFilmTranslations", mock.Anything, []int{1, 2}, []string{"de-AT", "de"}).Return([]entities.FilmTranslation{
+		{FilmID: 1, Locale: "de", Title: "Die Reise"},
+		{FilmID: 1, Locale: "de-AT", Title: "Die Reise (AT)"},
+	}, nil)
+	service := newTranslationTestService(t, mockRepo)
+
+	result, err := service.GetFilmPaginated(context.Background(), dto.FilmSearchRequest{
+		FilmFilter: dto.FilmFilter{Title: "reise"},
+		Page:       1,
+		PageSize:   10,
+		Locales:    []string{"de-AT", "de"},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Die Reise (AT)", result.Films[0].Title)
+	assert.Equal(t, "de-AT", result.Films[0].Locale)
+	assert.Equal(t, "The Return", result.Films[1].Title)
+	assert.Equal(t, "", result.Films[1].Locale)
+}
+
+func TestSaveFilmTranslation(t *testing.T) {
+	logger.InitializeForTest()
+
+	testCases := []struct {
+		name           string
+		request        dto.FilmTranslationRequest
+		mockBehavior   func(*mocks.Repository)
+		expectedResult dto.FilmTranslation
+		expectedError  error
+	}{
+		{
+			name:    "Locale is canonicalized",
+			request: dto.FilmTranslationRequest{FilmID: 1, Locale: "pt-br", Title: "A Viagem", UserID: 100},
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("GetFilm", mock.Anything, 1).Return(entities.Film{ID: 1, UserID: 100}, nil)
+				mr.On("SaveFilmTranslation", mock.Anything, entities.FilmTranslation{FilmID: 1, Locale: "pt-BR", Title: "A Viagem"}).Return(nil)
+			},
+			expectedResult: dto.FilmTranslation{Locale: "pt-BR", Title: "A Viagem"},
+		},
+		{
+			name:    "Banned words",
+			request: dto.FilmTranslationRequest{FilmID: 1, Locale: "en", Title: "A scam", UserID: 100},
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("GetFilm", mock.Anything, 1).Return(entities.Film{ID: 1, UserID: 100}, nil)
+			},
+			expectedError: customerror.NewI18nErrorWithParams(kterrors.ContentContainsBannedWordsError,
+				map[string]interface{}{"words": []string{"scam"}}),
+		},
+		{
+			name:    "Invalid locale",
+			request: dto.FilmTranslationRequest{FilmID: 1, Locale: "not a locale", Title: "A Viagem", UserID: 100},
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("GetFilm", mock.Anything, 1).Return(entities.Film{ID: 1, UserID: 100}, nil)
+			},
+			expectedError: customerror.NewI18nErrorWithParams(kterrors.InvalidLanguageTagError,
+				map[string]interface{}{"language": "not a locale"}),
+		},
+		{
+			name:    "User is not the creator",
+			request: dto.FilmTranslationRequest{FilmID: 1, Locale: "pt-BR", Title: "A Viagem", UserID: 100},
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("GetFilm", mock.Anything, 1).Return(entities.Film{ID: 1, UserID: 200}, nil)
+			},
+			expectedError: customerror.NewCustomError(kterrors.UserCannotUpdateFilmError),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := mocks.NewRepository(t)
+			tc.mockBehavior(mockRepo)
+			service := newTranslationTestService(t, mockRepo)
+
+			result, err := service.SaveFilmTranslation(context.Background(), tc.request)
+
+			if tc.expectedError != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tc.expectedError.Error(), err.Error())
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedResult, result)
+		})
+	}
+}
+
+func TestDeleteFilmTranslation(t *testing.T) {
+	logger.InitializeForTest()
+	mockRepo := mocks.NewRepository(t)
+	mockRepo.On("GetFilm", mock.Anything, 1).Return(entities.Film{ID: 1, UserID: 100}, nil)
+	mockRepo.On("DeleteFilmTranslation", mock.Anything, 1, "es-419").Return(gorm.ErrRecordNotFound)
+	service := newTranslationTestService(t, mockRepo)
+
+	err := service.DeleteFilmTranslation(context.Background(), 1, "es-419", 100)
+
+	assert.Equal(t, customerror.NewCustomErrorWithHttpCode(kterrors.FilmTranslationNotFoundError, http.StatusNotFound).Error(), err.Error())
+}
@@ -0,0 +1,59 @@
+// Code generated by mockery v2.52.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	dto "KTOnlinePlatform/internal/dto"
+	context "context"
+
+	jobs "KTOnlinePlatform/internal/services/jobs"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// JobRunner is an autogenerated mock type for the JobRunner type
+type JobRunner struct {
+	mock.Mock
+}
+
+// StartJob provides a mock function with given fields: ctx, jobType, userID, total, task
+func (_m *JobRunner) StartJob(ctx context.Context, jobType string, userID int, total int, task jobs.Task) (dto.Job, error) {
+	ret := _m.Called(ctx, jobType, userID, total, task)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StartJob")
+	}
+
+	var r0 dto.Job
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, int, jobs.Task) (dto.Job, error)); ok {
+		return rf(ctx, jobType, userID, total, task)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, int, jobs.Task) dto.Job); ok {
+		r0 = rf(ctx, jobType, userID, total, task)
+	} else {
+		r0 = ret.Get(0).(dto.Job)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int, int, jobs.Task) error); ok {
+		r1 = rf(ctx, jobType, userID, total, task)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewJobRunner creates a new instance of JobRunner. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewJobRunner(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *JobRunner {
+	mock := &JobRunner{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
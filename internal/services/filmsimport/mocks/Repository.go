@@ -0,0 +1,77 @@
+// Code generated by mockery v2.52.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	entities "KTOnlinePlatform/pkg/database/entities"
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Repository is an autogenerated mock type for the Repository type
+type Repository struct {
+	mock.Mock
+}
+
+// FindFilmsByTitles provides a mock function with given fields: ctx, titles
+func (_m *Repository) FindFilmsByTitles(ctx context.Context, titles []string) ([]entities.Film, error) {
+	ret := _m.Called(ctx, titles)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindFilmsByTitles")
+	}
+
+	var r0 []entities.Film
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) ([]entities.Film, error)); ok {
+		return rf(ctx, titles)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) []entities.Film); ok {
+		r0 = rf(ctx, titles)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entities.Film)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, titles)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SaveFilmsBatch provides a mock function with given fields: ctx, created, updated
+func (_m *Repository) SaveFilmsBatch(ctx context.Context, created []entities.Film, updated []entities.Film) error {
+	ret := _m.Called(ctx, created, updated)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveFilmsBatch")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []entities.Film, []entities.Film) error); ok {
+		r0 = rf(ctx, created, updated)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewRepository creates a new instance of Repository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Repository {
+	mock := &Repository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
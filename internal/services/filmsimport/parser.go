@@ -0,0 +1,131 @@
+package filmsimport
+
+import (
+	"KTOnlinePlatform/internal/dto"
+	"KTOnlinePlatform/internal/models/kterrors"
+	"KTOnlinePlatform/pkg/customerror"
+	"KTOnlinePlatform/pkg/database/entities/entitiescustom"
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"time"
+)
+
+const (
+	csvColumnTitle       = "title"
+	csvColumnDirector    = "director"
+	csvColumnReleaseDate = "release_date"
+	csvColumnSynopsis    = "synopsis"
+
+	maxNDJSONLineSize = 1024 * 1024
+)
+
+// importRow is a parsed film along with its line in the uploaded file
+type importRow struct {
+	Line int
+	Film dto.FilmImportRow
+}
+
+// parseCSV reads a CSV file whose first record is a header naming the columns, only title is mandatory
+func parseCSV(data []byte) ([]importRow, []entitiescustom.JobRowError, error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, nil, newInvalidFileError(err)
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	if _, ok := columns[csvColumnTitle]; !ok {
+		return nil, nil, newInvalidFileError(errors.New("missing title column"))
+	}
+
+	var rows []importRow
+	var rowErrors []entitiescustom.JobRowError
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		line, _ := reader.FieldPos(0)
+		if err != nil {
+			rowErrors = append(rowErrors, newInvalidRowError(line, err))
+			continue
+		}
+		value := func(column string) string {
+			i, ok := columns[column]
+			if !ok || i >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[i])
+		}
+
+		film := dto.FilmImportRow{
+			Title:    value(csvColumnTitle),
+			Director: value(csvColumnDirector),
+			Synopsis: value(csvColumnSynopsis),
+		}
+		if releaseDate := value(csvColumnReleaseDate); releaseDate != "" {
+			date, err := time.Parse(entitiescustom.ReleaseDateFormat, releaseDate)
+			if err != nil {
+				rowErrors = append(rowErrors, newInvalidRowError(line, err))
+				continue
+			}
+			film.ReleaseDate = &entitiescustom.ReleaseDate{Time: date}
+		}
+		rows = append(rows, importRow{Line: line, Film: film})
+	}
+	return rows, rowErrors, nil
+}
+
+// parseNDJSON reads one film object per line, blank lines are ignored
+func parseNDJSON(data []byte) ([]importRow, []entitiescustom.JobRowError, error) {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), maxNDJSONLineSize)
+
+	var rows []importRow
+	var rowErrors []entitiescustom.JobRowError
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := bytes.TrimSpace(scanner.Bytes())
+		if len(text) == 0 {
+			continue
+		}
+		film := dto.FilmImportRow{}
+		decoder := json.NewDecoder(bytes.NewReader(text))
+		decoder.DisallowUnknownFields()
+		err := decoder.Decode(&film)
+		if err != nil {
+			rowErrors = append(rowErrors, newInvalidRowError(line, err))
+			continue
+		}
+		rows = append(rows, importRow{Line: line, Film: film})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, nil, newInvalidFileError(err)
+	}
+	return rows, rowErrors, nil
+}
+
+func newInvalidFileError(err error) error {
+	return customerror.NewI18nErrorWithParams(
+		kterrors.InvalidImportFileError,
+		map[string]interface{}{"reason": err.Error()})
+}
+
+func newInvalidRowError(line int, err error) entitiescustom.JobRowError {
+	return entitiescustom.JobRowError{
+		Row:    line,
+		Code:   kterrors.InvalidImportRowError,
+		Params: map[string]interface{}{"reason": err.Error()},
+	}
+}
@@ -0,0 +1,274 @@
+package filmsimport
+
+import (
+	"KTOnlinePlatform/internal/dto"
+	"KTOnlinePlatform/internal/models"
+	"KTOnlinePlatform/internal/models/consts"
+	"KTOnlinePlatform/internal/models/kterrors"
+	"KTOnlinePlatform/internal/services/jobs"
+	"KTOnlinePlatform/pkg/customerror"
+	"KTOnlinePlatform/pkg/database/entities"
+	"KTOnlinePlatform/pkg/database/entities/entitiescustom"
+	"KTOnlinePlatform/pkg/validation"
+	"context"
+	"errors"
+	"github.com/go-playground/validator/v10"
+	"github.com/samber/lo"
+)
+
+type Repository interface {
+	FindFilmsByTitles(ctx context.Context, titles []string) ([]entities.Film, error)
+	SaveFilmsBatch(ctx context.Context, created []entities.Film, updated []entities.Film) error
+}
+
+type JobRunner interface {
+	StartJob(ctx context.Context, jobType string, userID int, total int, task jobs.Task) (dto.Job, error)
+}
+
+// ContentFilter rejects user written text containing banned words
+type ContentFilter interface {
+	Check(texts ...string) error
+}
+
+type Service struct {
+	repo     Repository
+	jobs     JobRunner
+	filter   ContentFilter
+	validate *validator.Validate
+}
+
+func NewService(repo Repository, jobs JobRunner, filter ContentFilter) *Service {
+	return &Service{
+		repo:     repo,
+		jobs:     jobs,
+		filter:   filter,
+		validate: validation.New(),
+	}
+}
+
+// ImportFilms parses and validates the uploaded file, then stores the valid rows in a background job
+func (s *Service) ImportFilms(ctx context.Context, request dto.FilmImportRequest) (dto.Job, error) {
+	if request.OnConflict == "" {
+		request.OnConflict = consts.ImportConflictSkip
+	}
+	if !lo.Contains([]string{consts.ImportConflictSkip, consts.ImportConflictUpdate, consts.ImportConflictFail}, request.OnConflict) {
+		return dto.Job{}, customerror.NewI18nErrorWithParams(
+			kterrors.InvalidConflictPolicyError,
+			map[string]interface{}{"onConflict": request.OnConflict})
+	}
+
+	var rows []importRow
+	var rowErrors []entitiescustom.JobRowError
+	var err error
+	switch request.Format {
+	case consts.CSVContentType:
+		rows, rowErrors, err = parseCSV(request.Data)
+	case consts.NDJSONContentType:
+		rows, rowErrors, err = parseNDJSON(request.Data)
+	default:
+		return dto.Job{}, customerror.NewI18nErrorWithParams(
+			kterrors.UnsupportedImportFormatError,
+			map[string]interface{}{"format": request.Format})
+	}
+	if err != nil {
+		return dto.Job{}, err
+	}
+
+	validRows := make([]importRow, 0, len(rows))
+	for _, row := range rows {
+		if err := s.validate.Struct(row.Film); err != nil {
+			rowErrors = append(rowErrors, newInvalidRowError(row.Line, err))
+			continue
+		}
+		// the same text is checked as for the films created one by one
+		if err := s.filter.Check(row.Film.Title, row.Film.Synopsis); err != nil {
+			rowErrors = append(rowErrors, newRowError(row.Line, err))
+			continue
+		}
+		validRows = append(validRows, row)
+	}
+
+	total := len(validRows) + len(rowErrors)
+	return s.jobs.StartJob(ctx, consts.JobTypeFilmImport, request.UserID, total,
+		func(ctx context.Context, tracker jobs.Tracker) error {
+			return s.importRows(ctx, tracker, request.UserID, request.OnConflict, validRows, rowErrors)
+		})
+}
+
+func (s *Service) importRows(ctx context.Context, tracker jobs.Tracker, userID int, onConflict string,
+	rows []importRow, rowErrors []entitiescustom.JobRowError) error {
+	if len(rowErrors) > 0 {
+		err := tracker.Advance(ctx, models.JobProgress{Failed: len(rowErrors), RowErrors: rowErrors})
+		if err != nil {
+			return err
+		}
+	}
+
+	if onConflict == consts.ImportConflictFail {
+		err := s.checkConflicts(ctx, rows)
+		if err != nil {
+			return err
+		}
+	}
+
+	// first line of each title seen so far, to catch duplicates inside the file itself
+	seen := make(map[string]int, len(rows))
+	for _, batch := range lo.Chunk(rows, consts.FilmImportBatchSize) {
+		progress, err := s.importBatch(ctx, userID, onConflict, batch, seen)
+		if err != nil {
+			return err
+		}
+		err = tracker.Advance(ctx, progress)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkConflicts returns the first title repeated in the file or already taken, so that the fail
+// policy stops the import before any film is saved. A title taken by a concurrent writer once the
+// import started still rolls back its batch only, the batches saved before it stay.
+func (s *Service) checkConflicts(ctx context.Context, rows []importRow) error {
+	seen := make(map[string]bool, len(rows))
+	for _, row := range rows {
+		if seen[row.Film.Title] {
+			return newConflictError(row.Line, row.Film.Title)
+		}
+		seen[row.Film.Title] = true
+	}
+
+	for _, batch := range lo.Chunk(rows, consts.FilmImportBatchSize) {
+		titles := lo.Map(batch, func(item importRow, index int) string {
+			return item.Film.Title
+		})
+		existing, err := s.repo.FindFilmsByTitles(ctx, titles)
+		if err != nil {
+			return err
+		}
+		taken := lo.SliceToMap(existing, func(item entities.Film) (string, bool) {
+			return item.Title, true
+		})
+		for _, row := range batch {
+			if taken[row.Film.Title] {
+				return newConflictError(row.Line, row.Film.Title)
+			}
+		}
+	}
+	return nil
+}
+
+func (s *Service) importBatch(ctx context.Context, userID int, onConflict string,
+	batch []importRow, seen map[string]int) (models.JobProgress, error) {
+	titles := lo.Map(batch, func(item importRow, index int) string {
+		return item.Film.Title
+	})
+	existing, err := s.repo.FindFilmsByTitles(ctx, titles)
+	if err != nil {
+		return models.JobProgress{}, err
+	}
+	existingByTitle := lo.KeyBy(existing, func(item entities.Film) string {
+		return item.Title
+	})
+
+	progress := models.JobProgress{}
+	var created, updated []entities.Film
+	var savedLines []int
+	for _, row := range batch {
+		title := row.Film.Title
+		if firstLine, ok := seen[title]; ok {
+			if onConflict == consts.ImportConflictFail {
+				return models.JobProgress{}, newConflictError(row.Line, title)
+			}
+			progress.Failed++
+			progress.RowErrors = append(progress.RowErrors, entitiescustom.JobRowError{
+				Row:    row.Line,
+				Code:   kterrors.FilmTitleAlreadyExistsError,
+				Params: map[string]interface{}{"title": title, "firstRow": firstLine},
+			})
+			continue
+		}
+		seen[title] = row.Line
+
+		film, exists := existingByTitle[title]
+		if !exists {
+			created = append(created, toFilm(entities.Film{UserID: userID}, row.Film))
+			savedLines = append(savedLines, row.Line)
+			continue
+		}
+		switch onConflict {
+		case consts.ImportConflictFail:
+			return models.JobProgress{}, newConflictError(row.Line, title)
+		case consts.ImportConflictSkip:
+			progress.Skipped++
+			progress.RowErrors = append(progress.RowErrors, entitiescustom.JobRowError{
+				Row:    row.Line,
+				Code:   kterrors.FilmTitleAlreadyExistsError,
+				Params: map[string]interface{}{"title": title},
+			})
+		case consts.ImportConflictUpdate:
+			if film.UserID != userID {
+				progress.Failed++
+				progress.RowErrors = append(progress.RowErrors, entitiescustom.JobRowError{
+					Row:    row.Line,
+					Code:   kterrors.UserCannotUpdateFilmError,
+					Params: map[string]interface{}{"title": title},
+				})
+				continue
+			}
+			updated = append(updated, toFilm(film, row.Film))
+			savedLines = append(savedLines, row.Line)
+		}
+	}
+
+	if len(savedLines) == 0 {
+		return progress, nil
+	}
+	err = s.repo.SaveFilmsBatch(ctx, created, updated)
+	if err != nil {
+		// a concurrent writer took one of the titles, the whole batch was rolled back
+		if !customerror.IsUniqueViolation(err) {
+			return models.JobProgress{}, err
+		}
+		for _, line := range savedLines {
+			progress.Failed++
+			progress.RowErrors = append(progress.RowErrors, entitiescustom.JobRowError{
+				Row:  line,
+				Code: kterrors.ImportBatchFailedError,
+			})
+		}
+		return progress, nil
+	}
+	progress.Succeeded += len(savedLines)
+	return progress, nil
+}
+
+func toFilm(film entities.Film, row dto.FilmImportRow) entities.Film {
+	film.Title = row.Title
+	film.Director = row.Director
+	film.ReleaseDate = entitiescustom.ReleaseDate{}
+	if row.ReleaseDate != nil {
+		film.ReleaseDate = *row.ReleaseDate
+	}
+	film.Synopsis = row.Synopsis
+	return film
+}
+
+// newRowError reports a custom error in the row it was found in
+func newRowError(line int, err error) entitiescustom.JobRowError {
+	var customError *customerror.CustomError
+	if errors.As(err, &customError) {
+		return entitiescustom.JobRowError{
+			Row:    line,
+			Code:   customError.Code,
+			Params: customError.Params,
+		}
+	}
+	return newInvalidRowError(line, err)
+}
+
+func newConflictError(line int, title string) error {
+	return customerror.NewI18nErrorWithParams(
+		kterrors.FilmTitleAlreadyExistsError,
+		map[string]interface{}{"title": title, "row": line})
+}
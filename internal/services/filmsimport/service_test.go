@@ -0,0 +1,253 @@
+package filmsimport
+
+import (
+	"KTOnlinePlatform/internal/dto"
+	"KTOnlinePlatform/internal/models"
+	"KTOnlinePlatform/internal/models/consts"
+	"KTOnlinePlatform/internal/models/kterrors"
+	"KTOnlinePlatform/internal/services/filmsimport/mocks"
+	"KTOnlinePlatform/internal/services/jobs"
+	jobsmocks "KTOnlinePlatform/internal/services/jobs/mocks"
+	"KTOnlinePlatform/pkg/customerror"
+	"KTOnlinePlatform/pkg/database/entities"
+	"KTOnlinePlatform/pkg/database/entities/entitiescustom"
+	"KTOnlinePlatform/pkg/logger"
+	"KTOnlinePlatform/pkg/wordfilter"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func TestImportFilms(t *testing.T) {
+	logger.InitializeForTest()
+	releaseDate := entitiescustom.ReleaseDate{Time: time.Date(2000, 9, 5, 0, 0, 0, 0, time.UTC)}
+	// a full batch of new titles, then one already taken in the next batch
+	var batchTitles []string
+	var batchData strings.Builder
+	for i := 1; i <= consts.FilmImportBatchSize; i++ {
+		batchTitles = append(batchTitles, fmt.Sprintf("Film %d", i))
+		batchData.WriteString(fmt.Sprintf(`{"title": "Film %d"}`+"\n", i))
+	}
+	batchData.WriteString(`{"title": "Heat"}` + "\n")
+
+	testCases := []struct {
+		name              string
+		request           dto.FilmImportRequest
+		expectedTotal     int
+		mockBehavior      func(*mocks.Repository, *jobsmocks.Tracker)
+		expectedError     error
+		expectedTaskError error
+	}{
+		{
+			name: "CSV import skips existing titles and reports invalid rows",
+			request: dto.FilmImportRequest{
+				Format: consts.CSVContentType,
+				Data: []byte("title,director,release_date\n" +
+					"Inception,Christopher Nolan,2010-07-16\n" +
+					"Memento,Christopher Nolan,2000-09-05\n" +
+					",Nobody,\n" +
+					"Tenet,Christopher Nolan,someday\n"),
+				UserID: 100,
+			},
+			expectedTotal: 4,
+			mockBehavior: func(mr *mocks.Repository, tracker *jobsmocks.Tracker) {
+				tracker.On("Advance", mock.Anything, mock.MatchedBy(func(progress models.JobProgress) bool {
+					return progress.Failed == 2 && len(progress.RowErrors) == 2 &&
+						progress.RowErrors[0].Row == 5 && progress.RowErrors[1].Row == 4
+				})).Return(nil).Once()
+				mr.On("FindFilmsByTitles", mock.Anything, []string{"Inception", "Memento"}).Return(
+					[]entities.Film{{ID: 1, Title: "Inception", UserID: 200}}, nil)
+				mr.On("SaveFilmsBatch", mock.Anything, []entities.Film{{
+					Title:       "Memento",
+					Director:    "Christopher Nolan",
+					ReleaseDate: releaseDate,
+					UserID:      100,
+				}}, []entities.Film(nil)).Return(nil)
+				tracker.On("Advance", mock.Anything, models.JobProgress{
+					Succeeded: 1,
+					Skipped:   1,
+					RowErrors: []entitiescustom.JobRowError{{
+						Row:    2,
+						Code:   kterrors.FilmTitleAlreadyExistsError,
+						Params: map[string]interface{}{"title": "Inception"},
+					}},
+				}).Return(nil).Once()
+			},
+		},
+		{
+			name: "Rows with banned words are not imported",
+			request: dto.FilmImportRequest{
+				Format: consts.NDJSONContentType,
+				Data: []byte(`{"title": "Heat", "synopsis": "A heist"}` + "\n" +
+					`{"title": "The Sting", "synopsis": "A long scam"}` + "\n"),
+				UserID: 100,
+			},
+			expectedTotal: 2,
+			mockBehavior: func(mr *mocks.Repository, tracker *jobsmocks.Tracker) {
+				tracker.On("Advance", mock.Anything, models.JobProgress{
+					Failed: 1,
+					RowErrors: []entitiescustom.JobRowError{{
+						Row:    2,
+						Code:   kterrors.ContentContainsBannedWordsError,
+						Params: map[string]interface{}{"words": []string{"scam"}},
+					}},
+				}).Return(nil).Once()
+				mr.On("FindFilmsByTitles", mock.Anything, []string{"Heat"}).Return([]entities.Film{}, nil)
+				mr.On("SaveFilmsBatch", mock.Anything, []entities.Film{{Title: "Heat", Synopsis: "A heist", UserID: 100}},
+					[]entities.Film(nil)).Return(nil)
+				tracker.On("Advance", mock.Anything, models.JobProgress{Succeeded: 1}).Return(nil).Once()
+			},
+		},
+		{
+			name: "NDJSON import updates own films only",
+			request: dto.FilmImportRequest{
+				Format:     consts.NDJSONContentType,
+				OnConflict: consts.ImportConflictUpdate,
+				Data: []byte(`{"title": "Memento", "director": "C. Nolan"}` + "\n\n" +
+					`{"title": "Heat", "director": "Michael Mann"}` + "\n"),
+				UserID: 100,
+			},
+			expectedTotal: 2,
+			mockBehavior: func(mr *mocks.Repository, tracker *jobsmocks.Tracker) {
+				mr.On("FindFilmsByTitles", mock.Anything, []string{"Memento", "Heat"}).Return(
+					[]entities.Film{
+						{ID: 1, Title: "Memento", UserID: 100, Version: 4},
+						{ID: 2, Title: "Heat", UserID: 200},
+					}, nil)
+				mr.On("SaveFilmsBatch", mock.Anything, []entities.Film(nil), []entities.Film{{
+					ID:       1,
+					Title:    "Memento",
+					Director: "C. Nolan",
+					UserID:   100,
+					Version:  4,
+				}}).Return(nil)
+				tracker.On("Advance", mock.Anything, models.JobProgress{
+					Succeeded: 1,
+					Failed:    1,
+					RowErrors: []entitiescustom.JobRowError{{
+						Row:    3,
+						Code:   kterrors.UserCannotUpdateFilmError,
+						Params: map[string]interface{}{"title": "Heat"},
+					}},
+				}).Return(nil).Once()
+			},
+		},
+		{
+			name: "Fail policy stops on a title repeated in the file",
+			request: dto.FilmImportRequest{
+				Format:     consts.NDJSONContentType,
+				OnConflict: consts.ImportConflictFail,
+				Data:       []byte(`{"title": "Memento"}` + "\n" + `{"title": "Memento"}` + "\n"),
+				UserID:     100,
+			},
+			expectedTotal: 2,
+			mockBehavior:  func(mr *mocks.Repository, tracker *jobsmocks.Tracker) {},
+			expectedTaskError: customerror.NewI18nErrorWithParams(kterrors.FilmTitleAlreadyExistsError,
+				map[string]interface{}{"title": "Memento", "row": 2}),
+		},
+		{
+			name: "Fail policy saves nothing when a title of a later batch exists",
+			request: dto.FilmImportRequest{
+				Format:     consts.NDJSONContentType,
+				OnConflict: consts.ImportConflictFail,
+				Data:       []byte(batchData.String()),
+				UserID:     100,
+			},
+			expectedTotal: consts.FilmImportBatchSize + 1,
+			mockBehavior: func(mr *mocks.Repository, tracker *jobsmocks.Tracker) {
+				mr.On("FindFilmsByTitles", mock.Anything, batchTitles).Return([]entities.Film{}, nil)
+				mr.On("FindFilmsByTitles", mock.Anything, []string{"Heat"}).Return(
+					[]entities.Film{{ID: 7, Title: "Heat", UserID: 100}}, nil)
+			},
+			expectedTaskError: customerror.NewI18nErrorWithParams(kterrors.FilmTitleAlreadyExistsError,
+				map[string]interface{}{"title": "Heat", "row": consts.FilmImportBatchSize + 1}),
+		},
+		{
+			name: "Batch rolled back by a concurrent insert",
+			request: dto.FilmImportRequest{
+				Format: consts.NDJSONContentType,
+				Data:   []byte(`{"title": "Memento"}` + "\n"),
+				UserID: 100,
+			},
+			expectedTotal: 1,
+			mockBehavior: func(mr *mocks.Repository, tracker *jobsmocks.Tracker) {
+				mr.On("FindFilmsByTitles", mock.Anything, []string{"Memento"}).Return([]entities.Film{}, nil)
+				mr.On("SaveFilmsBatch", mock.Anything, mock.Anything, mock.Anything).Return(gorm.ErrDuplicatedKey)
+				tracker.On("Advance", mock.Anything, models.JobProgress{
+					Failed:    1,
+					RowErrors: []entitiescustom.JobRowError{{Row: 1, Code: kterrors.ImportBatchFailedError}},
+				}).Return(nil).Once()
+			},
+		},
+		{
+			name: "CSV without a title column",
+			request: dto.FilmImportRequest{
+				Format: consts.CSVContentType,
+				Data:   []byte("name,director\nMemento,Christopher Nolan\n"),
+			},
+			expectedError: customerror.NewI18nErrorWithParams(kterrors.InvalidImportFileError,
+				map[string]interface{}{"reason": "missing title column"}),
+		},
+		{
+			name: "Unsupported format",
+			request: dto.FilmImportRequest{
+				Format: "application/xml",
+			},
+			expectedError: customerror.NewI18nErrorWithParams(kterrors.UnsupportedImportFormatError,
+				map[string]interface{}{"format": "application/xml"}),
+		},
+		{
+			name: "Invalid conflict policy",
+			request: dto.FilmImportRequest{
+				Format:     consts.CSVContentType,
+				OnConflict: "merge",
+			},
+			expectedError: customerror.NewI18nErrorWithParams(kterrors.InvalidConflictPolicyError,
+				map[string]interface{}{"onConflict": "merge"}),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := mocks.NewRepository(t)
+			mockRunner := mocks.NewJobRunner(t)
+			mockTracker := jobsmocks.NewTracker(t)
+
+			var task jobs.Task
+			if tc.expectedError == nil {
+				mockRunner.On("StartJob", mock.Anything, consts.JobTypeFilmImport, tc.request.UserID, tc.expectedTotal, mock.Anything).
+					Run(func(args mock.Arguments) {
+						task = args.Get(4).(jobs.Task)
+					}).
+					Return(dto.Job{ID: 1, Status: consts.JobStatusPending, Total: tc.expectedTotal}, nil)
+				tc.mockBehavior(mockRepo, mockTracker)
+			}
+
+			service := NewService(mockRepo, mockRunner, wordfilter.NewFilter([]string{"scam"}))
+
+			job, err := service.ImportFilms(context.Background(), tc.request)
+
+			if tc.expectedError != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tc.expectedError.Error(), err.Error())
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, 1, job.ID)
+
+			err = task(context.Background(), mockTracker)
+			if tc.expectedTaskError != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tc.expectedTaskError.Error(), err.Error())
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
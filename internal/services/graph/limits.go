@@ -0,0 +1,169 @@
+package graph
+
+import (
+	"KTOnlinePlatform/internal/models/consts"
+	"KTOnlinePlatform/internal/models/kterrors"
+	"KTOnlinePlatform/pkg/customerror"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+const (
+	pageSizeArg         = "pageSize"
+	introspectionPrefix = "__"
+	// sizes and costs saturate there so a huge pageSize cannot overflow the cost
+	maxMeasure = math.MaxInt32
+)
+
+// checkLimits rejects the operations nested too deep or costing too much before they run, the
+// document is valid so its fragments do not loop
+func (s *Service) checkLimits(document *ast.Document, operationName string, variables map[string]interface{}) error {
+	operation := findOperation(document, operationName)
+	if operation == nil {
+		// graphql-go reports it
+		return nil
+	}
+	m := measurer{
+		schema:    s.schema,
+		fragments: map[string]*ast.FragmentDefinition{},
+		variables: variables,
+	}
+	for _, definition := range document.Definitions {
+		if fragment, ok := definition.(*ast.FragmentDefinition); ok {
+			m.fragments[fragment.Name.Value] = fragment
+		}
+	}
+	depth, cost := m.measure(operation.SelectionSet, s.schema.QueryType(), 0)
+	if depth > s.maxDepth {
+		return customerror.NewI18nErrorWithParams(kterrors.QueryTooDeepError,
+			map[string]interface{}{"depth": depth, "max": s.maxDepth})
+	}
+	if cost > s.maxComplexity {
+		return customerror.NewI18nErrorWithParams(kterrors.QueryTooComplexError,
+			map[string]interface{}{"cost": cost, "max": s.maxComplexity})
+	}
+	return nil
+}
+
+func findOperation(document *ast.Document, operationName string) *ast.OperationDefinition {
+	for _, definition := range document.Definitions {
+		operation, ok := definition.(*ast.OperationDefinition)
+		if !ok {
+			continue
+		}
+		if operationName == "" || (operation.Name != nil && operation.Name.Value == operationName) {
+			return operation
+		}
+	}
+	return nil
+}
+
+type measurer struct {
+	schema    graphql.Schema
+	fragments map[string]*ast.FragmentDefinition
+	variables map[string]interface{}
+}
+
+// measure returns the depth and the cost of a selection set. pageSize is the size of the page the
+// selection belongs to, 0 outside a page. The introspection fields are free
+func (m measurer) measure(selectionSet *ast.SelectionSet, parent *graphql.Object, pageSize int) (depth int, cost int) {
+	if selectionSet == nil {
+		return 0, 0
+	}
+	for _, selection := range selectionSet.Selections {
+		switch selection := selection.(type) {
+		case *ast.Field:
+			name := selection.Name.Value
+			if strings.HasPrefix(name, introspectionPrefix) {
+				continue
+			}
+			definition, ok := parent.Fields()[name]
+			if !ok {
+				continue
+			}
+			childPageSize := pageSize
+			if size, ok := m.pageSize(selection); ok {
+				childPageSize = size
+			}
+			multiplier := 1
+			fieldType := definition.Type
+			if nonNull, ok := fieldType.(*graphql.NonNull); ok {
+				fieldType = nonNull.OfType
+			}
+			if _, ok := fieldType.(*graphql.List); ok {
+				multiplier = consts.GraphQLListSize
+				if childPageSize > 0 {
+					multiplier = childPageSize
+				}
+				childPageSize = 0
+			}
+			childDepth, childCost := 0, 0
+			if object, ok := graphql.GetNamed(definition.Type).(*graphql.Object); ok {
+				childDepth, childCost = m.measure(selection.SelectionSet, object, childPageSize)
+			}
+			depth = max(depth, childDepth+1)
+			cost = min(cost+multiplier*(1+childCost), maxMeasure)
+		case *ast.InlineFragment:
+			fragmentDepth, fragmentCost := m.measure(selection.SelectionSet, m.typeOf(selection.TypeCondition, parent), pageSize)
+			depth = max(depth, fragmentDepth)
+			cost = min(cost+fragmentCost, maxMeasure)
+		case *ast.FragmentSpread:
+			fragment, ok := m.fragments[selection.Name.Value]
+			if !ok {
+				continue
+			}
+			fragmentDepth, fragmentCost := m.measure(fragment.SelectionSet, m.typeOf(fragment.TypeCondition, parent), pageSize)
+			depth = max(depth, fragmentDepth)
+			cost = min(cost+fragmentCost, maxMeasure)
+		}
+	}
+	return depth, cost
+}
+
+// pageSize returns the pageSize argument of a field, from the query or its variables. A size the
+// resolver rejects counts as the largest page, so a negative one cannot lower the cost
+func (m measurer) pageSize(field *ast.Field) (int, bool) {
+	for _, argument := range field.Arguments {
+		if argument.Name.Value != pageSizeArg {
+			continue
+		}
+		switch value := argument.Value.(type) {
+		case *ast.IntValue:
+			size, err := strconv.Atoi(value.Value)
+			if err != nil {
+				return consts.GraphQLMaxPageSize, true
+			}
+			return pageSizeCost(size), true
+		case *ast.Variable:
+			// JSON numbers are decoded as float64
+			switch size := m.variables[value.Name.Value].(type) {
+			case float64:
+				return pageSizeCost(int(max(min(size, maxMeasure), -1))), true
+			case int:
+				return pageSizeCost(size), true
+			}
+		}
+	}
+	return 0, false
+}
+
+func pageSizeCost(size int) int {
+	if size < 1 || size > consts.GraphQLMaxPageSize {
+		return consts.GraphQLMaxPageSize
+	}
+	return size
+}
+
+func (m measurer) typeOf(condition *ast.Named, parent *graphql.Object) *graphql.Object {
+	if condition == nil {
+		return parent
+	}
+	if object, ok := m.schema.Type(condition.Name.Value).(*graphql.Object); ok {
+		return object
+	}
+	return parent
+}
@@ -0,0 +1,111 @@
+package graph
+
+import (
+	"KTOnlinePlatform/internal/dto"
+	"context"
+	"sync"
+)
+
+// loader batches the keys the resolvers of a level of the query ask for into one fetch, and keeps
+// the values for the rest of the query. The keys left out by the fetch have no value
+type loader[K comparable, V any] struct {
+	fetch func(keys []K) (map[K]V, error)
+
+	mu      sync.Mutex
+	pending []K
+	queued  map[K]bool
+	fetched map[K]bool
+	values  map[K]V
+	errs    map[K]error
+}
+
+func newLoader[K comparable, V any](fetch func(keys []K) (map[K]V, error)) *loader[K, V] {
+	return &loader[K, V]{
+		fetch:   fetch,
+		queued:  map[K]bool{},
+		fetched: map[K]bool{},
+		values:  map[K]V{},
+		errs:    map[K]error{},
+	}
+}
+
+// load queues the key and returns the thunk that fetches it, graphql-go calls the thunks once every
+// field of the level is resolved so the first one fetches the keys of all of them
+func (l *loader[K, V]) load(key K) func() (V, bool, error) {
+	l.mu.Lock()
+	if !l.queued[key] {
+		l.queued[key] = true
+		l.pending = append(l.pending, key)
+	}
+	l.mu.Unlock()
+
+	return func() (V, bool, error) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		if !l.fetched[key] {
+			l.dispatch()
+		}
+		if err := l.errs[key]; err != nil {
+			var zero V
+			return zero, false, err
+		}
+		value, found := l.values[key]
+		return value, found, nil
+	}
+}
+
+func (l *loader[K, V]) dispatch() {
+	keys := l.pending
+	l.pending = nil
+	values, err := l.fetch(keys)
+	for _, key := range keys {
+		l.fetched[key] = true
+		if err != nil {
+			l.errs[key] = err
+			continue
+		}
+		if value, found := values[key]; found {
+			l.values[key] = value
+		}
+	}
+}
+
+// request is the state of one query, its loaders are dropped with it so nothing is cached
+// between two queries
+type request struct {
+	userID  int
+	locales []string
+	films   *loader[int, dto.FilmDetail]
+	entries *loader[int, []dto.UserListEntry]
+}
+
+type requestKey struct{}
+
+func (s *Service) newRequest(ctx context.Context, userID int, locales []string) *request {
+	return &request{
+		userID:  userID,
+		locales: locales,
+		films: newLoader(func(filmIDs []int) (map[int]dto.FilmDetail, error) {
+			result, err := s.films.GetFilmDetails(ctx, filmIDs, locales)
+			if err != nil {
+				return nil, fieldError(err)
+			}
+			return result, nil
+		}),
+		entries: newLoader(func(listIDs []int) (map[int][]dto.UserListEntry, error) {
+			result, err := s.lists.GetUserListsEntries(ctx, userID, listIDs)
+			if err != nil {
+				return nil, fieldError(err)
+			}
+			return result, nil
+		}),
+	}
+}
+
+func withRequest(ctx context.Context, r *request) context.Context {
+	return context.WithValue(ctx, requestKey{}, r)
+}
+
+func requestFrom(ctx context.Context) *request {
+	return ctx.Value(requestKey{}).(*request)
+}
@@ -0,0 +1,87 @@
+// Code generated by mockery v2.52.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	dto "KTOnlinePlatform/internal/dto"
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// FilmService is an autogenerated mock type for the FilmService type
+type FilmService struct {
+	mock.Mock
+}
+
+// GetFilmDetails provides a mock function with given fields: ctx, IDs, locales
+func (_m *FilmService) GetFilmDetails(ctx context.Context, IDs []int, locales []string) (map[int]dto.FilmDetail, error) {
+	ret := _m.Called(ctx, IDs, locales)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFilmDetails")
+	}
+
+	var r0 map[int]dto.FilmDetail
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []int, []string) (map[int]dto.FilmDetail, error)); ok {
+		return rf(ctx, IDs, locales)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []int, []string) map[int]dto.FilmDetail); ok {
+		r0 = rf(ctx, IDs, locales)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[int]dto.FilmDetail)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []int, []string) error); ok {
+		r1 = rf(ctx, IDs, locales)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetFilmPaginated provides a mock function with given fields: ctx, request
+func (_m *FilmService) GetFilmPaginated(ctx context.Context, request dto.FilmSearchRequest) (dto.FilmsPaginated, error) {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFilmPaginated")
+	}
+
+	var r0 dto.FilmsPaginated
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, dto.FilmSearchRequest) (dto.FilmsPaginated, error)); ok {
+		return rf(ctx, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, dto.FilmSearchRequest) dto.FilmsPaginated); ok {
+		r0 = rf(ctx, request)
+	} else {
+		r0 = ret.Get(0).(dto.FilmsPaginated)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, dto.FilmSearchRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewFilmService creates a new instance of FilmService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewFilmService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *FilmService {
+	mock := &FilmService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,89 @@
+// Code generated by mockery v2.52.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	dto "KTOnlinePlatform/internal/dto"
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ListService is an autogenerated mock type for the ListService type
+type ListService struct {
+	mock.Mock
+}
+
+// GetUserLists provides a mock function with given fields: ctx, userID
+func (_m *ListService) GetUserLists(ctx context.Context, userID int) ([]dto.UserList, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserLists")
+	}
+
+	var r0 []dto.UserList
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]dto.UserList, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []dto.UserList); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]dto.UserList)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUserListsEntries provides a mock function with given fields: ctx, userID, listIDs
+func (_m *ListService) GetUserListsEntries(ctx context.Context, userID int, listIDs []int) (map[int][]dto.UserListEntry, error) {
+	ret := _m.Called(ctx, userID, listIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserListsEntries")
+	}
+
+	var r0 map[int][]dto.UserListEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, []int) (map[int][]dto.UserListEntry, error)); ok {
+		return rf(ctx, userID, listIDs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, []int) map[int][]dto.UserListEntry); ok {
+		r0 = rf(ctx, userID, listIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[int][]dto.UserListEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, []int) error); ok {
+		r1 = rf(ctx, userID, listIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewListService creates a new instance of ListService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewListService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ListService {
+	mock := &ListService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
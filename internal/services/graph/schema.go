@@ -0,0 +1,321 @@
+package graph
+
+import (
+	"KTOnlinePlatform/internal/dto"
+	"KTOnlinePlatform/internal/models/consts"
+	"KTOnlinePlatform/internal/models/kterrors"
+	"KTOnlinePlatform/pkg/customerror"
+	"KTOnlinePlatform/pkg/database/entities/entitiescustom"
+	"net/http"
+
+	"github.com/graphql-go/graphql"
+	"github.com/samber/lo"
+)
+
+// newSchema describes the films, lists and user of the REST API. A film is resolved from its ID,
+// its details are loaded with the other films of the same level of the query
+func (s *Service) newSchema() (graphql.Schema, error) {
+	poster := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Poster",
+		Fields: graphql.Fields{
+			"small":  &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"medium": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"large":  &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+		},
+	})
+	credit := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Credit",
+		Fields: graphql.Fields{
+			"id":            &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"personId":      &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"name":          &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"role":          &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"characterName": &graphql.Field{Type: graphql.String, Resolve: emptyAsNull},
+		},
+	})
+	subtitleTrack := graphql.NewObject(graphql.ObjectConfig{
+		Name: "SubtitleTrack",
+		Fields: graphql.Fields{
+			"language": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"label":    &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"url":      &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+		},
+	})
+	film := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Film",
+		Fields: graphql.Fields{
+			"id": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.Int),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source, nil
+				},
+			},
+			"title": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.String),
+				Resolve: filmField(func(film dto.FilmDetail) interface{} {
+					return film.Title
+				}),
+			},
+			"director": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.String),
+				Resolve: filmField(func(film dto.FilmDetail) interface{} {
+					return film.Director
+				}),
+			},
+			"releaseDate": &graphql.Field{
+				Type:        graphql.String,
+				Description: "YYYY-MM-DD",
+				Resolve: filmField(func(film dto.FilmDetail) interface{} {
+					if film.ReleaseDate.IsZero() {
+						return nil
+					}
+					return film.ReleaseDate.Format(entitiescustom.ReleaseDateFormat)
+				}),
+			},
+			"synopsis": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.String),
+				Resolve: filmField(func(film dto.FilmDetail) interface{} {
+					return film.Synopsis
+				}),
+			},
+			"locale": &graphql.Field{
+				Type:        graphql.String,
+				Description: "The locale of the translated title and synopsis, null for the original text",
+				Resolve: filmField(func(film dto.FilmDetail) interface{} {
+					return lo.EmptyableToPtr(film.Locale)
+				}),
+			},
+			"version": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.Int),
+				Resolve: filmField(func(film dto.FilmDetail) interface{} {
+					return film.Version
+				}),
+			},
+			"averageRating": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.Float),
+				Resolve: filmField(func(film dto.FilmDetail) interface{} {
+					return film.AverageRating
+				}),
+			},
+			"voteCount": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.Int),
+				Resolve: filmField(func(film dto.FilmDetail) interface{} {
+					return film.VoteCount
+				}),
+			},
+			"poster": &graphql.Field{
+				Type: poster,
+				Resolve: filmField(func(film dto.FilmDetail) interface{} {
+					return film.Poster
+				}),
+			},
+			"credits": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(credit))),
+				Resolve: filmField(func(film dto.FilmDetail) interface{} {
+					return film.Credits
+				}),
+			},
+			"subtitles": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(subtitleTrack))),
+				Resolve: filmField(func(film dto.FilmDetail) interface{} {
+					return film.Subtitles
+				}),
+			},
+		},
+	})
+	filmPage := graphql.NewObject(graphql.ObjectConfig{
+		Name: "FilmPage",
+		Fields: graphql.Fields{
+			"films": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(film))),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return lo.Map(p.Source.(dto.FilmsPaginated).Films, func(item dto.Film, index int) int {
+						return item.ID
+					}), nil
+				},
+			},
+			"count":    &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"page":     &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"pageSize": &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"next":     &graphql.Field{Type: graphql.String, Description: "The cursor of the next page", Resolve: emptyAsNull},
+			"prev":     &graphql.Field{Type: graphql.String, Description: "The cursor of the previous page", Resolve: emptyAsNull},
+		},
+	})
+	userListEntry := graphql.NewObject(graphql.ObjectConfig{
+		Name: "UserListEntry",
+		Fields: graphql.Fields{
+			"position": &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"addedAt":  &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+			"film": &graphql.Field{
+				Type:        film,
+				Description: "null once the film is hidden",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return loadFilm(p, p.Source.(dto.UserListEntry).FilmID, nil), nil
+				},
+			},
+		},
+	})
+	userList := graphql.NewObject(graphql.ObjectConfig{
+		Name: "UserList",
+		Fields: graphql.Fields{
+			"id":          &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"name":        &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"description": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"public":      &graphql.Field{Type: graphql.NewNonNull(graphql.Boolean)},
+			"shareUrl":    &graphql.Field{Type: graphql.String, Resolve: emptyAsNull},
+			"entryCount":  &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"createdAt":   &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+			"updatedAt":   &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+			"entries": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(userListEntry))),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					thunk := requestFrom(p.Context).entries.load(p.Source.(dto.UserList).ID)
+					return func() (interface{}, error) {
+						entries, _, err := thunk()
+						if err != nil {
+							return nil, err
+						}
+						// a list without entries is left out of the fetch
+						return lo.Ternary(entries == nil, []dto.UserListEntry{}, entries), nil
+					}, nil
+				},
+			},
+		},
+	})
+	user := graphql.NewObject(graphql.ObjectConfig{
+		Name: "User",
+		Fields: graphql.Fields{
+			"id":       &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"username": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"role":     &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"lists": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(userList))),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					result, err := s.lists.GetUserLists(p.Context, p.Source.(dto.User).ID)
+					if err != nil {
+						return nil, fieldError(err)
+					}
+					return result, nil
+				},
+			},
+		},
+	})
+
+	query := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"me": &graphql.Field{
+				Type: graphql.NewNonNull(user),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					result, err := s.users.GetUser(p.Context, requestFrom(p.Context).userID)
+					if err != nil {
+						return nil, fieldError(err)
+					}
+					return result, nil
+				},
+			},
+			"film": &graphql.Field{
+				Type: film,
+				Args: graphql.FieldConfigArgument{
+					"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.Int)},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					notFound := customerror.NewCustomErrorWithHttpCode(kterrors.FilmNotFoundError, http.StatusNotFound)
+					return loadFilm(p, p.Args["id"].(int), notFound), nil
+				},
+			},
+			"films": &graphql.Field{
+				Type: graphql.NewNonNull(filmPage),
+				Args: graphql.FieldConfigArgument{
+					"title":    &graphql.ArgumentConfig{Type: graphql.String},
+					"director": &graphql.ArgumentConfig{Type: graphql.String},
+					"year":     &graphql.ArgumentConfig{Type: graphql.Int},
+					"page":     &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: consts.BasicPaginationDefaultPageNumber},
+					"pageSize": &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: consts.PaginationDefaultPageSize},
+					"sort":     &graphql.ArgumentConfig{Type: graphql.String, DefaultValue: consts.FilmSortTitle},
+					"cursor":   &graphql.ArgumentConfig{Type: graphql.String},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					page, pageSize := intArg(p, "page"), intArg(p, pageSizeArg)
+					if page < 1 || pageSize < 1 || pageSize > consts.GraphQLMaxPageSize {
+						return nil, customerror.NewI18nErrorWithParams(kterrors.InvalidPaginationError,
+							map[string]interface{}{"page": page, "pageSize": pageSize, "max": consts.GraphQLMaxPageSize})
+					}
+					request := dto.FilmSearchRequest{
+						FilmFilter: dto.FilmFilter{
+							Title:    stringArg(p, "title"),
+							Director: stringArg(p, "director"),
+							Year:     intArg(p, "year"),
+						},
+						Page:     page,
+						PageSize: pageSize,
+						Cursor:   stringArg(p, "cursor"),
+						Sort:     stringArg(p, "sort"),
+						Locales:  requestFrom(p.Context).locales,
+					}
+					result, err := s.films.GetFilmPaginated(p.Context, request)
+					if err != nil {
+						return nil, fieldError(err)
+					}
+					return result, nil
+				},
+			},
+		},
+	})
+	return graphql.NewSchema(graphql.SchemaConfig{Query: query})
+}
+
+// filmField resolves a field of a film from its details
+func filmField(field func(film dto.FilmDetail) interface{}) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		thunk := requestFrom(p.Context).films.load(p.Source.(int))
+		return func() (interface{}, error) {
+			film, found, err := thunk()
+			if err != nil {
+				return nil, err
+			}
+			if !found {
+				return nil, customerror.NewCustomErrorWithHttpCode(kterrors.FilmNotFoundError, http.StatusNotFound)
+			}
+			return field(film), nil
+		}, nil
+	}
+}
+
+// loadFilm resolves to the ID of a visible film, a film hidden or not found is notFound, or null
+// when notFound is nil
+func loadFilm(p graphql.ResolveParams, filmID int, notFound error) func() (interface{}, error) {
+	thunk := requestFrom(p.Context).films.load(filmID)
+	return func() (interface{}, error) {
+		_, found, err := thunk()
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			return nil, notFound
+		}
+		return filmID, nil
+	}
+}
+
+// emptyAsNull resolves the fields the REST API omits when empty
+func emptyAsNull(p graphql.ResolveParams) (interface{}, error) {
+	value, err := graphql.DefaultResolveFn(p)
+	if err != nil {
+		return nil, err
+	}
+	if text, ok := value.(string); ok {
+		return lo.EmptyableToPtr(text), nil
+	}
+	return value, nil
+}
+
+func stringArg(p graphql.ResolveParams, name string) string {
+	value, _ := p.Args[name].(string)
+	return value
+}
+
+func intArg(p graphql.ResolveParams, name string) int {
+	value, _ := p.Args[name].(int)
+	return value
+}
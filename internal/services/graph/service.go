@@ -0,0 +1,147 @@
+package graph
+
+import (
+	"KTOnlinePlatform/internal/dto"
+	"KTOnlinePlatform/internal/models/consts"
+	"KTOnlinePlatform/pkg/customerror"
+	"KTOnlinePlatform/pkg/logger"
+	"context"
+	"errors"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/location"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/samber/lo"
+)
+
+const (
+	extensionCode   = "code"
+	extensionParams = "params"
+	extensionErrors = "errors"
+)
+
+// errInternal replaces the errors that are not custom errors, like a problem their detail is never sent
+var errInternal = errors.New("internal error")
+
+type FilmService interface {
+	GetFilmPaginated(ctx context.Context, request dto.FilmSearchRequest) (dto.FilmsPaginated, error)
+	GetFilmDetails(ctx context.Context, IDs []int, locales []string) (map[int]dto.FilmDetail, error)
+}
+
+type ListService interface {
+	GetUserLists(ctx context.Context, userID int) ([]dto.UserList, error)
+	GetUserListsEntries(ctx context.Context, userID int, listIDs []int) (map[int][]dto.UserListEntry, error)
+}
+
+type UserService interface {
+	GetUser(ctx context.Context, userID int) (dto.User, error)
+}
+
+type Service struct {
+	films      FilmService
+	lists      ListService
+	users      UserService
+	translator customerror.Translator
+	schema     graphql.Schema
+	// the schema has no cycle yet, maxDepth guards the types that will link back to their parent
+	maxDepth      int
+	maxComplexity int
+}
+
+func NewService(films FilmService, lists ListService, users UserService, translator customerror.Translator) *Service {
+	s := &Service{
+		films:         films,
+		lists:         lists,
+		users:         users,
+		translator:    translator,
+		maxDepth:      consts.GraphQLMaxDepth,
+		maxComplexity: consts.GraphQLMaxComplexity,
+	}
+	schema, err := s.newSchema()
+	if err != nil {
+		panic(err)
+	}
+	s.schema = schema
+	return s
+}
+
+// Execute runs a query for the user. The query is parsed, validated and measured against the depth
+// and complexity limits before any resolver runs. The errors are rendered in the locales of the request
+func (s *Service) Execute(ctx context.Context, request dto.GraphQLRequest) dto.GraphQLResponse {
+	document, err := parser.Parse(parser.ParseParams{Source: request.Query})
+	if err != nil {
+		return s.toResponse(request.Locales, &graphql.Result{Errors: gqlerrors.FormatErrors(err)})
+	}
+	validation := graphql.ValidateDocument(&s.schema, document, nil)
+	if !validation.IsValid {
+		return s.toResponse(request.Locales, &graphql.Result{Errors: validation.Errors})
+	}
+	err = s.checkLimits(document, request.OperationName, request.Variables)
+	if err != nil {
+		return s.toResponse(request.Locales, &graphql.Result{Errors: gqlerrors.FormatErrors(err)})
+	}
+	result := graphql.Execute(graphql.ExecuteParams{
+		Schema:        s.schema,
+		AST:           document,
+		OperationName: request.OperationName,
+		Args:          request.Variables,
+		Context:       withRequest(ctx, s.newRequest(ctx, request.UserID, request.Locales)),
+	})
+	return s.toResponse(request.Locales, result)
+}
+
+func (s *Service) toResponse(locales []string, result *graphql.Result) dto.GraphQLResponse {
+	response := dto.GraphQLResponse{Data: result.Data}
+	for _, formatted := range result.Errors {
+		graphError := dto.GraphQLError{
+			Message: formatted.Message,
+			Path:    formatted.Path,
+		}
+		if len(formatted.Locations) > 0 {
+			graphError.Locations = lo.Map(formatted.Locations, func(item location.SourceLocation, index int) dto.GraphQLLocation {
+				return dto.GraphQLLocation{Line: item.Line, Column: item.Column}
+			})
+		}
+		var customError *customerror.CustomError
+		if errors.As(originalError(formatted), &customError) {
+			graphError.Message, _ = s.translator.Message(locales, customError.Code, customError.Params)
+			graphError.Extensions = map[string]interface{}{extensionCode: customError.Code}
+			if len(customError.Params) > 0 {
+				graphError.Extensions[extensionParams] = customError.Params
+			}
+			if len(customError.Fields) > 0 {
+				graphError.Extensions[extensionErrors] = customError.Fields
+			}
+		}
+		response.Errors = append(response.Errors, graphError)
+	}
+	return response
+}
+
+// originalError digs the error of a resolver out of the errors graphql-go wraps it in, they do not unwrap
+func originalError(err error) error {
+	for {
+		var next error
+		switch wrapper := err.(type) {
+		case gqlerrors.FormattedError:
+			next = wrapper.OriginalError()
+		case *gqlerrors.Error:
+			next = wrapper.OriginalError
+		}
+		if next == nil {
+			return err
+		}
+		err = next
+	}
+}
+
+// fieldError keeps the custom errors for the client, the others are logged and sent as errInternal
+func fieldError(err error) error {
+	var customError *customerror.CustomError
+	if errors.As(err, &customError) {
+		return err
+	}
+	logger.Error().Err(err).Msg("graphql resolver failed")
+	return errInternal
+}
@@ -0,0 +1,304 @@
+package graph
+
+import (
+	"KTOnlinePlatform/internal/dto"
+	"KTOnlinePlatform/internal/models/consts"
+	"KTOnlinePlatform/internal/models/kterrors"
+	"KTOnlinePlatform/internal/services/graph/mocks"
+	"KTOnlinePlatform/pkg/customerror"
+	"KTOnlinePlatform/pkg/logger"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const testUserID = 100
+
+type testMocks struct {
+	films *mocks.FilmService
+	lists *mocks.ListService
+	users *mocks.UserService
+}
+
+func newTestService(t *testing.T) (*Service, testMocks) {
+	m := testMocks{
+		films: mocks.NewFilmService(t),
+		lists: mocks.NewListService(t),
+		users: mocks.NewUserService(t),
+	}
+	translator, err := kterrors.NewMessages()
+	require.NoError(t, err)
+	return NewService(m.films, m.lists, m.users, translator), m
+}
+
+func TestExecute(t *testing.T) {
+	logger.InitializeForTest()
+	addedAt := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name           string
+		request        dto.GraphQLRequest
+		mockBehavior   func(testMocks)
+		expectedData   string
+		expectedErrors []dto.GraphQLError
+	}{
+		{
+			name: "Films of the lists are loaded in one batch",
+			request: dto.GraphQLRequest{Query: `{
+				me { username lists { name entries { position film { id title credits { name } } } } }
+			}`},
+			mockBehavior: func(m testMocks) {
+				m.users.On("GetUser", mock.Anything, testUserID).Return(dto.User{ID: testUserID, Username: "validuser"}, nil).Once()
+				m.lists.On("GetUserLists", mock.Anything, testUserID).Return([]dto.UserList{
+					{ID: 10, Name: "Heists"},
+					{ID: 20, Name: "Empty"},
+					{ID: 30, Name: "Rewatch"},
+				}, nil).Once()
+				m.lists.On("GetUserListsEntries", mock.Anything, testUserID, []int{10, 20, 30}).Return(map[int][]dto.UserListEntry{
+					10: {{FilmID: 1, Position: 1, AddedAt: addedAt}, {FilmID: 2, Position: 2, AddedAt: addedAt}},
+					30: {{FilmID: 1, Position: 1, AddedAt: addedAt}, {FilmID: 3, Position: 2, AddedAt: addedAt}},
+				}, nil).Once()
+				// film 3 was hidden since it was added
+				m.films.On("GetFilmDetails", mock.Anything, []int{1, 2, 3}, []string(nil)).Return(map[int]dto.FilmDetail{
+					1: {ID: 1, Title: "Heat", Credits: []dto.FilmCredit{{Name: "Michael Mann", Role: consts.CreditRoleDirector}}},
+					2: {ID: 2, Title: "Thief", Credits: []dto.FilmCredit{}},
+				}, nil).Once()
+			},
+			expectedData: `{"me": {"username": "validuser", "lists": [
+				{"name": "Heists", "entries": [
+					{"position": 1, "film": {"id": 1, "title": "Heat", "credits": [{"name": "Michael Mann"}]}},
+					{"position": 2, "film": {"id": 2, "title": "Thief", "credits": []}}
+				]},
+				{"name": "Empty", "entries": []},
+				{"name": "Rewatch", "entries": [
+					{"position": 1, "film": {"id": 1, "title": "Heat", "credits": [{"name": "Michael Mann"}]}},
+					{"position": 2, "film": null}
+				]}
+			]}}`,
+		},
+		{
+			name: "Films of a page are loaded in one batch",
+			request: dto.GraphQLRequest{
+				Query:     `query Page($size: Int) { films(title: "he", pageSize: $size) { count next films { title director } } }`,
+				Variables: map[string]interface{}{"size": float64(2)},
+				Locales:   []string{"fr"},
+			},
+			mockBehavior: func(m testMocks) {
+				m.films.On("GetFilmPaginated", mock.Anything, dto.FilmSearchRequest{
+					FilmFilter: dto.FilmFilter{Title: "he"},
+					Page:       consts.BasicPaginationDefaultPageNumber,
+					PageSize:   2,
+					Sort:       consts.FilmSortTitle,
+					Locales:    []string{"fr"},
+				}).Return(dto.FilmsPaginated{Films: []dto.Film{{ID: 1}, {ID: 4}}, Count: 2}, nil).Once()
+				m.films.On("GetFilmDetails", mock.Anything, []int{1, 4}, []string{"fr"}).Return(map[int]dto.FilmDetail{
+					1: {ID: 1, Title: "Heat", Director: "Michael Mann"},
+					4: {ID: 4, Title: "Le Cercle rouge", Director: "Jean-Pierre Melville"},
+				}, nil).Once()
+			},
+			expectedData: `{"films": {"count": 2, "next": null, "films": [
+				{"title": "Heat", "director": "Michael Mann"},
+				{"title": "Le Cercle rouge", "director": "Jean-Pierre Melville"}
+			]}}`,
+		},
+		{
+			name:    "Film not found",
+			request: dto.GraphQLRequest{Query: `{ film(id: 9) { title } }`},
+			mockBehavior: func(m testMocks) {
+				m.films.On("GetFilmDetails", mock.Anything, []int{9}, []string(nil)).Return(map[int]dto.FilmDetail{}, nil).Once()
+			},
+			expectedData: `{"film": null}`,
+			expectedErrors: []dto.GraphQLError{{
+				Message:    "Film not found",
+				Locations:  []dto.GraphQLLocation{{Line: 1, Column: 3}},
+				Path:       []interface{}{"film"},
+				Extensions: map[string]interface{}{"code": kterrors.FilmNotFoundError},
+			}},
+		},
+		{
+			name:    "Internal errors are not sent",
+			request: dto.GraphQLRequest{Query: `{ me { id } }`},
+			mockBehavior: func(m testMocks) {
+				m.users.On("GetUser", mock.Anything, testUserID).Return(dto.User{}, errors.New("connection refused"))
+			},
+			expectedErrors: []dto.GraphQLError{{
+				Message:   "internal error",
+				Locations: []dto.GraphQLLocation{{Line: 1, Column: 3}},
+				Path:      []interface{}{"me"},
+			}},
+		},
+		{
+			name:    "Errors in the language of the user",
+			request: dto.GraphQLRequest{Query: `{ me { id } }`, Locales: []string{"fr"}},
+			mockBehavior: func(m testMocks) {
+				m.users.On("GetUser", mock.Anything, testUserID).Return(dto.User{},
+					customerror.NewCustomErrorWithHttpCode(kterrors.FilmNotFoundError, http.StatusNotFound))
+			},
+			expectedErrors: []dto.GraphQLError{{
+				Message:    "Film introuvable",
+				Locations:  []dto.GraphQLLocation{{Line: 1, Column: 3}},
+				Path:       []interface{}{"me"},
+				Extensions: map[string]interface{}{"code": kterrors.FilmNotFoundError},
+			}},
+		},
+		{
+			name:         "Invalid query does not run",
+			request:      dto.GraphQLRequest{Query: `{ me { password } }`},
+			mockBehavior: func(m testMocks) {},
+			expectedErrors: []dto.GraphQLError{{
+				Message:   `Cannot query field "password" on type "User".`,
+				Locations: []dto.GraphQLLocation{{Line: 1, Column: 8}},
+			}},
+		},
+		{
+			name: "Too complex query does not run",
+			request: dto.GraphQLRequest{Query: `{
+				a: films(pageSize: 100) { films { title credits { name } } }
+				b: films(pageSize: 100) { films { title credits { name } } }
+				c: films(pageSize: 100) { films { title credits { name } } }
+			}`},
+			mockBehavior: func(m testMocks) {},
+			expectedErrors: []dto.GraphQLError{{
+				Message: "The query costs 6603, the limit is 5000",
+				Extensions: map[string]interface{}{
+					"code":   kterrors.QueryTooComplexError,
+					"params": map[string]interface{}{"cost": 6603, "max": consts.GraphQLMaxComplexity},
+				},
+			}},
+		},
+		{
+			name:         "Page size out of range",
+			request:      dto.GraphQLRequest{Query: `{ films(pageSize: -1) { count } }`},
+			mockBehavior: func(m testMocks) {},
+			expectedErrors: []dto.GraphQLError{{
+				Message:   "The page must be at least 1 and the pageSize between 1 and 100",
+				Locations: []dto.GraphQLLocation{{Line: 1, Column: 3}},
+				Path:      []interface{}{"films"},
+				Extensions: map[string]interface{}{
+					"code":   kterrors.InvalidPaginationError,
+					"params": map[string]interface{}{"page": 1, "pageSize": -1, "max": consts.GraphQLMaxPageSize},
+				},
+			}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			service, m := newTestService(t)
+			tc.mockBehavior(m)
+			tc.request.UserID = testUserID
+
+			result := service.Execute(context.Background(), tc.request)
+
+			assert.Equal(t, tc.expectedErrors, result.Errors)
+			if tc.expectedData != "" {
+				data, err := json.Marshal(result.Data)
+				require.NoError(t, err)
+				assert.JSONEq(t, tc.expectedData, string(data))
+			}
+		})
+	}
+}
+
+func TestCheckLimits(t *testing.T) {
+	logger.InitializeForTest()
+
+	testCases := []struct {
+		name          string
+		query         string
+		variables     map[string]interface{}
+		expectedError string
+	}{
+		{
+			name:  "Within the limits",
+			query: `{ me { lists { entries { film { title } } } } }`,
+		},
+		{
+			name:          "Too deep",
+			query:         `{ me { lists { entries { film { credits { name } } } } } }`,
+			expectedError: kterrors.QueryTooDeepError,
+		},
+		{
+			name:          "Fragments count",
+			query:         `{ me { ...lists } } fragment lists on User { lists { entries { film { credits { name } } } } }`,
+			expectedError: kterrors.QueryTooDeepError,
+		},
+		{
+			name:          "Page size from the variables",
+			query:         `query Page($size: Int) { films(pageSize: $size) { films { id credits { name } } } }`,
+			variables:     map[string]interface{}{"size": float64(50)},
+			expectedError: kterrors.QueryTooComplexError,
+		},
+		{
+			name:          "Negative page size counts as the largest page",
+			query:         `{ films(pageSize: -1) { films { id credits { name } } } }`,
+			expectedError: kterrors.QueryTooComplexError,
+		},
+		{
+			name:      "Page size above the maximum counts as the largest page",
+			query:     `query Page($size: Int) { films(pageSize: $size) { films { id } } }`,
+			variables: map[string]interface{}{"size": float64(1e12)},
+		},
+		{
+			name:  "Introspection is free",
+			query: `{ __schema { types { name fields { name type { name ofType { name ofType { name } } } } } } }`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			service, _ := newTestService(t)
+			service.maxDepth = 5
+			service.maxComplexity = 500
+			document, err := parser.Parse(parser.ParseParams{Source: tc.query})
+			require.NoError(t, err)
+
+			err = service.checkLimits(document, "", tc.variables)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+				return
+			}
+			var customError *customerror.CustomError
+			require.ErrorAs(t, err, &customError)
+			assert.Equal(t, tc.expectedError, customError.Code)
+		})
+	}
+}
+
+func TestLoader(t *testing.T) {
+	calls := [][]int{}
+	l := newLoader(func(keys []int) (map[int]string, error) {
+		calls = append(calls, keys)
+		return map[int]string{1: "one", 2: "two"}, nil
+	})
+
+	first := l.load(1)
+	second := l.load(2)
+	missing := l.load(3)
+	again := l.load(1)
+
+	value, found, err := first()
+	assert.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, "one", value)
+	value, found, _ = second()
+	assert.True(t, found)
+	assert.Equal(t, "two", value)
+	_, found, _ = missing()
+	assert.False(t, found)
+	value, _, _ = again()
+	assert.Equal(t, "one", value)
+	value, _, _ = l.load(2)()
+	assert.Equal(t, "two", value)
+
+	assert.Equal(t, [][]int{{1, 2, 3}}, calls)
+}
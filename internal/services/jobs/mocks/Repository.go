@@ -0,0 +1,131 @@
+// Code generated by mockery v2.52.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	entities "KTOnlinePlatform/pkg/database/entities"
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Repository is an autogenerated mock type for the Repository type
+type Repository struct {
+	mock.Mock
+}
+
+// CreateJob provides a mock function with given fields: ctx, job
+func (_m *Repository) CreateJob(ctx context.Context, job entities.Job) (entities.Job, error) {
+	ret := _m.Called(ctx, job)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateJob")
+	}
+
+	var r0 entities.Job
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entities.Job) (entities.Job, error)); ok {
+		return rf(ctx, job)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entities.Job) entities.Job); ok {
+		r0 = rf(ctx, job)
+	} else {
+		r0 = ret.Get(0).(entities.Job)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entities.Job) error); ok {
+		r1 = rf(ctx, job)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FailJobs provides a mock function with given fields: ctx, statuses, message
+func (_m *Repository) FailJobs(ctx context.Context, statuses []string, message string) (int64, error) {
+	ret := _m.Called(ctx, statuses, message)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FailJobs")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string, string) (int64, error)); ok {
+		return rf(ctx, statuses, message)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string, string) int64); ok {
+		r0 = rf(ctx, statuses, message)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string, string) error); ok {
+		r1 = rf(ctx, statuses, message)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetJob provides a mock function with given fields: ctx, ID
+func (_m *Repository) GetJob(ctx context.Context, ID int) (entities.Job, error) {
+	ret := _m.Called(ctx, ID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetJob")
+	}
+
+	var r0 entities.Job
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (entities.Job, error)); ok {
+		return rf(ctx, ID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) entities.Job); ok {
+		r0 = rf(ctx, ID)
+	} else {
+		r0 = ret.Get(0).(entities.Job)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, ID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateJob provides a mock function with given fields: ctx, job
+func (_m *Repository) UpdateJob(ctx context.Context, job entities.Job) error {
+	ret := _m.Called(ctx, job)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateJob")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entities.Job) error); ok {
+		r0 = rf(ctx, job)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewRepository creates a new instance of Repository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Repository {
+	mock := &Repository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
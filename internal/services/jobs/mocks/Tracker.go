@@ -0,0 +1,48 @@
+// Code generated by mockery v2.52.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "KTOnlinePlatform/internal/models"
+)
+
+// Tracker is an autogenerated mock type for the Tracker type
+type Tracker struct {
+	mock.Mock
+}
+
+// Advance provides a mock function with given fields: ctx, progress
+func (_m *Tracker) Advance(ctx context.Context, progress models.JobProgress) error {
+	ret := _m.Called(ctx, progress)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Advance")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.JobProgress) error); ok {
+		r0 = rf(ctx, progress)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewTracker creates a new instance of Tracker. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewTracker(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Tracker {
+	mock := &Tracker{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,167 @@
+package jobs
+
+import (
+	"KTOnlinePlatform/internal/dto"
+	"KTOnlinePlatform/internal/models"
+	"KTOnlinePlatform/internal/models/consts"
+	"KTOnlinePlatform/internal/models/kterrors"
+	"KTOnlinePlatform/pkg/customerror"
+	"KTOnlinePlatform/pkg/database/entities"
+	"KTOnlinePlatform/pkg/database/entities/entitiescustom"
+	"KTOnlinePlatform/pkg/logger"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/samber/lo"
+	"sync"
+)
+
+type Repository interface {
+	CreateJob(ctx context.Context, job entities.Job) (entities.Job, error)
+	GetJob(ctx context.Context, ID int) (entities.Job, error)
+	UpdateJob(ctx context.Context, job entities.Job) error
+	FailJobs(ctx context.Context, statuses []string, message string) (int64, error)
+}
+
+// Tracker persists the progress of a running job
+type Tracker interface {
+	Advance(ctx context.Context, progress models.JobProgress) error
+}
+
+// Task is the work done by a job, it runs detached from the request that started it
+type Task func(ctx context.Context, tracker Tracker) error
+
+// errInterrupted is recorded on the jobs the shutdown of the server stopped before they finished
+var errInterrupted = errors.New("job interrupted by a shutdown")
+
+type Service struct {
+	repo    Repository
+	ctx     context.Context
+	running sync.WaitGroup
+}
+
+// NewService runs the jobs under ctx, cancelling it interrupts them and they are recorded as failed
+func NewService(ctx context.Context, repo Repository) *Service {
+	return &Service{
+		repo: repo,
+		ctx:  ctx,
+	}
+}
+
+// FailInterruptedJobs marks as failed the jobs a previous run of the server left pending or running,
+// nothing resumes them. It is called at startup, before any job is started.
+func (s *Service) FailInterruptedJobs(ctx context.Context) error {
+	count, err := s.repo.FailJobs(ctx, []string{consts.JobStatusPending, consts.JobStatusRunning}, errInterrupted.Error())
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		logger.Info().Msgf("%d interrupted jobs marked as failed", count)
+	}
+	return nil
+}
+
+// Wait blocks until every started job has recorded its final state
+func (s *Service) Wait() {
+	s.running.Wait()
+}
+
+func (s *Service) GetJob(ctx context.Context, jobID int, userID int) (dto.Job, error) {
+	job, err := s.repo.GetJob(ctx, jobID)
+	if err != nil {
+		return dto.Job{}, err
+	}
+	if job.UserID != userID {
+		return dto.Job{}, customerror.NewCustomError(kterrors.UserCannotAccessJobError)
+	}
+	return toJobDTO(job), nil
+}
+
+// StartJob records a pending job and runs the task in the background
+func (s *Service) StartJob(ctx context.Context, jobType string, userID int, total int, task Task) (dto.Job, error) {
+	job, err := s.repo.CreateJob(ctx, entities.Job{
+		Type:   jobType,
+		Status: consts.JobStatusPending,
+		UserID: userID,
+		Total:  total,
+	})
+	if err != nil {
+		return dto.Job{}, err
+	}
+	s.running.Add(1)
+	go func() {
+		defer s.running.Done()
+		s.run(job, task)
+	}()
+	return toJobDTO(job), nil
+}
+
+func (s *Service) run(job entities.Job, task Task) {
+	ctx := s.ctx
+	tracker := &jobTracker{repo: s.repo, job: job}
+	defer func() {
+		if r := recover(); r != nil {
+			s.finish(ctx, tracker, fmt.Errorf("job panicked: %v", r))
+		}
+	}()
+
+	tracker.job.Status = consts.JobStatusRunning
+	err := s.repo.UpdateJob(ctx, tracker.job)
+	if err != nil {
+		s.finish(ctx, tracker, err)
+		return
+	}
+	s.finish(ctx, tracker, task(ctx, tracker))
+}
+
+func (s *Service) finish(ctx context.Context, tracker *jobTracker, err error) {
+	if err != nil && ctx.Err() != nil {
+		err = errInterrupted
+	}
+	// the final state is saved even when the job was interrupted
+	ctx = context.WithoutCancel(ctx)
+	tracker.job.Status = consts.JobStatusCompleted
+	if err != nil {
+		logger.Error().Err(err).Msgf("job %d failed", tracker.job.ID)
+		tracker.job.Status = consts.JobStatusFailed
+		tracker.job.Error = err.Error()
+	}
+	if err := s.repo.UpdateJob(ctx, tracker.job); err != nil {
+		logger.Error().Err(err).Msgf("cannot save final state of job %d", tracker.job.ID)
+	}
+}
+
+type jobTracker struct {
+	repo Repository
+	job  entities.Job
+}
+
+func (t *jobTracker) Advance(ctx context.Context, progress models.JobProgress) error {
+	t.job.Succeeded += progress.Succeeded
+	t.job.Skipped += progress.Skipped
+	t.job.Failed += progress.Failed
+	t.job.Processed += progress.Succeeded + progress.Skipped + progress.Failed
+	t.job.RowErrors = append(t.job.RowErrors, progress.RowErrors...)
+	return t.repo.UpdateJob(ctx, t.job)
+}
+
+func toJobDTO(job entities.Job) dto.Job {
+	return dto.Job{
+		ID:        job.ID,
+		Type:      job.Type,
+		Status:    job.Status,
+		Total:     job.Total,
+		Processed: job.Processed,
+		Succeeded: job.Succeeded,
+		Skipped:   job.Skipped,
+		Failed:    job.Failed,
+		RowErrors: lo.Map(job.RowErrors, func(item entitiescustom.JobRowError, index int) dto.JobRowError {
+			return dto.JobRowError{
+				Row:    item.Row,
+				Code:   item.Code,
+				Params: item.Params,
+			}
+		}),
+		Error: job.Error,
+	}
+}
@@ -0,0 +1,186 @@
+package jobs
+
+import (
+	"KTOnlinePlatform/internal/dto"
+	"KTOnlinePlatform/internal/models"
+	"KTOnlinePlatform/internal/models/consts"
+	"KTOnlinePlatform/internal/models/kterrors"
+	"KTOnlinePlatform/internal/services/jobs/mocks"
+	"KTOnlinePlatform/pkg/customerror"
+	"KTOnlinePlatform/pkg/database/entities"
+	"KTOnlinePlatform/pkg/database/entities/entitiescustom"
+	"KTOnlinePlatform/pkg/logger"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetJob(t *testing.T) {
+	logger.InitializeForTest()
+
+	testCases := []struct {
+		name           string
+		jobID          int
+		userID         int
+		mockBehavior   func(*mocks.Repository)
+		expectedResult dto.Job
+		expectedError  error
+	}{
+		{
+			name:   "Successful job retrieval",
+			jobID:  1,
+			userID: 100,
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("GetJob", mock.Anything, 1).Return(entities.Job{
+					ID:        1,
+					Type:      consts.JobTypeFilmImport,
+					Status:    consts.JobStatusRunning,
+					UserID:    100,
+					Total:     3,
+					Processed: 2,
+					Succeeded: 1,
+					Failed:    1,
+					RowErrors: entitiescustom.JobRowErrors{
+						{Row: 2, Code: kterrors.InvalidImportRowError},
+					},
+				}, nil)
+			},
+			expectedResult: dto.Job{
+				ID:        1,
+				Type:      consts.JobTypeFilmImport,
+				Status:    consts.JobStatusRunning,
+				Total:     3,
+				Processed: 2,
+				Succeeded: 1,
+				Failed:    1,
+				RowErrors: []dto.JobRowError{
+					{Row: 2, Code: kterrors.InvalidImportRowError},
+				},
+			},
+		},
+		{
+			name:   "Job of another user",
+			jobID:  1,
+			userID: 200,
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("GetJob", mock.Anything, 1).Return(entities.Job{ID: 1, UserID: 100}, nil)
+			},
+			expectedError: customerror.NewCustomError(kterrors.UserCannotAccessJobError),
+		},
+		{
+			name:   "Job not found",
+			jobID:  999,
+			userID: 100,
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("GetJob", mock.Anything, 999).Return(entities.Job{}, errors.New("job not found"))
+			},
+			expectedError: errors.New("job not found"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := mocks.NewRepository(t)
+			tc.mockBehavior(mockRepo)
+
+			service := NewService(context.Background(), mockRepo)
+
+			result, err := service.GetJob(context.Background(), tc.jobID, tc.userID)
+
+			if tc.expectedError != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tc.expectedError.Error(), err.Error())
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedResult, result)
+		})
+	}
+}
+
+func TestRun(t *testing.T) {
+	logger.InitializeForTest()
+
+	testCases := []struct {
+		name           string
+		task           Task
+		cancelled      bool
+		expectedStatus string
+		expectedError  string
+	}{
+		{
+			name: "Task completes",
+			task: func(ctx context.Context, tracker Tracker) error {
+				return tracker.Advance(ctx, models.JobProgress{Succeeded: 2})
+			},
+			expectedStatus: consts.JobStatusCompleted,
+		},
+		{
+			name: "Task fails",
+			task: func(ctx context.Context, tracker Tracker) error {
+				return errors.New("database error")
+			},
+			expectedStatus: consts.JobStatusFailed,
+			expectedError:  "database error",
+		},
+		{
+			name: "Task panics",
+			task: func(ctx context.Context, tracker Tracker) error {
+				panic("boom")
+			},
+			expectedStatus: consts.JobStatusFailed,
+			expectedError:  "job panicked: boom",
+		},
+		{
+			name: "Task interrupted by a shutdown",
+			task: func(ctx context.Context, tracker Tracker) error {
+				return ctx.Err()
+			},
+			cancelled:      true,
+			expectedStatus: consts.JobStatusFailed,
+			expectedError:  "job interrupted by a shutdown",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := mocks.NewRepository(t)
+			var last entities.Job
+			var lastCtx context.Context
+			mockRepo.On("UpdateJob", mock.Anything, mock.AnythingOfType("entities.Job")).
+				Run(func(args mock.Arguments) {
+					lastCtx = args.Get(0).(context.Context)
+					last = args.Get(1).(entities.Job)
+				}).
+				Return(nil)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			if tc.cancelled {
+				cancel()
+			}
+			defer cancel()
+			service := NewService(ctx, mockRepo)
+			service.run(entities.Job{ID: 1, Status: consts.JobStatusPending, Total: 2}, tc.task)
+
+			assert.Equal(t, tc.expectedStatus, last.Status)
+			assert.Equal(t, tc.expectedError, last.Error)
+			assert.NoError(t, lastCtx.Err(), "the final state is saved with a live context")
+		})
+	}
+}
+
+func TestFailInterruptedJobs(t *testing.T) {
+	logger.InitializeForTest()
+
+	mockRepo := mocks.NewRepository(t)
+	mockRepo.On("FailJobs", mock.Anything, []string{consts.JobStatusPending, consts.JobStatusRunning}, "job interrupted by a shutdown").
+		Return(int64(2), nil)
+
+	err := NewService(context.Background(), mockRepo).FailInterruptedJobs(context.Background())
+
+	assert.NoError(t, err)
+}
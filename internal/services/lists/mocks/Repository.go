@@ -0,0 +1,285 @@
+// Code generated by mockery v2.52.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	entities "KTOnlinePlatform/pkg/database/entities"
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "KTOnlinePlatform/internal/models"
+)
+
+// Repository is an autogenerated mock type for the Repository type
+type Repository struct {
+	mock.Mock
+}
+
+// AddListEntry provides a mock function with given fields: ctx, listID, filmID
+func (_m *Repository) AddListEntry(ctx context.Context, listID int, filmID int) error {
+	ret := _m.Called(ctx, listID, filmID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddListEntry")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) error); ok {
+		r0 = rf(ctx, listID, filmID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateList provides a mock function with given fields: ctx, list
+func (_m *Repository) CreateList(ctx context.Context, list entities.UserList) error {
+	ret := _m.Called(ctx, list)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateList")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entities.UserList) error); ok {
+		r0 = rf(ctx, list)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteList provides a mock function with given fields: ctx, id
+func (_m *Repository) DeleteList(ctx context.Context, id int) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteList")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetList provides a mock function with given fields: ctx, ID
+func (_m *Repository) GetList(ctx context.Context, ID int) (entities.UserList, error) {
+	ret := _m.Called(ctx, ID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetList")
+	}
+
+	var r0 entities.UserList
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (entities.UserList, error)); ok {
+		return rf(ctx, ID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) entities.UserList); ok {
+		r0 = rf(ctx, ID)
+	} else {
+		r0 = ret.Get(0).(entities.UserList)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, ID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetListBySlug provides a mock function with given fields: ctx, slug
+func (_m *Repository) GetListBySlug(ctx context.Context, slug string) (entities.UserList, error) {
+	ret := _m.Called(ctx, slug)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetListBySlug")
+	}
+
+	var r0 entities.UserList
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (entities.UserList, error)); ok {
+		return rf(ctx, slug)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) entities.UserList); ok {
+		r0 = rf(ctx, slug)
+	} else {
+		r0 = ret.Get(0).(entities.UserList)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, slug)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetListEntries provides a mock function with given fields: ctx, listID
+func (_m *Repository) GetListEntries(ctx context.Context, listID int) ([]models.UserListEntry, error) {
+	ret := _m.Called(ctx, listID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetListEntries")
+	}
+
+	var r0 []models.UserListEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]models.UserListEntry, error)); ok {
+		return rf(ctx, listID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []models.UserListEntry); ok {
+		r0 = rf(ctx, listID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.UserListEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, listID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUserLists provides a mock function with given fields: ctx, userID
+func (_m *Repository) GetUserLists(ctx context.Context, userID int) ([]models.UserListSummary, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserLists")
+	}
+
+	var r0 []models.UserListSummary
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]models.UserListSummary, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []models.UserListSummary); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.UserListSummary)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUserListsEntries provides a mock function with given fields: ctx, userID, listIDs
+func (_m *Repository) GetUserListsEntries(ctx context.Context, userID int, listIDs []int) ([]models.UserListEntry, error) {
+	ret := _m.Called(ctx, userID, listIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserListsEntries")
+	}
+
+	var r0 []models.UserListEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, []int) ([]models.UserListEntry, error)); ok {
+		return rf(ctx, userID, listIDs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, []int) []models.UserListEntry); ok {
+		r0 = rf(ctx, userID, listIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.UserListEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, []int) error); ok {
+		r1 = rf(ctx, userID, listIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemoveListEntry provides a mock function with given fields: ctx, listID, filmID
+func (_m *Repository) RemoveListEntry(ctx context.Context, listID int, filmID int) error {
+	ret := _m.Called(ctx, listID, filmID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveListEntry")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) error); ok {
+		r0 = rf(ctx, listID, filmID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ReorderListEntries provides a mock function with given fields: ctx, listID, filmIDs
+func (_m *Repository) ReorderListEntries(ctx context.Context, listID int, filmIDs []int) error {
+	ret := _m.Called(ctx, listID, filmIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReorderListEntries")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, []int) error); ok {
+		r0 = rf(ctx, listID, filmIDs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateList provides a mock function with given fields: ctx, list
+func (_m *Repository) UpdateList(ctx context.Context, list entities.UserList) error {
+	ret := _m.Called(ctx, list)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateList")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entities.UserList) error); ok {
+		r0 = rf(ctx, list)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewRepository creates a new instance of Repository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Repository {
+	mock := &Repository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,269 @@
+package lists
+
+import (
+	"KTOnlinePlatform/internal/dto"
+	"KTOnlinePlatform/internal/models"
+	"KTOnlinePlatform/internal/models/consts"
+	"KTOnlinePlatform/internal/models/kterrors"
+	"KTOnlinePlatform/pkg/customerror"
+	"KTOnlinePlatform/pkg/database/entities"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"github.com/samber/lo"
+	"net/http"
+)
+
+type Repository interface {
+	GetUserLists(ctx context.Context, userID int) ([]models.UserListSummary, error)
+	GetList(ctx context.Context, ID int) (entities.UserList, error)
+	GetListBySlug(ctx context.Context, slug string) (entities.UserList, error)
+	CreateList(ctx context.Context, list entities.UserList) error
+	UpdateList(ctx context.Context, list entities.UserList) error
+	DeleteList(ctx context.Context, id int) error
+	GetListEntries(ctx context.Context, listID int) ([]models.UserListEntry, error)
+	GetUserListsEntries(ctx context.Context, userID int, listIDs []int) ([]models.UserListEntry, error)
+	AddListEntry(ctx context.Context, listID int, filmID int) error
+	RemoveListEntry(ctx context.Context, listID int, filmID int) error
+	ReorderListEntries(ctx context.Context, listID int, filmIDs []int) error
+}
+
+type Service struct {
+	repo Repository
+}
+
+func NewService(repo Repository) *Service {
+	return &Service{
+		repo: repo,
+	}
+}
+
+func (s *Service) GetUserLists(ctx context.Context, userID int) ([]dto.UserList, error) {
+	result, err := s.repo.GetUserLists(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return lo.Map(result, func(item models.UserListSummary, index int) dto.UserList {
+		return dto.UserList{
+			ID:          item.ID,
+			Name:        item.Name,
+			Description: item.Description,
+			Public:      item.Public,
+			ShareURL:    shareURL(item.Public, item.ShareSlug),
+			EntryCount:  item.EntryCount,
+			CreatedAt:   item.CreatedAt,
+			UpdatedAt:   item.UpdatedAt,
+		}
+	}), nil
+}
+
+// GetUserListsEntries returns the entries of several lists of the user in one query, the lists of
+// other users are left out of the map
+func (s *Service) GetUserListsEntries(ctx context.Context, userID int, listIDs []int) (map[int][]dto.UserListEntry, error) {
+	entries, err := s.repo.GetUserListsEntries(ctx, userID, lo.Uniq(listIDs))
+	if err != nil {
+		return nil, err
+	}
+	return lo.MapValues(lo.GroupBy(entries, func(item models.UserListEntry) int {
+		return item.ListID
+	}), func(value []models.UserListEntry, key int) []dto.UserListEntry {
+		return toUserListEntries(value)
+	}), nil
+}
+
+func (s *Service) GetUserList(ctx context.Context, listID int, userID int) (dto.UserList, error) {
+	list, err := s.getOwnList(ctx, listID, userID)
+	if err != nil {
+		return dto.UserList{}, err
+	}
+	return s.withEntries(ctx, list, true)
+}
+
+// GetSharedList is what the shareable URL shows, private lists are not found
+func (s *Service) GetSharedList(ctx context.Context, slug string) (dto.UserList, error) {
+	list, err := s.repo.GetListBySlug(ctx, slug)
+	if err != nil {
+		if customerror.IsNotFoundError(err) {
+			return dto.UserList{}, newListNotFoundError()
+		}
+		return dto.UserList{}, err
+	}
+	if !list.Public {
+		return dto.UserList{}, newListNotFoundError()
+	}
+	return s.withEntries(ctx, list, false)
+}
+
+func (s *Service) CreateList(ctx context.Context, request dto.UserListCreateRequest) error {
+	slug, err := newShareSlug()
+	if err != nil {
+		return err
+	}
+	err = s.repo.CreateList(ctx, entities.UserList{
+		UserID:      request.UserID,
+		Name:        request.Name,
+		Description: request.Description,
+		Public:      request.Public,
+		ShareSlug:   slug,
+	})
+	if customerror.IsUniqueViolation(err) {
+		return customerror.NewCustomError(kterrors.ListNameAlreadyExistsError)
+	}
+	return err
+}
+
+func (s *Service) UpdateList(ctx context.Context, request dto.UserListUpdateRequest) error {
+	list, err := s.getOwnList(ctx, request.ID, request.UserID)
+	if err != nil {
+		return err
+	}
+	list.Name = request.Name
+	list.Description = request.Description
+	list.Public = request.Public
+	err = s.repo.UpdateList(ctx, list)
+	if customerror.IsUniqueViolation(err) {
+		return customerror.NewCustomError(kterrors.ListNameAlreadyExistsError)
+	}
+	return err
+}
+
+func (s *Service) DeleteList(ctx context.Context, listID int, userID int) error {
+	_, err := s.getOwnList(ctx, listID, userID)
+	if err != nil {
+		return err
+	}
+	return s.repo.DeleteList(ctx, listID)
+}
+
+func (s *Service) AddListEntry(ctx context.Context, request dto.UserListEntryRequest) error {
+	_, err := s.getOwnList(ctx, request.ListID, request.UserID)
+	if err != nil {
+		return err
+	}
+	err = s.repo.AddListEntry(ctx, request.ListID, request.FilmID)
+	if err != nil {
+		if customerror.IsUniqueViolation(err) {
+			return customerror.NewCustomError(kterrors.FilmAlreadyInListError)
+		}
+		if customerror.IsForeignKeyViolation(err) {
+			return customerror.NewCustomErrorWithHttpCode(kterrors.FilmNotFoundError, http.StatusNotFound)
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *Service) RemoveListEntry(ctx context.Context, listID int, filmID int, userID int) error {
+	_, err := s.getOwnList(ctx, listID, userID)
+	if err != nil {
+		return err
+	}
+	err = s.repo.RemoveListEntry(ctx, listID, filmID)
+	if customerror.IsNotFoundError(err) {
+		return customerror.NewCustomErrorWithHttpCode(kterrors.FilmNotInListError, http.StatusNotFound)
+	}
+	return err
+}
+
+// ReorderListEntries replaces the order of the list, the request must list every film of it exactly
+// once, hidden ones included
+func (s *Service) ReorderListEntries(ctx context.Context, request dto.UserListOrderRequest) error {
+	_, err := s.getOwnList(ctx, request.ListID, request.UserID)
+	if err != nil {
+		return err
+	}
+	entries, err := s.repo.GetListEntries(ctx, request.ListID)
+	if err != nil {
+		return err
+	}
+	current := lo.Map(entries, func(item models.UserListEntry, index int) int {
+		return item.FilmID
+	})
+	if len(request.FilmIDs) != len(current) ||
+		len(lo.Uniq(request.FilmIDs)) != len(request.FilmIDs) ||
+		!lo.Every(current, request.FilmIDs) {
+		return customerror.NewCustomError(kterrors.InvalidListOrderError)
+	}
+	return s.repo.ReorderListEntries(ctx, request.ListID, request.FilmIDs)
+}
+
+func (s *Service) getOwnList(ctx context.Context, listID int, userID int) (entities.UserList, error) {
+	list, err := s.repo.GetList(ctx, listID)
+	if err != nil {
+		if customerror.IsNotFoundError(err) {
+			return entities.UserList{}, newListNotFoundError()
+		}
+		return entities.UserList{}, err
+	}
+	if list.UserID != userID {
+		return entities.UserList{}, customerror.NewCustomError(kterrors.UserCannotAccessListError)
+	}
+	return list, nil
+}
+
+// withEntries shows the owner every entry, others do not see the hidden films and the positions
+// are numbered again without them
+func (s *Service) withEntries(ctx context.Context, list entities.UserList, owner bool) (dto.UserList, error) {
+	entries, err := s.repo.GetListEntries(ctx, list.ID)
+	if err != nil {
+		return dto.UserList{}, err
+	}
+	if !owner {
+		entries = lo.Reject(entries, func(item models.UserListEntry, index int) bool {
+			return item.Hidden
+		})
+		for i := range entries {
+			entries[i].Position = i + 1
+		}
+	}
+	result := dto.UserList{
+		ID:          list.ID,
+		Name:        list.Name,
+		Description: list.Description,
+		Public:      list.Public,
+		ShareURL:    shareURL(list.Public, list.ShareSlug),
+		EntryCount:  len(entries),
+		Entries:     toUserListEntries(entries),
+	}
+	if list.CreatedAt != nil {
+		result.CreatedAt = *list.CreatedAt
+	}
+	if list.UpdatedAt != nil {
+		result.UpdatedAt = *list.UpdatedAt
+	}
+	return result, nil
+}
+
+func toUserListEntries(entries []models.UserListEntry) []dto.UserListEntry {
+	return lo.Map(entries, func(item models.UserListEntry, index int) dto.UserListEntry {
+		return dto.UserListEntry{
+			FilmID:   item.FilmID,
+			Title:    lo.Ternary(item.Hidden, "", item.Title),
+			Hidden:   item.Hidden,
+			Position: item.Position,
+			AddedAt:  item.CreatedAt,
+		}
+	})
+}
+
+func newListNotFoundError() error {
+	return customerror.NewCustomErrorWithHttpCode(kterrors.ListNotFoundError, http.StatusNotFound)
+}
+
+// shareURL is only given out for public lists
+func shareURL(public bool, slug string) string {
+	if !public {
+		return ""
+	}
+	return consts.ListSharePath + slug
+}
+
+// newShareSlug is random and unguessable, the list id would let anyone enumerate lists
+func newShareSlug() (string, error) {
+	b := make([]byte, consts.ListShareSlugLen)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,321 @@
+package lists
+
+import (
+	"KTOnlinePlatform/internal/dto"
+	"KTOnlinePlatform/internal/models"
+	"KTOnlinePlatform/internal/models/consts"
+	"KTOnlinePlatform/internal/models/kterrors"
+	"KTOnlinePlatform/internal/services/lists/mocks"
+	"KTOnlinePlatform/pkg/customerror"
+	"KTOnlinePlatform/pkg/database/entities"
+	"KTOnlinePlatform/pkg/logger"
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func TestCreateList(t *testing.T) {
+	logger.InitializeForTest()
+
+	testCases := []struct {
+		name          string
+		mockBehavior  func(*mocks.Repository)
+		expectedError error
+	}{
+		{
+			name: "List is created with a share slug",
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("CreateList", mock.Anything, mock.MatchedBy(func(list entities.UserList) bool {
+					return list.UserID == 100 && list.Name == "Watchlist" && len(list.ShareSlug) == 16
+				})).Return(nil)
+			},
+		},
+		{
+			name: "Name already used by the user",
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("CreateList", mock.Anything, mock.AnythingOfType("entities.UserList")).Return(gorm.ErrDuplicatedKey)
+			},
+			expectedError: customerror.NewCustomError(kterrors.ListNameAlreadyExistsError),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := mocks.NewRepository(t)
+			tc.mockBehavior(mockRepo)
+			service := NewService(mockRepo)
+
+			err := service.CreateList(context.Background(), dto.UserListCreateRequest{Name: "Watchlist", UserID: 100})
+
+			if tc.expectedError != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tc.expectedError.Error(), err.Error())
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestGetSharedList(t *testing.T) {
+	logger.InitializeForTest()
+	addedAt := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name           string
+		mockBehavior   func(*mocks.Repository)
+		expectedResult dto.UserList
+		expectedError  error
+	}{
+		{
+			name: "Public list with its entries",
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("GetListBySlug", mock.Anything, "abc").Return(
+					entities.UserList{ID: 1, UserID: 100, Name: "Heists", Public: true, ShareSlug: "abc"}, nil)
+				mr.On("GetListEntries", mock.Anything, 1).Return([]models.UserListEntry{
+					{FilmID: 7, Title: "Heat", Position: 1, CreatedAt: addedAt},
+				}, nil)
+			},
+			expectedResult: dto.UserList{
+				ID:         1,
+				Name:       "Heists",
+				Public:     true,
+				ShareURL:   consts.ListSharePath + "abc",
+				EntryCount: 1,
+				Entries: []dto.UserListEntry{
+					{FilmID: 7, Title: "Heat", Position: 1, AddedAt: addedAt},
+				},
+			},
+		},
+		{
+			name: "Hidden films are left out and the positions follow",
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("GetListBySlug", mock.Anything, "abc").Return(
+					entities.UserList{ID: 1, UserID: 100, Name: "Heists", Public: true, ShareSlug: "abc"}, nil)
+				mr.On("GetListEntries", mock.Anything, 1).Return([]models.UserListEntry{
+					{FilmID: 7, Title: "Heat", Position: 1, CreatedAt: addedAt},
+					{FilmID: 8, Title: "Banned", Hidden: true, Position: 2, CreatedAt: addedAt},
+					{FilmID: 9, Title: "Thief", Position: 3, CreatedAt: addedAt},
+				}, nil)
+			},
+			expectedResult: dto.UserList{
+				ID:         1,
+				Name:       "Heists",
+				Public:     true,
+				ShareURL:   consts.ListSharePath + "abc",
+				EntryCount: 2,
+				Entries: []dto.UserListEntry{
+					{FilmID: 7, Title: "Heat", Position: 1, AddedAt: addedAt},
+					{FilmID: 9, Title: "Thief", Position: 2, AddedAt: addedAt},
+				},
+			},
+		},
+		{
+			name: "Private list is not shared",
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("GetListBySlug", mock.Anything, "abc").Return(
+					entities.UserList{ID: 1, UserID: 100, Name: "Heists", ShareSlug: "abc"}, nil)
+			},
+			expectedError: customerror.NewCustomErrorWithHttpCode(kterrors.ListNotFoundError, http.StatusNotFound),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := mocks.NewRepository(t)
+			tc.mockBehavior(mockRepo)
+			service := NewService(mockRepo)
+
+			result, err := service.GetSharedList(context.Background(), "abc")
+
+			if tc.expectedError != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tc.expectedError.Error(), err.Error())
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedResult, result)
+		})
+	}
+}
+
+func TestGetUserList(t *testing.T) {
+	logger.InitializeForTest()
+	addedAt := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	mockRepo := mocks.NewRepository(t)
+	mockRepo.On("GetList", mock.Anything, 1).Return(entities.UserList{ID: 1, UserID: 100, Name: "Heists"}, nil)
+	mockRepo.On("GetListEntries", mock.Anything, 1).Return([]models.UserListEntry{
+		{FilmID: 7, Title: "Heat", Position: 1, CreatedAt: addedAt},
+		{FilmID: 8, Title: "Banned", Hidden: true, Position: 2, CreatedAt: addedAt},
+	}, nil)
+	service := NewService(mockRepo)
+
+	result, err := service.GetUserList(context.Background(), 1, 100)
+
+	assert.NoError(t, err)
+	assert.Equal(t, dto.UserList{
+		ID:         1,
+		Name:       "Heists",
+		EntryCount: 2,
+		Entries: []dto.UserListEntry{
+			{FilmID: 7, Title: "Heat", Position: 1, AddedAt: addedAt},
+			{FilmID: 8, Hidden: true, Position: 2, AddedAt: addedAt},
+		},
+	}, result, "the owner sees the hidden film to remove it")
+}
+
+func TestGetUserListsEntries(t *testing.T) {
+	logger.InitializeForTest()
+	addedAt := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	mockRepo := mocks.NewRepository(t)
+	mockRepo.On("GetUserListsEntries", mock.Anything, 100, []int{1, 2, 3}).Return([]models.UserListEntry{
+		{ListID: 1, FilmID: 7, Title: "Heat", Position: 1, CreatedAt: addedAt},
+		{ListID: 1, FilmID: 8, Title: "Thief", Position: 2, CreatedAt: addedAt},
+		{ListID: 3, FilmID: 7, Title: "Heat", Position: 1, CreatedAt: addedAt},
+	}, nil).Once()
+	service := NewService(mockRepo)
+
+	result, err := service.GetUserListsEntries(context.Background(), 100, []int{1, 2, 3, 1})
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[int][]dto.UserListEntry{
+		1: {
+			{FilmID: 7, Title: "Heat", Position: 1, AddedAt: addedAt},
+			{FilmID: 8, Title: "Thief", Position: 2, AddedAt: addedAt},
+		},
+		3: {
+			{FilmID: 7, Title: "Heat", Position: 1, AddedAt: addedAt},
+		},
+	}, result)
+}
+
+func TestAddListEntry(t *testing.T) {
+	logger.InitializeForTest()
+
+	testCases := []struct {
+		name          string
+		userID        int
+		mockBehavior  func(*mocks.Repository)
+		expectedError error
+	}{
+		{
+			name:   "Film is appended",
+			userID: 100,
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("GetList", mock.Anything, 1).Return(entities.UserList{ID: 1, UserID: 100}, nil)
+				mr.On("AddListEntry", mock.Anything, 1, 7).Return(nil)
+			},
+		},
+		{
+			name:   "List of another user",
+			userID: 200,
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("GetList", mock.Anything, 1).Return(entities.UserList{ID: 1, UserID: 100}, nil)
+			},
+			expectedError: customerror.NewCustomError(kterrors.UserCannotAccessListError),
+		},
+		{
+			name:   "Film already in the list",
+			userID: 100,
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("GetList", mock.Anything, 1).Return(entities.UserList{ID: 1, UserID: 100}, nil)
+				mr.On("AddListEntry", mock.Anything, 1, 7).Return(gorm.ErrDuplicatedKey)
+			},
+			expectedError: customerror.NewCustomError(kterrors.FilmAlreadyInListError),
+		},
+		{
+			name:   "Film does not exist",
+			userID: 100,
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("GetList", mock.Anything, 1).Return(entities.UserList{ID: 1, UserID: 100}, nil)
+				mr.On("AddListEntry", mock.Anything, 1, 7).Return(gorm.ErrForeignKeyViolated)
+			},
+			expectedError: customerror.NewCustomErrorWithHttpCode(kterrors.FilmNotFoundError, http.StatusNotFound),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := mocks.NewRepository(t)
+			tc.mockBehavior(mockRepo)
+			service := NewService(mockRepo)
+
+			err := service.AddListEntry(context.Background(), dto.UserListEntryRequest{ListID: 1, FilmID: 7, UserID: tc.userID})
+
+			if tc.expectedError != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tc.expectedError.Error(), err.Error())
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestReorderListEntries(t *testing.T) {
+	logger.InitializeForTest()
+	entries := []models.UserListEntry{
+		{FilmID: 7, Position: 1},
+		{FilmID: 8, Hidden: true, Position: 2},
+		{FilmID: 9, Position: 3},
+	}
+
+	testCases := []struct {
+		name          string
+		filmIDs       []int
+		mockBehavior  func(*mocks.Repository)
+		expectedError error
+	}{
+		{
+			name:    "New order is saved",
+			filmIDs: []int{9, 7, 8},
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("ReorderListEntries", mock.Anything, 1, []int{9, 7, 8}).Return(nil)
+			},
+		},
+		{
+			name:          "Missing hidden film",
+			filmIDs:       []int{9, 7},
+			mockBehavior:  func(mr *mocks.Repository) {},
+			expectedError: customerror.NewCustomError(kterrors.InvalidListOrderError),
+		},
+		{
+			name:          "Duplicated film",
+			filmIDs:       []int{9, 7, 7},
+			mockBehavior:  func(mr *mocks.Repository) {},
+			expectedError: customerror.NewCustomError(kterrors.InvalidListOrderError),
+		},
+		{
+			name:          "Film that is not in the list",
+			filmIDs:       []int{9, 7, 10},
+			mockBehavior:  func(mr *mocks.Repository) {},
+			expectedError: customerror.NewCustomError(kterrors.InvalidListOrderError),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := mocks.NewRepository(t)
+			mockRepo.On("GetList", mock.Anything, 1).Return(entities.UserList{ID: 1, UserID: 100}, nil)
+			mockRepo.On("GetListEntries", mock.Anything, 1).Return(entries, nil)
+			tc.mockBehavior(mockRepo)
+			service := NewService(mockRepo)
+
+			err := service.ReorderListEntries(context.Background(), dto.UserListOrderRequest{ListID: 1, FilmIDs: tc.filmIDs, UserID: 100})
+
+			if tc.expectedError != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tc.expectedError.Error(), err.Error())
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package media
+
+import (
+	"KTOnlinePlatform/internal/dto"
+	"KTOnlinePlatform/internal/models/kterrors"
+	"KTOnlinePlatform/pkg/blobstore"
+	"KTOnlinePlatform/pkg/customerror"
+	"KTOnlinePlatform/pkg/mediasign"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// BlobStore opens the stored media, seekable to serve range requests
+type BlobStore interface {
+	Get(ctx context.Context, key string) (io.ReadSeekCloser, error)
+}
+
+// URLVerifier checks the signed media URLs
+type URLVerifier interface {
+	Verify(key string, query url.Values) error
+}
+
+type Service struct {
+	blobs    BlobStore
+	verifier URLVerifier
+}
+
+func NewService(blobs BlobStore, verifier URLVerifier) *Service {
+	return &Service{
+		blobs:    blobs,
+		verifier: verifier,
+	}
+}
+
+// OpenMedia checks the signature of the URL before opening the blob. No token is needed, the
+// players fetching the segments of a playlist have none. The caller closes it
+func (s *Service) OpenMedia(ctx context.Context, request dto.MediaRequest) (io.ReadSeekCloser, error) {
+	err := s.verifier.Verify(request.Key, request.Query)
+	if err != nil {
+		if errors.Is(err, mediasign.ErrExpired) {
+			return nil, customerror.NewCustomErrorWithHttpCode(kterrors.MediaURLExpiredError, http.StatusForbidden)
+		}
+		return nil, customerror.NewCustomErrorWithHttpCode(kterrors.InvalidMediaSignatureError, http.StatusForbidden)
+	}
+	blob, err := s.blobs.Get(ctx, request.Key)
+	if err != nil {
+		if errors.Is(err, blobstore.ErrNotFound) || errors.Is(err, blobstore.ErrInvalidKey) {
+			return nil, customerror.NewCustomErrorWithHttpCode(kterrors.MediaNotFoundError, http.StatusNotFound)
+		}
+		return nil, err
+	}
+	return blob, nil
+}
@@ -0,0 +1,91 @@
+package media
+
+import (
+	"KTOnlinePlatform/internal/dto"
+	"KTOnlinePlatform/internal/models/kterrors"
+	"KTOnlinePlatform/pkg/blobstore"
+	"KTOnlinePlatform/pkg/customerror"
+	"KTOnlinePlatform/pkg/logger"
+	"KTOnlinePlatform/pkg/mediasign"
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testKey = "posters/1/abc/small.jpg"
+
+// expiredVerifier stands in for a signer whose URL is past its expiry
+type expiredVerifier struct{}
+
+func (expiredVerifier) Verify(key string, query url.Values) error {
+	return mediasign.ErrExpired
+}
+
+func signedQuery(signer *mediasign.Signer, key string) url.Values {
+	signed, _ := url.Parse(signer.URL(key, time.Hour))
+	return signed.Query()
+}
+
+func TestOpenMedia(t *testing.T) {
+	logger.InitializeForTest()
+	signer := mediasign.NewSigner([]string{"test-media-key"}, "/media")
+
+	testCases := []struct {
+		name            string
+		verifier        URLVerifier
+		request         dto.MediaRequest
+		expectedContent string
+		expectedError   error
+	}{
+		{
+			name:            "Signed url",
+			verifier:        signer,
+			request:         dto.MediaRequest{Key: testKey, Query: signedQuery(signer, testKey)},
+			expectedContent: "poster",
+		},
+		{
+			name:          "Signature of another blob",
+			verifier:      signer,
+			request:       dto.MediaRequest{Key: testKey, Query: signedQuery(signer, "posters/2/abc/small.jpg")},
+			expectedError: customerror.NewCustomErrorWithHttpCode(kterrors.InvalidMediaSignatureError, http.StatusForbidden),
+		},
+		{
+			name:          "Expired url",
+			verifier:      expiredVerifier{},
+			request:       dto.MediaRequest{Key: testKey},
+			expectedError: customerror.NewCustomErrorWithHttpCode(kterrors.MediaURLExpiredError, http.StatusForbidden),
+		},
+		{
+			name:          "Blob removed since the url was signed",
+			verifier:      signer,
+			request:       dto.MediaRequest{Key: "posters/1/old/small.jpg", Query: signedQuery(signer, "posters/1/old/small.jpg")},
+			expectedError: customerror.NewCustomErrorWithHttpCode(kterrors.MediaNotFoundError, http.StatusNotFound),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			store := blobstore.NewLocalStore(t.TempDir())
+			_ = store.Put(context.Background(), testKey, "image/jpeg", strings.NewReader("poster"), 6)
+			service := NewService(store, tc.verifier)
+
+			blob, err := service.OpenMedia(context.Background(), tc.request)
+
+			if tc.expectedError != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tc.expectedError.Error(), err.Error())
+				return
+			}
+			assert.NoError(t, err)
+			content, _ := io.ReadAll(blob)
+			_ = blob.Close()
+			assert.Equal(t, tc.expectedContent, string(content))
+		})
+	}
+}
@@ -0,0 +1,229 @@
+// Code generated by mockery v2.52.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	entities "KTOnlinePlatform/pkg/database/entities"
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "KTOnlinePlatform/internal/models"
+)
+
+// Repository is an autogenerated mock type for the Repository type
+type Repository struct {
+	mock.Mock
+}
+
+// ClaimReport provides a mock function with given fields: ctx, reportID, action
+func (_m *Repository) ClaimReport(ctx context.Context, reportID int, action entities.ModerationAction) error {
+	ret := _m.Called(ctx, reportID, action)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClaimReport")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, entities.ModerationAction) error); ok {
+		r0 = rf(ctx, reportID, action)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateReport provides a mock function with given fields: ctx, report
+func (_m *Repository) CreateReport(ctx context.Context, report entities.Report) error {
+	ret := _m.Called(ctx, report)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateReport")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entities.Report) error); ok {
+		r0 = rf(ctx, report)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetAuditTrail provides a mock function with given fields: ctx, moderatorID, pageSize, offset
+func (_m *Repository) GetAuditTrail(ctx context.Context, moderatorID int, pageSize int, offset int) ([]models.ModerationActionPaginated, error) {
+	ret := _m.Called(ctx, moderatorID, pageSize, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAuditTrail")
+	}
+
+	var r0 []models.ModerationActionPaginated
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, int) ([]models.ModerationActionPaginated, error)); ok {
+		return rf(ctx, moderatorID, pageSize, offset)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, int) []models.ModerationActionPaginated); ok {
+		r0 = rf(ctx, moderatorID, pageSize, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.ModerationActionPaginated)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int, int) error); ok {
+		r1 = rf(ctx, moderatorID, pageSize, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetReport provides a mock function with given fields: ctx, ID
+func (_m *Repository) GetReport(ctx context.Context, ID int) (entities.Report, error) {
+	ret := _m.Called(ctx, ID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReport")
+	}
+
+	var r0 entities.Report
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (entities.Report, error)); ok {
+		return rf(ctx, ID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) entities.Report); ok {
+		r0 = rf(ctx, ID)
+	} else {
+		r0 = ret.Get(0).(entities.Report)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, ID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetReportsPaginated provides a mock function with given fields: ctx, statuses, pageSize, offset
+func (_m *Repository) GetReportsPaginated(ctx context.Context, statuses []string, pageSize int, offset int) ([]models.ReportPaginated, error) {
+	ret := _m.Called(ctx, statuses, pageSize, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReportsPaginated")
+	}
+
+	var r0 []models.ReportPaginated
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string, int, int) ([]models.ReportPaginated, error)); ok {
+		return rf(ctx, statuses, pageSize, offset)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string, int, int) []models.ReportPaginated); ok {
+		r0 = rf(ctx, statuses, pageSize, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.ReportPaginated)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string, int, int) error); ok {
+		r1 = rf(ctx, statuses, pageSize, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTargetOwner provides a mock function with given fields: ctx, targetType, targetID
+func (_m *Repository) GetTargetOwner(ctx context.Context, targetType string, targetID int) (int, error) {
+	ret := _m.Called(ctx, targetType, targetID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTargetOwner")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) (int, error)); ok {
+		return rf(ctx, targetType, targetID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) int); ok {
+		r0 = rf(ctx, targetType, targetID)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = rf(ctx, targetType, targetID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUser provides a mock function with given fields: ctx, ID
+func (_m *Repository) GetUser(ctx context.Context, ID int) (entities.User, error) {
+	ret := _m.Called(ctx, ID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUser")
+	}
+
+	var r0 entities.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (entities.User, error)); ok {
+		return rf(ctx, ID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) entities.User); ok {
+		r0 = rf(ctx, ID)
+	} else {
+		r0 = ret.Get(0).(entities.User)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, ID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ResolveReport provides a mock function with given fields: ctx, effect, action
+func (_m *Repository) ResolveReport(ctx context.Context, effect models.ModerationEffect, action entities.ModerationAction) error {
+	ret := _m.Called(ctx, effect, action)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResolveReport")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.ModerationEffect, entities.ModerationAction) error); ok {
+		r0 = rf(ctx, effect, action)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewRepository creates a new instance of Repository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Repository {
+	mock := &Repository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,261 @@
+package moderation
+
+import (
+	"KTOnlinePlatform/internal/dto"
+	"KTOnlinePlatform/internal/models"
+	"KTOnlinePlatform/internal/models/consts"
+	"KTOnlinePlatform/internal/models/kterrors"
+	"KTOnlinePlatform/pkg/customerror"
+	"KTOnlinePlatform/pkg/database/entities"
+	"KTOnlinePlatform/pkg/utils"
+	"context"
+	"github.com/samber/lo"
+	"net/http"
+)
+
+type Repository interface {
+	GetUser(ctx context.Context, ID int) (entities.User, error)
+	GetTargetOwner(ctx context.Context, targetType string, targetID int) (int, error)
+	CreateReport(ctx context.Context, report entities.Report) error
+	GetReport(ctx context.Context, ID int) (entities.Report, error)
+	GetReportsPaginated(ctx context.Context, statuses []string, pageSize int, offset int) ([]models.ReportPaginated, error)
+	ClaimReport(ctx context.Context, reportID int, action entities.ModerationAction) error
+	ResolveReport(ctx context.Context, effect models.ModerationEffect, action entities.ModerationAction) error
+	GetAuditTrail(ctx context.Context, moderatorID int, pageSize int, offset int) ([]models.ModerationActionPaginated, error)
+}
+
+type Service struct {
+	repo Repository
+}
+
+func NewService(repo Repository) *Service {
+	return &Service{
+		repo: repo,
+	}
+}
+
+// CreateReport flags a film, a review or a user for the moderators, any user can report
+func (s *Service) CreateReport(ctx context.Context, request dto.ReportCreateRequest) error {
+	_, err := s.repo.GetTargetOwner(ctx, request.TargetType, request.TargetID)
+	if err != nil {
+		if customerror.IsNotFoundError(err) {
+			return customerror.NewCustomErrorWithHttpCode(kterrors.ReportTargetNotFoundError, http.StatusNotFound)
+		}
+		return err
+	}
+	err = s.repo.CreateReport(ctx, entities.Report{
+		ReporterID: request.UserID,
+		TargetType: request.TargetType,
+		TargetID:   request.TargetID,
+		Reason:     request.Reason,
+	})
+	if err != nil {
+		if customerror.IsUniqueViolation(err) {
+			return customerror.NewCustomError(kterrors.ReportAlreadyExistsError)
+		}
+		return err
+	}
+	return nil
+}
+
+// GetReportsPaginated is the moderation queue, oldest first. Without a status it lists
+// every report that is not resolved yet
+func (s *Service) GetReportsPaginated(ctx context.Context, request dto.ReportSearchRequest) (dto.ReportsPaginated, error) {
+	err := s.requireModerator(ctx, request.UserID)
+	if err != nil {
+		return dto.ReportsPaginated{}, err
+	}
+	statuses := []string{consts.ReportStatusOpen, consts.ReportStatusClaimed}
+	switch request.Status {
+	case "":
+	case consts.ReportStatusOpen, consts.ReportStatusClaimed, consts.ReportStatusResolved:
+		statuses = []string{request.Status}
+	default:
+		return dto.ReportsPaginated{}, customerror.NewI18nErrorWithParams(
+			kterrors.InvalidReportStatusError,
+			map[string]interface{}{"status": request.Status})
+	}
+	result, err := s.repo.GetReportsPaginated(ctx, statuses, request.PageSize, utils.CalculateOffset(request.Page, request.PageSize))
+	if err != nil {
+		return dto.ReportsPaginated{}, err
+	}
+	if len(result) == 0 {
+		return dto.ReportsPaginated{
+			Page:     request.Page,
+			PageSize: request.PageSize,
+		}, nil
+	}
+	reports := lo.Map(result, func(item models.ReportPaginated, index int) dto.Report {
+		return dto.Report{
+			ID:                item.ID,
+			ReporterID:        item.ReporterID,
+			ReporterUsername:  item.ReporterUsername,
+			TargetType:        item.TargetType,
+			TargetID:          item.TargetID,
+			Reason:            item.Reason,
+			Status:            item.Status,
+			ModeratorID:       item.ModeratorID,
+			ModeratorUsername: item.ModeratorUsername,
+			Resolution:        item.Resolution,
+			CreatedAt:         item.CreatedAt,
+			UpdatedAt:         item.UpdatedAt,
+		}
+	})
+	return dto.ReportsPaginated{
+		Reports:  reports,
+		Count:    result[0].Qty,
+		Page:     request.Page,
+		PageSize: request.PageSize,
+	}, nil
+}
+
+// ClaimReport takes an open report out of the queue for the moderator, so two moderators
+// never handle the same report
+func (s *Service) ClaimReport(ctx context.Context, reportID int, userID int) error {
+	err := s.requireModerator(ctx, userID)
+	if err != nil {
+		return err
+	}
+	report, err := s.getReport(ctx, reportID)
+	if err != nil {
+		return err
+	}
+	if report.Status != consts.ReportStatusOpen {
+		return newReportNotOpenError()
+	}
+	err = s.repo.ClaimReport(ctx, reportID, entities.ModerationAction{
+		ModeratorID: userID,
+		ReportID:    &report.ID,
+		Action:      consts.ModerationActionClaim,
+		TargetType:  report.TargetType,
+		TargetID:    report.TargetID,
+	})
+	if customerror.IsNotFoundError(err) {
+		return newReportNotOpenError()
+	}
+	return err
+}
+
+// ResolveReport closes a report claimed by the moderator with one of the moderation actions.
+// Hiding applies to films and reviews, suspending applies to the author of the reported content
+func (s *Service) ResolveReport(ctx context.Context, request dto.ReportResolveRequest) error {
+	err := s.requireModerator(ctx, request.UserID)
+	if err != nil {
+		return err
+	}
+	report, err := s.getReport(ctx, request.ReportID)
+	if err != nil {
+		return err
+	}
+	if report.Status != consts.ReportStatusClaimed || report.ModeratorID == nil || *report.ModeratorID != request.UserID {
+		return newReportNotClaimedByUserError()
+	}
+	effect, err := s.moderationEffect(ctx, report, request.Action)
+	if err != nil {
+		return err
+	}
+	err = s.repo.ResolveReport(ctx, effect, entities.ModerationAction{
+		ModeratorID: request.UserID,
+		ReportID:    &report.ID,
+		Action:      request.Action,
+		TargetType:  report.TargetType,
+		TargetID:    report.TargetID,
+		Note:        request.Note,
+	})
+	if customerror.IsNotFoundError(err) {
+		return newReportNotClaimedByUserError()
+	}
+	return err
+}
+
+func (s *Service) GetAuditTrail(ctx context.Context, request dto.AuditSearchRequest) (dto.AuditTrail, error) {
+	err := s.requireModerator(ctx, request.UserID)
+	if err != nil {
+		return dto.AuditTrail{}, err
+	}
+	result, err := s.repo.GetAuditTrail(ctx, request.ModeratorID, request.PageSize, utils.CalculateOffset(request.Page, request.PageSize))
+	if err != nil {
+		return dto.AuditTrail{}, err
+	}
+	if len(result) == 0 {
+		return dto.AuditTrail{
+			Page:     request.Page,
+			PageSize: request.PageSize,
+		}, nil
+	}
+	actions := lo.Map(result, func(item models.ModerationActionPaginated, index int) dto.ModerationAction {
+		return dto.ModerationAction{
+			ID:                item.ID,
+			ModeratorID:       item.ModeratorID,
+			ModeratorUsername: item.ModeratorUsername,
+			ReportID:          item.ReportID,
+			Action:            item.Action,
+			TargetType:        item.TargetType,
+			TargetID:          item.TargetID,
+			Note:              item.Note,
+			CreatedAt:         item.CreatedAt,
+		}
+	})
+	return dto.AuditTrail{
+		Actions:  actions,
+		Count:    result[0].Qty,
+		Page:     request.Page,
+		PageSize: request.PageSize,
+	}, nil
+}
+
+func (s *Service) moderationEffect(ctx context.Context, report entities.Report, action string) (models.ModerationEffect, error) {
+	switch action {
+	case consts.ModerationActionDismiss:
+		return models.ModerationEffect{}, nil
+	case consts.ModerationActionHideContent:
+		switch report.TargetType {
+		case consts.ReportTargetFilm:
+			return models.ModerationEffect{HideFilmID: report.TargetID}, nil
+		case consts.ReportTargetReview:
+			return models.ModerationEffect{HideReviewID: report.TargetID}, nil
+		}
+	case consts.ModerationActionSuspendUser:
+		ownerID, err := s.repo.GetTargetOwner(ctx, report.TargetType, report.TargetID)
+		if err != nil {
+			if customerror.IsNotFoundError(err) {
+				return models.ModerationEffect{}, customerror.NewCustomErrorWithHttpCode(kterrors.ReportTargetNotFoundError, http.StatusNotFound)
+			}
+			return models.ModerationEffect{}, err
+		}
+		return models.ModerationEffect{SuspendUserID: ownerID}, nil
+	}
+	return models.ModerationEffect{}, customerror.NewI18nErrorWithParams(
+		kterrors.ModerationActionNotAllowedError,
+		map[string]interface{}{"action": action, "targetType": report.TargetType})
+}
+
+func (s *Service) requireModerator(ctx context.Context, userID int) error {
+	user, err := s.repo.GetUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user.Role != consts.UserRoleModerator {
+		return customerror.NewCustomErrorWithHttpCode(kterrors.UserNotModeratorError, http.StatusForbidden)
+	}
+	return nil
+}
+
+func (s *Service) getReport(ctx context.Context, reportID int) (entities.Report, error) {
+	report, err := s.repo.GetReport(ctx, reportID)
+	if err != nil {
+		if customerror.IsNotFoundError(err) {
+			return entities.Report{}, customerror.NewCustomErrorWithHttpCode(kterrors.ReportNotFoundError, http.StatusNotFound)
+		}
+		return entities.Report{}, err
+	}
+	return report, nil
+}
+
+func newReportNotOpenError() error {
+	return customerror.NewCustomErrorWithHttpCode(kterrors.ReportNotOpenError, http.StatusConflict)
+}
+
+func newReportNotClaimedByUserError() error {
+	return customerror.NewCustomErrorWithHttpCode(kterrors.ReportNotClaimedByUserError, http.StatusConflict)
+}
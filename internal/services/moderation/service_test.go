@@ -0,0 +1,335 @@
+package moderation
+
+import (
+	"KTOnlinePlatform/internal/dto"
+	"KTOnlinePlatform/internal/models"
+	"KTOnlinePlatform/internal/models/consts"
+	"KTOnlinePlatform/internal/models/kterrors"
+	"KTOnlinePlatform/internal/services/moderation/mocks"
+	"KTOnlinePlatform/pkg/customerror"
+	"KTOnlinePlatform/pkg/database/entities"
+	"KTOnlinePlatform/pkg/logger"
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+const (
+	moderatorID = 7
+	reporterID  = 100
+	authorID    = 200
+)
+
+func userID(id int) *int {
+	return &id
+}
+
+func expectModerator(mr *mocks.Repository) {
+	mr.On("GetUser", mock.Anything, moderatorID).Return(entities.User{ID: moderatorID, Role: consts.UserRoleModerator}, nil)
+}
+
+func TestCreateReport(t *testing.T) {
+	logger.InitializeForTest()
+	request := dto.ReportCreateRequest{TargetType: consts.ReportTargetFilm, TargetID: 1, Reason: "Spam synopsis", UserID: reporterID}
+	report := entities.Report{ReporterID: reporterID, TargetType: consts.ReportTargetFilm, TargetID: 1, Reason: "Spam synopsis"}
+
+	testCases := []struct {
+		name          string
+		mockBehavior  func(*mocks.Repository)
+		expectedError error
+	}{
+		{
+			name: "Film is reported",
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("GetTargetOwner", mock.Anything, consts.ReportTargetFilm, 1).Return(authorID, nil)
+				mr.On("CreateReport", mock.Anything, report).Return(nil)
+			},
+		},
+		{
+			name: "Reported film does not exist",
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("GetTargetOwner", mock.Anything, consts.ReportTargetFilm, 1).Return(0, gorm.ErrRecordNotFound)
+			},
+			expectedError: customerror.NewCustomErrorWithHttpCode(kterrors.ReportTargetNotFoundError, http.StatusNotFound),
+		},
+		{
+			name: "Same target reported twice",
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("GetTargetOwner", mock.Anything, consts.ReportTargetFilm, 1).Return(authorID, nil)
+				mr.On("CreateReport", mock.Anything, report).Return(gorm.ErrDuplicatedKey)
+			},
+			expectedError: customerror.NewCustomError(kterrors.ReportAlreadyExistsError),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := mocks.NewRepository(t)
+			tc.mockBehavior(mockRepo)
+			service := NewService(mockRepo)
+
+			err := service.CreateReport(context.Background(), request)
+
+			if tc.expectedError != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tc.expectedError.Error(), err.Error())
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestGetReportsPaginated(t *testing.T) {
+	logger.InitializeForTest()
+
+	testCases := []struct {
+		name           string
+		request        dto.ReportSearchRequest
+		mockBehavior   func(*mocks.Repository)
+		expectedResult dto.ReportsPaginated
+		expectedError  error
+	}{
+		{
+			name:    "Unresolved reports by default",
+			request: dto.ReportSearchRequest{Page: 1, PageSize: 10, UserID: moderatorID},
+			mockBehavior: func(mr *mocks.Repository) {
+				expectModerator(mr)
+				mr.On("GetReportsPaginated", mock.Anything, []string{consts.ReportStatusOpen, consts.ReportStatusClaimed}, 10, 0).Return(
+					[]models.ReportPaginated{
+						{ID: 3, ReporterID: reporterID, ReporterUsername: "alice", TargetType: consts.ReportTargetUser, TargetID: authorID, Reason: "Abuse", Status: consts.ReportStatusOpen, Qty: 1},
+					}, nil)
+			},
+			expectedResult: dto.ReportsPaginated{
+				Reports: []dto.Report{
+					{ID: 3, ReporterID: reporterID, ReporterUsername: "alice", TargetType: consts.ReportTargetUser, TargetID: authorID, Reason: "Abuse", Status: consts.ReportStatusOpen},
+				},
+				Count:    1,
+				Page:     1,
+				PageSize: 10,
+			},
+		},
+		{
+			name:    "Regular users cannot see the queue",
+			request: dto.ReportSearchRequest{Page: 1, PageSize: 10, UserID: reporterID},
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("GetUser", mock.Anything, reporterID).Return(entities.User{ID: reporterID, Role: consts.UserRoleUser}, nil)
+			},
+			expectedError: customerror.NewCustomErrorWithHttpCode(kterrors.UserNotModeratorError, http.StatusForbidden),
+		},
+		{
+			name:    "Unknown status",
+			request: dto.ReportSearchRequest{Status: "DONE", Page: 1, PageSize: 10, UserID: moderatorID},
+			mockBehavior: func(mr *mocks.Repository) {
+				expectModerator(mr)
+			},
+			expectedError: customerror.NewI18nErrorWithParams(
+				kterrors.InvalidReportStatusError,
+				map[string]interface{}{"status": "DONE"}),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := mocks.NewRepository(t)
+			tc.mockBehavior(mockRepo)
+			service := NewService(mockRepo)
+
+			result, err := service.GetReportsPaginated(context.Background(), tc.request)
+
+			if tc.expectedError != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tc.expectedError.Error(), err.Error())
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedResult, result)
+		})
+	}
+}
+
+func TestClaimReport(t *testing.T) {
+	logger.InitializeForTest()
+	report := entities.Report{ID: 3, ReporterID: reporterID, TargetType: consts.ReportTargetFilm, TargetID: 1}
+	claim := entities.ModerationAction{
+		ModeratorID: moderatorID,
+		ReportID:    userID(3),
+		Action:      consts.ModerationActionClaim,
+		TargetType:  consts.ReportTargetFilm,
+		TargetID:    1,
+	}
+
+	testCases := []struct {
+		name          string
+		mockBehavior  func(*mocks.Repository)
+		expectedError error
+	}{
+		{
+			name: "Open report is claimed",
+			mockBehavior: func(mr *mocks.Repository) {
+				expectModerator(mr)
+				open := report
+				open.Status = consts.ReportStatusOpen
+				mr.On("GetReport", mock.Anything, 3).Return(open, nil)
+				mr.On("ClaimReport", mock.Anything, 3, claim).Return(nil)
+			},
+		},
+		{
+			name: "Report already claimed",
+			mockBehavior: func(mr *mocks.Repository) {
+				expectModerator(mr)
+				claimed := report
+				claimed.Status = consts.ReportStatusClaimed
+				mr.On("GetReport", mock.Anything, 3).Return(claimed, nil)
+			},
+			expectedError: customerror.NewCustomErrorWithHttpCode(kterrors.ReportNotOpenError, http.StatusConflict),
+		},
+		{
+			name: "Another moderator claims it first",
+			mockBehavior: func(mr *mocks.Repository) {
+				expectModerator(mr)
+				open := report
+				open.Status = consts.ReportStatusOpen
+				mr.On("GetReport", mock.Anything, 3).Return(open, nil)
+				mr.On("ClaimReport", mock.Anything, 3, claim).Return(gorm.ErrRecordNotFound)
+			},
+			expectedError: customerror.NewCustomErrorWithHttpCode(kterrors.ReportNotOpenError, http.StatusConflict),
+		},
+		{
+			name: "Report not found",
+			mockBehavior: func(mr *mocks.Repository) {
+				expectModerator(mr)
+				mr.On("GetReport", mock.Anything, 3).Return(entities.Report{}, gorm.ErrRecordNotFound)
+			},
+			expectedError: customerror.NewCustomErrorWithHttpCode(kterrors.ReportNotFoundError, http.StatusNotFound),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := mocks.NewRepository(t)
+			tc.mockBehavior(mockRepo)
+			service := NewService(mockRepo)
+
+			err := service.ClaimReport(context.Background(), 3, moderatorID)
+
+			if tc.expectedError != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tc.expectedError.Error(), err.Error())
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestResolveReport(t *testing.T) {
+	logger.InitializeForTest()
+	claimedReport := func(targetType string, targetID int) entities.Report {
+		return entities.Report{
+			ID:          3,
+			ReporterID:  reporterID,
+			TargetType:  targetType,
+			TargetID:    targetID,
+			Status:      consts.ReportStatusClaimed,
+			ModeratorID: userID(moderatorID),
+		}
+	}
+	audit := func(action string, targetType string, targetID int) entities.ModerationAction {
+		return entities.ModerationAction{
+			ModeratorID: moderatorID,
+			ReportID:    userID(3),
+			Action:      action,
+			TargetType:  targetType,
+			TargetID:    targetID,
+			Note:        "checked",
+		}
+	}
+
+	testCases := []struct {
+		name          string
+		action        string
+		mockBehavior  func(*mocks.Repository)
+		expectedError error
+	}{
+		{
+			name:   "Dismiss",
+			action: consts.ModerationActionDismiss,
+			mockBehavior: func(mr *mocks.Repository) {
+				expectModerator(mr)
+				mr.On("GetReport", mock.Anything, 3).Return(claimedReport(consts.ReportTargetFilm, 1), nil)
+				mr.On("ResolveReport", mock.Anything, models.ModerationEffect{},
+					audit(consts.ModerationActionDismiss, consts.ReportTargetFilm, 1)).Return(nil)
+			},
+		},
+		{
+			name:   "Hide a review",
+			action: consts.ModerationActionHideContent,
+			mockBehavior: func(mr *mocks.Repository) {
+				expectModerator(mr)
+				mr.On("GetReport", mock.Anything, 3).Return(claimedReport(consts.ReportTargetReview, 4), nil)
+				mr.On("ResolveReport", mock.Anything, models.ModerationEffect{HideReviewID: 4},
+					audit(consts.ModerationActionHideContent, consts.ReportTargetReview, 4)).Return(nil)
+			},
+		},
+		{
+			name:   "Suspend the author of a film",
+			action: consts.ModerationActionSuspendUser,
+			mockBehavior: func(mr *mocks.Repository) {
+				expectModerator(mr)
+				mr.On("GetReport", mock.Anything, 3).Return(claimedReport(consts.ReportTargetFilm, 1), nil)
+				mr.On("GetTargetOwner", mock.Anything, consts.ReportTargetFilm, 1).Return(authorID, nil)
+				mr.On("ResolveReport", mock.Anything, models.ModerationEffect{SuspendUserID: authorID},
+					audit(consts.ModerationActionSuspendUser, consts.ReportTargetFilm, 1)).Return(nil)
+			},
+		},
+		{
+			name:   "A user cannot be hidden",
+			action: consts.ModerationActionHideContent,
+			mockBehavior: func(mr *mocks.Repository) {
+				expectModerator(mr)
+				mr.On("GetReport", mock.Anything, 3).Return(claimedReport(consts.ReportTargetUser, authorID), nil)
+			},
+			expectedError: customerror.NewI18nErrorWithParams(
+				kterrors.ModerationActionNotAllowedError,
+				map[string]interface{}{"action": consts.ModerationActionHideContent, "targetType": consts.ReportTargetUser}),
+		},
+		{
+			name:   "Report claimed by another moderator",
+			action: consts.ModerationActionDismiss,
+			mockBehavior: func(mr *mocks.Repository) {
+				expectModerator(mr)
+				report := claimedReport(consts.ReportTargetFilm, 1)
+				report.ModeratorID = userID(8)
+				mr.On("GetReport", mock.Anything, 3).Return(report, nil)
+			},
+			expectedError: customerror.NewCustomErrorWithHttpCode(kterrors.ReportNotClaimedByUserError, http.StatusConflict),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := mocks.NewRepository(t)
+			tc.mockBehavior(mockRepo)
+			service := NewService(mockRepo)
+
+			err := service.ResolveReport(context.Background(), dto.ReportResolveRequest{
+				ReportID: 3,
+				Action:   tc.action,
+				Note:     "checked",
+				UserID:   moderatorID,
+			})
+
+			if tc.expectedError != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tc.expectedError.Error(), err.Error())
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
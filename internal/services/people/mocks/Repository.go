@@ -0,0 +1,201 @@
+// Code generated by mockery v2.52.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	entities "KTOnlinePlatform/pkg/database/entities"
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "KTOnlinePlatform/internal/models"
+)
+
+// Repository is an autogenerated mock type for the Repository type
+type Repository struct {
+	mock.Mock
+}
+
+// CreatePerson provides a mock function with given fields: ctx, person
+func (_m *Repository) CreatePerson(ctx context.Context, person entities.Person) error {
+	ret := _m.Called(ctx, person)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreatePerson")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entities.Person) error); ok {
+		r0 = rf(ctx, person)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeletePerson provides a mock function with given fields: ctx, id
+func (_m *Repository) DeletePerson(ctx context.Context, id int) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeletePerson")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetFilmography provides a mock function with given fields: ctx, personID
+func (_m *Repository) GetFilmography(ctx context.Context, personID int) ([]models.FilmographyEntry, error) {
+	ret := _m.Called(ctx, personID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFilmography")
+	}
+
+	var r0 []models.FilmographyEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]models.FilmographyEntry, error)); ok {
+		return rf(ctx, personID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []models.FilmographyEntry); ok {
+		r0 = rf(ctx, personID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.FilmographyEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, personID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPeoplePaginated provides a mock function with given fields: ctx, name, pageSize, offset
+func (_m *Repository) GetPeoplePaginated(ctx context.Context, name string, pageSize int, offset int) ([]models.PersonPaginated, error) {
+	ret := _m.Called(ctx, name, pageSize, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPeoplePaginated")
+	}
+
+	var r0 []models.PersonPaginated
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) ([]models.PersonPaginated, error)); ok {
+		return rf(ctx, name, pageSize, offset)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) []models.PersonPaginated); ok {
+		r0 = rf(ctx, name, pageSize, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.PersonPaginated)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int, int) error); ok {
+		r1 = rf(ctx, name, pageSize, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPerson provides a mock function with given fields: ctx, ID
+func (_m *Repository) GetPerson(ctx context.Context, ID int) (entities.Person, error) {
+	ret := _m.Called(ctx, ID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPerson")
+	}
+
+	var r0 entities.Person
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (entities.Person, error)); ok {
+		return rf(ctx, ID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) entities.Person); ok {
+		r0 = rf(ctx, ID)
+	} else {
+		r0 = ret.Get(0).(entities.Person)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, ID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUser provides a mock function with given fields: ctx, ID
+func (_m *Repository) GetUser(ctx context.Context, ID int) (entities.User, error) {
+	ret := _m.Called(ctx, ID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUser")
+	}
+
+	var r0 entities.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (entities.User, error)); ok {
+		return rf(ctx, ID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) entities.User); ok {
+		r0 = rf(ctx, ID)
+	} else {
+		r0 = ret.Get(0).(entities.User)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, ID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdatePerson provides a mock function with given fields: ctx, person
+func (_m *Repository) UpdatePerson(ctx context.Context, person entities.Person) error {
+	ret := _m.Called(ctx, person)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdatePerson")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entities.Person) error); ok {
+		r0 = rf(ctx, person)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewRepository creates a new instance of Repository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Repository {
+	mock := &Repository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,157 @@
+package people
+
+import (
+	"KTOnlinePlatform/internal/dto"
+	"KTOnlinePlatform/internal/models"
+	"KTOnlinePlatform/internal/models/consts"
+	"KTOnlinePlatform/internal/models/kterrors"
+	"KTOnlinePlatform/pkg/customerror"
+	"KTOnlinePlatform/pkg/database/entities"
+	"KTOnlinePlatform/pkg/utils"
+	"context"
+	"github.com/samber/lo"
+)
+
+type Repository interface {
+	GetPeoplePaginated(ctx context.Context, name string, pageSize int, offset int) ([]models.PersonPaginated, error)
+	GetPerson(ctx context.Context, ID int) (entities.Person, error)
+	CreatePerson(ctx context.Context, person entities.Person) error
+	UpdatePerson(ctx context.Context, person entities.Person) error
+	DeletePerson(ctx context.Context, id int) error
+	GetFilmography(ctx context.Context, personID int) ([]models.FilmographyEntry, error)
+	GetUser(ctx context.Context, ID int) (entities.User, error)
+}
+
+type Service struct {
+	repo Repository
+}
+
+func NewService(repo Repository) *Service {
+	return &Service{
+		repo: repo,
+	}
+}
+
+func (s *Service) GetPeoplePaginated(ctx context.Context, request dto.PeopleSearchRequest) (dto.PeoplePaginated, error) {
+	offset := utils.CalculateOffset(request.Page, request.PageSize)
+	result, err := s.repo.GetPeoplePaginated(ctx, request.Name, request.PageSize, offset)
+	if err != nil {
+		return dto.PeoplePaginated{}, err
+	}
+	if len(result) == 0 {
+		return dto.PeoplePaginated{
+			Page:     request.Page,
+			PageSize: request.PageSize,
+		}, nil
+	}
+	people := lo.Map(result, func(item models.PersonPaginated, index int) dto.Person {
+		return dto.Person{
+			ID:   item.ID,
+			Name: item.Name,
+		}
+	})
+	return dto.PeoplePaginated{
+		People:   people,
+		Count:    result[0].Qty,
+		Page:     request.Page,
+		PageSize: request.PageSize,
+	}, nil
+}
+
+func (s *Service) GetPerson(ctx context.Context, ID int) (dto.Person, error) {
+	person, err := s.repo.GetPerson(ctx, ID)
+	if err != nil {
+		return dto.Person{}, err
+	}
+	return toPersonDTO(person), nil
+}
+
+func (s *Service) CreatePerson(ctx context.Context, request dto.PersonCreateRequest) error {
+	return s.repo.CreatePerson(ctx, entities.Person{
+		Name:      request.Name,
+		Biography: request.Biography,
+		UserID:    &request.UserID,
+	})
+}
+
+// UpdatePerson lets the creator edit a person, or a moderator when the person has no creator
+func (s *Service) UpdatePerson(ctx context.Context, request dto.PersonUpdateRequest) error {
+	person, err := s.repo.GetPerson(ctx, request.ID)
+	if err != nil {
+		return err
+	}
+	allowed, err := s.canChange(ctx, person, request.UserID)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return customerror.NewCustomError(kterrors.UserCannotUpdatePersonError)
+	}
+	person.Name = request.Name
+	person.Biography = request.Biography
+	return s.repo.UpdatePerson(ctx, person)
+}
+
+// DeletePerson also removes the credits of the person, the same users as UpdatePerson can do it
+func (s *Service) DeletePerson(ctx context.Context, personID int, userID int) error {
+	person, err := s.repo.GetPerson(ctx, personID)
+	if err != nil {
+		return err
+	}
+	allowed, err := s.canChange(ctx, person, userID)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return customerror.NewCustomError(kterrors.UserCannotDeletePersonError)
+	}
+	return s.repo.DeletePerson(ctx, personID)
+}
+
+// canChange tells whether the user is the creator of the person. People backfilled from the old
+// director column have no creator, only the moderators can change them
+func (s *Service) canChange(ctx context.Context, person entities.Person, userID int) (bool, error) {
+	if person.UserID != nil {
+		return *person.UserID == userID, nil
+	}
+	user, err := s.repo.GetUser(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	return user.Role == consts.UserRoleModerator, nil
+}
+
+func (s *Service) GetFilmography(ctx context.Context, personID int) (dto.Filmography, error) {
+	person, err := s.repo.GetPerson(ctx, personID)
+	if err != nil {
+		return dto.Filmography{}, err
+	}
+	entries, err := s.repo.GetFilmography(ctx, personID)
+	if err != nil {
+		return dto.Filmography{}, err
+	}
+	return dto.Filmography{
+		Person: toPersonDTO(person),
+		Credits: lo.Map(entries, func(item models.FilmographyEntry, index int) dto.FilmographyEntry {
+			entry := dto.FilmographyEntry{
+				FilmID:        item.FilmID,
+				Title:         item.Title,
+				Role:          item.Role,
+				CharacterName: item.CharacterName,
+			}
+			if !item.ReleaseDate.IsZero() {
+				releaseDate := item.ReleaseDate
+				entry.ReleaseDate = &releaseDate
+			}
+			return entry
+		}),
+	}, nil
+}
+
+func toPersonDTO(person entities.Person) dto.Person {
+	return dto.Person{
+		ID:        person.ID,
+		Name:      person.Name,
+		Biography: person.Biography,
+	}
+}
@@ -0,0 +1,193 @@
+package people
+
+import (
+	"KTOnlinePlatform/internal/dto"
+	"KTOnlinePlatform/internal/models"
+	"KTOnlinePlatform/internal/models/consts"
+	"KTOnlinePlatform/internal/models/kterrors"
+	"KTOnlinePlatform/internal/services/people/mocks"
+	"KTOnlinePlatform/pkg/customerror"
+	"KTOnlinePlatform/pkg/database/entities"
+	"KTOnlinePlatform/pkg/database/entities/entitiescustom"
+	"KTOnlinePlatform/pkg/logger"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func ownerID(id int) *int {
+	return &id
+}
+
+func TestUpdatePerson(t *testing.T) {
+	logger.InitializeForTest()
+
+	testCases := []struct {
+		name          string
+		request       dto.PersonUpdateRequest
+		mockBehavior  func(*mocks.Repository)
+		expectedError error
+	}{
+		{
+			name:    "Creator updates the person",
+			request: dto.PersonUpdateRequest{ID: 1, Name: "Christopher Nolan", UserID: 100},
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("GetPerson", mock.Anything, 1).Return(entities.Person{ID: 1, Name: "Nolan", UserID: ownerID(100)}, nil)
+				mr.On("UpdatePerson", mock.Anything, entities.Person{ID: 1, Name: "Christopher Nolan", UserID: ownerID(100)}).Return(nil)
+			},
+		},
+		{
+			name:    "Moderator corrects a backfilled person",
+			request: dto.PersonUpdateRequest{ID: 1, Name: "Christopher Nolan", UserID: 200},
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("GetPerson", mock.Anything, 1).Return(entities.Person{ID: 1, Name: "Nolan"}, nil)
+				mr.On("GetUser", mock.Anything, 200).Return(entities.User{ID: 200, Role: consts.UserRoleModerator}, nil)
+				mr.On("UpdatePerson", mock.Anything, entities.Person{ID: 1, Name: "Christopher Nolan"}).Return(nil)
+			},
+		},
+		{
+			name:    "Backfilled person cannot be updated by a user",
+			request: dto.PersonUpdateRequest{ID: 1, Name: "Christopher Nolan", UserID: 200},
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("GetPerson", mock.Anything, 1).Return(entities.Person{ID: 1, Name: "Nolan"}, nil)
+				mr.On("GetUser", mock.Anything, 200).Return(entities.User{ID: 200}, nil)
+			},
+			expectedError: customerror.NewCustomError(kterrors.UserCannotUpdatePersonError),
+		},
+		{
+			name:    "Unauthorized person update",
+			request: dto.PersonUpdateRequest{ID: 1, Name: "Christopher Nolan", UserID: 200},
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("GetPerson", mock.Anything, 1).Return(entities.Person{ID: 1, Name: "Nolan", UserID: ownerID(100)}, nil)
+			},
+			expectedError: customerror.NewCustomError(kterrors.UserCannotUpdatePersonError),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := mocks.NewRepository(t)
+			tc.mockBehavior(mockRepo)
+
+			service := NewService(mockRepo)
+
+			err := service.UpdatePerson(context.Background(), tc.request)
+
+			if tc.expectedError != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tc.expectedError.Error(), err.Error())
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestDeletePerson(t *testing.T) {
+	logger.InitializeForTest()
+
+	testCases := []struct {
+		name          string
+		personID      int
+		userID        int
+		mockBehavior  func(*mocks.Repository)
+		expectedError error
+	}{
+		{
+			name:     "Creator deletes the person",
+			personID: 1,
+			userID:   100,
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("GetPerson", mock.Anything, 1).Return(entities.Person{ID: 1, UserID: ownerID(100)}, nil)
+				mr.On("DeletePerson", mock.Anything, 1).Return(nil)
+			},
+		},
+		{
+			name:     "Moderator deletes a backfilled person",
+			personID: 1,
+			userID:   200,
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("GetPerson", mock.Anything, 1).Return(entities.Person{ID: 1}, nil)
+				mr.On("GetUser", mock.Anything, 200).Return(entities.User{ID: 200, Role: consts.UserRoleModerator}, nil)
+				mr.On("DeletePerson", mock.Anything, 1).Return(nil)
+			},
+		},
+		{
+			name:     "Backfilled person cannot be deleted by a user",
+			personID: 1,
+			userID:   100,
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("GetPerson", mock.Anything, 1).Return(entities.Person{ID: 1}, nil)
+				mr.On("GetUser", mock.Anything, 100).Return(entities.User{ID: 100}, nil)
+			},
+			expectedError: customerror.NewCustomError(kterrors.UserCannotDeletePersonError),
+		},
+		{
+			name:     "Moderator cannot delete the person of another user",
+			personID: 1,
+			userID:   200,
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("GetPerson", mock.Anything, 1).Return(entities.Person{ID: 1, UserID: ownerID(100)}, nil)
+			},
+			expectedError: customerror.NewCustomError(kterrors.UserCannotDeletePersonError),
+		},
+		{
+			name:     "Person not found",
+			personID: 999,
+			userID:   100,
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("GetPerson", mock.Anything, 999).Return(entities.Person{}, errors.New("person not found"))
+			},
+			expectedError: errors.New("person not found"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := mocks.NewRepository(t)
+			tc.mockBehavior(mockRepo)
+
+			service := NewService(mockRepo)
+
+			err := service.DeletePerson(context.Background(), tc.personID, tc.userID)
+
+			if tc.expectedError != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tc.expectedError.Error(), err.Error())
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestGetFilmography(t *testing.T) {
+	logger.InitializeForTest()
+	releaseDate := entitiescustom.ReleaseDate{Time: time.Date(2010, 7, 16, 0, 0, 0, 0, time.UTC)}
+
+	mockRepo := mocks.NewRepository(t)
+	mockRepo.On("GetPerson", mock.Anything, 1).Return(entities.Person{ID: 1, Name: "Christopher Nolan"}, nil)
+	mockRepo.On("GetFilmography", mock.Anything, 1).Return([]models.FilmographyEntry{
+		{FilmID: 3, Title: "Inception", ReleaseDate: releaseDate, Role: consts.CreditRoleDirector},
+		{FilmID: 9, Title: "Unreleased", Role: consts.CreditRoleWriter},
+	}, nil)
+
+	service := NewService(mockRepo)
+
+	result, err := service.GetFilmography(context.Background(), 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, dto.Filmography{
+		Person: dto.Person{ID: 1, Name: "Christopher Nolan"},
+		Credits: []dto.FilmographyEntry{
+			{FilmID: 3, Title: "Inception", ReleaseDate: &releaseDate, Role: consts.CreditRoleDirector},
+			{FilmID: 9, Title: "Unreleased", Role: consts.CreditRoleWriter},
+		},
+	}, result)
+}
@@ -0,0 +1,107 @@
+// Code generated by mockery v2.52.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	entities "KTOnlinePlatform/pkg/database/entities"
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "KTOnlinePlatform/internal/models"
+)
+
+// Repository is an autogenerated mock type for the Repository type
+type Repository struct {
+	mock.Mock
+}
+
+// FilmExists provides a mock function with given fields: ctx, filmID
+func (_m *Repository) FilmExists(ctx context.Context, filmID int) (bool, error) {
+	ret := _m.Called(ctx, filmID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FilmExists")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (bool, error)); ok {
+		return rf(ctx, filmID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) bool); ok {
+		r0 = rf(ctx, filmID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, filmID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetHistory provides a mock function with given fields: ctx, userID, sort, pageSize, offset
+func (_m *Repository) GetHistory(ctx context.Context, userID int, sort string, pageSize int, offset int) ([]models.ViewingHistoryEntry, error) {
+	ret := _m.Called(ctx, userID, sort, pageSize, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetHistory")
+	}
+
+	var r0 []models.ViewingHistoryEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, string, int, int) ([]models.ViewingHistoryEntry, error)); ok {
+		return rf(ctx, userID, sort, pageSize, offset)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, string, int, int) []models.ViewingHistoryEntry); ok {
+		r0 = rf(ctx, userID, sort, pageSize, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.ViewingHistoryEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, string, int, int) error); ok {
+		r1 = rf(ctx, userID, sort, pageSize, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SaveProgress provides a mock function with given fields: ctx, _a1
+func (_m *Repository) SaveProgress(ctx context.Context, _a1 []entities.ViewingProgress) error {
+	ret := _m.Called(ctx, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveProgress")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []entities.ViewingProgress) error); ok {
+		r0 = rf(ctx, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewRepository creates a new instance of Repository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Repository {
+	mock := &Repository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
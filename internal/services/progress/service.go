@@ -0,0 +1,204 @@
+package progress
+
+import (
+	"KTOnlinePlatform/internal/dto"
+	"KTOnlinePlatform/internal/models"
+	"KTOnlinePlatform/internal/models/consts"
+	"KTOnlinePlatform/internal/models/kterrors"
+	"KTOnlinePlatform/pkg/customerror"
+	"KTOnlinePlatform/pkg/database/entities"
+	"KTOnlinePlatform/pkg/logger"
+	"KTOnlinePlatform/pkg/utils"
+	"context"
+	"github.com/samber/lo"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+)
+
+type Repository interface {
+	FilmExists(ctx context.Context, filmID int) (bool, error)
+	SaveProgress(ctx context.Context, progress []entities.ViewingProgress) error
+	GetHistory(ctx context.Context, userID int, sort string, pageSize int, offset int) ([]models.ViewingHistoryEntry, error)
+}
+
+type progressKey struct {
+	userID int
+	filmID int
+}
+
+// Service buffers playback heartbeats in memory. A player sends one every few seconds, only
+// the latest one per user and film matters, so they are coalesced and written in batches
+type Service struct {
+	repo       Repository
+	flushSize  int
+	maxPending int
+
+	mu      sync.Mutex
+	pending map[progressKey]entities.ViewingProgress
+}
+
+func NewService(repo Repository, flushSize int, maxPending int) *Service {
+	return &Service{
+		repo:       repo,
+		flushSize:  flushSize,
+		maxPending: maxPending,
+		pending:    make(map[progressKey]entities.ViewingProgress),
+	}
+}
+
+// RecordProgress keeps the heartbeat until the next flush, replacing the previous one of the
+// user on the same film. It only writes when the buffer is full. The film is checked on the
+// first heartbeat of a flush, the next ones replace a heartbeat already checked
+func (s *Service) RecordProgress(ctx context.Context, request dto.ProgressRequest) error {
+	key := progressKey{userID: request.UserID, filmID: request.FilmID}
+	s.mu.Lock()
+	_, checked := s.pending[key]
+	s.mu.Unlock()
+	if !checked {
+		exists, err := s.repo.FilmExists(ctx, request.FilmID)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return customerror.NewCustomErrorWithHttpCode(kterrors.FilmNotFoundError, http.StatusNotFound)
+		}
+	}
+
+	now := utils.TimeNowInUTC()
+	s.mu.Lock()
+	s.pending[key] = entities.ViewingProgress{
+		UserID:          request.UserID,
+		FilmID:          request.FilmID,
+		PositionSeconds: request.PositionSeconds,
+		DurationSeconds: request.DurationSeconds,
+		Completed:       request.Completed,
+		UpdatedAt:       &now,
+	}
+	full := len(s.pending) >= s.flushSize
+	s.mu.Unlock()
+
+	if full {
+		return s.Flush(ctx)
+	}
+	return nil
+}
+
+// Run flushes the buffer every interval until ctx is done, then flushes one last time
+func (s *Service) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			err := s.Flush(context.Background())
+			if err != nil {
+				logger.Error().Err(err).Msg("final progress flush failed")
+			}
+			return
+		case <-ticker.C:
+			err := s.Flush(ctx)
+			if err != nil {
+				logger.Error().Err(err).Msg("progress flush failed")
+			}
+		}
+	}
+}
+
+// Flush writes every buffered heartbeat
+func (s *Service) Flush(ctx context.Context) error {
+	return s.flush(ctx, func(key progressKey) bool {
+		return true
+	})
+}
+
+// flush writes the buffered heartbeats selected by keep. When the write fails they go back
+// to the buffer, unless a newer heartbeat arrived in the meantime or the buffer holds
+// maxPending heartbeats, the oldest ones are then dropped
+func (s *Service) flush(ctx context.Context, keep func(key progressKey) bool) error {
+	s.mu.Lock()
+	batch := make(map[progressKey]entities.ViewingProgress)
+	for key, progress := range s.pending {
+		if keep(key) {
+			batch[key] = progress
+			delete(s.pending, key)
+		}
+	}
+	s.mu.Unlock()
+	if len(batch) == 0 {
+		return nil
+	}
+
+	err := s.repo.SaveProgress(ctx, lo.Values(batch))
+	if err != nil {
+		failed := lo.Values(batch)
+		slices.SortFunc(failed, func(a, b entities.ViewingProgress) int {
+			return b.UpdatedAt.Compare(*a.UpdatedAt)
+		})
+		dropped := 0
+		s.mu.Lock()
+		for _, progress := range failed {
+			key := progressKey{userID: progress.UserID, filmID: progress.FilmID}
+			if _, newer := s.pending[key]; newer {
+				continue
+			}
+			if len(s.pending) >= s.maxPending {
+				dropped++
+				continue
+			}
+			s.pending[key] = progress
+		}
+		s.mu.Unlock()
+		if dropped > 0 {
+			logger.Warn().Msgf("%d progress heartbeats dropped, the buffer is full", dropped)
+		}
+		return err
+	}
+	return nil
+}
+
+// GetHistory writes the user's buffered heartbeats first, so the history is never behind the player
+func (s *Service) GetHistory(ctx context.Context, request dto.HistorySearchRequest) (dto.ViewingHistory, error) {
+	if request.Sort == "" {
+		request.Sort = consts.HistorySortRecent
+	}
+	if request.Sort != consts.HistorySortRecent && request.Sort != consts.HistorySortContinue {
+		return dto.ViewingHistory{}, customerror.NewI18nErrorWithParams(
+			kterrors.InvalidHistorySortError,
+			map[string]interface{}{"sort": request.Sort})
+	}
+	err := s.flush(ctx, func(key progressKey) bool {
+		return key.userID == request.UserID
+	})
+	if err != nil {
+		return dto.ViewingHistory{}, err
+	}
+	offset := utils.CalculateOffset(request.Page, request.PageSize)
+	result, err := s.repo.GetHistory(ctx, request.UserID, request.Sort, request.PageSize, offset)
+	if err != nil {
+		return dto.ViewingHistory{}, err
+	}
+	if len(result) == 0 {
+		return dto.ViewingHistory{
+			Page:     request.Page,
+			PageSize: request.PageSize,
+		}, nil
+	}
+	entries := lo.Map(result, func(item models.ViewingHistoryEntry, index int) dto.ViewingHistoryEntry {
+		return dto.ViewingHistoryEntry{
+			FilmID:          item.FilmID,
+			Title:           item.Title,
+			PositionSeconds: item.PositionSeconds,
+			DurationSeconds: item.DurationSeconds,
+			Completed:       item.Completed,
+			WatchedAt:       item.UpdatedAt,
+		}
+	})
+	return dto.ViewingHistory{
+		Entries:  entries,
+		Count:    result[0].Qty,
+		Page:     request.Page,
+		PageSize: request.PageSize,
+	}, nil
+}
@@ -0,0 +1,227 @@
+package progress
+
+import (
+	"KTOnlinePlatform/internal/dto"
+	"KTOnlinePlatform/internal/models"
+	"KTOnlinePlatform/internal/models/consts"
+	"KTOnlinePlatform/internal/models/kterrors"
+	"KTOnlinePlatform/internal/services/progress/mocks"
+	"KTOnlinePlatform/pkg/customerror"
+	"KTOnlinePlatform/pkg/database/entities"
+	"KTOnlinePlatform/pkg/logger"
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRecordProgress(t *testing.T) {
+	logger.InitializeForTest()
+
+	testCases := []struct {
+		name          string
+		flushSize     int
+		heartbeats    []dto.ProgressRequest
+		mockBehavior  func(*mocks.Repository)
+		expectedError error
+	}{
+		{
+			name:      "Heartbeats are buffered until the batch is full",
+			flushSize: 10,
+			heartbeats: []dto.ProgressRequest{
+				{FilmID: 1, PositionSeconds: 10, DurationSeconds: 100, UserID: 100},
+				{FilmID: 1, PositionSeconds: 20, DurationSeconds: 100, UserID: 100},
+			},
+			mockBehavior: func(mr *mocks.Repository) {
+				// checked once, the second heartbeat replaces the first
+				mr.On("FilmExists", mock.Anything, 1).Return(true, nil).Once()
+			},
+		},
+		{
+			name:      "Full batch is written with the latest heartbeat per film",
+			flushSize: 2,
+			heartbeats: []dto.ProgressRequest{
+				{FilmID: 1, PositionSeconds: 10, DurationSeconds: 100, UserID: 100},
+				{FilmID: 1, PositionSeconds: 20, DurationSeconds: 100, UserID: 100},
+				{FilmID: 2, PositionSeconds: 5, DurationSeconds: 100, UserID: 100},
+			},
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("FilmExists", mock.Anything, 1).Return(true, nil).Once()
+				mr.On("FilmExists", mock.Anything, 2).Return(true, nil).Once()
+				mr.On("SaveProgress", mock.Anything, mock.MatchedBy(func(progress []entities.ViewingProgress) bool {
+					if len(progress) != 2 {
+						return false
+					}
+					for _, p := range progress {
+						if p.FilmID == 1 && p.PositionSeconds != 20 {
+							return false
+						}
+					}
+					return true
+				})).Return(nil).Once()
+			},
+		},
+		{
+			name:      "Write failure is returned",
+			flushSize: 1,
+			heartbeats: []dto.ProgressRequest{
+				{FilmID: 1, PositionSeconds: 10, DurationSeconds: 100, UserID: 100},
+			},
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("FilmExists", mock.Anything, 1).Return(true, nil).Once()
+				mr.On("SaveProgress", mock.Anything, mock.Anything).Return(errors.New("connection refused"))
+			},
+			expectedError: errors.New("connection refused"),
+		},
+		{
+			name:      "Film does not exist",
+			flushSize: 10,
+			heartbeats: []dto.ProgressRequest{
+				{FilmID: 9, PositionSeconds: 10, DurationSeconds: 100, UserID: 100},
+			},
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("FilmExists", mock.Anything, 9).Return(false, nil).Once()
+			},
+			expectedError: customerror.NewCustomErrorWithHttpCode(kterrors.FilmNotFoundError, http.StatusNotFound),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := mocks.NewRepository(t)
+			tc.mockBehavior(mockRepo)
+			service := NewService(mockRepo, tc.flushSize, consts.ProgressMaxPending)
+
+			var err error
+			for _, heartbeat := range tc.heartbeats {
+				err = service.RecordProgress(context.Background(), heartbeat)
+			}
+
+			if tc.expectedError != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tc.expectedError.Error(), err.Error())
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestFlushRequeuesOnFailure(t *testing.T) {
+	logger.InitializeForTest()
+
+	mockRepo := mocks.NewRepository(t)
+	mockRepo.On("FilmExists", mock.Anything, 1).Return(true, nil).Once()
+	mockRepo.On("SaveProgress", mock.Anything, mock.Anything).Return(errors.New("connection refused")).Once()
+	mockRepo.On("SaveProgress", mock.Anything, mock.MatchedBy(func(progress []entities.ViewingProgress) bool {
+		return len(progress) == 1 && progress[0].PositionSeconds == 10
+	})).Return(nil).Once()
+	service := NewService(mockRepo, consts.ProgressFlushSize, consts.ProgressMaxPending)
+
+	err := service.RecordProgress(context.Background(), dto.ProgressRequest{FilmID: 1, PositionSeconds: 10, DurationSeconds: 100, UserID: 100})
+	assert.NoError(t, err)
+
+	assert.Error(t, service.Flush(context.Background()))
+	assert.NoError(t, service.Flush(context.Background()))
+	// nothing left to write
+	assert.NoError(t, service.Flush(context.Background()))
+}
+
+func TestFlushDropsTheOldestOverMaxPending(t *testing.T) {
+	logger.InitializeForTest()
+
+	mockRepo := mocks.NewRepository(t)
+	mockRepo.On("FilmExists", mock.Anything, mock.Anything).Return(true, nil).Twice()
+	mockRepo.On("SaveProgress", mock.Anything, mock.Anything).Return(errors.New("connection refused")).Once()
+	mockRepo.On("SaveProgress", mock.Anything, mock.MatchedBy(func(progress []entities.ViewingProgress) bool {
+		return len(progress) == 1 && progress[0].FilmID == 2
+	})).Return(nil).Once()
+	service := NewService(mockRepo, consts.ProgressFlushSize, 1)
+
+	assert.NoError(t, service.RecordProgress(context.Background(), dto.ProgressRequest{FilmID: 1, PositionSeconds: 10, DurationSeconds: 100, UserID: 100}))
+	time.Sleep(time.Millisecond)
+	assert.NoError(t, service.RecordProgress(context.Background(), dto.ProgressRequest{FilmID: 2, PositionSeconds: 10, DurationSeconds: 100, UserID: 100}))
+
+	assert.Error(t, service.Flush(context.Background()))
+	// only the latest heartbeat was kept
+	assert.NoError(t, service.Flush(context.Background()))
+}
+
+func TestGetHistory(t *testing.T) {
+	logger.InitializeForTest()
+	watchedAt := time.Date(2024, 5, 1, 20, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name           string
+		request        dto.HistorySearchRequest
+		pending        []dto.ProgressRequest
+		mockBehavior   func(*mocks.Repository)
+		expectedResult dto.ViewingHistory
+		expectedError  error
+	}{
+		{
+			name:    "Default sort is recent",
+			request: dto.HistorySearchRequest{Page: 1, PageSize: 10, UserID: 100},
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("GetHistory", mock.Anything, 100, consts.HistorySortRecent, 10, 0).Return([]models.ViewingHistoryEntry{
+					{FilmID: 1, Title: "Heat", PositionSeconds: 50, DurationSeconds: 100, UpdatedAt: watchedAt, Qty: 1},
+				}, nil)
+			},
+			expectedResult: dto.ViewingHistory{
+				Entries: []dto.ViewingHistoryEntry{
+					{FilmID: 1, Title: "Heat", PositionSeconds: 50, DurationSeconds: 100, WatchedAt: watchedAt},
+				},
+				Count:    1,
+				Page:     1,
+				PageSize: 10,
+			},
+		},
+		{
+			name:    "Pending heartbeats of the user are written first",
+			request: dto.HistorySearchRequest{Sort: consts.HistorySortContinue, Page: 1, PageSize: 10, UserID: 100},
+			pending: []dto.ProgressRequest{
+				{FilmID: 1, PositionSeconds: 10, DurationSeconds: 100, UserID: 100},
+				{FilmID: 1, PositionSeconds: 10, DurationSeconds: 100, UserID: 200},
+			},
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("FilmExists", mock.Anything, 1).Return(true, nil).Twice()
+				mr.On("SaveProgress", mock.Anything, mock.MatchedBy(func(progress []entities.ViewingProgress) bool {
+					return len(progress) == 1 && progress[0].UserID == 100
+				})).Return(nil).Once()
+				mr.On("GetHistory", mock.Anything, 100, consts.HistorySortContinue, 10, 0).Return([]models.ViewingHistoryEntry{}, nil)
+			},
+			expectedResult: dto.ViewingHistory{Page: 1, PageSize: 10},
+		},
+		{
+			name:          "Invalid sort",
+			request:       dto.HistorySearchRequest{Sort: "rating", Page: 1, PageSize: 10, UserID: 100},
+			mockBehavior:  func(mr *mocks.Repository) {},
+			expectedError: customerror.NewI18nErrorWithParams(kterrors.InvalidHistorySortError, map[string]interface{}{"sort": "rating"}),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := mocks.NewRepository(t)
+			tc.mockBehavior(mockRepo)
+			service := NewService(mockRepo, consts.ProgressFlushSize, consts.ProgressMaxPending)
+			for _, heartbeat := range tc.pending {
+				assert.NoError(t, service.RecordProgress(context.Background(), heartbeat))
+			}
+
+			result, err := service.GetHistory(context.Background(), tc.request)
+
+			if tc.expectedError != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tc.expectedError.Error(), err.Error())
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedResult, result)
+		})
+	}
+}
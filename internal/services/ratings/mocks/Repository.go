@@ -0,0 +1,65 @@
+// Code generated by mockery v2.52.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	entities "KTOnlinePlatform/pkg/database/entities"
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Repository is an autogenerated mock type for the Repository type
+type Repository struct {
+	mock.Mock
+}
+
+// DeleteRating provides a mock function with given fields: ctx, filmID, userID
+func (_m *Repository) DeleteRating(ctx context.Context, filmID int, userID int) error {
+	ret := _m.Called(ctx, filmID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteRating")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) error); ok {
+		r0 = rf(ctx, filmID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SaveRating provides a mock function with given fields: ctx, rating
+func (_m *Repository) SaveRating(ctx context.Context, rating entities.FilmRating) error {
+	ret := _m.Called(ctx, rating)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveRating")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entities.FilmRating) error); ok {
+		r0 = rf(ctx, rating)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewRepository creates a new instance of Repository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Repository {
+	mock := &Repository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,46 @@
+package ratings
+
+import (
+	"KTOnlinePlatform/internal/dto"
+	"KTOnlinePlatform/internal/models/kterrors"
+	"KTOnlinePlatform/pkg/customerror"
+	"KTOnlinePlatform/pkg/database/entities"
+	"context"
+	"net/http"
+)
+
+type Repository interface {
+	SaveRating(ctx context.Context, rating entities.FilmRating) error
+	DeleteRating(ctx context.Context, filmID int, userID int) error
+}
+
+type Service struct {
+	repo Repository
+}
+
+func NewService(repo Repository) *Service {
+	return &Service{
+		repo: repo,
+	}
+}
+
+// RateFilm stores one score per user and film, rating again replaces the previous score
+func (s *Service) RateFilm(ctx context.Context, request dto.FilmRatingRequest) error {
+	err := s.repo.SaveRating(ctx, entities.FilmRating{
+		FilmID: request.FilmID,
+		UserID: request.UserID,
+		Score:  request.Score,
+	})
+	if customerror.IsNotFoundError(err) {
+		return customerror.NewCustomErrorWithHttpCode(kterrors.FilmNotFoundError, http.StatusNotFound)
+	}
+	return err
+}
+
+func (s *Service) DeleteRating(ctx context.Context, filmID int, userID int) error {
+	err := s.repo.DeleteRating(ctx, filmID, userID)
+	if customerror.IsNotFoundError(err) {
+		return customerror.NewCustomErrorWithHttpCode(kterrors.RatingNotFoundError, http.StatusNotFound)
+	}
+	return err
+}
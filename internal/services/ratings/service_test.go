@@ -0,0 +1,111 @@
+package ratings
+
+import (
+	"KTOnlinePlatform/internal/dto"
+	"KTOnlinePlatform/internal/models/kterrors"
+	"KTOnlinePlatform/internal/services/ratings/mocks"
+	"KTOnlinePlatform/pkg/customerror"
+	"KTOnlinePlatform/pkg/database/entities"
+	"KTOnlinePlatform/pkg/logger"
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func TestRateFilm(t *testing.T) {
+	logger.InitializeForTest()
+
+	testCases := []struct {
+		name          string
+		request       dto.FilmRatingRequest
+		mockBehavior  func(*mocks.Repository)
+		expectedError error
+	}{
+		{
+			name:    "Score is saved",
+			request: dto.FilmRatingRequest{FilmID: 1, Score: 8, UserID: 100},
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("SaveRating", mock.Anything, entities.FilmRating{FilmID: 1, UserID: 100, Score: 8}).Return(nil)
+			},
+		},
+		{
+			name:    "Film not found",
+			request: dto.FilmRatingRequest{FilmID: 99, Score: 8, UserID: 100},
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("SaveRating", mock.Anything, entities.FilmRating{FilmID: 99, UserID: 100, Score: 8}).Return(gorm.ErrRecordNotFound)
+			},
+			expectedError: customerror.NewCustomErrorWithHttpCode(kterrors.FilmNotFoundError, http.StatusNotFound),
+		},
+		{
+			name:    "Repository error",
+			request: dto.FilmRatingRequest{FilmID: 1, Score: 8, UserID: 100},
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("SaveRating", mock.Anything, entities.FilmRating{FilmID: 1, UserID: 100, Score: 8}).Return(errors.New("database error"))
+			},
+			expectedError: errors.New("database error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := mocks.NewRepository(t)
+			tc.mockBehavior(mockRepo)
+			service := NewService(mockRepo)
+
+			err := service.RateFilm(context.Background(), tc.request)
+
+			if tc.expectedError != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tc.expectedError.Error(), err.Error())
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestDeleteRating(t *testing.T) {
+	logger.InitializeForTest()
+
+	testCases := []struct {
+		name          string
+		mockBehavior  func(*mocks.Repository)
+		expectedError error
+	}{
+		{
+			name: "Score is removed",
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("DeleteRating", mock.Anything, 1, 100).Return(nil)
+			},
+		},
+		{
+			name: "User never rated the film",
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("DeleteRating", mock.Anything, 1, 100).Return(gorm.ErrRecordNotFound)
+			},
+			expectedError: customerror.NewCustomErrorWithHttpCode(kterrors.RatingNotFoundError, http.StatusNotFound),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := mocks.NewRepository(t)
+			tc.mockBehavior(mockRepo)
+			service := NewService(mockRepo)
+
+			err := service.DeleteRating(context.Background(), 1, 100)
+
+			if tc.expectedError != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tc.expectedError.Error(), err.Error())
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,195 @@
+// Code generated by mockery v2.52.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	entities "KTOnlinePlatform/pkg/database/entities"
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "KTOnlinePlatform/internal/models"
+)
+
+// Repository is an autogenerated mock type for the Repository type
+type Repository struct {
+	mock.Mock
+}
+
+// GetFilm provides a mock function with given fields: ctx, ID
+func (_m *Repository) GetFilm(ctx context.Context, ID int) (entities.Film, error) {
+	ret := _m.Called(ctx, ID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFilm")
+	}
+
+	var r0 entities.Film
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (entities.Film, error)); ok {
+		return rf(ctx, ID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) entities.Film); ok {
+		r0 = rf(ctx, ID)
+	} else {
+		r0 = ret.Get(0).(entities.Film)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, ID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPopularFilms provides a mock function with given fields: ctx, window, pageSize, offset
+func (_m *Repository) GetPopularFilms(ctx context.Context, window string, pageSize int, offset int) ([]models.RecommendedFilm, error) {
+	ret := _m.Called(ctx, window, pageSize, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPopularFilms")
+	}
+
+	var r0 []models.RecommendedFilm
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) ([]models.RecommendedFilm, error)); ok {
+		return rf(ctx, window, pageSize, offset)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) []models.RecommendedFilm); ok {
+		r0 = rf(ctx, window, pageSize, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.RecommendedFilm)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int, int) error); ok {
+		r1 = rf(ctx, window, pageSize, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRecommendations provides a mock function with given fields: ctx, userID, minRating, pageSize, offset
+func (_m *Repository) GetRecommendations(ctx context.Context, userID int, minRating int, pageSize int, offset int) ([]models.RecommendedFilm, error) {
+	ret := _m.Called(ctx, userID, minRating, pageSize, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRecommendations")
+	}
+
+	var r0 []models.RecommendedFilm
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, int, int) ([]models.RecommendedFilm, error)); ok {
+		return rf(ctx, userID, minRating, pageSize, offset)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, int, int) []models.RecommendedFilm); ok {
+		r0 = rf(ctx, userID, minRating, pageSize, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.RecommendedFilm)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int, int, int) error); ok {
+		r1 = rf(ctx, userID, minRating, pageSize, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSimilarFilms provides a mock function with given fields: ctx, filmID, limit
+func (_m *Repository) GetSimilarFilms(ctx context.Context, filmID int, limit int) ([]models.RecommendedFilm, error) {
+	ret := _m.Called(ctx, filmID, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSimilarFilms")
+	}
+
+	var r0 []models.RecommendedFilm
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) ([]models.RecommendedFilm, error)); ok {
+		return rf(ctx, filmID, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) []models.RecommendedFilm); ok {
+		r0 = rf(ctx, filmID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.RecommendedFilm)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, filmID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// HasInteractions provides a mock function with given fields: ctx, userID, minRating
+func (_m *Repository) HasInteractions(ctx context.Context, userID int, minRating int) (bool, error) {
+	ret := _m.Called(ctx, userID, minRating)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HasInteractions")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) (bool, error)); ok {
+		return rf(ctx, userID, minRating)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) bool); ok {
+		r0 = rf(ctx, userID, minRating)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, userID, minRating)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RebuildSimilarities provides a mock function with given fields: ctx, minRating, minCoOccurrence
+func (_m *Repository) RebuildSimilarities(ctx context.Context, minRating int, minCoOccurrence int) error {
+	ret := _m.Called(ctx, minRating, minCoOccurrence)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RebuildSimilarities")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) error); ok {
+		r0 = rf(ctx, minRating, minCoOccurrence)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewRepository creates a new instance of Repository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Repository {
+	mock := &Repository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,136 @@
+package recommendations
+
+import (
+	"KTOnlinePlatform/internal/dto"
+	"KTOnlinePlatform/internal/models"
+	"KTOnlinePlatform/internal/models/consts"
+	"KTOnlinePlatform/internal/models/kterrors"
+	"KTOnlinePlatform/pkg/customerror"
+	"KTOnlinePlatform/pkg/database/entities"
+	"KTOnlinePlatform/pkg/logger"
+	"KTOnlinePlatform/pkg/utils"
+	"context"
+	"github.com/samber/lo"
+	"net/http"
+	"time"
+)
+
+type Repository interface {
+	RebuildSimilarities(ctx context.Context, minRating int, minCoOccurrence int) error
+	HasInteractions(ctx context.Context, userID int, minRating int) (bool, error)
+	GetRecommendations(ctx context.Context, userID int, minRating int, pageSize int, offset int) ([]models.RecommendedFilm, error)
+	GetPopularFilms(ctx context.Context, window string, pageSize int, offset int) ([]models.RecommendedFilm, error)
+	GetSimilarFilms(ctx context.Context, filmID int, limit int) ([]models.RecommendedFilm, error)
+	GetFilm(ctx context.Context, ID int) (entities.Film, error)
+}
+
+// Posters signs the poster URLs of the films listed
+type Posters interface {
+	Poster(posterKey string) *dto.Poster
+}
+
+type Service struct {
+	repo    Repository
+	posters Posters
+}
+
+func NewService(repo Repository, posters Posters) *Service {
+	return &Service{
+		repo:    repo,
+		posters: posters,
+	}
+}
+
+// Run rebuilds the similarity table right away and then every interval until ctx is done
+func (s *Service) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		err := s.RebuildSimilarities(ctx)
+		if err != nil {
+			logger.Error().Err(err).Msg("rebuild similarities failed")
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *Service) RebuildSimilarities(ctx context.Context) error {
+	start := time.Now()
+	err := s.repo.RebuildSimilarities(ctx, consts.RecommendationMinRating, consts.RecommendationMinCoOccurrence)
+	if err != nil {
+		return err
+	}
+	logger.Info().Dur("duration", time.Since(start)).Msg("similarities rebuilt")
+	return nil
+}
+
+// GetRecommendations ranks films from what the user liked. A user without any history
+// gets every page of the popular films of GET /films/popular instead
+func (s *Service) GetRecommendations(ctx context.Context, request dto.RecommendationsRequest) (dto.Recommendations, error) {
+	hasHistory, err := s.repo.HasInteractions(ctx, request.UserID, consts.RecommendationMinRating)
+	if err != nil {
+		return dto.Recommendations{}, err
+	}
+	offset := utils.CalculateOffset(request.Page, request.PageSize)
+	var result []models.RecommendedFilm
+	if hasHistory {
+		result, err = s.repo.GetRecommendations(ctx, request.UserID, consts.RecommendationMinRating, request.PageSize, offset)
+	} else {
+		result, err = s.repo.GetPopularFilms(ctx, consts.TrendingWindowAll, request.PageSize, offset)
+	}
+	if err != nil {
+		return dto.Recommendations{}, err
+	}
+	if len(result) == 0 {
+		return dto.Recommendations{
+			Page:     request.Page,
+			PageSize: request.PageSize,
+			Popular:  !hasHistory,
+		}, nil
+	}
+	return dto.Recommendations{
+		Films:    s.toFilms(result),
+		Count:    result[0].Qty,
+		Page:     request.Page,
+		PageSize: request.PageSize,
+		Popular:  !hasHistory,
+	}, nil
+}
+
+// GetSimilarFilms ranks the films sharing the people of a film
+func (s *Service) GetSimilarFilms(ctx context.Context, request dto.SimilarFilmsRequest) (dto.SimilarFilms, error) {
+	film, err := s.repo.GetFilm(ctx, request.FilmID)
+	if err != nil {
+		if customerror.IsNotFoundError(err) {
+			return dto.SimilarFilms{}, customerror.NewCustomErrorWithHttpCode(kterrors.FilmNotFoundError, http.StatusNotFound)
+		}
+		return dto.SimilarFilms{}, err
+	}
+	if film.Hidden {
+		return dto.SimilarFilms{}, customerror.NewCustomErrorWithHttpCode(kterrors.FilmNotFoundError, http.StatusNotFound)
+	}
+	if request.PageSize == 0 {
+		request.PageSize = consts.PaginationDefaultPageSize
+	}
+	result, err := s.repo.GetSimilarFilms(ctx, request.FilmID, request.PageSize)
+	if err != nil {
+		return dto.SimilarFilms{}, err
+	}
+	return dto.SimilarFilms{Films: s.toFilms(result)}, nil
+}
+
+func (s *Service) toFilms(result []models.RecommendedFilm) []dto.Film {
+	return lo.Map(result, func(item models.RecommendedFilm, index int) dto.Film {
+		return dto.Film{
+			ID:            item.ID,
+			Title:         item.Title,
+			AverageRating: utils.AverageRating(item.RatingSum, item.RatingCount),
+			VoteCount:     item.RatingCount,
+			Poster:        s.posters.Poster(item.PosterKey),
+		}
+	})
+}
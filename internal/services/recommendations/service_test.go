@@ -0,0 +1,173 @@
+package recommendations
+
+import (
+	"KTOnlinePlatform/internal/dto"
+	"KTOnlinePlatform/internal/models"
+	"KTOnlinePlatform/internal/models/consts"
+	"KTOnlinePlatform/internal/models/kterrors"
+	"KTOnlinePlatform/internal/services/recommendations/mocks"
+	"KTOnlinePlatform/pkg/customerror"
+	"KTOnlinePlatform/pkg/database/entities"
+	"KTOnlinePlatform/pkg/logger"
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func TestGetRecommendations(t *testing.T) {
+	logger.InitializeForTest()
+
+	testCases := []struct {
+		name           string
+		request        dto.RecommendationsRequest
+		mockBehavior   func(*mocks.Repository)
+		expectedResult dto.Recommendations
+	}{
+		{
+			name:    "Films similar to what the user liked",
+			request: dto.RecommendationsRequest{Page: 1, PageSize: 10, UserID: 100},
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("HasInteractions", mock.Anything, 100, consts.RecommendationMinRating).Return(true, nil)
+				mr.On("GetRecommendations", mock.Anything, 100, consts.RecommendationMinRating, 10, 0).Return([]models.RecommendedFilm{
+					{ID: 2, Title: "Heat", RatingSum: 17, RatingCount: 2, PosterKey: "posters/2/ab", Score: 1.4, Qty: 1},
+				}, nil)
+			},
+			expectedResult: dto.Recommendations{
+				Films: []dto.Film{{ID: 2, Title: "Heat", AverageRating: 8.5, VoteCount: 2, Poster: &dto.Poster{
+					Small: "posters/2/ab/small", Medium: "posters/2/ab/medium", Large: "posters/2/ab/large",
+				}}},
+				Count:    1,
+				Page:     1,
+				PageSize: 10,
+			},
+		},
+		{
+			name:    "Cold start user gets the popular films",
+			request: dto.RecommendationsRequest{Page: 1, PageSize: 10, UserID: 100},
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("HasInteractions", mock.Anything, 100, consts.RecommendationMinRating).Return(false, nil)
+				mr.On("GetPopularFilms", mock.Anything, consts.TrendingWindowAll, 10, 0).Return([]models.RecommendedFilm{
+					{ID: 3, Title: "Alien", Score: 40, Qty: 15},
+				}, nil)
+			},
+			expectedResult: dto.Recommendations{
+				Films:    []dto.Film{{ID: 3, Title: "Alien"}},
+				Count:    15,
+				Page:     1,
+				PageSize: 10,
+				Popular:  true,
+			},
+		},
+		{
+			name:    "Cold start user gets the next pages of the popular films",
+			request: dto.RecommendationsRequest{Page: 2, PageSize: 10, UserID: 100},
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("HasInteractions", mock.Anything, 100, consts.RecommendationMinRating).Return(false, nil)
+				mr.On("GetPopularFilms", mock.Anything, consts.TrendingWindowAll, 10, 10).Return([]models.RecommendedFilm{
+					{ID: 4, Title: "Thief", Score: 12, Qty: 15},
+				}, nil)
+			},
+			expectedResult: dto.Recommendations{
+				Films:    []dto.Film{{ID: 4, Title: "Thief"}},
+				Count:    15,
+				Page:     2,
+				PageSize: 10,
+				Popular:  true,
+			},
+		},
+		{
+			name:    "Page past the end of the recommendations",
+			request: dto.RecommendationsRequest{Page: 3, PageSize: 10, UserID: 100},
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("HasInteractions", mock.Anything, 100, consts.RecommendationMinRating).Return(true, nil)
+				mr.On("GetRecommendations", mock.Anything, 100, consts.RecommendationMinRating, 10, 20).Return([]models.RecommendedFilm{}, nil)
+			},
+			expectedResult: dto.Recommendations{Page: 3, PageSize: 10},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := mocks.NewRepository(t)
+			tc.mockBehavior(mockRepo)
+			service := NewService(mockRepo, testPosters{})
+
+			result, err := service.GetRecommendations(context.Background(), tc.request)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedResult, result)
+		})
+	}
+}
+
+func TestGetSimilarFilms(t *testing.T) {
+	logger.InitializeForTest()
+
+	testCases := []struct {
+		name           string
+		request        dto.SimilarFilmsRequest
+		mockBehavior   func(*mocks.Repository)
+		expectedResult dto.SimilarFilms
+		expectedError  error
+	}{
+		{
+			name:    "Films sharing credits",
+			request: dto.SimilarFilmsRequest{FilmID: 1},
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("GetFilm", mock.Anything, 1).Return(entities.Film{ID: 1}, nil)
+				mr.On("GetSimilarFilms", mock.Anything, 1, consts.PaginationDefaultPageSize).Return([]models.RecommendedFilm{
+					{ID: 2, Title: "Heat", Score: 3},
+				}, nil)
+			},
+			expectedResult: dto.SimilarFilms{Films: []dto.Film{{ID: 2, Title: "Heat"}}},
+		},
+		{
+			name:    "Film does not exist",
+			request: dto.SimilarFilmsRequest{FilmID: 1},
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("GetFilm", mock.Anything, 1).Return(entities.Film{}, gorm.ErrRecordNotFound)
+			},
+			expectedError: customerror.NewCustomErrorWithHttpCode(kterrors.FilmNotFoundError, http.StatusNotFound),
+		},
+		{
+			name:    "Film is hidden",
+			request: dto.SimilarFilmsRequest{FilmID: 1},
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("GetFilm", mock.Anything, 1).Return(entities.Film{ID: 1, Hidden: true}, nil)
+			},
+			expectedError: customerror.NewCustomErrorWithHttpCode(kterrors.FilmNotFoundError, http.StatusNotFound),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := mocks.NewRepository(t)
+			tc.mockBehavior(mockRepo)
+			service := NewService(mockRepo, testPosters{})
+
+			result, err := service.GetSimilarFilms(context.Background(), tc.request)
+
+			if tc.expectedError != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tc.expectedError.Error(), err.Error())
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedResult, result)
+		})
+	}
+}
+
+// testPosters stands for the films service, the URLs are the poster key
+type testPosters struct{}
+
+func (testPosters) Poster(posterKey string) *dto.Poster {
+	if posterKey == "" {
+		return nil
+	}
+	return &dto.Poster{Small: posterKey + "/small", Medium: posterKey + "/medium", Large: posterKey + "/large"}
+}
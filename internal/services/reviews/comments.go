@@ -0,0 +1,143 @@
+package reviews
+
+import (
+	"KTOnlinePlatform/internal/dto"
+	"KTOnlinePlatform/internal/models"
+	"KTOnlinePlatform/internal/models/kterrors"
+	"KTOnlinePlatform/pkg/customerror"
+	"KTOnlinePlatform/pkg/database/entities"
+	"KTOnlinePlatform/pkg/utils"
+	"context"
+	"github.com/samber/lo"
+	"net/http"
+)
+
+// GetCommentsPaginated pages through the top level comments of a review and attaches
+// every reply of those threads, nested under the comment it answers
+func (s *Service) GetCommentsPaginated(ctx context.Context, request dto.CommentSearchRequest) (dto.CommentsPaginated, error) {
+	_, err := s.getFilmReview(ctx, request.FilmID, request.ReviewID)
+	if err != nil {
+		return dto.CommentsPaginated{}, err
+	}
+	roots, err := s.repo.GetRootCommentsPaginated(ctx, request.ReviewID, request.PageSize, utils.CalculateOffset(request.Page, request.PageSize))
+	if err != nil {
+		return dto.CommentsPaginated{}, err
+	}
+	if len(roots) == 0 {
+		return dto.CommentsPaginated{
+			Page:     request.Page,
+			PageSize: request.PageSize,
+		}, nil
+	}
+	replies, err := s.repo.GetCommentReplies(ctx, lo.Map(roots, func(item models.ReviewComment, index int) int {
+		return item.ID
+	}))
+	if err != nil {
+		return dto.CommentsPaginated{}, err
+	}
+	children := lo.GroupBy(replies, func(item models.ReviewComment) int {
+		return *item.ParentID
+	})
+	return dto.CommentsPaginated{
+		Comments: buildThreads(roots, children),
+		Count:    roots[0].Qty,
+		Page:     request.Page,
+		PageSize: request.PageSize,
+	}, nil
+}
+
+func (s *Service) CreateComment(ctx context.Context, request dto.CommentCreateRequest) error {
+	err := s.filter.Check(request.Body)
+	if err != nil {
+		return err
+	}
+	_, err = s.getFilmReview(ctx, request.FilmID, request.ReviewID)
+	if err != nil {
+		return err
+	}
+	comment := entities.ReviewComment{
+		ReviewID: request.ReviewID,
+		UserID:   request.UserID,
+		Body:     request.Body,
+	}
+	if request.ParentID != nil {
+		parent, err := s.getReviewComment(ctx, request.ReviewID, *request.ParentID)
+		if err != nil {
+			return err
+		}
+		comment.ParentID = &parent.ID
+		comment.RootID = parent.RootID
+		if comment.RootID == nil {
+			comment.RootID = &parent.ID
+		}
+	}
+	return s.repo.CreateComment(ctx, comment)
+}
+
+func (s *Service) UpdateComment(ctx context.Context, request dto.CommentUpdateRequest) error {
+	_, err := s.getFilmReview(ctx, request.FilmID, request.ReviewID)
+	if err != nil {
+		return err
+	}
+	comment, err := s.getReviewComment(ctx, request.ReviewID, request.CommentID)
+	if err != nil {
+		return err
+	}
+	if comment.UserID != request.UserID {
+		return customerror.NewCustomError(kterrors.UserCannotUpdateCommentError)
+	}
+	err = s.filter.Check(request.Body)
+	if err != nil {
+		return err
+	}
+	comment.Body = request.Body
+	return s.repo.UpdateComment(ctx, comment)
+}
+
+func (s *Service) DeleteComment(ctx context.Context, filmID int, reviewID int, commentID int, userID int) error {
+	_, err := s.getFilmReview(ctx, filmID, reviewID)
+	if err != nil {
+		return err
+	}
+	comment, err := s.getReviewComment(ctx, reviewID, commentID)
+	if err != nil {
+		return err
+	}
+	if comment.UserID != userID {
+		return customerror.NewCustomError(kterrors.UserCannotDeleteCommentError)
+	}
+	return s.repo.DeleteComment(ctx, commentID)
+}
+
+func (s *Service) getReviewComment(ctx context.Context, reviewID int, commentID int) (entities.ReviewComment, error) {
+	comment, err := s.repo.GetComment(ctx, commentID)
+	if err != nil {
+		if customerror.IsNotFoundError(err) {
+			return entities.ReviewComment{}, newCommentNotFoundError()
+		}
+		return entities.ReviewComment{}, err
+	}
+	if comment.ReviewID != reviewID {
+		return entities.ReviewComment{}, newCommentNotFoundError()
+	}
+	return comment, nil
+}
+
+func newCommentNotFoundError() error {
+	return customerror.NewCustomErrorWithHttpCode(kterrors.CommentNotFoundError, http.StatusNotFound)
+}
+
+func buildThreads(comments []models.ReviewComment, children map[int][]models.ReviewComment) []dto.Comment {
+	return lo.Map(comments, func(item models.ReviewComment, index int) dto.Comment {
+		return dto.Comment{
+			ID:        item.ID,
+			ParentID:  item.ParentID,
+			UserID:    item.UserID,
+			Username:  item.Username,
+			Body:      item.Body,
+			CreatedAt: item.CreatedAt,
+			UpdatedAt: item.UpdatedAt,
+			Replies:   buildThreads(children[item.ID], children),
+		}
+	})
+}
@@ -0,0 +1,321 @@
+// Code generated by mockery v2.52.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	entities "KTOnlinePlatform/pkg/database/entities"
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "KTOnlinePlatform/internal/models"
+)
+
+// Repository is an autogenerated mock type for the Repository type
+type Repository struct {
+	mock.Mock
+}
+
+// CreateComment provides a mock function with given fields: ctx, comment
+func (_m *Repository) CreateComment(ctx context.Context, comment entities.ReviewComment) error {
+	ret := _m.Called(ctx, comment)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateComment")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entities.ReviewComment) error); ok {
+		r0 = rf(ctx, comment)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateReview provides a mock function with given fields: ctx, review
+func (_m *Repository) CreateReview(ctx context.Context, review entities.Review) error {
+	ret := _m.Called(ctx, review)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateReview")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entities.Review) error); ok {
+		r0 = rf(ctx, review)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteComment provides a mock function with given fields: ctx, id
+func (_m *Repository) DeleteComment(ctx context.Context, id int) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteComment")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteReview provides a mock function with given fields: ctx, id
+func (_m *Repository) DeleteReview(ctx context.Context, id int) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteReview")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetComment provides a mock function with given fields: ctx, ID
+func (_m *Repository) GetComment(ctx context.Context, ID int) (entities.ReviewComment, error) {
+	ret := _m.Called(ctx, ID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetComment")
+	}
+
+	var r0 entities.ReviewComment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (entities.ReviewComment, error)); ok {
+		return rf(ctx, ID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) entities.ReviewComment); ok {
+		r0 = rf(ctx, ID)
+	} else {
+		r0 = ret.Get(0).(entities.ReviewComment)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, ID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetCommentReplies provides a mock function with given fields: ctx, rootIDs
+func (_m *Repository) GetCommentReplies(ctx context.Context, rootIDs []int) ([]models.ReviewComment, error) {
+	ret := _m.Called(ctx, rootIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCommentReplies")
+	}
+
+	var r0 []models.ReviewComment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []int) ([]models.ReviewComment, error)); ok {
+		return rf(ctx, rootIDs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []int) []models.ReviewComment); ok {
+		r0 = rf(ctx, rootIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.ReviewComment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []int) error); ok {
+		r1 = rf(ctx, rootIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetReview provides a mock function with given fields: ctx, ID
+func (_m *Repository) GetReview(ctx context.Context, ID int) (entities.Review, error) {
+	ret := _m.Called(ctx, ID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReview")
+	}
+
+	var r0 entities.Review
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (entities.Review, error)); ok {
+		return rf(ctx, ID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) entities.Review); ok {
+		r0 = rf(ctx, ID)
+	} else {
+		r0 = ret.Get(0).(entities.Review)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, ID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetReviewsPaginated provides a mock function with given fields: ctx, filmID, sort, pageSize, offset
+func (_m *Repository) GetReviewsPaginated(ctx context.Context, filmID int, sort string, pageSize int, offset int) ([]models.ReviewPaginated, error) {
+	ret := _m.Called(ctx, filmID, sort, pageSize, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReviewsPaginated")
+	}
+
+	var r0 []models.ReviewPaginated
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, string, int, int) ([]models.ReviewPaginated, error)); ok {
+		return rf(ctx, filmID, sort, pageSize, offset)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, string, int, int) []models.ReviewPaginated); ok {
+		r0 = rf(ctx, filmID, sort, pageSize, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.ReviewPaginated)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, string, int, int) error); ok {
+		r1 = rf(ctx, filmID, sort, pageSize, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRootCommentsPaginated provides a mock function with given fields: ctx, reviewID, pageSize, offset
+func (_m *Repository) GetRootCommentsPaginated(ctx context.Context, reviewID int, pageSize int, offset int) ([]models.ReviewComment, error) {
+	ret := _m.Called(ctx, reviewID, pageSize, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRootCommentsPaginated")
+	}
+
+	var r0 []models.ReviewComment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, int) ([]models.ReviewComment, error)); ok {
+		return rf(ctx, reviewID, pageSize, offset)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, int) []models.ReviewComment); ok {
+		r0 = rf(ctx, reviewID, pageSize, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.ReviewComment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int, int) error); ok {
+		r1 = rf(ctx, reviewID, pageSize, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LikeReview provides a mock function with given fields: ctx, reviewID, userID
+func (_m *Repository) LikeReview(ctx context.Context, reviewID int, userID int) error {
+	ret := _m.Called(ctx, reviewID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LikeReview")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) error); ok {
+		r0 = rf(ctx, reviewID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UnlikeReview provides a mock function with given fields: ctx, reviewID, userID
+func (_m *Repository) UnlikeReview(ctx context.Context, reviewID int, userID int) error {
+	ret := _m.Called(ctx, reviewID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UnlikeReview")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) error); ok {
+		r0 = rf(ctx, reviewID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateComment provides a mock function with given fields: ctx, comment
+func (_m *Repository) UpdateComment(ctx context.Context, comment entities.ReviewComment) error {
+	ret := _m.Called(ctx, comment)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateComment")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entities.ReviewComment) error); ok {
+		r0 = rf(ctx, comment)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateReview provides a mock function with given fields: ctx, review
+func (_m *Repository) UpdateReview(ctx context.Context, review entities.Review) error {
+	ret := _m.Called(ctx, review)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateReview")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entities.Review) error); ok {
+		r0 = rf(ctx, review)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewRepository creates a new instance of Repository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Repository {
+	mock := &Repository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
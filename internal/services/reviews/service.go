@@ -0,0 +1,212 @@
+package reviews
+
+import (
+	"KTOnlinePlatform/internal/dto"
+	"KTOnlinePlatform/internal/models"
+	"KTOnlinePlatform/internal/models/consts"
+	"KTOnlinePlatform/internal/models/kterrors"
+	"KTOnlinePlatform/pkg/customerror"
+	"KTOnlinePlatform/pkg/database/entities"
+	"KTOnlinePlatform/pkg/utils"
+	"context"
+	"github.com/samber/lo"
+	"net/http"
+)
+
+type Repository interface {
+	GetReviewsPaginated(ctx context.Context, filmID int, sort string, pageSize int, offset int) ([]models.ReviewPaginated, error)
+	GetReview(ctx context.Context, ID int) (entities.Review, error)
+	CreateReview(ctx context.Context, review entities.Review) error
+	UpdateReview(ctx context.Context, review entities.Review) error
+	DeleteReview(ctx context.Context, id int) error
+	LikeReview(ctx context.Context, reviewID int, userID int) error
+	UnlikeReview(ctx context.Context, reviewID int, userID int) error
+	GetRootCommentsPaginated(ctx context.Context, reviewID int, pageSize int, offset int) ([]models.ReviewComment, error)
+	GetCommentReplies(ctx context.Context, rootIDs []int) ([]models.ReviewComment, error)
+	GetComment(ctx context.Context, ID int) (entities.ReviewComment, error)
+	CreateComment(ctx context.Context, comment entities.ReviewComment) error
+	UpdateComment(ctx context.Context, comment entities.ReviewComment) error
+	DeleteComment(ctx context.Context, id int) error
+}
+
+// ContentFilter rejects user written text containing banned words
+type ContentFilter interface {
+	Check(texts ...string) error
+}
+
+type Service struct {
+	repo   Repository
+	filter ContentFilter
+}
+
+func NewService(repo Repository, filter ContentFilter) *Service {
+	return &Service{
+		repo:   repo,
+		filter: filter,
+	}
+}
+
+func (s *Service) GetReviewsPaginated(ctx context.Context, request dto.ReviewSearchRequest) (dto.ReviewsPaginated, error) {
+	if request.Sort == "" {
+		request.Sort = consts.ReviewSortRecent
+	}
+	if request.Sort != consts.ReviewSortRecent && request.Sort != consts.ReviewSortHelpful {
+		return dto.ReviewsPaginated{}, customerror.NewI18nErrorWithParams(
+			kterrors.InvalidReviewSortError,
+			map[string]interface{}{"sort": request.Sort})
+	}
+	result, err := s.repo.GetReviewsPaginated(ctx, request.FilmID, request.Sort, request.PageSize, utils.CalculateOffset(request.Page, request.PageSize))
+	if err != nil {
+		return dto.ReviewsPaginated{}, err
+	}
+	if len(result) == 0 {
+		return dto.ReviewsPaginated{
+			Page:     request.Page,
+			PageSize: request.PageSize,
+		}, nil
+	}
+	reviews := lo.Map(result, func(item models.ReviewPaginated, index int) dto.Review {
+		return dto.Review{
+			ID:        item.ID,
+			FilmID:    request.FilmID,
+			UserID:    item.UserID,
+			Username:  item.Username,
+			Title:     item.Title,
+			Body:      item.Body,
+			Spoiler:   item.Spoiler,
+			LikeCount: item.LikeCount,
+			CreatedAt: item.CreatedAt,
+			UpdatedAt: item.UpdatedAt,
+		}
+	})
+	return dto.ReviewsPaginated{
+		Reviews:  reviews,
+		Count:    result[0].Qty,
+		Page:     request.Page,
+		PageSize: request.PageSize,
+	}, nil
+}
+
+func (s *Service) GetReview(ctx context.Context, filmID int, reviewID int) (dto.Review, error) {
+	review, err := s.getFilmReview(ctx, filmID, reviewID)
+	if err != nil {
+		return dto.Review{}, err
+	}
+	return toReviewDTO(review), nil
+}
+
+func (s *Service) CreateReview(ctx context.Context, request dto.ReviewCreateRequest) error {
+	err := s.filter.Check(request.Title, request.Body)
+	if err != nil {
+		return err
+	}
+	err = s.repo.CreateReview(ctx, entities.Review{
+		FilmID:  request.FilmID,
+		UserID:  request.UserID,
+		Title:   request.Title,
+		Body:    request.Body,
+		Spoiler: request.Spoiler,
+	})
+	if err != nil {
+		if customerror.IsUniqueViolation(err) {
+			return customerror.NewCustomError(kterrors.ReviewAlreadyExistsError)
+		}
+		if customerror.IsForeignKeyViolation(err) {
+			return customerror.NewCustomErrorWithHttpCode(kterrors.FilmNotFoundError, http.StatusNotFound)
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *Service) UpdateReview(ctx context.Context, request dto.ReviewUpdateRequest) error {
+	review, err := s.getFilmReview(ctx, request.FilmID, request.ReviewID)
+	if err != nil {
+		return err
+	}
+	if review.UserID != request.UserID {
+		return customerror.NewCustomError(kterrors.UserCannotUpdateReviewError)
+	}
+	err = s.filter.Check(request.Title, request.Body)
+	if err != nil {
+		return err
+	}
+	review.Title = request.Title
+	review.Body = request.Body
+	review.Spoiler = request.Spoiler
+	return s.repo.UpdateReview(ctx, review)
+}
+
+func (s *Service) DeleteReview(ctx context.Context, filmID int, reviewID int, userID int) error {
+	review, err := s.getFilmReview(ctx, filmID, reviewID)
+	if err != nil {
+		return err
+	}
+	if review.UserID != userID {
+		return customerror.NewCustomError(kterrors.UserCannotDeleteReviewError)
+	}
+	return s.repo.DeleteReview(ctx, reviewID)
+}
+
+func (s *Service) LikeReview(ctx context.Context, filmID int, reviewID int, userID int) error {
+	_, err := s.getFilmReview(ctx, filmID, reviewID)
+	if err != nil {
+		return err
+	}
+	err = s.repo.LikeReview(ctx, reviewID, userID)
+	if customerror.IsUniqueViolation(err) {
+		return customerror.NewCustomError(kterrors.ReviewAlreadyLikedError)
+	}
+	return err
+}
+
+func (s *Service) UnlikeReview(ctx context.Context, filmID int, reviewID int, userID int) error {
+	_, err := s.getFilmReview(ctx, filmID, reviewID)
+	if err != nil {
+		return err
+	}
+	err = s.repo.UnlikeReview(ctx, reviewID, userID)
+	if customerror.IsNotFoundError(err) {
+		return customerror.NewCustomErrorWithHttpCode(kterrors.ReviewNotLikedError, http.StatusNotFound)
+	}
+	return err
+}
+
+// getFilmReview loads a review reached through the given film, a review of another film
+// or one hidden by a moderator is not found
+func (s *Service) getFilmReview(ctx context.Context, filmID int, reviewID int) (entities.Review, error) {
+	review, err := s.repo.GetReview(ctx, reviewID)
+	if err != nil {
+		if customerror.IsNotFoundError(err) {
+			return entities.Review{}, newReviewNotFoundError()
+		}
+		return entities.Review{}, err
+	}
+	if review.FilmID != filmID || review.Hidden {
+		return entities.Review{}, newReviewNotFoundError()
+	}
+	return review, nil
+}
+
+func newReviewNotFoundError() error {
+	return customerror.NewCustomErrorWithHttpCode(kterrors.ReviewNotFoundError, http.StatusNotFound)
+}
+
+func toReviewDTO(review entities.Review) dto.Review {
+	result := dto.Review{
+		ID:        review.ID,
+		FilmID:    review.FilmID,
+		UserID:    review.UserID,
+		Title:     review.Title,
+		Body:      review.Body,
+		Spoiler:   review.Spoiler,
+		LikeCount: review.LikeCount,
+	}
+	if review.CreatedAt != nil {
+		result.CreatedAt = *review.CreatedAt
+	}
+	if review.UpdatedAt != nil {
+		result.UpdatedAt = *review.UpdatedAt
+	}
+	return result
+}
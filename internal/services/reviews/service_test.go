@@ -0,0 +1,496 @@
+package reviews
+
+import (
+	"KTOnlinePlatform/internal/dto"
+	"KTOnlinePlatform/internal/models"
+	"KTOnlinePlatform/internal/models/consts"
+	"KTOnlinePlatform/internal/models/kterrors"
+	"KTOnlinePlatform/internal/services/reviews/mocks"
+	"KTOnlinePlatform/pkg/customerror"
+	"KTOnlinePlatform/pkg/database/entities"
+	"KTOnlinePlatform/pkg/logger"
+	"KTOnlinePlatform/pkg/wordfilter"
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+var testBannedWords = []string{"scam"}
+
+func commentID(id int) *int {
+	return &id
+}
+
+func TestGetReviewsPaginated(t *testing.T) {
+	logger.InitializeForTest()
+	createdAt := time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name           string
+		request        dto.ReviewSearchRequest
+		mockBehavior   func(*mocks.Repository)
+		expectedResult dto.ReviewsPaginated
+		expectedError  error
+	}{
+		{
+			name:    "Most recent by default",
+			request: dto.ReviewSearchRequest{FilmID: 1, Page: 1, PageSize: 10},
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("GetReviewsPaginated", mock.Anything, 1, consts.ReviewSortRecent, 10, 0).Return(
+					[]models.ReviewPaginated{
+						{ID: 4, UserID: 100, Username: "alice", Title: "Great", Body: "Loved it", LikeCount: 2, CreatedAt: createdAt, UpdatedAt: createdAt, Qty: 1},
+					}, nil)
+			},
+			expectedResult: dto.ReviewsPaginated{
+				Reviews: []dto.Review{
+					{ID: 4, FilmID: 1, UserID: 100, Username: "alice", Title: "Great", Body: "Loved it", LikeCount: 2, CreatedAt: createdAt, UpdatedAt: createdAt},
+				},
+				Count:    1,
+				Page:     1,
+				PageSize: 10,
+			},
+		},
+		{
+			name:    "Most helpful",
+			request: dto.ReviewSearchRequest{FilmID: 1, Sort: consts.ReviewSortHelpful, Page: 2, PageSize: 10},
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("GetReviewsPaginated", mock.Anything, 1, consts.ReviewSortHelpful, 10, 10).Return(
+					[]models.ReviewPaginated{}, nil)
+			},
+			expectedResult: dto.ReviewsPaginated{
+				Page:     2,
+				PageSize: 10,
+			},
+		},
+		{
+			name:         "Unknown sort",
+			request:      dto.ReviewSearchRequest{FilmID: 1, Sort: "oldest", Page: 1, PageSize: 10},
+			mockBehavior: func(mr *mocks.Repository) {},
+			expectedError: customerror.NewI18nErrorWithParams(
+				kterrors.InvalidReviewSortError,
+				map[string]interface{}{"sort": "oldest"}),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := mocks.NewRepository(t)
+			tc.mockBehavior(mockRepo)
+			service := NewService(mockRepo, wordfilter.NewFilter(testBannedWords))
+
+			result, err := service.GetReviewsPaginated(context.Background(), tc.request)
+
+			if tc.expectedError != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tc.expectedError.Error(), err.Error())
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedResult, result)
+		})
+	}
+}
+
+func TestCreateReview(t *testing.T) {
+	logger.InitializeForTest()
+	request := dto.ReviewCreateRequest{FilmID: 1, Title: "Great", Body: "Loved it", Spoiler: true, UserID: 100}
+	review := entities.Review{FilmID: 1, UserID: 100, Title: "Great", Body: "Loved it", Spoiler: true}
+
+	testCases := []struct {
+		name          string
+		request       dto.ReviewCreateRequest
+		mockBehavior  func(*mocks.Repository)
+		expectedError error
+	}{
+		{
+			name:    "Review is created",
+			request: request,
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("CreateReview", mock.Anything, review).Return(nil)
+			},
+		},
+		{
+			name:    "Second review of the same film",
+			request: request,
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("CreateReview", mock.Anything, review).Return(gorm.ErrDuplicatedKey)
+			},
+			expectedError: customerror.NewCustomError(kterrors.ReviewAlreadyExistsError),
+		},
+		{
+			name:    "Film does not exist",
+			request: request,
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("CreateReview", mock.Anything, review).Return(gorm.ErrForeignKeyViolated)
+			},
+			expectedError: customerror.NewCustomErrorWithHttpCode(kterrors.FilmNotFoundError, http.StatusNotFound),
+		},
+		{
+			name:         "Review with banned words",
+			request:      dto.ReviewCreateRequest{FilmID: 1, Title: "Great", Body: "A scam", UserID: 100},
+			mockBehavior: func(mr *mocks.Repository) {},
+			expectedError: customerror.NewI18nErrorWithParams(kterrors.ContentContainsBannedWordsError,
+				map[string]interface{}{"words": []string{"scam"}}),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := mocks.NewRepository(t)
+			tc.mockBehavior(mockRepo)
+			service := NewService(mockRepo, wordfilter.NewFilter(testBannedWords))
+
+			err := service.CreateReview(context.Background(), tc.request)
+
+			if tc.expectedError != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tc.expectedError.Error(), err.Error())
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestUpdateReview(t *testing.T) {
+	logger.InitializeForTest()
+
+	testCases := []struct {
+		name          string
+		request       dto.ReviewUpdateRequest
+		mockBehavior  func(*mocks.Repository)
+		expectedError error
+	}{
+		{
+			name:    "Author updates the review",
+			request: dto.ReviewUpdateRequest{FilmID: 1, ReviewID: 4, Title: "Great", Body: "Edited", UserID: 100},
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("GetReview", mock.Anything, 4).Return(entities.Review{ID: 4, FilmID: 1, UserID: 100, Title: "Good"}, nil)
+				mr.On("UpdateReview", mock.Anything, entities.Review{ID: 4, FilmID: 1, UserID: 100, Title: "Great", Body: "Edited"}).Return(nil)
+			},
+		},
+		{
+			name:    "Unauthorized review update",
+			request: dto.ReviewUpdateRequest{FilmID: 1, ReviewID: 4, Title: "Great", Body: "Edited", UserID: 200},
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("GetReview", mock.Anything, 4).Return(entities.Review{ID: 4, FilmID: 1, UserID: 100}, nil)
+			},
+			expectedError: customerror.NewCustomError(kterrors.UserCannotUpdateReviewError),
+		},
+		{
+			name:    "Review with banned words",
+			request: dto.ReviewUpdateRequest{FilmID: 1, ReviewID: 4, Title: "Scam", Body: "Edited", UserID: 100},
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("GetReview", mock.Anything, 4).Return(entities.Review{ID: 4, FilmID: 1, UserID: 100, Title: "Good"}, nil)
+			},
+			expectedError: customerror.NewI18nErrorWithParams(kterrors.ContentContainsBannedWordsError,
+				map[string]interface{}{"words": []string{"scam"}}),
+		},
+		{
+			name:    "Review of another film",
+			request: dto.ReviewUpdateRequest{FilmID: 2, ReviewID: 4, Title: "Great", Body: "Edited", UserID: 100},
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("GetReview", mock.Anything, 4).Return(entities.Review{ID: 4, FilmID: 1, UserID: 100}, nil)
+			},
+			expectedError: customerror.NewCustomErrorWithHttpCode(kterrors.ReviewNotFoundError, http.StatusNotFound),
+		},
+		{
+			name:    "Review not found",
+			request: dto.ReviewUpdateRequest{FilmID: 1, ReviewID: 9, Title: "Great", Body: "Edited", UserID: 100},
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("GetReview", mock.Anything, 9).Return(entities.Review{}, gorm.ErrRecordNotFound)
+			},
+			expectedError: customerror.NewCustomErrorWithHttpCode(kterrors.ReviewNotFoundError, http.StatusNotFound),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := mocks.NewRepository(t)
+			tc.mockBehavior(mockRepo)
+			service := NewService(mockRepo, wordfilter.NewFilter(testBannedWords))
+
+			err := service.UpdateReview(context.Background(), tc.request)
+
+			if tc.expectedError != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tc.expectedError.Error(), err.Error())
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestDeleteReview(t *testing.T) {
+	logger.InitializeForTest()
+
+	testCases := []struct {
+		name          string
+		userID        int
+		mockBehavior  func(*mocks.Repository)
+		expectedError error
+	}{
+		{
+			name:   "Author deletes the review",
+			userID: 100,
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("GetReview", mock.Anything, 4).Return(entities.Review{ID: 4, FilmID: 1, UserID: 100}, nil)
+				mr.On("DeleteReview", mock.Anything, 4).Return(nil)
+			},
+		},
+		{
+			name:   "Unauthorized review deletion",
+			userID: 200,
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("GetReview", mock.Anything, 4).Return(entities.Review{ID: 4, FilmID: 1, UserID: 100}, nil)
+			},
+			expectedError: customerror.NewCustomError(kterrors.UserCannotDeleteReviewError),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := mocks.NewRepository(t)
+			tc.mockBehavior(mockRepo)
+			service := NewService(mockRepo, wordfilter.NewFilter(testBannedWords))
+
+			err := service.DeleteReview(context.Background(), 1, 4, tc.userID)
+
+			if tc.expectedError != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tc.expectedError.Error(), err.Error())
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestLikeReview(t *testing.T) {
+	logger.InitializeForTest()
+
+	testCases := []struct {
+		name          string
+		unlike        bool
+		mockBehavior  func(*mocks.Repository)
+		expectedError error
+	}{
+		{
+			name: "Like is recorded",
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("GetReview", mock.Anything, 4).Return(entities.Review{ID: 4, FilmID: 1, UserID: 100}, nil)
+				mr.On("LikeReview", mock.Anything, 4, 200).Return(nil)
+			},
+		},
+		{
+			name: "Review liked twice",
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("GetReview", mock.Anything, 4).Return(entities.Review{ID: 4, FilmID: 1, UserID: 100}, nil)
+				mr.On("LikeReview", mock.Anything, 4, 200).Return(gorm.ErrDuplicatedKey)
+			},
+			expectedError: customerror.NewCustomError(kterrors.ReviewAlreadyLikedError),
+		},
+		{
+			name:   "Unlike a review that was not liked",
+			unlike: true,
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("GetReview", mock.Anything, 4).Return(entities.Review{ID: 4, FilmID: 1, UserID: 100}, nil)
+				mr.On("UnlikeReview", mock.Anything, 4, 200).Return(gorm.ErrRecordNotFound)
+			},
+			expectedError: customerror.NewCustomErrorWithHttpCode(kterrors.ReviewNotLikedError, http.StatusNotFound),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := mocks.NewRepository(t)
+			tc.mockBehavior(mockRepo)
+			service := NewService(mockRepo, wordfilter.NewFilter(testBannedWords))
+
+			var err error
+			if tc.unlike {
+				err = service.UnlikeReview(context.Background(), 1, 4, 200)
+			} else {
+				err = service.LikeReview(context.Background(), 1, 4, 200)
+			}
+
+			if tc.expectedError != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tc.expectedError.Error(), err.Error())
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestGetCommentsPaginated(t *testing.T) {
+	logger.InitializeForTest()
+	mockRepo := mocks.NewRepository(t)
+	mockRepo.On("GetReview", mock.Anything, 4).Return(entities.Review{ID: 4, FilmID: 1, UserID: 100}, nil)
+	mockRepo.On("GetRootCommentsPaginated", mock.Anything, 4, 10, 0).Return(
+		[]models.ReviewComment{
+			{ID: 10, UserID: 100, Username: "alice", Body: "First", Qty: 2},
+			{ID: 11, UserID: 200, Username: "bob", Body: "Second", Qty: 2},
+		}, nil)
+	mockRepo.On("GetCommentReplies", mock.Anything, []int{10, 11}).Return(
+		[]models.ReviewComment{
+			{ID: 12, ParentID: commentID(10), RootID: commentID(10), UserID: 200, Username: "bob", Body: "Reply"},
+			{ID: 13, ParentID: commentID(12), RootID: commentID(10), UserID: 100, Username: "alice", Body: "Reply to reply"},
+		}, nil)
+	service := NewService(mockRepo, wordfilter.NewFilter(testBannedWords))
+
+	result, err := service.GetCommentsPaginated(context.Background(), dto.CommentSearchRequest{FilmID: 1, ReviewID: 4, Page: 1, PageSize: 10})
+
+	assert.NoError(t, err)
+	assert.Equal(t, dto.CommentsPaginated{
+		Comments: []dto.Comment{
+			{ID: 10, UserID: 100, Username: "alice", Body: "First", Replies: []dto.Comment{
+				{ID: 12, ParentID: commentID(10), UserID: 200, Username: "bob", Body: "Reply", Replies: []dto.Comment{
+					{ID: 13, ParentID: commentID(12), UserID: 100, Username: "alice", Body: "Reply to reply", Replies: []dto.Comment{}},
+				}},
+			}},
+			{ID: 11, UserID: 200, Username: "bob", Body: "Second", Replies: []dto.Comment{}},
+		},
+		Count:    2,
+		Page:     1,
+		PageSize: 10,
+	}, result)
+}
+
+func TestCreateComment(t *testing.T) {
+	logger.InitializeForTest()
+
+	testCases := []struct {
+		name          string
+		request       dto.CommentCreateRequest
+		mockBehavior  func(*mocks.Repository)
+		expectedError error
+	}{
+		{
+			name:    "Top level comment",
+			request: dto.CommentCreateRequest{FilmID: 1, ReviewID: 4, Body: "First", UserID: 200},
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("GetReview", mock.Anything, 4).Return(entities.Review{ID: 4, FilmID: 1, UserID: 100}, nil)
+				mr.On("CreateComment", mock.Anything, entities.ReviewComment{ReviewID: 4, UserID: 200, Body: "First"}).Return(nil)
+			},
+		},
+		{
+			name:    "Reply to a top level comment",
+			request: dto.CommentCreateRequest{FilmID: 1, ReviewID: 4, ParentID: commentID(10), Body: "Reply", UserID: 200},
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("GetReview", mock.Anything, 4).Return(entities.Review{ID: 4, FilmID: 1, UserID: 100}, nil)
+				mr.On("GetComment", mock.Anything, 10).Return(entities.ReviewComment{ID: 10, ReviewID: 4, UserID: 100}, nil)
+				mr.On("CreateComment", mock.Anything, entities.ReviewComment{
+					ReviewID: 4, ParentID: commentID(10), RootID: commentID(10), UserID: 200, Body: "Reply",
+				}).Return(nil)
+			},
+		},
+		{
+			name:    "Nested reply keeps the thread root",
+			request: dto.CommentCreateRequest{FilmID: 1, ReviewID: 4, ParentID: commentID(12), Body: "Reply", UserID: 100},
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("GetReview", mock.Anything, 4).Return(entities.Review{ID: 4, FilmID: 1, UserID: 100}, nil)
+				mr.On("GetComment", mock.Anything, 12).Return(entities.ReviewComment{ID: 12, ReviewID: 4, ParentID: commentID(10), RootID: commentID(10), UserID: 200}, nil)
+				mr.On("CreateComment", mock.Anything, entities.ReviewComment{
+					ReviewID: 4, ParentID: commentID(12), RootID: commentID(10), UserID: 100, Body: "Reply",
+				}).Return(nil)
+			},
+		},
+		{
+			name:         "Comment with banned words",
+			request:      dto.CommentCreateRequest{FilmID: 1, ReviewID: 4, Body: "Total scam", UserID: 200},
+			mockBehavior: func(mr *mocks.Repository) {},
+			expectedError: customerror.NewI18nErrorWithParams(kterrors.ContentContainsBannedWordsError,
+				map[string]interface{}{"words": []string{"scam"}}),
+		},
+		{
+			name:    "Parent comment on another review",
+			request: dto.CommentCreateRequest{FilmID: 1, ReviewID: 4, ParentID: commentID(20), Body: "Reply", UserID: 200},
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("GetReview", mock.Anything, 4).Return(entities.Review{ID: 4, FilmID: 1, UserID: 100}, nil)
+				mr.On("GetComment", mock.Anything, 20).Return(entities.ReviewComment{ID: 20, ReviewID: 5, UserID: 100}, nil)
+			},
+			expectedError: customerror.NewCustomErrorWithHttpCode(kterrors.CommentNotFoundError, http.StatusNotFound),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := mocks.NewRepository(t)
+			tc.mockBehavior(mockRepo)
+			service := NewService(mockRepo, wordfilter.NewFilter(testBannedWords))
+
+			err := service.CreateComment(context.Background(), tc.request)
+
+			if tc.expectedError != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tc.expectedError.Error(), err.Error())
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestDeleteComment(t *testing.T) {
+	logger.InitializeForTest()
+
+	testCases := []struct {
+		name          string
+		userID        int
+		mockBehavior  func(*mocks.Repository)
+		expectedError error
+	}{
+		{
+			name:   "Author deletes the comment",
+			userID: 200,
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("GetReview", mock.Anything, 4).Return(entities.Review{ID: 4, FilmID: 1, UserID: 100}, nil)
+				mr.On("GetComment", mock.Anything, 10).Return(entities.ReviewComment{ID: 10, ReviewID: 4, UserID: 200}, nil)
+				mr.On("DeleteComment", mock.Anything, 10).Return(nil)
+			},
+		},
+		{
+			name:   "Unauthorized comment deletion",
+			userID: 100,
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("GetReview", mock.Anything, 4).Return(entities.Review{ID: 4, FilmID: 1, UserID: 100}, nil)
+				mr.On("GetComment", mock.Anything, 10).Return(entities.ReviewComment{ID: 10, ReviewID: 4, UserID: 200}, nil)
+			},
+			expectedError: customerror.NewCustomError(kterrors.UserCannotDeleteCommentError),
+		},
+		{
+			name:   "Repository error",
+			userID: 200,
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("GetReview", mock.Anything, 4).Return(entities.Review{}, errors.New("database error"))
+			},
+			expectedError: errors.New("database error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := mocks.NewRepository(t)
+			tc.mockBehavior(mockRepo)
+			service := NewService(mockRepo, wordfilter.NewFilter(testBannedWords))
+
+			err := service.DeleteComment(context.Background(), 1, 4, 10, tc.userID)
+
+			if tc.expectedError != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tc.expectedError.Error(), err.Error())
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,107 @@
+// Code generated by mockery v2.52.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	models "KTOnlinePlatform/internal/models"
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Repository is an autogenerated mock type for the Repository type
+type Repository struct {
+	mock.Mock
+}
+
+// GetNewReleases provides a mock function with given fields: ctx, pageSize, offset
+func (_m *Repository) GetNewReleases(ctx context.Context, pageSize int, offset int) ([]models.FilmPaginated, error) {
+	ret := _m.Called(ctx, pageSize, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetNewReleases")
+	}
+
+	var r0 []models.FilmPaginated
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) ([]models.FilmPaginated, error)); ok {
+		return rf(ctx, pageSize, offset)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) []models.FilmPaginated); ok {
+		r0 = rf(ctx, pageSize, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.FilmPaginated)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, pageSize, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTrending provides a mock function with given fields: ctx, window, pageSize, offset
+func (_m *Repository) GetTrending(ctx context.Context, window string, pageSize int, offset int) ([]models.FilmPaginated, error) {
+	ret := _m.Called(ctx, window, pageSize, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTrending")
+	}
+
+	var r0 []models.FilmPaginated
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) ([]models.FilmPaginated, error)); ok {
+		return rf(ctx, window, pageSize, offset)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) []models.FilmPaginated); ok {
+		r0 = rf(ctx, window, pageSize, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.FilmPaginated)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int, int) error); ok {
+		r1 = rf(ctx, window, pageSize, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RebuildTrending provides a mock function with given fields: ctx, window, weights
+func (_m *Repository) RebuildTrending(ctx context.Context, window models.TrendingWindow, weights models.TrendingWeights) error {
+	ret := _m.Called(ctx, window, weights)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RebuildTrending")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.TrendingWindow, models.TrendingWeights) error); ok {
+		r0 = rf(ctx, window, weights)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewRepository creates a new instance of Repository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Repository {
+	mock := &Repository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
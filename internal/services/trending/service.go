@@ -0,0 +1,143 @@
+package trending
+
+import (
+	"KTOnlinePlatform/internal/dto"
+	"KTOnlinePlatform/internal/models"
+	"KTOnlinePlatform/internal/models/consts"
+	"KTOnlinePlatform/internal/models/kterrors"
+	"KTOnlinePlatform/pkg/customerror"
+	"KTOnlinePlatform/pkg/logger"
+	"KTOnlinePlatform/pkg/utils"
+	"context"
+	"github.com/samber/lo"
+	"time"
+)
+
+type Repository interface {
+	RebuildTrending(ctx context.Context, window models.TrendingWindow, weights models.TrendingWeights) error
+	GetTrending(ctx context.Context, window string, pageSize int, offset int) ([]models.FilmPaginated, error)
+	GetNewReleases(ctx context.Context, pageSize int, offset int) ([]models.FilmPaginated, error)
+}
+
+// windows are rebuilt in this order, engagement older than the period is ignored and
+// the rest fades with the half life
+var windows = []models.TrendingWindow{
+	{Name: consts.TrendingWindowDay, Period: 24 * time.Hour, HalfLife: 6 * time.Hour},
+	{Name: consts.TrendingWindowWeek, Period: 7 * 24 * time.Hour, HalfLife: 2 * 24 * time.Hour},
+	{Name: consts.TrendingWindowMonth, Period: 30 * 24 * time.Hour, HalfLife: 7 * 24 * time.Hour},
+	{Name: consts.TrendingWindowAll},
+}
+
+var weights = models.TrendingWeights{
+	View:   consts.TrendingViewWeight,
+	Rating: consts.TrendingRatingWeight,
+	List:   consts.TrendingListWeight,
+}
+
+// Posters signs the poster URLs of the films listed
+type Posters interface {
+	Poster(posterKey string) *dto.Poster
+}
+
+type Service struct {
+	repo    Repository
+	posters Posters
+}
+
+func NewService(repo Repository, posters Posters) *Service {
+	return &Service{
+		repo:    repo,
+		posters: posters,
+	}
+}
+
+// Run rebuilds the rankings right away and then every interval until ctx is done
+func (s *Service) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		err := s.RebuildTrending(ctx)
+		if err != nil {
+			logger.Error().Err(err).Msg("rebuild trending failed")
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RebuildTrending rebuilds every window, one failing does not keep the others from being rebuilt
+func (s *Service) RebuildTrending(ctx context.Context) error {
+	var firstErr error
+	for _, window := range windows {
+		err := s.repo.RebuildTrending(ctx, window, weights)
+		if err != nil {
+			logger.Error().Err(err).Str("window", window.Name).Msg("rebuild trending window failed")
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+	return firstErr
+}
+
+// GetTrending ranks the films by their recent engagement, the week by default
+func (s *Service) GetTrending(ctx context.Context, request dto.TrendingRequest) (dto.FilmsPaginated, error) {
+	if request.Window == "" {
+		request.Window = consts.TrendingWindowWeek
+	}
+	if request.Window != consts.TrendingWindowDay &&
+		request.Window != consts.TrendingWindowWeek &&
+		request.Window != consts.TrendingWindowMonth {
+		return dto.FilmsPaginated{}, customerror.NewI18nErrorWithParams(
+			kterrors.InvalidTrendingWindowError,
+			map[string]interface{}{"window": request.Window})
+	}
+	result, err := s.repo.GetTrending(ctx, request.Window, request.PageSize, utils.CalculateOffset(request.Page, request.PageSize))
+	if err != nil {
+		return dto.FilmsPaginated{}, err
+	}
+	return s.toFilmsPaginated(result, request.Page, request.PageSize), nil
+}
+
+// GetPopular ranks the films by their engagement since the beginning
+func (s *Service) GetPopular(ctx context.Context, request dto.FeedRequest) (dto.FilmsPaginated, error) {
+	result, err := s.repo.GetTrending(ctx, consts.TrendingWindowAll, request.PageSize, utils.CalculateOffset(request.Page, request.PageSize))
+	if err != nil {
+		return dto.FilmsPaginated{}, err
+	}
+	return s.toFilmsPaginated(result, request.Page, request.PageSize), nil
+}
+
+func (s *Service) GetNewReleases(ctx context.Context, request dto.FeedRequest) (dto.FilmsPaginated, error) {
+	result, err := s.repo.GetNewReleases(ctx, request.PageSize, utils.CalculateOffset(request.Page, request.PageSize))
+	if err != nil {
+		return dto.FilmsPaginated{}, err
+	}
+	return s.toFilmsPaginated(result, request.Page, request.PageSize), nil
+}
+
+func (s *Service) toFilmsPaginated(result []models.FilmPaginated, page int, pageSize int) dto.FilmsPaginated {
+	if len(result) == 0 {
+		return dto.FilmsPaginated{
+			Page:     page,
+			PageSize: pageSize,
+		}
+	}
+	return dto.FilmsPaginated{
+		Films: lo.Map(result, func(item models.FilmPaginated, index int) dto.Film {
+			return dto.Film{
+				ID:            item.ID,
+				Title:         item.Title,
+				AverageRating: utils.AverageRating(item.RatingSum, item.RatingCount),
+				VoteCount:     item.RatingCount,
+				Poster:        s.posters.Poster(item.PosterKey),
+			}
+		}),
+		Count:    result[0].Qty,
+		Page:     page,
+		PageSize: pageSize,
+	}
+}
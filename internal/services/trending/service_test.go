@@ -0,0 +1,148 @@
+package trending
+
+import (
+	"KTOnlinePlatform/internal/dto"
+	"KTOnlinePlatform/internal/models"
+	"KTOnlinePlatform/internal/models/consts"
+	"KTOnlinePlatform/internal/models/kterrors"
+	"KTOnlinePlatform/internal/services/trending/mocks"
+	"KTOnlinePlatform/pkg/customerror"
+	"KTOnlinePlatform/pkg/logger"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetTrending(t *testing.T) {
+	logger.InitializeForTest()
+
+	testCases := []struct {
+		name           string
+		request        dto.TrendingRequest
+		mockBehavior   func(*mocks.Repository)
+		expectedResult dto.FilmsPaginated
+		expectedError  error
+	}{
+		{
+			name:    "Default window is the week",
+			request: dto.TrendingRequest{Page: 2, PageSize: 10},
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("GetTrending", mock.Anything, consts.TrendingWindowWeek, 10, 10).Return([]models.FilmPaginated{
+					{ID: 1, Title: "Heat", RatingSum: 17, RatingCount: 2, PosterKey: "posters/1/ab", Qty: 11},
+				}, nil)
+			},
+			expectedResult: dto.FilmsPaginated{
+				Films: []dto.Film{{ID: 1, Title: "Heat", AverageRating: 8.5, VoteCount: 2, Poster: &dto.Poster{
+					Small: "posters/1/ab/small", Medium: "posters/1/ab/medium", Large: "posters/1/ab/large",
+				}}},
+				Count:    11,
+				Page:     2,
+				PageSize: 10,
+			},
+		},
+		{
+			name:    "No engagement in the window",
+			request: dto.TrendingRequest{Window: consts.TrendingWindowDay, Page: 1, PageSize: 10},
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("GetTrending", mock.Anything, consts.TrendingWindowDay, 10, 0).Return([]models.FilmPaginated{}, nil)
+			},
+			expectedResult: dto.FilmsPaginated{Page: 1, PageSize: 10},
+		},
+		{
+			name:          "All time ranking is only served as the popular feed",
+			request:       dto.TrendingRequest{Window: consts.TrendingWindowAll, Page: 1, PageSize: 10},
+			mockBehavior:  func(mr *mocks.Repository) {},
+			expectedError: customerror.NewI18nErrorWithParams(kterrors.InvalidTrendingWindowError, map[string]interface{}{"window": consts.TrendingWindowAll}),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := mocks.NewRepository(t)
+			tc.mockBehavior(mockRepo)
+			service := NewService(mockRepo, testPosters{})
+
+			result, err := service.GetTrending(context.Background(), tc.request)
+
+			if tc.expectedError != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tc.expectedError.Error(), err.Error())
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedResult, result)
+		})
+	}
+}
+
+func TestGetPopular(t *testing.T) {
+	logger.InitializeForTest()
+
+	mockRepo := mocks.NewRepository(t)
+	mockRepo.On("GetTrending", mock.Anything, consts.TrendingWindowAll, 10, 0).Return([]models.FilmPaginated{
+		{ID: 1, Title: "Heat", Qty: 1},
+	}, nil)
+	service := NewService(mockRepo, testPosters{})
+
+	result, err := service.GetPopular(context.Background(), dto.FeedRequest{Page: 1, PageSize: 10})
+
+	assert.NoError(t, err)
+	assert.Equal(t, dto.FilmsPaginated{Films: []dto.Film{{ID: 1, Title: "Heat"}}, Count: 1, Page: 1, PageSize: 10}, result)
+}
+
+func TestRebuildTrending(t *testing.T) {
+	logger.InitializeForTest()
+
+	testCases := []struct {
+		name          string
+		mockBehavior  func(*mocks.Repository)
+		expectedError error
+	}{
+		{
+			name: "Every window is rebuilt",
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("RebuildTrending", mock.Anything, mock.AnythingOfType("models.TrendingWindow"), weights).Return(nil).Times(len(windows))
+			},
+		},
+		{
+			name: "A failing window does not stop the others",
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("RebuildTrending", mock.Anything, mock.MatchedBy(func(window models.TrendingWindow) bool {
+					return window.Name == consts.TrendingWindowDay
+				}), weights).Return(errors.New("statement timeout")).Once()
+				mr.On("RebuildTrending", mock.Anything, mock.AnythingOfType("models.TrendingWindow"), weights).Return(nil).Times(len(windows) - 1)
+			},
+			expectedError: errors.New("statement timeout"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := mocks.NewRepository(t)
+			tc.mockBehavior(mockRepo)
+			service := NewService(mockRepo, testPosters{})
+
+			err := service.RebuildTrending(context.Background())
+
+			if tc.expectedError != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tc.expectedError.Error(), err.Error())
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+// testPosters stands for the films service, the URLs are the poster key
+type testPosters struct{}
+
+func (testPosters) Poster(posterKey string) *dto.Poster {
+	if posterKey == "" {
+		return nil
+	}
+	return &dto.Poster{Small: posterKey + "/small", Medium: posterKey + "/medium", Large: posterKey + "/large"}
+}
@@ -0,0 +1,190 @@
+// Code generated by mockery v2.52.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	entities "KTOnlinePlatform/pkg/database/entities"
+	entitiescustom "KTOnlinePlatform/pkg/database/entities/entitiescustom"
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// Repository is an autogenerated mock type for the Repository type
+type Repository struct {
+	mock.Mock
+}
+
+// DeleteRetiredVideoVersion provides a mock function with given fields: ctx, ID
+func (_m *Repository) DeleteRetiredVideoVersion(ctx context.Context, ID int) error {
+	ret := _m.Called(ctx, ID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteRetiredVideoVersion")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
+		r0 = rf(ctx, ID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetFilm provides a mock function with given fields: ctx, ID
+func (_m *Repository) GetFilm(ctx context.Context, ID int) (entities.Film, error) {
+	ret := _m.Called(ctx, ID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFilm")
+	}
+
+	var r0 entities.Film
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (entities.Film, error)); ok {
+		return rf(ctx, ID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) entities.Film); ok {
+		r0 = rf(ctx, ID)
+	} else {
+		r0 = ret.Get(0).(entities.Film)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, ID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRetiredVideoVersions provides a mock function with given fields: ctx, before
+func (_m *Repository) GetRetiredVideoVersions(ctx context.Context, before time.Time) ([]entities.VideoRetiredVersion, error) {
+	ret := _m.Called(ctx, before)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRetiredVideoVersions")
+	}
+
+	var r0 []entities.VideoRetiredVersion
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) ([]entities.VideoRetiredVersion, error)); ok {
+		return rf(ctx, before)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []entities.VideoRetiredVersion); ok {
+		r0 = rf(ctx, before)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entities.VideoRetiredVersion)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, before)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetVideoAsset provides a mock function with given fields: ctx, filmID
+func (_m *Repository) GetVideoAsset(ctx context.Context, filmID int) (entities.VideoAsset, error) {
+	ret := _m.Called(ctx, filmID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetVideoAsset")
+	}
+
+	var r0 entities.VideoAsset
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (entities.VideoAsset, error)); ok {
+		return rf(ctx, filmID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) entities.VideoAsset); ok {
+		r0 = rf(ctx, filmID)
+	} else {
+		r0 = ret.Get(0).(entities.VideoAsset)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, filmID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SaveVideoAsset provides a mock function with given fields: ctx, asset
+func (_m *Repository) SaveVideoAsset(ctx context.Context, asset entities.VideoAsset) error {
+	ret := _m.Called(ctx, asset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveVideoAsset")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entities.VideoAsset) error); ok {
+		r0 = rf(ctx, asset)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetVideoReady provides a mock function with given fields: ctx, filmID, version, renditions
+func (_m *Repository) SetVideoReady(ctx context.Context, filmID int, version string, renditions entitiescustom.VideoRenditions) error {
+	ret := _m.Called(ctx, filmID, version, renditions)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetVideoReady")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, string, entitiescustom.VideoRenditions) error); ok {
+		r0 = rf(ctx, filmID, version, renditions)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetVideoStatus provides a mock function with given fields: ctx, filmID, version, status, message
+func (_m *Repository) SetVideoStatus(ctx context.Context, filmID int, version string, status string, message string) error {
+	ret := _m.Called(ctx, filmID, version, status, message)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetVideoStatus")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, string, string, string) error); ok {
+		r0 = rf(ctx, filmID, version, status, message)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewRepository creates a new instance of Repository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Repository {
+	mock := &Repository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,47 @@
+// Code generated by mockery v2.52.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	transcoder "KTOnlinePlatform/pkg/transcoder"
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Transcoder is an autogenerated mock type for the Transcoder type
+type Transcoder struct {
+	mock.Mock
+}
+
+// HLS provides a mock function with given fields: ctx, source, dir, rendition
+func (_m *Transcoder) HLS(ctx context.Context, source string, dir string, rendition transcoder.Rendition) error {
+	ret := _m.Called(ctx, source, dir, rendition)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HLS")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, transcoder.Rendition) error); ok {
+		r0 = rf(ctx, source, dir, rendition)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewTranscoder creates a new instance of Transcoder. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewTranscoder(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Transcoder {
+	mock := &Transcoder{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,131 @@
+package videos
+
+import (
+	"KTOnlinePlatform/internal/dto"
+	"KTOnlinePlatform/internal/models/consts"
+	"KTOnlinePlatform/internal/models/kterrors"
+	"KTOnlinePlatform/internal/services/jobs"
+	"KTOnlinePlatform/pkg/customerror"
+	"KTOnlinePlatform/pkg/database/entities"
+	"KTOnlinePlatform/pkg/database/entities/entitiescustom"
+	"KTOnlinePlatform/pkg/transcoder"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/samber/lo"
+)
+
+// errTranscodingInterrupted is reported for the uploads whose job was lost
+var errTranscodingInterrupted = errors.New("transcoding was interrupted")
+
+type Repository interface {
+	GetFilm(ctx context.Context, ID int) (entities.Film, error)
+	GetVideoAsset(ctx context.Context, filmID int) (entities.VideoAsset, error)
+	SaveVideoAsset(ctx context.Context, asset entities.VideoAsset) error
+	SetVideoStatus(ctx context.Context, filmID int, version string, status string, message string) error
+	SetVideoReady(ctx context.Context, filmID int, version string, renditions entitiescustom.VideoRenditions) error
+	GetRetiredVideoVersions(ctx context.Context, before time.Time) ([]entities.VideoRetiredVersion, error)
+	DeleteRetiredVideoVersion(ctx context.Context, ID int) error
+}
+
+// BlobStore keeps the source videos and their renditions
+type BlobStore interface {
+	Put(ctx context.Context, key string, contentType string, body io.Reader, size int64) error
+	Get(ctx context.Context, key string) (io.ReadSeekCloser, error)
+	Delete(ctx context.Context, key string) error
+}
+
+// MediaSigner builds and checks the expiring URLs of the rendition playlists and of the segments
+type MediaSigner interface {
+	URL(key string, ttl time.Duration) string
+	Query(key string, ttl time.Duration) url.Values
+	Verify(key string, query url.Values) error
+}
+
+type JobRunner interface {
+	StartJob(ctx context.Context, jobType string, userID int, total int, task jobs.Task) (dto.Job, error)
+}
+
+// Transcoder writes the HLS playlist and segments of a rendition into dir/<rendition name>
+type Transcoder interface {
+	HLS(ctx context.Context, source string, dir string, rendition transcoder.Rendition) error
+}
+
+// renditions is the bitrate ladder every video is transcoded into
+var renditions = []transcoder.Rendition{
+	{Name: "360p", Height: 360, VideoBitrate: 800, AudioBitrate: 96},
+	{Name: "720p", Height: 720, VideoBitrate: 2800, AudioBitrate: 128},
+	{Name: "1080p", Height: 1080, VideoBitrate: 5000, AudioBitrate: 192},
+}
+
+type Service struct {
+	repo       Repository
+	blobs      BlobStore
+	media      MediaSigner
+	jobs       JobRunner
+	transcoder Transcoder
+}
+
+func NewService(repo Repository, blobs BlobStore, media MediaSigner, jobs JobRunner, transcoder Transcoder) *Service {
+	return &Service{
+		repo:       repo,
+		blobs:      blobs,
+		media:      media,
+		jobs:       jobs,
+		transcoder: transcoder,
+	}
+}
+
+// GetVideo lets the creator follow the transcoding of the film video
+func (s *Service) GetVideo(ctx context.Context, request dto.VideoRequest) (dto.VideoAsset, error) {
+	film, err := s.getFilm(ctx, request.FilmID)
+	if err != nil {
+		return dto.VideoAsset{}, err
+	}
+	if film.UserID != request.UserID {
+		return dto.VideoAsset{}, customerror.NewCustomError(kterrors.UserCannotAccessVideoError)
+	}
+	asset, err := s.repo.GetVideoAsset(ctx, request.FilmID)
+	if err != nil {
+		if customerror.IsNotFoundError(err) {
+			return dto.VideoAsset{}, newVideoNotFoundError()
+		}
+		return dto.VideoAsset{}, err
+	}
+	if isStale(asset) {
+		asset.Status = consts.VideoStatusFailed
+		asset.Error = errTranscodingInterrupted.Error()
+	}
+	return dto.VideoAsset{
+		Status: asset.Status,
+		Error:  asset.Error,
+		Renditions: lo.Map(asset.Renditions, func(item entitiescustom.VideoRendition, index int) string {
+			return item.Name
+		}),
+	}, nil
+}
+
+// inProgress tells whether an upload is still transcoding, a stale one does not hold the film anymore
+func inProgress(asset entities.VideoAsset) bool {
+	return (asset.Status == consts.VideoStatusPending || asset.Status == consts.VideoStatusProcessing) && !isStale(asset)
+}
+
+// isStale tells whether an upload pending or processing lost its job
+func isStale(asset entities.VideoAsset) bool {
+	if asset.Status != consts.VideoStatusPending && asset.Status != consts.VideoStatusProcessing {
+		return false
+	}
+	return asset.UpdatedAt != nil && time.Since(*asset.UpdatedAt) > consts.VideoProcessingTimeout
+}
+
+func newVideoNotFoundError() error {
+	return customerror.NewCustomErrorWithHttpCode(kterrors.VideoNotFoundError, http.StatusNotFound)
+}
+
+func newVideoProcessingError() error {
+	return customerror.NewCustomErrorWithHttpCode(kterrors.VideoProcessingError, http.StatusConflict)
+}
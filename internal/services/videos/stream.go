@@ -0,0 +1,146 @@
+package videos
+
+import (
+	"KTOnlinePlatform/internal/dto"
+	"KTOnlinePlatform/internal/models/consts"
+	"KTOnlinePlatform/internal/models/kterrors"
+	"KTOnlinePlatform/pkg/customerror"
+	"KTOnlinePlatform/pkg/database/entities"
+	"KTOnlinePlatform/pkg/database/entities/entitiescustom"
+	"KTOnlinePlatform/pkg/mediasign"
+	"KTOnlinePlatform/pkg/transcoder"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/samber/lo"
+)
+
+const (
+	// streamPath is where the rendition playlists are served, relative to the master playlist
+	streamPath = "stream"
+	// renditionPlaylistKeyFormat is what the URL of a rendition playlist is signed for
+	renditionPlaylistKeyFormat = "films/%d/stream/%s"
+)
+
+// GetMasterPlaylist lists the renditions of the film, players pick one from the bandwidth. The
+// rendition playlists are fetched from URLs signed like the segments, the players have no token
+func (s *Service) GetMasterPlaylist(ctx context.Context, request dto.StreamRequest) (string, error) {
+	asset, err := s.getStreamedAsset(ctx, request.FilmID)
+	if err != nil {
+		return "", err
+	}
+	var playlist strings.Builder
+	playlist.WriteString("#EXTM3U\n#EXT-X-VERSION:3\n#EXT-X-INDEPENDENT-SEGMENTS\n")
+	for _, rendition := range asset.Renditions {
+		query := s.media.Query(fmt.Sprintf(renditionPlaylistKeyFormat, request.FilmID, rendition.Name), consts.StreamURLTTL)
+		fmt.Fprintf(&playlist, "#EXT-X-STREAM-INF:BANDWIDTH=%d,NAME=\"%s\"\n%s/%s?%s\n",
+			rendition.Bandwidth, rendition.Name, streamPath, rendition.Name, query.Encode())
+	}
+	return playlist.String(), nil
+}
+
+// GetRenditionPlaylist checks the signature of the URL and returns the playlist of a rendition
+// with its segments pointing to signed URLs, they expire after consts.StreamURLTTL
+func (s *Service) GetRenditionPlaylist(ctx context.Context, request dto.StreamRequest) (string, error) {
+	err := s.media.Verify(fmt.Sprintf(renditionPlaylistKeyFormat, request.FilmID, request.Rendition), request.Query)
+	if err != nil {
+		if errors.Is(err, mediasign.ErrExpired) {
+			return "", customerror.NewCustomErrorWithHttpCode(kterrors.MediaURLExpiredError, http.StatusForbidden)
+		}
+		return "", customerror.NewCustomErrorWithHttpCode(kterrors.InvalidMediaSignatureError, http.StatusForbidden)
+	}
+	asset, err := s.getStreamedAsset(ctx, request.FilmID)
+	if err != nil {
+		return "", err
+	}
+	rendition, found := lo.Find(asset.Renditions, func(item entitiescustom.VideoRendition) bool {
+		return item.Name == request.Rendition
+	})
+	if !found {
+		return "", customerror.NewCustomErrorWithHttpCode(kterrors.VideoRenditionNotFoundError, http.StatusNotFound)
+	}
+	prefix := videoKey(request.FilmID, asset.ReadyVersion, rendition.Name)
+	playlist, err := s.readBlob(ctx, prefix+"/"+transcoder.PlaylistName)
+	if err != nil {
+		return "", err
+	}
+	lines := strings.Split(playlist, "\n")
+	for i, line := range lines {
+		if isSegment(line) {
+			lines[i] = s.media.URL(prefix+"/"+path.Base(strings.TrimSpace(line)), consts.StreamURLTTL)
+		}
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// getStreamedAsset returns the video of a visible film that has a version ready
+func (s *Service) getStreamedAsset(ctx context.Context, filmID int) (entities.VideoAsset, error) {
+	film, err := s.repo.GetFilm(ctx, filmID)
+	if err != nil {
+		if customerror.IsNotFoundError(err) {
+			return entities.VideoAsset{}, newFilmNotFoundError()
+		}
+		return entities.VideoAsset{}, err
+	}
+	if film.Hidden {
+		return entities.VideoAsset{}, newFilmNotFoundError()
+	}
+	asset, err := s.repo.GetVideoAsset(ctx, filmID)
+	if err != nil {
+		if customerror.IsNotFoundError(err) {
+			return entities.VideoAsset{}, newVideoNotFoundError()
+		}
+		return entities.VideoAsset{}, err
+	}
+	if asset.ReadyVersion == "" {
+		return entities.VideoAsset{}, newVideoNotFoundError()
+	}
+	return asset, nil
+}
+
+func (s *Service) readBlob(ctx context.Context, key string) (string, error) {
+	blob, err := s.blobs.Get(ctx, key)
+	if err != nil {
+		return "", err
+	}
+	defer blob.Close()
+	data, err := io.ReadAll(blob)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// isSegment tells the URI lines of a playlist from its tags and blank lines
+func isSegment(line string) bool {
+	line = strings.TrimSpace(line)
+	return line != "" && !strings.HasPrefix(line, "#")
+}
+
+func segmentNames(playlist string) []string {
+	var names []string
+	for _, line := range strings.Split(playlist, "\n") {
+		if isSegment(line) {
+			names = append(names, path.Base(strings.TrimSpace(line)))
+		}
+	}
+	return names
+}
+
+func newFilmNotFoundError() error {
+	return customerror.NewCustomErrorWithHttpCode(kterrors.FilmNotFoundError, http.StatusNotFound)
+}
+
+// getFilm reports a missing film as a 404 rather than the raw database error
+func (s *Service) getFilm(ctx context.Context, ID int) (entities.Film, error) {
+	film, err := s.repo.GetFilm(ctx, ID)
+	if customerror.IsNotFoundError(err) {
+		return film, newFilmNotFoundError()
+	}
+	return film, err
+}
@@ -0,0 +1,179 @@
+package videos
+
+import (
+	"KTOnlinePlatform/internal/dto"
+	"KTOnlinePlatform/internal/models/consts"
+	"KTOnlinePlatform/internal/models/kterrors"
+	"KTOnlinePlatform/internal/services/videos/mocks"
+	"KTOnlinePlatform/pkg/blobstore"
+	"KTOnlinePlatform/pkg/customerror"
+	"KTOnlinePlatform/pkg/database/entities"
+	"KTOnlinePlatform/pkg/database/entities/entitiescustom"
+	"KTOnlinePlatform/pkg/logger"
+	"KTOnlinePlatform/pkg/mediasign"
+	"KTOnlinePlatform/pkg/transcoder"
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+var testReadyAsset = entities.VideoAsset{
+	FilmID:       1,
+	Status:       consts.VideoStatusProcessing,
+	Version:      "next",
+	ReadyVersion: "v1",
+	Renditions: entitiescustom.VideoRenditions{
+		{Name: "360p", Height: 360, Bandwidth: 976000},
+		{Name: "720p", Height: 720, Bandwidth: 3208000},
+	},
+}
+
+var testSigner = mediasign.NewSigner(testMediaKeys, testMediaURL)
+
+// renditionQuery is the signed query of the playlist URL of a rendition of the film 1
+func renditionQuery(rendition string) url.Values {
+	return testSigner.Query("films/1/stream/"+rendition, consts.StreamURLTTL)
+}
+
+func TestGetMasterPlaylist(t *testing.T) {
+	logger.InitializeForTest()
+
+	testCases := []struct {
+		name             string
+		mockBehavior     func(*mocks.Repository)
+		expectedPlaylist string
+		expectedError    error
+	}{
+		{
+			name: "Ready version is streamed while the next one transcodes",
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("GetFilm", mock.Anything, 1).Return(entities.Film{ID: 1}, nil)
+				mr.On("GetVideoAsset", mock.Anything, 1).Return(testReadyAsset, nil)
+			},
+			expectedPlaylist: "#EXTM3U\n#EXT-X-VERSION:3\n#EXT-X-INDEPENDENT-SEGMENTS\n" +
+				"#EXT-X-STREAM-INF:BANDWIDTH=976000,NAME=\"360p\"\nstream/360p?" + renditionQuery("360p").Encode() + "\n" +
+				"#EXT-X-STREAM-INF:BANDWIDTH=3208000,NAME=\"720p\"\nstream/720p?" + renditionQuery("720p").Encode() + "\n",
+		},
+		{
+			name: "First upload not ready yet",
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("GetFilm", mock.Anything, 1).Return(entities.Film{ID: 1}, nil)
+				mr.On("GetVideoAsset", mock.Anything, 1).Return(entities.VideoAsset{FilmID: 1, Status: consts.VideoStatusPending}, nil)
+			},
+			expectedError: customerror.NewCustomErrorWithHttpCode(kterrors.VideoNotFoundError, http.StatusNotFound),
+		},
+		{
+			name: "Film without video",
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("GetFilm", mock.Anything, 1).Return(entities.Film{ID: 1}, nil)
+				mr.On("GetVideoAsset", mock.Anything, 1).Return(entities.VideoAsset{}, gorm.ErrRecordNotFound)
+			},
+			expectedError: customerror.NewCustomErrorWithHttpCode(kterrors.VideoNotFoundError, http.StatusNotFound),
+		},
+		{
+			name: "Hidden film",
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("GetFilm", mock.Anything, 1).Return(entities.Film{ID: 1, Hidden: true}, nil)
+			},
+			expectedError: customerror.NewCustomErrorWithHttpCode(kterrors.FilmNotFoundError, http.StatusNotFound),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := mocks.NewRepository(t)
+			tc.mockBehavior(mockRepo)
+			service := NewService(mockRepo, blobstore.NewLocalStore(t.TempDir()), testSigner, mocks.NewJobRunner(t), mocks.NewTranscoder(t))
+
+			playlist, err := service.GetMasterPlaylist(context.Background(), dto.StreamRequest{FilmID: 1})
+
+			if tc.expectedError != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tc.expectedError.Error(), err.Error())
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedPlaylist, playlist)
+		})
+	}
+}
+
+func TestGetRenditionPlaylist(t *testing.T) {
+	logger.InitializeForTest()
+
+	testCases := []struct {
+		name          string
+		rendition     string
+		query         url.Values
+		mockBehavior  func(*mocks.Repository)
+		expectedError error
+	}{
+		{
+			name:      "Segments point to signed URLs",
+			rendition: "720p",
+			query:     renditionQuery("720p"),
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("GetFilm", mock.Anything, 1).Return(entities.Film{ID: 1}, nil)
+				mr.On("GetVideoAsset", mock.Anything, 1).Return(testReadyAsset, nil)
+			},
+		},
+		{
+			name:      "Unknown rendition",
+			rendition: "4k",
+			query:     renditionQuery("4k"),
+			mockBehavior: func(mr *mocks.Repository) {
+				mr.On("GetFilm", mock.Anything, 1).Return(entities.Film{ID: 1}, nil)
+				mr.On("GetVideoAsset", mock.Anything, 1).Return(testReadyAsset, nil)
+			},
+			expectedError: customerror.NewCustomErrorWithHttpCode(kterrors.VideoRenditionNotFoundError, http.StatusNotFound),
+		},
+		{
+			name:          "Unsigned url",
+			rendition:     "720p",
+			mockBehavior:  func(mr *mocks.Repository) {},
+			expectedError: customerror.NewCustomErrorWithHttpCode(kterrors.InvalidMediaSignatureError, http.StatusForbidden),
+		},
+		{
+			name:          "Signature of another rendition",
+			rendition:     "720p",
+			query:         renditionQuery("360p"),
+			mockBehavior:  func(mr *mocks.Repository) {},
+			expectedError: customerror.NewCustomErrorWithHttpCode(kterrors.InvalidMediaSignatureError, http.StatusForbidden),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			store := blobstore.NewLocalStore(t.TempDir())
+			_ = store.Put(context.Background(), "videos/1/v1/720p/"+transcoder.PlaylistName, consts.HLSContentType,
+				strings.NewReader(testPlaylist), int64(len(testPlaylist)))
+			mockRepo := mocks.NewRepository(t)
+			tc.mockBehavior(mockRepo)
+			service := NewService(mockRepo, store, testSigner, mocks.NewJobRunner(t), mocks.NewTranscoder(t))
+
+			playlist, err := service.GetRenditionPlaylist(context.Background(), dto.StreamRequest{FilmID: 1, Rendition: tc.rendition, Query: tc.query})
+
+			if tc.expectedError != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tc.expectedError.Error(), err.Error())
+				return
+			}
+			assert.NoError(t, err)
+			lines := strings.Split(playlist, "\n")
+			assert.Equal(t, "#EXTINF:6.000000,", lines[4])
+			assert.Equal(t, "#EXT-X-ENDLIST", lines[8])
+			for i, segment := range []string{"segment_000.ts", "segment_001.ts"} {
+				key := "videos/1/v1/720p/" + segment
+				assert.True(t, strings.HasPrefix(lines[5+2*i], testMediaURL+"/"+key+"?"))
+				query, _ := url.ParseQuery(lines[5+2*i][strings.Index(lines[5+2*i], "?")+1:])
+				assert.NoError(t, testSigner.Verify(key, query))
+			}
+		})
+	}
+}
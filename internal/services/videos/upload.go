@@ -0,0 +1,323 @@
+package videos
+
+import (
+	"KTOnlinePlatform/internal/dto"
+	"KTOnlinePlatform/internal/models"
+	"KTOnlinePlatform/internal/models/consts"
+	"KTOnlinePlatform/internal/models/kterrors"
+	"KTOnlinePlatform/internal/services/jobs"
+	"KTOnlinePlatform/pkg/customerror"
+	"KTOnlinePlatform/pkg/database/entities"
+	"KTOnlinePlatform/pkg/database/entities/entitiescustom"
+	"KTOnlinePlatform/pkg/logger"
+	"KTOnlinePlatform/pkg/transcoder"
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	sourceName = "source"
+	// bytes http.DetectContentType looks at
+	sniffLength = 512
+)
+
+// UploadVideo stores the source video of a film and transcodes it in a background job.
+// The version already streamed keeps playing until the new one is ready
+func (s *Service) UploadVideo(ctx context.Context, request dto.VideoUploadRequest) (dto.Job, error) {
+	film, err := s.getFilm(ctx, request.FilmID)
+	if err != nil {
+		return dto.Job{}, err
+	}
+	if film.UserID != request.UserID {
+		return dto.Job{}, customerror.NewCustomError(kterrors.UserCannotUpdateFilmError)
+	}
+	previous, err := s.repo.GetVideoAsset(ctx, request.FilmID)
+	if err != nil && !customerror.IsNotFoundError(err) {
+		return dto.Job{}, err
+	}
+	// checked before the upload so a busy film does not cost a transfer, the save checks again
+	if inProgress(previous) {
+		return dto.Job{}, newVideoProcessingError()
+	}
+	source, contentType, err := sniffVideo(request.Source)
+	if err != nil {
+		return dto.Job{}, err
+	}
+
+	version, err := newVersion()
+	if err != nil {
+		return dto.Job{}, err
+	}
+	sourceKey := videoKey(request.FilmID, version, sourceName)
+	err = s.blobs.Put(ctx, sourceKey, contentType, source, request.Size)
+	if err != nil {
+		return dto.Job{}, err
+	}
+	err = s.repo.SaveVideoAsset(ctx, entities.VideoAsset{
+		FilmID:  request.FilmID,
+		Status:  consts.VideoStatusPending,
+		Version: version,
+	})
+	if err != nil {
+		s.deleteBlobs(ctx, []string{sourceKey})
+		if customerror.IsNotFoundError(err) {
+			return dto.Job{}, newVideoProcessingError()
+		}
+		return dto.Job{}, err
+	}
+
+	job, err := s.jobs.StartJob(ctx, consts.JobTypeVideoTranscode, request.UserID, len(renditions),
+		func(ctx context.Context, tracker jobs.Tracker) error {
+			return s.transcode(ctx, tracker, request.FilmID, version)
+		})
+	if err != nil {
+		// nothing will pick the upload up, it must not block the next one
+		s.fail(ctx, request.FilmID, version, []string{sourceKey}, err)
+		return dto.Job{}, err
+	}
+	return job, nil
+}
+
+// sniffVideo checks the first bytes of the upload and gives back a reader of the whole upload
+func sniffVideo(source io.Reader) (io.Reader, string, error) {
+	head := make([]byte, sniffLength)
+	n, err := io.ReadFull(source, head)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, "", err
+	}
+	if n == 0 {
+		return nil, "", customerror.NewCustomError(kterrors.VideoRequiredError)
+	}
+	contentType := http.DetectContentType(head[:n])
+	if !strings.HasPrefix(contentType, "video/") {
+		return nil, "", customerror.NewCustomErrorWithHttpCode(kterrors.UnsupportedVideoTypeError, http.StatusUnsupportedMediaType)
+	}
+	return io.MultiReader(bytes.NewReader(head[:n]), source), contentType, nil
+}
+
+// transcode renders every rendition of the version, then streams it instead of the previous one.
+// The previous one is retired, the players still hold URLs of its segments
+func (s *Service) transcode(ctx context.Context, tracker jobs.Tracker, filmID int, version string) error {
+	err := s.repo.SetVideoStatus(ctx, filmID, version, consts.VideoStatusProcessing, "")
+	if err != nil {
+		return err
+	}
+	result, uploaded, err := s.renderRenditions(ctx, tracker, filmID, version)
+	if err != nil {
+		s.fail(ctx, filmID, version, append(uploaded, videoKey(filmID, version, sourceName)), err)
+		return err
+	}
+	err = s.repo.SetVideoReady(ctx, filmID, version, result)
+	if err != nil {
+		s.fail(ctx, filmID, version, append(uploaded, videoKey(filmID, version, sourceName)), err)
+		return err
+	}
+	return nil
+}
+
+// Run removes the retired versions right away and then every interval until ctx is done
+func (s *Service) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		err := s.RemoveRetiredVersions(ctx)
+		if err != nil {
+			logger.Error().Err(err).Msg("remove retired videos failed")
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RemoveRetiredVersions deletes the blobs of the versions retired for consts.VideoRetiredVersionRetention,
+// no URL signed for them is valid anymore
+func (s *Service) RemoveRetiredVersions(ctx context.Context) error {
+	retired, err := s.repo.GetRetiredVideoVersions(ctx, time.Now().Add(-consts.VideoRetiredVersionRetention))
+	if err != nil {
+		return err
+	}
+	for _, version := range retired {
+		s.deleteVersion(ctx, version.FilmID, version.Version, version.Renditions)
+		err = s.repo.DeleteRetiredVideoVersion(ctx, version.ID)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// renderRenditions returns the keys it uploaded even on failure, so they can be removed
+func (s *Service) renderRenditions(ctx context.Context, tracker jobs.Tracker, filmID int, version string) (entitiescustom.VideoRenditions, []string, error) {
+	dir, err := os.MkdirTemp("", "transcode-")
+	if err != nil {
+		return nil, nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	source := filepath.Join(dir, sourceName)
+	err = s.download(ctx, videoKey(filmID, version, sourceName), source)
+	if err != nil {
+		return nil, nil, err
+	}
+	var result entitiescustom.VideoRenditions
+	var uploaded []string
+	for _, rendition := range renditions {
+		err = s.transcoder.HLS(ctx, source, dir, rendition)
+		if err != nil {
+			return nil, uploaded, err
+		}
+		keys, err := s.uploadDir(ctx, filepath.Join(dir, rendition.Name), videoKey(filmID, version, rendition.Name))
+		uploaded = append(uploaded, keys...)
+		if err != nil {
+			return nil, uploaded, err
+		}
+		result = append(result, entitiescustom.VideoRendition{
+			Name:      rendition.Name,
+			Height:    rendition.Height,
+			Bandwidth: bandwidth(rendition),
+		})
+		err = tracker.Advance(ctx, models.JobProgress{Succeeded: 1})
+		if err != nil {
+			return nil, uploaded, err
+		}
+		// keeps the upload from going stale, and stops when a new upload replaced it
+		err = s.repo.SetVideoStatus(ctx, filmID, version, consts.VideoStatusProcessing, "")
+		if err != nil {
+			return nil, uploaded, err
+		}
+	}
+	return result, uploaded, nil
+}
+
+func (s *Service) download(ctx context.Context, key string, destination string) error {
+	blob, err := s.blobs.Get(ctx, key)
+	if err != nil {
+		return err
+	}
+	defer blob.Close()
+	file, err := os.Create(destination)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(file, blob)
+	if err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// uploadDir puts every file of dir under the prefix
+func (s *Service) uploadDir(ctx context.Context, dir string, prefix string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var uploaded []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		key := prefix + "/" + entry.Name()
+		err = s.uploadFile(ctx, filepath.Join(dir, entry.Name()), key)
+		if err != nil {
+			return uploaded, err
+		}
+		uploaded = append(uploaded, key)
+	}
+	return uploaded, nil
+}
+
+func (s *Service) uploadFile(ctx context.Context, name string, key string) error {
+	file, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+	return s.blobs.Put(ctx, key, contentTypeOf(key), file, info.Size())
+}
+
+func contentTypeOf(key string) string {
+	switch path.Ext(key) {
+	case ".m3u8":
+		return consts.HLSContentType
+	case ".ts":
+		return consts.MPEGTSContentType
+	default:
+		return "application/octet-stream"
+	}
+}
+
+// fail marks the version failed and removes what it left in the blob store
+func (s *Service) fail(ctx context.Context, filmID int, version string, keys []string, cause error) {
+	s.deleteBlobs(ctx, keys)
+	err := s.repo.SetVideoStatus(ctx, filmID, version, consts.VideoStatusFailed, cause.Error())
+	if err != nil {
+		logger.Error().Err(err).Msgf("cannot mark video %s of film %d failed", version, filmID)
+	}
+}
+
+// deleteVersion removes a version no longer streamed, the segments are found in the playlists.
+// It is best effort, the version is not referenced anymore
+func (s *Service) deleteVersion(ctx context.Context, filmID int, version string, previous entitiescustom.VideoRenditions) {
+	keys := []string{videoKey(filmID, version, sourceName)}
+	for _, rendition := range previous {
+		prefix := videoKey(filmID, version, rendition.Name)
+		playlist, err := s.readBlob(ctx, prefix+"/"+transcoder.PlaylistName)
+		if err != nil {
+			logger.Error().Err(err).Msgf("cannot read playlist %s of film %d", rendition.Name, filmID)
+			continue
+		}
+		for _, segment := range segmentNames(playlist) {
+			keys = append(keys, prefix+"/"+segment)
+		}
+		keys = append(keys, prefix+"/"+transcoder.PlaylistName)
+	}
+	s.deleteBlobs(ctx, keys)
+}
+
+func (s *Service) deleteBlobs(ctx context.Context, keys []string) {
+	for _, key := range keys {
+		err := s.blobs.Delete(ctx, key)
+		if err != nil {
+			logger.Error().Err(err).Msgf("cannot delete video blob %s", key)
+		}
+	}
+}
+
+// bandwidth is the peak bit rate announced in the master playlist, with some room for the
+// variations of the encoder and the container overhead
+func bandwidth(rendition transcoder.Rendition) int {
+	return (rendition.VideoBitrate*11/10 + rendition.AudioBitrate) * 1000
+}
+
+func videoKey(filmID int, version string, name string) string {
+	return fmt.Sprintf("videos/%d/%s/%s", filmID, version, name)
+}
+
+func newVersion() (string, error) {
+	b := make([]byte, 8)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
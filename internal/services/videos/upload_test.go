@@ -0,0 +1,312 @@
+package videos
+
+import (
+	"KTOnlinePlatform/internal/dto"
+	"KTOnlinePlatform/internal/models"
+	"KTOnlinePlatform/internal/models/consts"
+	"KTOnlinePlatform/internal/models/kterrors"
+	"KTOnlinePlatform/internal/services/jobs"
+	jobsmocks "KTOnlinePlatform/internal/services/jobs/mocks"
+	"KTOnlinePlatform/internal/services/videos/mocks"
+	"KTOnlinePlatform/pkg/blobstore"
+	"KTOnlinePlatform/pkg/customerror"
+	"KTOnlinePlatform/pkg/database/entities"
+	"KTOnlinePlatform/pkg/database/entities/entitiescustom"
+	"KTOnlinePlatform/pkg/logger"
+	"KTOnlinePlatform/pkg/mediasign"
+	"KTOnlinePlatform/pkg/transcoder"
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+var (
+	testMediaKeys = []string{"0f1e2d3c4b5a69788796a5b4c3d2e1f0"}
+	testMediaURL  = "http://localhost/media"
+	// testMP4 is the ftyp box opening every MP4 file, enough to be sniffed as a video
+	testMP4 = []byte("\x00\x00\x00\x18ftypmp42\x00\x00\x00\x00mp42isom")
+)
+
+// writeRendition mimics ffmpeg, a playlist and two segments in dir/<rendition name>
+func writeRendition(args mock.Arguments) {
+	dir := filepath.Join(args.String(2), args.Get(3).(transcoder.Rendition).Name)
+	_ = os.MkdirAll(dir, 0755)
+	_ = os.WriteFile(filepath.Join(dir, transcoder.PlaylistName), []byte(testPlaylist), 0644)
+	_ = os.WriteFile(filepath.Join(dir, "segment_000.ts"), []byte("segment 0"), 0644)
+	_ = os.WriteFile(filepath.Join(dir, "segment_001.ts"), []byte("segment 1"), 0644)
+}
+
+const testPlaylist = "#EXTM3U\n#EXT-X-VERSION:3\n#EXT-X-TARGETDURATION:6\n#EXT-X-PLAYLIST-TYPE:VOD\n" +
+	"#EXTINF:6.000000,\nsegment_000.ts\n#EXTINF:2.500000,\nsegment_001.ts\n#EXT-X-ENDLIST\n"
+
+func TestUploadVideo(t *testing.T) {
+	logger.InitializeForTest()
+	previous := entities.VideoAsset{
+		FilmID:       1,
+		Status:       consts.VideoStatusReady,
+		Version:      "old",
+		ReadyVersion: "old",
+		Renditions:   entitiescustom.VideoRenditions{{Name: "360p", Height: 360, Bandwidth: 976000}},
+	}
+
+	testCases := []struct {
+		name              string
+		data              []byte
+		mockBehavior      func(*mocks.Repository, *mocks.Transcoder, *jobsmocks.Tracker)
+		expectedError     error
+		expectedTaskError error
+		expectedFiles     []string
+	}{
+		{
+			name: "Every rendition is uploaded, the previous version is kept for its signed URLs",
+			data: testMP4,
+			mockBehavior: func(mr *mocks.Repository, mt *mocks.Transcoder, tracker *jobsmocks.Tracker) {
+				mr.On("GetFilm", mock.Anything, 1).Return(entities.Film{ID: 1, UserID: 100}, nil)
+				mr.On("GetVideoAsset", mock.Anything, 1).Return(previous, nil)
+				mr.On("SaveVideoAsset", mock.Anything, mock.MatchedBy(func(asset entities.VideoAsset) bool {
+					return asset.FilmID == 1 && asset.Status == consts.VideoStatusPending && asset.Version != "old"
+				})).Return(nil)
+				mr.On("SetVideoStatus", mock.Anything, 1, mock.Anything, consts.VideoStatusProcessing, "").Return(nil)
+				mt.On("HLS", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Run(writeRendition).Return(nil).Times(len(renditions))
+				tracker.On("Advance", mock.Anything, models.JobProgress{Succeeded: 1}).Return(nil).Times(len(renditions))
+				mr.On("SetVideoReady", mock.Anything, 1, mock.Anything, entitiescustom.VideoRenditions{
+					{Name: "360p", Height: 360, Bandwidth: 976000},
+					{Name: "720p", Height: 720, Bandwidth: 3208000},
+					{Name: "1080p", Height: 1080, Bandwidth: 5692000},
+				}).Return(nil)
+			},
+			expectedFiles: []string{"1080p/index.m3u8", "1080p/segment_000.ts", "1080p/segment_001.ts",
+				"360p/index.m3u8", "360p/segment_000.ts", "360p/segment_001.ts",
+				"720p/index.m3u8", "720p/segment_000.ts", "720p/segment_001.ts", "source"},
+		},
+		{
+			name: "Failed transcoding removes the upload",
+			data: testMP4,
+			mockBehavior: func(mr *mocks.Repository, mt *mocks.Transcoder, tracker *jobsmocks.Tracker) {
+				mr.On("GetFilm", mock.Anything, 1).Return(entities.Film{ID: 1, UserID: 100}, nil)
+				mr.On("GetVideoAsset", mock.Anything, 1).Return(previous, nil)
+				mr.On("SaveVideoAsset", mock.Anything, mock.Anything).Return(nil)
+				mr.On("SetVideoStatus", mock.Anything, 1, mock.Anything, consts.VideoStatusProcessing, "").Return(nil)
+				mt.On("HLS", mock.Anything, mock.Anything, mock.Anything, renditions[0]).Run(writeRendition).Return(nil).Once()
+				tracker.On("Advance", mock.Anything, models.JobProgress{Succeeded: 1}).Return(nil).Once()
+				mt.On("HLS", mock.Anything, mock.Anything, mock.Anything, renditions[1]).Return(errors.New("invalid data found")).Once()
+				mr.On("SetVideoStatus", mock.Anything, 1, mock.Anything, consts.VideoStatusFailed, "invalid data found").Return(nil)
+			},
+			expectedTaskError: errors.New("invalid data found"),
+		},
+		{
+			name: "Failed save of the ready version removes the upload",
+			data: testMP4,
+			mockBehavior: func(mr *mocks.Repository, mt *mocks.Transcoder, tracker *jobsmocks.Tracker) {
+				mr.On("GetFilm", mock.Anything, 1).Return(entities.Film{ID: 1, UserID: 100}, nil)
+				mr.On("GetVideoAsset", mock.Anything, 1).Return(previous, nil)
+				mr.On("SaveVideoAsset", mock.Anything, mock.Anything).Return(nil)
+				mr.On("SetVideoStatus", mock.Anything, 1, mock.Anything, consts.VideoStatusProcessing, "").Return(nil)
+				mt.On("HLS", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Run(writeRendition).Return(nil).Times(len(renditions))
+				tracker.On("Advance", mock.Anything, models.JobProgress{Succeeded: 1}).Return(nil).Times(len(renditions))
+				mr.On("SetVideoReady", mock.Anything, 1, mock.Anything, mock.Anything).Return(errors.New("connection reset"))
+				mr.On("SetVideoStatus", mock.Anything, 1, mock.Anything, consts.VideoStatusFailed, "connection reset").Return(nil)
+			},
+			expectedTaskError: errors.New("connection reset"),
+		},
+		{
+			name: "Previous upload still transcoding",
+			data: testMP4,
+			mockBehavior: func(mr *mocks.Repository, mt *mocks.Transcoder, tracker *jobsmocks.Tracker) {
+				mr.On("GetFilm", mock.Anything, 1).Return(entities.Film{ID: 1, UserID: 100}, nil)
+				mr.On("GetVideoAsset", mock.Anything, 1).Return(entities.VideoAsset{FilmID: 1, Status: consts.VideoStatusProcessing,
+					UpdatedAt: lo.ToPtr(time.Now().Add(-time.Minute))}, nil)
+			},
+			expectedError: customerror.NewCustomErrorWithHttpCode(kterrors.VideoProcessingError, http.StatusConflict),
+		},
+		{
+			name: "Previous upload whose job was lost is replaced",
+			data: testMP4,
+			mockBehavior: func(mr *mocks.Repository, mt *mocks.Transcoder, tracker *jobsmocks.Tracker) {
+				mr.On("GetFilm", mock.Anything, 1).Return(entities.Film{ID: 1, UserID: 100}, nil)
+				mr.On("GetVideoAsset", mock.Anything, 1).Return(entities.VideoAsset{FilmID: 1, Status: consts.VideoStatusProcessing,
+					Version: "lost", ReadyVersion: "old", Renditions: previous.Renditions, UpdatedAt: lo.ToPtr(time.Now().Add(-consts.VideoProcessingTimeout - time.Minute))}, nil)
+				mr.On("SaveVideoAsset", mock.Anything, mock.Anything).Return(nil)
+				mr.On("SetVideoStatus", mock.Anything, 1, mock.Anything, consts.VideoStatusProcessing, "").Return(nil)
+				mt.On("HLS", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Run(writeRendition).Return(nil).Times(len(renditions))
+				tracker.On("Advance", mock.Anything, models.JobProgress{Succeeded: 1}).Return(nil).Times(len(renditions))
+				mr.On("SetVideoReady", mock.Anything, 1, mock.Anything, mock.Anything).Return(nil)
+			},
+			expectedFiles: []string{"1080p/index.m3u8", "1080p/segment_000.ts", "1080p/segment_001.ts",
+				"360p/index.m3u8", "360p/segment_000.ts", "360p/segment_001.ts",
+				"720p/index.m3u8", "720p/segment_000.ts", "720p/segment_001.ts", "source"},
+		},
+		{
+			name: "Concurrent upload saved first",
+			data: testMP4,
+			mockBehavior: func(mr *mocks.Repository, mt *mocks.Transcoder, tracker *jobsmocks.Tracker) {
+				mr.On("GetFilm", mock.Anything, 1).Return(entities.Film{ID: 1, UserID: 100}, nil)
+				mr.On("GetVideoAsset", mock.Anything, 1).Return(entities.VideoAsset{}, gorm.ErrRecordNotFound)
+				mr.On("SaveVideoAsset", mock.Anything, mock.Anything).Return(gorm.ErrRecordNotFound)
+			},
+			expectedError: customerror.NewCustomErrorWithHttpCode(kterrors.VideoProcessingError, http.StatusConflict),
+		},
+		{
+			name: "User is not the creator",
+			data: testMP4,
+			mockBehavior: func(mr *mocks.Repository, mt *mocks.Transcoder, tracker *jobsmocks.Tracker) {
+				mr.On("GetFilm", mock.Anything, 1).Return(entities.Film{ID: 1, UserID: 200}, nil)
+			},
+			expectedError: customerror.NewCustomError(kterrors.UserCannotUpdateFilmError),
+		},
+		{
+			name: "Not a video",
+			data: []byte("%PDF-1.4 not a video"),
+			mockBehavior: func(mr *mocks.Repository, mt *mocks.Transcoder, tracker *jobsmocks.Tracker) {
+				mr.On("GetFilm", mock.Anything, 1).Return(entities.Film{ID: 1, UserID: 100}, nil)
+				mr.On("GetVideoAsset", mock.Anything, 1).Return(entities.VideoAsset{}, gorm.ErrRecordNotFound)
+			},
+			expectedError: customerror.NewCustomErrorWithHttpCode(kterrors.UnsupportedVideoTypeError, http.StatusUnsupportedMediaType),
+		},
+		{
+			name: "Empty upload",
+			mockBehavior: func(mr *mocks.Repository, mt *mocks.Transcoder, tracker *jobsmocks.Tracker) {
+				mr.On("GetFilm", mock.Anything, 1).Return(entities.Film{ID: 1, UserID: 100}, nil)
+				mr.On("GetVideoAsset", mock.Anything, 1).Return(entities.VideoAsset{}, gorm.ErrRecordNotFound)
+			},
+			expectedError: customerror.NewCustomError(kterrors.VideoRequiredError),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			store := blobstore.NewLocalStore(dir)
+			oldPrefix := filepath.Join(dir, "videos", "1", "old")
+			_ = os.MkdirAll(filepath.Join(oldPrefix, "360p"), 0755)
+			_ = os.WriteFile(filepath.Join(oldPrefix, sourceName), testMP4, 0644)
+			_ = os.WriteFile(filepath.Join(oldPrefix, "360p", transcoder.PlaylistName), []byte(testPlaylist), 0644)
+			_ = os.WriteFile(filepath.Join(oldPrefix, "360p", "segment_000.ts"), []byte("segment 0"), 0644)
+			_ = os.WriteFile(filepath.Join(oldPrefix, "360p", "segment_001.ts"), []byte("segment 1"), 0644)
+
+			mockRepo := mocks.NewRepository(t)
+			mockRunner := mocks.NewJobRunner(t)
+			mockTranscoder := mocks.NewTranscoder(t)
+			mockTracker := jobsmocks.NewTracker(t)
+			var task jobs.Task
+			if tc.expectedError == nil {
+				mockRunner.On("StartJob", mock.Anything, consts.JobTypeVideoTranscode, 100, len(renditions), mock.Anything).
+					Run(func(args mock.Arguments) {
+						task = args.Get(4).(jobs.Task)
+					}).
+					Return(dto.Job{ID: 1, Status: consts.JobStatusPending, Total: len(renditions)}, nil)
+			}
+			tc.mockBehavior(mockRepo, mockTranscoder, mockTracker)
+			service := NewService(mockRepo, store, mediasign.NewSigner(testMediaKeys, testMediaURL), mockRunner, mockTranscoder)
+
+			job, err := service.UploadVideo(context.Background(), dto.VideoUploadRequest{
+				FilmID: 1,
+				Source: bytes.NewReader(tc.data),
+				Size:   int64(len(tc.data)),
+				UserID: 100,
+			})
+
+			if tc.expectedError != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tc.expectedError.Error(), err.Error())
+				assert.Equal(t, []string{"old"}, listVersions(dir))
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, 1, job.ID)
+
+			err = task(context.Background(), mockTracker)
+			if tc.expectedTaskError != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tc.expectedTaskError.Error(), err.Error())
+				assert.Equal(t, []string{"old"}, listVersions(dir))
+				return
+			}
+			assert.NoError(t, err)
+			versions := lo.Without(listVersions(dir), "old")
+			if !assert.Len(t, versions, 1) {
+				return
+			}
+			assert.Equal(t, tc.expectedFiles, listFiles(filepath.Join(dir, "videos", "1", versions[0])))
+			assert.Contains(t, listVersions(dir), "old", "the retired version is removed later")
+		})
+	}
+}
+
+func TestRemoveRetiredVersions(t *testing.T) {
+	logger.InitializeForTest()
+	dir := t.TempDir()
+	store := blobstore.NewLocalStore(dir)
+	oldPrefix := filepath.Join(dir, "videos", "1", "old")
+	_ = os.MkdirAll(filepath.Join(oldPrefix, "360p"), 0755)
+	_ = os.WriteFile(filepath.Join(oldPrefix, sourceName), testMP4, 0644)
+	_ = os.WriteFile(filepath.Join(oldPrefix, "360p", transcoder.PlaylistName), []byte(testPlaylist), 0644)
+	_ = os.WriteFile(filepath.Join(oldPrefix, "360p", "segment_000.ts"), []byte("segment 0"), 0644)
+	_ = os.WriteFile(filepath.Join(oldPrefix, "360p", "segment_001.ts"), []byte("segment 1"), 0644)
+	mockRepo := mocks.NewRepository(t)
+	mockRepo.On("GetRetiredVideoVersions", mock.Anything, mock.MatchedBy(func(before time.Time) bool {
+		return time.Since(before) >= consts.VideoRetiredVersionRetention
+	})).Return([]entities.VideoRetiredVersion{{
+		ID:         7,
+		FilmID:     1,
+		Version:    "old",
+		Renditions: entitiescustom.VideoRenditions{{Name: "360p", Height: 360, Bandwidth: 976000}},
+	}}, nil)
+	mockRepo.On("DeleteRetiredVideoVersion", mock.Anything, 7).Return(nil)
+	service := NewService(mockRepo, store, mediasign.NewSigner(testMediaKeys, testMediaURL), mocks.NewJobRunner(t), mocks.NewTranscoder(t))
+
+	err := service.RemoveRetiredVersions(context.Background())
+
+	assert.NoError(t, err)
+	assert.Empty(t, listVersions(dir))
+}
+
+// listVersions returns the versions of film 1 holding files, empty directories are left behind
+func listVersions(dir string) []string {
+	entries, _ := os.ReadDir(filepath.Join(dir, "videos", "1"))
+	var versions []string
+	for _, entry := range entries {
+		if len(listFiles(filepath.Join(dir, "videos", "1", entry.Name()))) > 0 {
+			versions = append(versions, entry.Name())
+		}
+	}
+	return versions
+}
+
+func listFiles(dir string) []string {
+	var files []string
+	_ = filepath.WalkDir(dir, func(name string, entry os.DirEntry, err error) error {
+		if err == nil && !entry.IsDir() && !strings.HasPrefix(entry.Name(), ".") {
+			rel, _ := filepath.Rel(dir, name)
+			files = append(files, filepath.ToSlash(rel))
+		}
+		return nil
+	})
+	return files
+}
+
+func TestGetVideoLostJob(t *testing.T) {
+	mockRepo := mocks.NewRepository(t)
+	mockRepo.On("GetFilm", mock.Anything, 1).Return(entities.Film{ID: 1, UserID: 100}, nil)
+	mockRepo.On("GetVideoAsset", mock.Anything, 1).Return(entities.VideoAsset{FilmID: 1, Status: consts.VideoStatusPending,
+		UpdatedAt: lo.ToPtr(time.Now().Add(-consts.VideoProcessingTimeout - time.Minute))}, nil)
+	service := NewService(mockRepo, blobstore.NewLocalStore(t.TempDir()), mediasign.NewSigner(testMediaKeys, testMediaURL),
+		mocks.NewJobRunner(t), mocks.NewTranscoder(t))
+
+	video, err := service.GetVideo(context.Background(), dto.VideoRequest{FilmID: 1, UserID: 100})
+
+	assert.NoError(t, err)
+	assert.Equal(t, consts.VideoStatusFailed, video.Status)
+	assert.Equal(t, errTranscodingInterrupted.Error(), video.Error)
+}
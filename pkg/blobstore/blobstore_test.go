@@ -0,0 +1,153 @@
+package blobstore
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocalStore(t *testing.T) {
+	store := NewLocalStore(t.TempDir())
+	ctx := context.Background()
+
+	err := store.Put(ctx, "posters/1/small.jpg", "image/jpeg", strings.NewReader("poster"), 6)
+	assert.NoError(t, err)
+
+	body, err := store.Get(ctx, "posters/1/small.jpg")
+	if !assert.NoError(t, err) {
+		return
+	}
+	content, _ := io.ReadAll(body)
+	_ = body.Close()
+	assert.Equal(t, "poster", string(content))
+
+	assert.NoError(t, store.Delete(ctx, "posters/1/small.jpg"))
+	assert.NoError(t, store.Delete(ctx, "posters/1/small.jpg"))
+	_, err = store.Get(ctx, "posters/1/small.jpg")
+	assert.Equal(t, ErrNotFound, err)
+}
+
+func TestInvalidKeys(t *testing.T) {
+	store := NewLocalStore(t.TempDir())
+
+	for _, key := range []string{"", "/etc/passwd", "../secret", "posters/../../secret", "posters//1", "posters\\1"} {
+		err := store.Put(context.Background(), key, "image/jpeg", strings.NewReader("x"), 1)
+		assert.Equal(t, ErrInvalidKey, err, key)
+	}
+}
+
+// example from the signature V4 documentation
+func TestSigningKey(t *testing.T) {
+	key := signingKey("wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY", "20120215", "us-east-1", "iam")
+
+	assert.Equal(t, "f4780e2d9f65fa895f9c67b32ce1baf0b0d8a43505a000a1a9e090d414db404d", hex.EncodeToString(key))
+}
+
+// fakeS3 stands in for a bucket, it keeps objects in memory and rejects unsigned requests
+type fakeS3 struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	authorization := r.Header.Get("Authorization")
+	if !strings.HasPrefix(authorization, "AWS4-HMAC-SHA256 Credential=access/20240501/eu-west-1/s3/aws4_request, SignedHeaders=") ||
+		r.Header.Get("X-Amz-Date") != "20240501T120000Z" {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	switch r.Method {
+	case http.MethodPut:
+		body, _ := io.ReadAll(r.Body)
+		f.objects[r.URL.Path] = body
+	case http.MethodGet, http.MethodHead:
+		body, ok := f.objects[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		http.ServeContent(w, r, "", time.Time{}, bytes.NewReader(body))
+	case http.MethodDelete:
+		delete(f.objects, r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func TestS3Store(t *testing.T) {
+	bucket := &fakeS3{objects: make(map[string][]byte)}
+	server := httptest.NewServer(bucket)
+	defer server.Close()
+
+	store, err := NewS3Store(S3Config{
+		Endpoint:  server.URL,
+		Region:    "eu-west-1",
+		Bucket:    "kt",
+		AccessKey: "access",
+		SecretKey: "secret",
+	}, server.Client())
+	if !assert.NoError(t, err) {
+		return
+	}
+	store.now = func() time.Time {
+		return time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	}
+	ctx := context.Background()
+
+	err = store.Put(ctx, "posters/1/small.jpg", "image/jpeg", bytes.NewReader([]byte("poster")), 6)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("poster"), bucket.objects["/kt/posters/1/small.jpg"])
+
+	body, err := store.Get(ctx, "posters/1/small.jpg")
+	if !assert.NoError(t, err) {
+		return
+	}
+	content, _ := io.ReadAll(body)
+	_ = body.Close()
+	assert.Equal(t, "poster", string(content))
+
+	// a seek starts a ranged download
+	body, err = store.Get(ctx, "posters/1/small.jpg")
+	if !assert.NoError(t, err) {
+		return
+	}
+	_, err = body.Seek(2, io.SeekStart)
+	assert.NoError(t, err)
+	content, _ = io.ReadAll(body)
+	_ = body.Close()
+	assert.Equal(t, "ster", string(content))
+
+	assert.NoError(t, store.Delete(ctx, "posters/1/small.jpg"))
+	_, err = store.Get(ctx, "posters/1/small.jpg")
+	assert.Equal(t, ErrNotFound, err)
+}
+
+func TestS3StoreRejected(t *testing.T) {
+	server := httptest.NewServer(&fakeS3{objects: make(map[string][]byte)})
+	defer server.Close()
+
+	store, err := NewS3Store(S3Config{
+		Endpoint:  server.URL,
+		Region:    "eu-west-1",
+		Bucket:    "kt",
+		AccessKey: "access",
+		SecretKey: "secret",
+	}, server.Client())
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	err = store.Put(context.Background(), "posters/1/small.jpg", "image/jpeg", strings.NewReader("x"), 1)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "403")
+}
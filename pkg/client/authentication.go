@@ -0,0 +1,50 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+)
+
+// Register creates a user, Login is still needed to call the API as this user
+func (c *Client) Register(ctx context.Context, request CreateUserRequest) error {
+	return c.do(ctx, call{method: http.MethodPost, path: "/register", body: request, public: true}, nil)
+}
+
+// Login gets the tokens of a user, the following calls are made as this user
+func (c *Client) Login(ctx context.Context, request Login) (JWTTokens, error) {
+	tokens := JWTTokens{}
+	err := c.do(ctx, call{method: http.MethodPut, path: "/login", body: request, public: true}, &tokens)
+	if err != nil {
+		return JWTTokens{}, err
+	}
+	c.SetTokens(tokens)
+	return tokens, nil
+}
+
+// Refresh exchanges the refresh token for new tokens, the calls do it on their own once the access token expired
+func (c *Client) Refresh(ctx context.Context) (JWTTokens, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.tokens.RefreshToken == "" {
+		return JWTTokens{}, errors.New("client: no refresh token, log in first")
+	}
+	tokens, err := c.refresh(ctx, c.tokens.RefreshToken)
+	if err != nil {
+		return JWTTokens{}, err
+	}
+	c.tokens = tokens
+	return tokens, nil
+}
+
+// refresh is called with the lock held, the call is public so it does not read the tokens
+func (c *Client) refresh(ctx context.Context, refreshToken string) (JWTTokens, error) {
+	tokens := JWTTokens{}
+	err := c.do(ctx, call{
+		method: http.MethodPost,
+		path:   "/refresh-token",
+		body:   RefreshRequest{RefreshToken: refreshToken},
+		public: true,
+	}, &tokens)
+	return tokens, err
+}
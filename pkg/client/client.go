@@ -0,0 +1,261 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	apiPrefix         = "/api/v1"
+	defaultMaxRetries = 3
+	defaultBackoff    = 200 * time.Millisecond
+
+	headerAcceptLanguage = "Accept-Language"
+)
+
+// Config configures a Client, only BaseURL is required
+type Config struct {
+	// BaseURL is the address of the API, like http://localhost:8080
+	BaseURL string
+	// HTTPClient sends the requests, http.DefaultClient when nil
+	HTTPClient *http.Client
+	// MaxRetries is the number of retries of an idempotent call after a network error or a 429, 502, 503
+	// or 504, 3 when 0, none when negative
+	MaxRetries int
+	// Backoff is the delay before the first retry, doubled before each following one
+	Backoff time.Duration
+	// Language is sent as Accept-Language, the films and the errors are translated into it
+	Language string
+}
+
+// Client calls the API with the tokens of a user. The access token is refreshed when the API rejects it,
+// so a Client is meant to be shared by the goroutines of the user
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+	maxRetries int
+	backoff    time.Duration
+	language   string
+
+	mu     sync.Mutex
+	tokens JWTTokens
+}
+
+func NewClient(config Config) *Client {
+	if config.BaseURL == "" {
+		panic(config.BaseURL)
+	}
+	client := &Client{
+		baseURL:    strings.TrimSuffix(config.BaseURL, "/") + apiPrefix,
+		httpClient: config.HTTPClient,
+		maxRetries: config.MaxRetries,
+		backoff:    config.Backoff,
+		language:   config.Language,
+	}
+	if client.httpClient == nil {
+		client.httpClient = http.DefaultClient
+	}
+	if client.maxRetries == 0 {
+		client.maxRetries = defaultMaxRetries
+	}
+	if client.maxRetries < 0 {
+		client.maxRetries = 0
+	}
+	if client.backoff == 0 {
+		client.backoff = defaultBackoff
+	}
+	return client
+}
+
+// Tokens returns the current tokens, to keep them between two runs
+func (c *Client) Tokens() JWTTokens {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.tokens
+}
+
+// SetTokens makes the client call the API as the user of the tokens, instead of logging in
+func (c *Client) SetTokens(tokens JWTTokens) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.tokens = tokens
+}
+
+type call struct {
+	method string
+	// path follows /api/v1
+	path   string
+	query  url.Values
+	body   interface{}
+	public bool
+}
+
+// do sends a request and decodes the JSON response into result, when not nil
+func (c *Client) do(ctx context.Context, r call, result interface{}) error {
+	var body []byte
+	if r.body != nil {
+		var err error
+		body, err = json.Marshal(r.body)
+		if err != nil {
+			return err
+		}
+	}
+
+	refreshed := false
+	retries := 0
+	for {
+		accessToken := ""
+		if !r.public {
+			accessToken = c.Tokens().AccessToken
+		}
+		response, err := c.send(ctx, r, body, accessToken)
+		if err == nil && response.StatusCode == http.StatusUnauthorized && accessToken != "" && !refreshed {
+			discard(response)
+			refreshed = true
+			if err := c.refreshAfter(ctx, accessToken); err != nil {
+				return err
+			}
+			continue
+		}
+		if retries < c.maxRetries && retryable(ctx, r.method, response, err) {
+			if response != nil {
+				discard(response)
+			}
+			if err := sleep(ctx, c.backoff<<retries); err != nil {
+				return err
+			}
+			retries++
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		return decode(response, result)
+	}
+}
+
+func (c *Client) send(ctx context.Context, r call, body []byte, accessToken string) (*http.Response, error) {
+	target := c.baseURL + r.path
+	if len(r.query) > 0 {
+		target += "?" + r.query.Encode()
+	}
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	httpRequest, err := http.NewRequestWithContext(ctx, r.method, target, reader)
+	if err != nil {
+		return nil, err
+	}
+	httpRequest.Header.Set("Accept", "application/json")
+	if body != nil {
+		httpRequest.Header.Set("Content-Type", "application/json")
+	}
+	if accessToken != "" {
+		httpRequest.Header.Set("Authorization", "Bearer "+accessToken)
+	}
+	if c.language != "" {
+		httpRequest.Header.Set(headerAcceptLanguage, c.language)
+	}
+	return c.httpClient.Do(httpRequest)
+}
+
+// refreshAfter refreshes the tokens once the access token was rejected, unless another call already did
+func (c *Client) refreshAfter(ctx context.Context, rejected string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.tokens.AccessToken != rejected {
+		return nil
+	}
+	if c.tokens.RefreshToken == "" {
+		return errors.New("client: the access token was rejected and there is no refresh token")
+	}
+	tokens, err := c.refresh(ctx, c.tokens.RefreshToken)
+	if err != nil {
+		return err
+	}
+	c.tokens = tokens
+	return nil
+}
+
+// retryable tells whether a failed call may be sent again, only the idempotent methods are
+func retryable(ctx context.Context, method string, response *http.Response, err error) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete:
+	default:
+		return false
+	}
+	if err != nil {
+		// a canceled call is not retried
+		return ctx.Err() == nil
+	}
+	switch response.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+func sleep(ctx context.Context, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func decode(response *http.Response, result interface{}) error {
+	defer discard(response)
+	if response.StatusCode >= http.StatusBadRequest {
+		return newError(response)
+	}
+	if result == nil || response.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	return json.NewDecoder(response.Body).Decode(result)
+}
+
+// discard reads the rest of the body so the connection is reused
+func discard(response *http.Response) {
+	_, _ = io.Copy(io.Discard, response.Body)
+	_ = response.Body.Close()
+}
+
+// Error is a problem sent by the API
+type Error struct {
+	Problem
+}
+
+func newError(response *http.Response) *Error {
+	apiError := &Error{}
+	// a body that is not a problem, from a proxy for instance, only leaves the status
+	_ = json.NewDecoder(response.Body).Decode(&apiError.Problem)
+	apiError.Status = response.StatusCode
+	if apiError.Title == "" {
+		apiError.Title = http.StatusText(response.StatusCode)
+	}
+	return apiError
+}
+
+func (e *Error) Error() string {
+	message := fmt.Sprintf("client: %d %s", e.Status, e.Title)
+	if e.Code != "" {
+		message += " " + e.Code
+	}
+	if e.Detail != "" {
+		message += ": " + e.Detail
+	}
+	return message
+}
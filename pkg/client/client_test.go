@@ -0,0 +1,322 @@
+package client_test
+
+import (
+	authcontroller "KTOnlinePlatform/internal/controllers/authentication"
+	filmscontroller "KTOnlinePlatform/internal/controllers/films"
+	"KTOnlinePlatform/internal/dto"
+	"KTOnlinePlatform/internal/models/kterrors"
+	authservice "KTOnlinePlatform/internal/services/authentication"
+	authmocks "KTOnlinePlatform/internal/services/authentication/mocks"
+	filmsservice "KTOnlinePlatform/internal/services/films"
+	"KTOnlinePlatform/pkg/client"
+	"KTOnlinePlatform/pkg/configuration"
+	"KTOnlinePlatform/pkg/customerror"
+	"KTOnlinePlatform/pkg/database/entities"
+	"KTOnlinePlatform/pkg/database/entities/entitiescustom"
+	"KTOnlinePlatform/pkg/logger"
+	"KTOnlinePlatform/pkg/middlewares"
+	"KTOnlinePlatform/pkg/webutils"
+	"context"
+	"errors"
+	"github.com/golang-jwt/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"golang.org/x/crypto/bcrypt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const (
+	jwtSecret = "secret"
+	username  = "ripley"
+	password  = "Nostromo-1979"
+	userID    = 1
+)
+
+// filmService keeps the films in memory, it embeds the service for the methods the tests do not call
+type filmService struct {
+	*filmsservice.Service
+	mu     sync.Mutex
+	films  map[int]dto.FilmDetail
+	nextID int
+}
+
+func (s *filmService) GetFilmPaginated(ctx context.Context, request dto.FilmSearchRequest) (dto.FilmsPaginated, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	result := dto.FilmsPaginated{Films: []dto.Film{}, Page: request.Page, PageSize: request.PageSize}
+	for ID := 1; ID < s.nextID; ID++ {
+		film, ok := s.films[ID]
+		if ok && strings.Contains(film.Title, request.Title) && (request.Year == 0 || film.ReleaseDate.Year() == request.Year) {
+			result.Films = append(result.Films, dto.Film{ID: film.ID, Title: film.Title})
+		}
+	}
+	result.Count = len(result.Films)
+	return result, nil
+}
+
+func (s *filmService) GetFilmDetail(ctx context.Context, ID int, locales []string) (dto.FilmDetail, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	film, ok := s.films[ID]
+	if !ok {
+		return dto.FilmDetail{}, customerror.NewCustomErrorWithHttpCode(kterrors.FilmNotFoundError, http.StatusNotFound)
+	}
+	return film, nil
+}
+
+func (s *filmService) CreateFilm(ctx context.Context, request dto.FilmCreateRequest) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.films[s.nextID] = dto.FilmDetail{
+		ID:          s.nextID,
+		Title:       request.Title,
+		Director:    request.Director,
+		ReleaseDate: request.ReleaseDate,
+		Synopsis:    request.Synopsis,
+		Version:     1,
+	}
+	s.nextID++
+	return nil
+}
+
+func (s *filmService) UpdateFilm(ctx context.Context, request dto.FilmUpdateRequest) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	film, ok := s.films[request.ID]
+	if !ok {
+		return customerror.NewCustomErrorWithHttpCode(kterrors.FilmNotFoundError, http.StatusNotFound)
+	}
+	if request.Version != film.Version {
+		return customerror.NewCustomErrorWithHttpCode(kterrors.FilmVersionMismatchError, http.StatusPreconditionFailed)
+	}
+	film.Title = request.Title
+	film.Director = request.Director
+	film.ReleaseDate = request.ReleaseDate
+	film.Synopsis = request.Synopsis
+	film.Version++
+	s.films[request.ID] = film
+	return nil
+}
+
+func (s *filmService) DeleteFilm(ctx context.Context, filmID int, userID int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.films, filmID)
+	return nil
+}
+
+// activeUsers is never suspended
+type activeUsers struct{}
+
+func (activeUsers) IsUserSuspended(ctx context.Context, userID int) (bool, error) {
+	return false, nil
+}
+
+// newServer serves the real router, failures makes the next calls fail with a 503 before reaching it
+func newServer(t *testing.T) (*httptest.Server, *atomic.Int32, *atomic.Int32) {
+	logger.InitializeForTest()
+	messages, err := kterrors.NewMessages()
+	assert.NoError(t, err)
+	e := webutils.NewEcho(configuration.ConfigEcho{AllowedOrigins: "*"}, messages, kterrors.Statuses)
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.MinCost)
+	assert.NoError(t, err)
+	repo := new(authmocks.Repository)
+	repo.On("FindUser", mock.Anything, username).Return(entities.User{ID: userID, Username: username, Password: string(hashedPassword)}, nil)
+	middleware := middlewares.NewMiddleware(jwtSecret, activeUsers{})
+	authcontroller.NewController(authservice.NewService(repo, middleware)).RegisterRoutes(e)
+	filmscontroller.NewController(&filmService{films: map[int]dto.FilmDetail{}, nextID: 1}, middleware).RegisterRoutes(e)
+
+	calls := &atomic.Int32{}
+	failures := &atomic.Int32{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		if failures.Add(-1) >= 0 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		e.ServeHTTP(w, r)
+	}))
+	t.Cleanup(server.Close)
+	return server, calls, failures
+}
+
+func newClient(server *httptest.Server) *client.Client {
+	return client.NewClient(client.Config{BaseURL: server.URL, Backoff: time.Millisecond})
+}
+
+func releaseDate(year int, month time.Month, day int) entitiescustom.ReleaseDate {
+	return entitiescustom.ReleaseDate{Time: time.Date(year, month, day, 0, 0, 0, 0, time.UTC)}
+}
+
+func TestFilms(t *testing.T) {
+	server, _, _ := newServer(t)
+	c := newClient(server)
+	ctx := context.Background()
+
+	tokens, err := c.Login(ctx, client.Login{Username: username, Password: password})
+	assert.NoError(t, err)
+	assert.NotEmpty(t, tokens.AccessToken)
+	assert.Equal(t, tokens, c.Tokens())
+
+	alien := releaseDate(1979, time.May, 25)
+	assert.NoError(t, c.CreateFilm(ctx, client.FilmCreateRequest{Title: "Alien", Director: "Ridley Scott", ReleaseDate: alien}))
+	assert.NoError(t, c.CreateFilm(ctx, client.FilmCreateRequest{Title: "Aliens", Director: "James Cameron"}))
+
+	films, err := c.SearchFilms(ctx, client.FilmSearchRequest{FilmFilter: client.FilmFilter{Title: "Alien", Year: 1979}})
+	assert.NoError(t, err)
+	assert.Equal(t, []client.Film{{ID: 1, Title: "Alien"}}, films.Films)
+
+	film, err := c.GetFilm(ctx, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, "Ridley Scott", film.Director)
+	assert.Equal(t, alien, film.ReleaseDate)
+
+	err = c.UpdateFilm(ctx, client.FilmUpdateRequest{ID: 1, Title: "Alien", Director: "Ridley Scott", Synopsis: "In space", Version: film.Version})
+	assert.NoError(t, err)
+	err = c.UpdateFilm(ctx, client.FilmUpdateRequest{ID: 1, Title: "Alien", Version: film.Version})
+	var apiError *client.Error
+	if assert.True(t, errors.As(err, &apiError)) {
+		assert.Equal(t, http.StatusPreconditionFailed, apiError.Status)
+		assert.Equal(t, kterrors.FilmVersionMismatchError, apiError.Code)
+	}
+
+	assert.NoError(t, c.DeleteFilm(ctx, 1))
+	_, err = c.GetFilm(ctx, 1)
+	if assert.True(t, errors.As(err, &apiError)) {
+		assert.Equal(t, http.StatusNotFound, apiError.Status)
+		assert.Equal(t, kterrors.FilmNotFoundError, apiError.Code)
+	}
+}
+
+func TestValidationError(t *testing.T) {
+	server, _, _ := newServer(t)
+	c := newClient(server)
+	ctx := context.Background()
+	_, err := c.Login(ctx, client.Login{Username: username, Password: password})
+	assert.NoError(t, err)
+
+	err = c.CreateFilm(ctx, client.FilmCreateRequest{Title: " "})
+
+	var apiError *client.Error
+	if assert.True(t, errors.As(err, &apiError)) {
+		assert.Equal(t, http.StatusUnprocessableEntity, apiError.Status)
+		assert.Equal(t, []client.FieldError{{Field: "title", Rule: "title"}}, apiError.Errors)
+	}
+}
+
+func TestRefresh(t *testing.T) {
+	server, _, _ := newServer(t)
+	c := newClient(server)
+	ctx := context.Background()
+	tokens, err := c.Login(ctx, client.Login{Username: username, Password: password})
+	assert.NoError(t, err)
+
+	// an access token that expired a minute ago
+	expired := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"sub":      "1",
+		"username": username,
+		"exp":      time.Now().Add(-time.Minute).Unix(),
+	})
+	expiredToken, err := expired.SignedString([]byte(jwtSecret))
+	assert.NoError(t, err)
+	c.SetTokens(client.JWTTokens{AccessToken: expiredToken, RefreshToken: tokens.RefreshToken})
+
+	_, err = c.SearchFilms(ctx, client.FilmSearchRequest{})
+	assert.NoError(t, err)
+	assert.NotEqual(t, expiredToken, c.Tokens().AccessToken)
+
+	// a refresh token does not authenticate the calls
+	c.SetTokens(client.JWTTokens{AccessToken: tokens.RefreshToken})
+	_, err = c.SearchFilms(ctx, client.FilmSearchRequest{})
+	assert.EqualError(t, err, "client: the access token was rejected and there is no refresh token")
+
+	c.SetTokens(client.JWTTokens{AccessToken: expiredToken, RefreshToken: "not a token"})
+	_, err = c.SearchFilms(ctx, client.FilmSearchRequest{})
+	var apiError *client.Error
+	if assert.True(t, errors.As(err, &apiError)) {
+		assert.Equal(t, http.StatusUnauthorized, apiError.Status)
+		assert.Equal(t, kterrors.InvalidRefreshTokenError, apiError.Code)
+	}
+}
+
+func TestRetries(t *testing.T) {
+	tests := []struct {
+		name          string
+		failures      int32
+		call          func(ctx context.Context, c *client.Client) error
+		expectedCalls int32
+		expectedError bool
+	}{
+		{
+			name:     "Idempotent call retried",
+			failures: 2,
+			call: func(ctx context.Context, c *client.Client) error {
+				_, err := c.GetFilm(ctx, 1)
+				return err
+			},
+			expectedCalls: 3,
+		},
+		{
+			name:     "Retries exhausted",
+			failures: 10,
+			call: func(ctx context.Context, c *client.Client) error {
+				return c.DeleteFilm(ctx, 1)
+			},
+			expectedCalls: 4,
+			expectedError: true,
+		},
+		{
+			name:     "Creation not retried",
+			failures: 1,
+			call: func(ctx context.Context, c *client.Client) error {
+				return c.CreateFilm(ctx, client.FilmCreateRequest{Title: "Alien"})
+			},
+			expectedCalls: 1,
+			expectedError: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, calls, failures := newServer(t)
+			c := newClient(server)
+			ctx := context.Background()
+			_, err := c.Login(ctx, client.Login{Username: username, Password: password})
+			assert.NoError(t, err)
+			assert.NoError(t, c.CreateFilm(ctx, client.FilmCreateRequest{Title: "Alien"}))
+			calls.Store(0)
+			failures.Store(tt.failures)
+
+			err = tt.call(ctx, c)
+
+			assert.Equal(t, tt.expectedCalls, calls.Load())
+			if tt.expectedError {
+				var apiError *client.Error
+				if assert.True(t, errors.As(err, &apiError)) {
+					assert.Equal(t, http.StatusServiceUnavailable, apiError.Status)
+				}
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestCanceledDuringBackoff(t *testing.T) {
+	server, calls, failures := newServer(t)
+	c := client.NewClient(client.Config{BaseURL: server.URL, Backoff: time.Hour})
+	failures.Store(1)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := c.GetFilm(ctx, 1)
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, int32(1), calls.Load())
+}
@@ -0,0 +1,66 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+const filmsPath = "/films"
+
+// SearchFilms gets a page of films, the next one is requested with the Cursor set to NextCursor
+func (c *Client) SearchFilms(ctx context.Context, request FilmSearchRequest) (FilmsPaginated, error) {
+	result := FilmsPaginated{}
+	err := c.do(ctx, call{method: http.MethodGet, path: filmsPath, query: searchQuery(request)}, &result)
+	return result, err
+}
+
+func (c *Client) GetFilm(ctx context.Context, filmID int) (FilmDetail, error) {
+	result := FilmDetail{}
+	err := c.do(ctx, call{method: http.MethodGet, path: filmPath(filmID)}, &result)
+	return result, err
+}
+
+// CreateFilm is not retried, a retry could create the film twice
+func (c *Client) CreateFilm(ctx context.Context, request FilmCreateRequest) error {
+	return c.do(ctx, call{method: http.MethodPost, path: filmsPath, body: request}, nil)
+}
+
+// UpdateFilm replaces a film, its Version is the one of the FilmDetail the update is based on
+func (c *Client) UpdateFilm(ctx context.Context, request FilmUpdateRequest) error {
+	return c.do(ctx, call{method: http.MethodPut, path: filmPath(request.ID), body: request}, nil)
+}
+
+func (c *Client) DeleteFilm(ctx context.Context, filmID int) error {
+	return c.do(ctx, call{method: http.MethodDelete, path: filmPath(filmID)}, nil)
+}
+
+func filmPath(filmID int) string {
+	return filmsPath + "/" + strconv.Itoa(filmID)
+}
+
+func searchQuery(request FilmSearchRequest) url.Values {
+	query := url.Values{}
+	set := func(name string, value string) {
+		if value != "" {
+			query.Set(name, value)
+		}
+	}
+	setInt := func(name string, value int) {
+		if value != 0 {
+			query.Set(name, strconv.Itoa(value))
+		}
+	}
+	set("title", request.Title)
+	set("director", request.Director)
+	setInt("year", request.Year)
+	setInt("page", request.Page)
+	setInt("pageSize", request.PageSize)
+	set("cursor", request.Cursor)
+	if request.IncludeTotal {
+		query.Set("includeTotal", "true")
+	}
+	set("sort", request.Sort)
+	return query
+}
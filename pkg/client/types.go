@@ -0,0 +1,29 @@
+package client
+
+import (
+	"KTOnlinePlatform/internal/dto"
+	"KTOnlinePlatform/pkg/customerror"
+)
+
+// The types of the API are the dto ones, the aliases let the packages outside of this module name them
+
+type (
+	Login             = dto.Login
+	CreateUserRequest = dto.CreateUserRequest
+	RefreshRequest    = dto.RefreshRequest
+	JWTTokens         = dto.JWTTokens
+
+	Film              = dto.Film
+	FilmDetail        = dto.FilmDetail
+	FilmFilter        = dto.FilmFilter
+	FilmSearchRequest = dto.FilmSearchRequest
+	FilmsPaginated    = dto.FilmsPaginated
+	FilmCreateRequest = dto.FilmCreateRequest
+	FilmUpdateRequest = dto.FilmUpdateRequest
+	FilmCredit        = dto.FilmCredit
+	Poster            = dto.Poster
+	SubtitleTrack     = dto.SubtitleTrack
+
+	Problem    = customerror.Problem
+	FieldError = customerror.FieldError
+)
@@ -14,10 +14,17 @@ const (
 )
 
 type Config struct {
-	ConfigLogger   `mapstructure:",squash"`
-	ConfigEcho     `mapstructure:",squash"`
-	ConfigDatabase `mapstructure:",squash"`
-	JWTSecret      string `mapstructure:"JWT_SECRET,required=true"`
+	ConfigLogger    `mapstructure:",squash"`
+	ConfigEcho      `mapstructure:",squash"`
+	ConfigDatabase  `mapstructure:",squash"`
+	ConfigBlobStore `mapstructure:",squash"`
+	JWTSecret       string `mapstructure:"JWT_SECRET,required=true"`
+	CursorSecret    string `mapstructure:"CURSOR_SECRET,required=true"`
+	// MediaSigningKeys are tried in order, the first one signs
+	MediaSigningKeys []string `mapstructure:"MEDIA_SIGNING_KEYS,required=true"`
+	BannedWords      []string `mapstructure:"BANNED_WORDS"`
+	// FFmpegPath is the transcoder of the uploaded videos
+	FFmpegPath string `mapstructure:"FFMPEG_PATH" default:"ffmpeg"`
 }
 
 type ConfigLogger struct {
@@ -29,6 +36,8 @@ type ConfigEcho struct {
 	AllowedOrigins   string `mapstructure:"ALLOWED_ORIGINS" default:"*"`
 	AllowCredentials bool   `mapstructure:"ALLOW_CREDENTIALS"`
 	AddressEcho      string `mapstructure:"ADDRESS_ECHO" default:":8080"`
+	// ValidateRequests checks the requests against the OpenAPI document before their handler
+	ValidateRequests bool `mapstructure:"VALIDATE_REQUESTS"`
 }
 
 type ConfigDatabase struct {
@@ -41,6 +50,18 @@ type ConfigDatabase struct {
 	MaxIdleConnections int    `mapstructure:"DB_MAX_IDLE_CONNECTIONS,default=2"`
 }
 
+// ConfigBlobStore picks where uploaded media is kept, local directory or S3 compatible bucket
+type ConfigBlobStore struct {
+	BlobStore    string `mapstructure:"BLOB_STORE" default:"local"`
+	BlobLocalDir string `mapstructure:"BLOB_LOCAL_DIR" default:"media"`
+	MediaBaseURL string `mapstructure:"MEDIA_BASE_URL"`
+	S3Endpoint   string `mapstructure:"S3_ENDPOINT"`
+	S3Region     string `mapstructure:"S3_REGION"`
+	S3Bucket     string `mapstructure:"S3_BUCKET"`
+	S3AccessKey  string `mapstructure:"S3_ACCESS_KEY"`
+	S3SecretKey  string `mapstructure:"S3_SECRET_KEY"`
+}
+
 func (c ConfigDatabase) GetDSN() string {
 	return fmt.Sprintf(
 		"host=%s port=%s user=%s password=%s dbname=%s search_path=public",
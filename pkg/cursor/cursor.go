@@ -0,0 +1,67 @@
+package cursor
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+const separator = "."
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Signer turns pagination positions into opaque tokens that clients cannot forge
+type Signer struct {
+	secret []byte
+}
+
+func NewSigner(secret string) *Signer {
+	if secret == "" {
+		panic(secret)
+	}
+	return &Signer{
+		secret: []byte(secret),
+	}
+}
+
+// Encode serializes v and appends an HMAC of the payload
+func (s *Signer) Encode(v interface{}) (string, error) {
+	payload, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(payload) + separator +
+		base64.RawURLEncoding.EncodeToString(s.sign(payload)), nil
+}
+
+// Decode checks the signature of the token and deserializes it into v
+func (s *Signer) Decode(token string, v interface{}) error {
+	encodedPayload, encodedSignature, found := strings.Cut(token, separator)
+	if !found {
+		return ErrInvalidCursor
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil {
+		return ErrInvalidCursor
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(encodedSignature)
+	if err != nil {
+		return ErrInvalidCursor
+	}
+	if !hmac.Equal(signature, s.sign(payload)) {
+		return ErrInvalidCursor
+	}
+	if err := json.Unmarshal(payload, v); err != nil {
+		return ErrInvalidCursor
+	}
+	return nil
+}
+
+func (s *Signer) sign(payload []byte) []byte {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
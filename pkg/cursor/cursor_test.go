@@ -0,0 +1,63 @@
+package cursor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type position struct {
+	Title string `json:"t"`
+	ID    int    `json:"i"`
+}
+
+func TestSigner(t *testing.T) {
+	signer := NewSigner("secret")
+	token, err := signer.Encode(position{Title: "Inception", ID: 3})
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		signer  *Signer
+		token   string
+		want    position
+		wantErr error
+	}{
+		{
+			name:   "round trip",
+			signer: signer,
+			token:  token,
+			want:   position{Title: "Inception", ID: 3},
+		},
+		{
+			name:    "signed with another secret",
+			signer:  NewSigner("another secret"),
+			token:   token,
+			wantErr: ErrInvalidCursor,
+		},
+		{
+			name:    "tampered payload",
+			signer:  signer,
+			token:   "eyJ0IjoiWiIsImkiOjF9" + token[len("eyJ0IjoiSW5jZXB0aW9uIiwiaSI6M30"):],
+			wantErr: ErrInvalidCursor,
+		},
+		{
+			name:    "missing signature",
+			signer:  signer,
+			token:   "eyJ0IjoiSW5jZXB0aW9uIiwiaSI6M30",
+			wantErr: ErrInvalidCursor,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := position{}
+			err := tt.signer.Decode(tt.token, &got)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -3,8 +3,10 @@ package customerror
 import (
 	"errors"
 	"fmt"
-	"github.com/labstack/echo/v4"
+	"github.com/go-playground/validator/v10"
 	"gorm.io/gorm"
+	"net/http"
+	"strings"
 )
 
 const (
@@ -17,6 +19,14 @@ type CustomError struct {
 	HttpCode int                    `json:"httpCode"`
 	Params   map[string]interface{} `json:"params,omitempty"`
 	Type     string                 `json:"type"`
+	Fields   []FieldError           `json:"fields,omitempty"`
+}
+
+// FieldError is a rule broken by a field of the input, with the param of the rule if it has one
+type FieldError struct {
+	Field string `json:"field"`
+	Rule  string `json:"rule"`
+	Param string `json:"param,omitempty"`
 }
 
 func NewCustomError(code string) *CustomError {
@@ -27,6 +37,14 @@ func NewCustomError(code string) *CustomError {
 	}
 }
 
+func NewCustomErrorWithHttpCode(code string, httpCode int) *CustomError {
+	return &CustomError{
+		Code:     code,
+		HttpCode: httpCode,
+		Type:     customErrorType,
+	}
+}
+
 func NewI18nErrorWithParams(code string, params map[string]interface{}) *CustomError {
 	return &CustomError{
 		Code:     code,
@@ -36,20 +54,31 @@ func NewI18nErrorWithParams(code string, params map[string]interface{}) *CustomE
 	}
 }
 
-func ErrorHandler(err error, c echo.Context) {
-	var customError *CustomError
-	if errors.As(err, &customError) {
-		_ = c.JSON(customError.HttpCode, customError)
-		return
+// NewValidationError reports the fields of the input breaking a rule
+func NewValidationError(code string, fields []FieldError) *CustomError {
+	return &CustomError{
+		Code:     code,
+		HttpCode: http.StatusUnprocessableEntity,
+		Type:     customErrorType,
+		Fields:   fields,
 	}
-	var errorHTTP *echo.HTTPError
-	if errors.As(err, &errorHTTP) {
-		_ = c.JSON(errorHTTP.Code, errorHTTP)
-		return
+}
+
+// FieldErrors names the fields by their path in the input, without the request struct
+func FieldErrors(errs validator.ValidationErrors) []FieldError {
+	fields := make([]FieldError, 0, len(errs))
+	for _, fieldError := range errs {
+		_, field, found := strings.Cut(fieldError.Namespace(), ".")
+		if !found {
+			field = fieldError.Field()
+		}
+		fields = append(fields, FieldError{
+			Field: field,
+			Rule:  fieldError.Tag(),
+			Param: fieldError.Param(),
+		})
 	}
-	_ = c.JSON(DefaultHttpErrorCode, map[string]interface{}{
-		"message": err.Error(),
-	})
+	return fields
 }
 
 func (c *CustomError) Error() string {
@@ -69,3 +98,10 @@ func IsUniqueViolation(err error) bool {
 	}
 	return false
 }
+
+func IsForeignKeyViolation(err error) bool {
+	if errors.Is(err, gorm.ErrForeignKeyViolated) {
+		return true
+	}
+	return false
+}
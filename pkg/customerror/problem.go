@@ -0,0 +1,119 @@
+package customerror
+
+import (
+	"KTOnlinePlatform/pkg/i18n"
+	"KTOnlinePlatform/pkg/logger"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+	"net/http"
+)
+
+const (
+	ProblemContentType = "application/problem+json"
+	// ProblemTypePrefix is followed by the error code to identify the type of a problem
+	ProblemTypePrefix = "urn:ktonline:error:"
+	// blankProblemType is the RFC 7807 type of problems that are only described by their status
+	blankProblemType = "about:blank"
+
+	headerAcceptLanguage  = "Accept-Language"
+	headerContentLanguage = "Content-Language"
+)
+
+// Problem is an RFC 7807 error response, extended with the code and params of the custom errors
+type Problem struct {
+	Type      string                 `json:"type"`
+	Title     string                 `json:"title"`
+	Status    int                    `json:"status"`
+	Detail    string                 `json:"detail,omitempty"`
+	Instance  string                 `json:"instance,omitempty"`
+	RequestID string                 `json:"requestId,omitempty"`
+	Code      string                 `json:"code,omitempty"`
+	Params    map[string]interface{} `json:"params,omitempty"`
+	Errors    []FieldError           `json:"errors,omitempty"`
+}
+
+// Translator renders the message of an error code in the first of the locales it can, and
+// returns the locale used
+type Translator interface {
+	Message(locales []string, code string, params map[string]interface{}) (string, string)
+}
+
+// NewErrorHandler sends every error as a problem. The status of a custom error comes from the
+// statuses by code, or the one it was created with. Its detail is rendered in the language
+// of the Accept-Language header. Server errors are logged and their detail is never sent
+func NewErrorHandler(translator Translator, statuses map[string]int) echo.HTTPErrorHandler {
+	if translator == nil {
+		panic(translator)
+	}
+	if statuses == nil {
+		panic(statuses)
+	}
+	return func(err error, c echo.Context) {
+		// a streamed response already sent its status, nothing can be reported anymore
+		if c.Response().Committed {
+			return
+		}
+		problem := Problem{
+			Type:      blankProblemType,
+			Instance:  c.Request().URL.Path,
+			RequestID: c.Response().Header().Get(echo.HeaderXRequestID),
+		}
+		var customError *CustomError
+		var validationErrors validator.ValidationErrors
+		var errorHTTP *echo.HTTPError
+		switch {
+		case errors.As(err, &customError):
+			problem.Status = customError.HttpCode
+			if status, ok := statuses[customError.Code]; ok {
+				problem.Status = status
+			}
+			problem.Type = ProblemTypePrefix + customError.Code
+			problem.Code = customError.Code
+			problem.Params = customError.Params
+			problem.Errors = customError.Fields
+			problem.Detail = translate(c, translator, customError)
+		case errors.As(err, &validationErrors):
+			problem.Status = http.StatusUnprocessableEntity
+			problem.Errors = FieldErrors(validationErrors)
+		case errors.As(err, &errorHTTP):
+			problem.Status = errorHTTP.Code
+			problem.Detail = fmt.Sprint(errorHTTP.Message)
+		case IsNotFoundError(err):
+			problem.Status = http.StatusNotFound
+		default:
+			problem.Status = http.StatusInternalServerError
+		}
+		if problem.Status >= http.StatusInternalServerError {
+			logger.Error().Err(err).Str("requestId", problem.RequestID).Str("path", problem.Instance).Msg("request failed")
+			problem.Detail = ""
+		}
+		problem.Title = http.StatusText(problem.Status)
+		_ = writeProblem(c, problem)
+	}
+}
+
+// translate renders the message of the custom error and tells the client which language it is in
+func translate(c echo.Context, translator Translator, customError *CustomError) string {
+	locales := i18n.ParseAcceptLanguage(c.Request().Header.Get(headerAcceptLanguage))
+	message, locale := translator.Message(locales, customError.Code, customError.Params)
+	header := c.Response().Header()
+	header.Add(echo.HeaderVary, headerAcceptLanguage)
+	if locale != "" {
+		header.Set(headerContentLanguage, locale)
+	}
+	return message
+}
+
+func writeProblem(c echo.Context, problem Problem) error {
+	if c.Request().Method == http.MethodHead {
+		return c.NoContent(problem.Status)
+	}
+	body, err := json.Marshal(problem)
+	if err != nil {
+		return err
+	}
+	return c.Blob(problem.Status, ProblemContentType, body)
+}
@@ -0,0 +1,159 @@
+package customerror
+
+import (
+	"KTOnlinePlatform/pkg/logger"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+type testTranslator map[string]string
+
+func (t testTranslator) Message(locales []string, code string, params map[string]interface{}) (string, string) {
+	for _, locale := range append(locales, "en") {
+		if message, ok := t[locale]; ok {
+			return message, locale
+		}
+	}
+	return "", ""
+}
+
+var testStatuses = map[string]int{
+	"FILM_NOT_FOUND_ERROR":          http.StatusNotFound,
+	"USER_CANNOT_UPDATE_FILM_ERROR": http.StatusForbidden,
+}
+
+func TestErrorHandler(t *testing.T) {
+	logger.InitializeForTest()
+
+	testCases := []struct {
+		name             string
+		acceptLanguage   string
+		err              error
+		expectedProblem  Problem
+		expectedLanguage string
+	}{
+		{
+			name:           "Custom error in the accepted language",
+			acceptLanguage: "fr-FR, en;q=0.5",
+			err:            NewCustomErrorWithHttpCode("FILM_NOT_FOUND_ERROR", http.StatusNotFound),
+			expectedProblem: Problem{
+				Type:   ProblemTypePrefix + "FILM_NOT_FOUND_ERROR",
+				Title:  "Not Found",
+				Status: http.StatusNotFound,
+				Detail: "Film introuvable",
+				Code:   "FILM_NOT_FOUND_ERROR",
+			},
+			expectedLanguage: "fr",
+		},
+		{
+			name: "Status of the code replaces the default one",
+			err:  fmt.Errorf("update: %w", NewCustomError("USER_CANNOT_UPDATE_FILM_ERROR")),
+			expectedProblem: Problem{
+				Type:   ProblemTypePrefix + "USER_CANNOT_UPDATE_FILM_ERROR",
+				Title:  "Forbidden",
+				Status: http.StatusForbidden,
+				Detail: "Film not found",
+				Code:   "USER_CANNOT_UPDATE_FILM_ERROR",
+			},
+			expectedLanguage: "en",
+		},
+		{
+			name: "Code without status keeps the one it was created with",
+			err:  NewI18nErrorWithParams("INVALID_CURSOR_ERROR", map[string]interface{}{"cursor": "x"}),
+			expectedProblem: Problem{
+				Type:   ProblemTypePrefix + "INVALID_CURSOR_ERROR",
+				Title:  "Bad Request",
+				Status: http.StatusBadRequest,
+				Detail: "Film not found",
+				Code:   "INVALID_CURSOR_ERROR",
+				Params: map[string]interface{}{"cursor": "x"},
+			},
+			expectedLanguage: "en",
+		},
+		{
+			name: "Validation error",
+			err: validator.New().Struct(struct {
+				Title string `validate:"required"`
+				Score int    `validate:"max=10"`
+			}{Score: 11}),
+			expectedProblem: Problem{
+				Type:   blankProblemType,
+				Title:  "Unprocessable Entity",
+				Status: http.StatusUnprocessableEntity,
+				Errors: []FieldError{{Field: "Title", Rule: "required"}, {Field: "Score", Rule: "max", Param: "10"}},
+			},
+		},
+		{
+			name: "Fields of a validation error",
+			err:  NewValidationError("VALIDATION_ERROR", []FieldError{{Field: "credits[0].role", Rule: "oneof", Param: "ACTOR WRITER"}}),
+			expectedProblem: Problem{
+				Type:   ProblemTypePrefix + "VALIDATION_ERROR",
+				Title:  "Unprocessable Entity",
+				Status: http.StatusUnprocessableEntity,
+				Detail: "Film not found",
+				Code:   "VALIDATION_ERROR",
+				Errors: []FieldError{{Field: "credits[0].role", Rule: "oneof", Param: "ACTOR WRITER"}},
+			},
+			expectedLanguage: "en",
+		},
+		{
+			name: "Echo error",
+			err:  echo.NewHTTPError(http.StatusUnauthorized, "missing or malformed jwt"),
+			expectedProblem: Problem{
+				Type:   blankProblemType,
+				Title:  "Unauthorized",
+				Status: http.StatusUnauthorized,
+				Detail: "missing or malformed jwt",
+			},
+		},
+		{
+			name: "Record not found",
+			err:  gorm.ErrRecordNotFound,
+			expectedProblem: Problem{
+				Type:   blankProblemType,
+				Title:  "Not Found",
+				Status: http.StatusNotFound,
+			},
+		},
+		{
+			name: "Internal errors are masked",
+			err:  errors.New("pq: password authentication failed"),
+			expectedProblem: Problem{
+				Type:   blankProblemType,
+				Title:  "Internal Server Error",
+				Status: http.StatusInternalServerError,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/films/1?locale=fr", nil)
+			req.Header.Set(headerAcceptLanguage, tc.acceptLanguage)
+			rec := httptest.NewRecorder()
+			rec.Header().Set(echo.HeaderXRequestID, "req-1")
+			handler := NewErrorHandler(testTranslator{"en": "Film not found", "fr": "Film introuvable"}, testStatuses)
+
+			handler(tc.err, e.NewContext(req, rec))
+
+			var problem Problem
+			assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &problem))
+			tc.expectedProblem.Instance = "/api/v1/films/1"
+			tc.expectedProblem.RequestID = "req-1"
+			assert.Equal(t, tc.expectedProblem, problem)
+			assert.Equal(t, tc.expectedProblem.Status, rec.Code)
+			assert.Equal(t, ProblemContentType, rec.Header().Get(echo.HeaderContentType))
+			assert.Equal(t, tc.expectedLanguage, rec.Header().Get(headerContentLanguage))
+		})
+	}
+}
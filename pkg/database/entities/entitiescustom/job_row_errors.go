@@ -0,0 +1,44 @@
+package entitiescustom
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// JobRowError describes why a single row of a job could not be processed
+type JobRowError struct {
+	Row    int                    `json:"row"`
+	Code   string                 `json:"code"`
+	Params map[string]interface{} `json:"params,omitempty"`
+}
+
+// JobRowErrors is stored as a JSONB array
+type JobRowErrors []JobRowError
+
+// Value returns a driver value
+func (e JobRowErrors) Value() (driver.Value, error) {
+	if e == nil {
+		return "[]", nil
+	}
+	b, err := json.Marshal(e)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// Scan returns the parsed row errors
+func (e *JobRowErrors) Scan(src interface{}) error {
+	switch value := src.(type) {
+	case nil:
+		*e = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(value, e)
+	case string:
+		return json.Unmarshal([]byte(value), e)
+	default:
+		return fmt.Errorf("cannot scan %T into JobRowErrors", src)
+	}
+}
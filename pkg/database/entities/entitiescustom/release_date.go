@@ -13,8 +13,11 @@ type ReleaseDate struct{ time.Time }
 
 const ReleaseDateFormat = "2006-01-02"
 
-// Value returns a driver value
+// Value returns a driver value, an unset date is stored as NULL
 func (rd ReleaseDate) Value() (driver.Value, error) {
+	if rd.IsZero() {
+		return nil, nil
+	}
 	b, err := json.Marshal(&rd)
 	if err != nil {
 		return nil, err
@@ -24,6 +27,10 @@ func (rd ReleaseDate) Value() (driver.Value, error) {
 
 // Scan returns a parsed campaign
 func (rd *ReleaseDate) Scan(src interface{}) error {
+	if src == nil {
+		rd.Time = time.Time{}
+		return nil
+	}
 	srcTime := src.(time.Time)
 	// fix format
 	date, err := time.Parse(ReleaseDateFormat,
@@ -0,0 +1,44 @@
+package entitiescustom
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// VideoRendition is one transcoded quality of a video, Bandwidth is its peak in bit/s
+type VideoRendition struct {
+	Name      string `json:"name"`
+	Height    int    `json:"height"`
+	Bandwidth int    `json:"bandwidth"`
+}
+
+// VideoRenditions is stored as a JSONB array
+type VideoRenditions []VideoRendition
+
+// Value returns a driver value
+func (r VideoRenditions) Value() (driver.Value, error) {
+	if r == nil {
+		return "[]", nil
+	}
+	b, err := json.Marshal(r)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// Scan returns the parsed renditions
+func (r *VideoRenditions) Scan(src interface{}) error {
+	switch value := src.(type) {
+	case nil:
+		*r = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(value, r)
+	case string:
+		return json.Unmarshal([]byte(value), r)
+	default:
+		return fmt.Errorf("cannot scan %T into VideoRenditions", src)
+	}
+}
@@ -12,6 +12,11 @@ type Film struct {
 	ReleaseDate entitiescustom.ReleaseDate `db:"release_date" json:"release_date"`
 	Synopsis    string                     `db:"synopsis" json:"synopsis"`
 	UserID      int                        `db:"user_id" json:"user_id"`
+	Version     int                        `db:"version" gorm:"column:version;default:1;" json:"version"`
+	RatingSum   int                        `db:"rating_sum" json:"rating_sum"`
+	RatingCount int                        `db:"rating_count" json:"rating_count"`
+	Hidden      bool                       `db:"hidden" json:"hidden"`
+	PosterKey   string                     `db:"poster_key" json:"poster_key"`
 	CreatedAt   *time.Time                 `db:"created_at" gorm:"column:created_at;type:TIMESTAMPTZ;" json:"createdAt"`
 	UpdatedAt   *time.Time                 `db:"updated_at" gorm:"column:updated_at;type:TIMESTAMPTZ;" json:"updatedAt"`
 }
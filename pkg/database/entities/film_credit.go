@@ -0,0 +1,16 @@
+package entities
+
+import (
+	"time"
+)
+
+type FilmCredit struct {
+	ID            int        `db:"id"  json:"id"`
+	FilmID        int        `db:"film_id" json:"film_id"`
+	PersonID      int        `db:"person_id" json:"person_id"`
+	Role          string     `db:"role" json:"role"`
+	CharacterName string     `db:"character_name" json:"character_name"`
+	Position      int        `db:"position" json:"position"`
+	CreatedAt     *time.Time `db:"created_at" gorm:"column:created_at;type:TIMESTAMPTZ;" json:"createdAt"`
+	UpdatedAt     *time.Time `db:"updated_at" gorm:"column:updated_at;type:TIMESTAMPTZ;" json:"updatedAt"`
+}
@@ -0,0 +1,13 @@
+package entities
+
+import (
+	"time"
+)
+
+type FilmRating struct {
+	FilmID    int        `db:"film_id" gorm:"primaryKey" json:"film_id"`
+	UserID    int        `db:"user_id" gorm:"primaryKey" json:"user_id"`
+	Score     int        `db:"score" json:"score"`
+	CreatedAt *time.Time `db:"created_at" gorm:"column:created_at;type:TIMESTAMPTZ;" json:"createdAt"`
+	UpdatedAt *time.Time `db:"updated_at" gorm:"column:updated_at;type:TIMESTAMPTZ;" json:"updatedAt"`
+}
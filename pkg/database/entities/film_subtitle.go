@@ -0,0 +1,15 @@
+package entities
+
+import (
+	"time"
+)
+
+type FilmSubtitle struct {
+	ID        int        `db:"id"  json:"id"`
+	FilmID    int        `db:"film_id" json:"film_id"`
+	Language  string     `db:"language" json:"language"`
+	Label     string     `db:"label" json:"label"`
+	BlobKey   string     `db:"blob_key" json:"blob_key"`
+	CreatedAt *time.Time `db:"created_at" gorm:"column:created_at;type:TIMESTAMPTZ;" json:"createdAt"`
+	UpdatedAt *time.Time `db:"updated_at" gorm:"column:updated_at;type:TIMESTAMPTZ;" json:"updatedAt"`
+}
@@ -0,0 +1,14 @@
+package entities
+
+import (
+	"time"
+)
+
+type FilmTranslation struct {
+	FilmID    int        `db:"film_id" gorm:"primaryKey" json:"film_id"`
+	Locale    string     `db:"locale" gorm:"primaryKey" json:"locale"`
+	Title     string     `db:"title" json:"title"`
+	Synopsis  string     `db:"synopsis" json:"synopsis"`
+	CreatedAt *time.Time `db:"created_at" gorm:"column:created_at;type:TIMESTAMPTZ;" json:"createdAt"`
+	UpdatedAt *time.Time `db:"updated_at" gorm:"column:updated_at;type:TIMESTAMPTZ;" json:"updatedAt"`
+}
@@ -0,0 +1,22 @@
+package entities
+
+import (
+	"KTOnlinePlatform/pkg/database/entities/entitiescustom"
+	"time"
+)
+
+type Job struct {
+	ID        int                         `db:"id"  json:"id"`
+	Type      string                      `db:"type" json:"type"`
+	Status    string                      `db:"status" json:"status"`
+	UserID    int                         `db:"user_id" json:"user_id"`
+	Total     int                         `db:"total" json:"total"`
+	Processed int                         `db:"processed" json:"processed"`
+	Succeeded int                         `db:"succeeded" json:"succeeded"`
+	Skipped   int                         `db:"skipped" json:"skipped"`
+	Failed    int                         `db:"failed" json:"failed"`
+	RowErrors entitiescustom.JobRowErrors `db:"row_errors" gorm:"column:row_errors;type:JSONB;" json:"row_errors"`
+	Error     string                      `db:"error" json:"error"`
+	CreatedAt *time.Time                  `db:"created_at" gorm:"column:created_at;type:TIMESTAMPTZ;" json:"createdAt"`
+	UpdatedAt *time.Time                  `db:"updated_at" gorm:"column:updated_at;type:TIMESTAMPTZ;" json:"updatedAt"`
+}
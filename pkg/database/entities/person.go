@@ -0,0 +1,18 @@
+package entities
+
+import (
+	"time"
+)
+
+type Person struct {
+	ID        int        `db:"id"  json:"id"`
+	Name      string     `db:"name" json:"name"`
+	Biography string     `db:"biography" json:"biography"`
+	UserID    *int       `db:"user_id" json:"user_id"`
+	CreatedAt *time.Time `db:"created_at" gorm:"column:created_at;type:TIMESTAMPTZ;" json:"createdAt"`
+	UpdatedAt *time.Time `db:"updated_at" gorm:"column:updated_at;type:TIMESTAMPTZ;" json:"updatedAt"`
+}
+
+func (Person) TableName() string {
+	return "people"
+}
@@ -0,0 +1,30 @@
+package entities
+
+import (
+	"time"
+)
+
+type Report struct {
+	ID          int        `db:"id"  json:"id"`
+	ReporterID  int        `db:"reporter_id" json:"reporter_id"`
+	TargetType  string     `db:"target_type" json:"target_type"`
+	TargetID    int        `db:"target_id" json:"target_id"`
+	Reason      string     `db:"reason" json:"reason"`
+	Status      string     `db:"status" gorm:"column:status;default:OPEN;" json:"status"`
+	ModeratorID *int       `db:"moderator_id" json:"moderator_id"`
+	Resolution  *string    `db:"resolution" json:"resolution"`
+	CreatedAt   *time.Time `db:"created_at" gorm:"column:created_at;type:TIMESTAMPTZ;" json:"createdAt"`
+	UpdatedAt   *time.Time `db:"updated_at" gorm:"column:updated_at;type:TIMESTAMPTZ;" json:"updatedAt"`
+}
+
+// ModerationAction is one line of the moderation audit trail, it is never updated nor deleted
+type ModerationAction struct {
+	ID          int        `db:"id"  json:"id"`
+	ModeratorID int        `db:"moderator_id" json:"moderator_id"`
+	ReportID    *int       `db:"report_id" json:"report_id"`
+	Action      string     `db:"action" json:"action"`
+	TargetType  string     `db:"target_type" json:"target_type"`
+	TargetID    int        `db:"target_id" json:"target_id"`
+	Note        string     `db:"note" json:"note"`
+	CreatedAt   *time.Time `db:"created_at" gorm:"column:created_at;type:TIMESTAMPTZ;" json:"createdAt"`
+}
@@ -0,0 +1,35 @@
+package entities
+
+import (
+	"time"
+)
+
+type Review struct {
+	ID        int        `db:"id"  json:"id"`
+	FilmID    int        `db:"film_id" json:"film_id"`
+	UserID    int        `db:"user_id" json:"user_id"`
+	Title     string     `db:"title" json:"title"`
+	Body      string     `db:"body" json:"body"`
+	Spoiler   bool       `db:"spoiler" json:"spoiler"`
+	LikeCount int        `db:"like_count" gorm:"column:like_count;default:0;" json:"like_count"`
+	Hidden    bool       `db:"hidden" json:"hidden"`
+	CreatedAt *time.Time `db:"created_at" gorm:"column:created_at;type:TIMESTAMPTZ;" json:"createdAt"`
+	UpdatedAt *time.Time `db:"updated_at" gorm:"column:updated_at;type:TIMESTAMPTZ;" json:"updatedAt"`
+}
+
+type ReviewLike struct {
+	ReviewID  int        `db:"review_id" gorm:"primaryKey" json:"review_id"`
+	UserID    int        `db:"user_id" gorm:"primaryKey" json:"user_id"`
+	CreatedAt *time.Time `db:"created_at" gorm:"column:created_at;type:TIMESTAMPTZ;" json:"createdAt"`
+}
+
+type ReviewComment struct {
+	ID        int        `db:"id"  json:"id"`
+	ReviewID  int        `db:"review_id" json:"review_id"`
+	ParentID  *int       `db:"parent_id" json:"parent_id"`
+	RootID    *int       `db:"root_id" json:"root_id"`
+	UserID    int        `db:"user_id" json:"user_id"`
+	Body      string     `db:"body" json:"body"`
+	CreatedAt *time.Time `db:"created_at" gorm:"column:created_at;type:TIMESTAMPTZ;" json:"createdAt"`
+	UpdatedAt *time.Time `db:"updated_at" gorm:"column:updated_at;type:TIMESTAMPTZ;" json:"updatedAt"`
+}
@@ -5,9 +5,11 @@ import (
 )
 
 type User struct {
-	ID        int        `db:"id"  json:"id"`
-	Username  string     `db:"username" json:"username"`
-	Password  string     `db:"password" json:"password"`
-	CreatedAt *time.Time `db:"created_at" gorm:"column:created_at;type:TIMESTAMPTZ;" json:"createdAt"`
-	UpdatedAt *time.Time `db:"updated_at" gorm:"column:updated_at;type:TIMESTAMPTZ;" json:"updatedAt"`
+	ID          int        `db:"id"  json:"id"`
+	Username    string     `db:"username" json:"username"`
+	Password    string     `db:"password" json:"password"`
+	Role        string     `db:"role" gorm:"column:role;default:USER;" json:"role"`
+	SuspendedAt *time.Time `db:"suspended_at" gorm:"column:suspended_at;type:TIMESTAMPTZ;" json:"suspendedAt"`
+	CreatedAt   *time.Time `db:"created_at" gorm:"column:created_at;type:TIMESTAMPTZ;" json:"createdAt"`
+	UpdatedAt   *time.Time `db:"updated_at" gorm:"column:updated_at;type:TIMESTAMPTZ;" json:"updatedAt"`
 }
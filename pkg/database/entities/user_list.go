@@ -0,0 +1,23 @@
+package entities
+
+import (
+	"time"
+)
+
+type UserList struct {
+	ID          int        `db:"id"  json:"id"`
+	UserID      int        `db:"user_id" json:"user_id"`
+	Name        string     `db:"name" json:"name"`
+	Description string     `db:"description" json:"description"`
+	Public      bool       `db:"public" json:"public"`
+	ShareSlug   string     `db:"share_slug" json:"share_slug"`
+	CreatedAt   *time.Time `db:"created_at" gorm:"column:created_at;type:TIMESTAMPTZ;" json:"createdAt"`
+	UpdatedAt   *time.Time `db:"updated_at" gorm:"column:updated_at;type:TIMESTAMPTZ;" json:"updatedAt"`
+}
+
+type UserListEntry struct {
+	ListID    int        `db:"list_id" gorm:"primaryKey" json:"list_id"`
+	FilmID    int        `db:"film_id" gorm:"primaryKey" json:"film_id"`
+	Position  int        `db:"position" json:"position"`
+	CreatedAt *time.Time `db:"created_at" gorm:"column:created_at;type:TIMESTAMPTZ;" json:"createdAt"`
+}
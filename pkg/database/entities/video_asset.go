@@ -0,0 +1,20 @@
+package entities
+
+import (
+	"KTOnlinePlatform/pkg/database/entities/entitiescustom"
+	"time"
+)
+
+// VideoAsset is the video of a film. Version is the latest upload, ReadyVersion the one being
+// streamed, so a new upload does not interrupt the stream while it is transcoded
+type VideoAsset struct {
+	ID           int                            `db:"id"  json:"id"`
+	FilmID       int                            `db:"film_id" json:"film_id"`
+	Status       string                         `db:"status" json:"status"`
+	Version      string                         `db:"version" json:"version"`
+	ReadyVersion string                         `db:"ready_version" json:"ready_version"`
+	Renditions   entitiescustom.VideoRenditions `db:"renditions" gorm:"column:renditions;type:JSONB;" json:"renditions"`
+	Error        string                         `db:"error" json:"error"`
+	CreatedAt    *time.Time                     `db:"created_at" gorm:"column:created_at;type:TIMESTAMPTZ;" json:"createdAt"`
+	UpdatedAt    *time.Time                     `db:"updated_at" gorm:"column:updated_at;type:TIMESTAMPTZ;" json:"updatedAt"`
+}
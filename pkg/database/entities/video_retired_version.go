@@ -0,0 +1,16 @@
+package entities
+
+import (
+	"KTOnlinePlatform/pkg/database/entities/entitiescustom"
+	"time"
+)
+
+// VideoRetiredVersion is a version of a film video no longer streamed, its blobs are kept until
+// the URLs signed for it expired
+type VideoRetiredVersion struct {
+	ID         int                            `db:"id"  json:"id"`
+	FilmID     int                            `db:"film_id" json:"film_id"`
+	Version    string                         `db:"version" json:"version"`
+	Renditions entitiescustom.VideoRenditions `db:"renditions" gorm:"column:renditions;type:JSONB;" json:"renditions"`
+	RetiredAt  *time.Time                     `db:"retired_at" gorm:"column:retired_at;type:TIMESTAMPTZ;" json:"retiredAt"`
+}
@@ -0,0 +1,18 @@
+package entities
+
+import (
+	"time"
+)
+
+type ViewingProgress struct {
+	UserID          int        `db:"user_id" gorm:"primaryKey" json:"user_id"`
+	FilmID          int        `db:"film_id" gorm:"primaryKey" json:"film_id"`
+	PositionSeconds int        `db:"position_seconds" json:"position_seconds"`
+	DurationSeconds int        `db:"duration_seconds" json:"duration_seconds"`
+	Completed       bool       `db:"completed" json:"completed"`
+	UpdatedAt       *time.Time `db:"updated_at" gorm:"column:updated_at;type:TIMESTAMPTZ;autoUpdateTime:false" json:"updatedAt"`
+}
+
+func (ViewingProgress) TableName() string {
+	return "viewing_progress"
+}
@@ -0,0 +1,145 @@
+package i18n
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// placeholder is a {name} in a message, replaced by the param of that name
+var placeholder = regexp.MustCompile(`\{([A-Za-z0-9_]+)\}`)
+
+// Catalog holds the messages of every locale by code, the fallback locale is used when
+// none of the accepted ones has the message
+type Catalog struct {
+	fallback string
+	messages map[string]map[string]string
+}
+
+func NewCatalog(fallback string, messages map[string]map[string]string) *Catalog {
+	if _, ok := messages[fallback]; !ok {
+		panic(fallback)
+	}
+	return &Catalog{
+		fallback: fallback,
+		messages: messages,
+	}
+}
+
+// LoadCatalog reads the <locale>.json files of dir, each one a flat object of messages by code
+func LoadCatalog(fsys fs.FS, dir string, fallback string) (*Catalog, error) {
+	files, err := fs.Glob(fsys, path.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	messages := make(map[string]map[string]string, len(files))
+	for _, file := range files {
+		data, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return nil, err
+		}
+		locale := strings.TrimSuffix(path.Base(file), ".json")
+		catalog := map[string]string{}
+		err = json.Unmarshal(data, &catalog)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", file, err)
+		}
+		messages[locale] = catalog
+	}
+	if _, ok := messages[fallback]; !ok {
+		return nil, fmt.Errorf("no messages for the fallback locale %s", fallback)
+	}
+	return NewCatalog(fallback, messages), nil
+}
+
+// Locales returns the locales of the catalog, sorted
+func (c *Catalog) Locales() []string {
+	locales := make([]string, 0, len(c.messages))
+	for locale := range c.messages {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+	return locales
+}
+
+// Template returns the raw message of a code in a locale
+func (c *Catalog) Template(locale string, code string) (string, bool) {
+	message, ok := c.messages[locale][code]
+	return message, ok
+}
+
+// Message renders the message of the code in the first accepted locale that has one, and tells
+// which locale it is. Params named after another code of the catalog are details rendered after
+// the message, their value is the {value} of their own message. Empty when the code is unknown
+func (c *Catalog) Message(locales []string, code string, params map[string]interface{}) (string, string) {
+	chain := make([]string, 0, len(locales)+1)
+	chain = append(append(chain, locales...), c.fallback)
+	for _, locale := range chain {
+		message, ok := c.messages[locale][code]
+		if !ok {
+			continue
+		}
+		message = render(message, params)
+		details := c.details(locale, params)
+		if len(details) > 0 {
+			message += ": " + strings.Join(details, ", ")
+		}
+		return message, locale
+	}
+	return "", ""
+}
+
+func (c *Catalog) details(locale string, params map[string]interface{}) []string {
+	var codes []string
+	for name := range params {
+		if _, ok := c.messages[locale][name]; ok {
+			codes = append(codes, name)
+		}
+	}
+	sort.Strings(codes)
+	details := make([]string, 0, len(codes))
+	for _, code := range codes {
+		details = append(details, render(c.messages[locale][code], map[string]interface{}{"value": params[code]}))
+	}
+	return details
+}
+
+func render(message string, params map[string]interface{}) string {
+	return placeholder.ReplaceAllStringFunc(message, func(match string) string {
+		value, ok := params[match[1:len(match)-1]]
+		if !ok {
+			return ""
+		}
+		return formatValue(value)
+	})
+}
+
+// formatValue joins lists, every other value is printed as is
+func formatValue(value interface{}) string {
+	switch v := value.(type) {
+	case []string:
+		return strings.Join(v, ", ")
+	case []interface{}:
+		items := make([]string, 0, len(v))
+		for _, item := range v {
+			items = append(items, fmt.Sprint(item))
+		}
+		return strings.Join(items, ", ")
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// Placeholders returns the param names a message refers to, sorted
+func Placeholders(message string) []string {
+	var names []string
+	for _, match := range placeholder.FindAllStringSubmatch(message, -1) {
+		names = append(names, match[1])
+	}
+	sort.Strings(names)
+	return names
+}
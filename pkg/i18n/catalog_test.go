@@ -0,0 +1,100 @@
+package i18n
+
+import (
+	"testing"
+	"testing/fstest"
+)
+
+func testCatalog() *Catalog {
+	return NewCatalog("en", map[string]map[string]string{
+		"en": {
+			"SORT":     "Cannot sort by {sort}",
+			"BANNED":   "Banned words: {words}",
+			"PASSWORD": "Weak password",
+			"LENGTH":   "at least {value} characters",
+			"UPPER":    "one uppercase letter",
+		},
+		"fr": {
+			"SORT":   "Tri par {sort} impossible",
+			"LENGTH": "au moins {value} caractères",
+		},
+	})
+}
+
+func TestCatalogMessage(t *testing.T) {
+	tests := []struct {
+		name       string
+		locales    []string
+		code       string
+		params     map[string]interface{}
+		wantMsg    string
+		wantLocale string
+	}{
+		{
+			name:       "first accepted locale with the message",
+			locales:    []string{"de", "fr"},
+			code:       "SORT",
+			params:     map[string]interface{}{"sort": "year"},
+			wantMsg:    "Tri par year impossible",
+			wantLocale: "fr",
+		},
+		{
+			name:       "fallback when no accepted locale has it",
+			locales:    []string{"fr"},
+			code:       "BANNED",
+			params:     map[string]interface{}{"words": []string{"foo", "bar"}},
+			wantMsg:    "Banned words: foo, bar",
+			wantLocale: "en",
+		},
+		{
+			name:       "missing param renders empty",
+			code:       "SORT",
+			wantMsg:    "Cannot sort by ",
+			wantLocale: "en",
+		},
+		{
+			name:       "params named after codes are details",
+			code:       "PASSWORD",
+			params:     map[string]interface{}{"UPPER": true, "LENGTH": 8},
+			wantMsg:    "Weak password: at least 8 characters, one uppercase letter",
+			wantLocale: "en",
+		},
+		{
+			name: "unknown code",
+			code: "UNKNOWN",
+		},
+	}
+	catalog := testCatalog()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg, locale := catalog.Message(tt.locales, tt.code, tt.params)
+			if msg != tt.wantMsg || locale != tt.wantLocale {
+				t.Errorf("Message() = %q, %q, want %q, %q", msg, locale, tt.wantMsg, tt.wantLocale)
+			}
+		})
+	}
+}
+
+func TestLoadCatalog(t *testing.T) {
+	fsys := fstest.MapFS{
+		"messages/en.json": {Data: []byte(`{"SORT": "Cannot sort by {sort}"}`)},
+		"messages/fr.json": {Data: []byte(`{"SORT": "Tri par {sort} impossible"}`)},
+	}
+	catalog, err := LoadCatalog(fsys, "messages", "en")
+	if err != nil {
+		t.Fatalf("LoadCatalog() error = %v", err)
+	}
+	if locales := catalog.Locales(); len(locales) != 2 || locales[0] != "en" || locales[1] != "fr" {
+		t.Errorf("Locales() = %v", locales)
+	}
+
+	_, err = LoadCatalog(fsys, "messages", "de")
+	if err == nil {
+		t.Errorf("LoadCatalog() without the fallback locale should fail")
+	}
+	fsys["messages/es.json"] = &fstest.MapFile{Data: []byte(`{"SORT": `)}
+	_, err = LoadCatalog(fsys, "messages", "en")
+	if err == nil {
+		t.Errorf("LoadCatalog() with an invalid file should fail")
+	}
+}
@@ -0,0 +1,37 @@
+package i18n
+
+import (
+	"golang.org/x/text/language"
+)
+
+// maxAcceptedLanguages bounds the fallback chain a client can make the server walk
+const maxAcceptedLanguages = 10
+
+var wildcardLanguage = language.Make("mul")
+
+// ParseAcceptLanguage returns the canonical BCP 47 tags of an Accept-Language header by preference,
+// each one followed by its parents ("fr-CA, en" is fr-CA, fr, en). Empty when the header is absent or malformed
+func ParseAcceptLanguage(header string) []string {
+	if header == "" {
+		return nil
+	}
+	tags, _, err := language.ParseAcceptLanguage(header)
+	if err != nil {
+		return nil
+	}
+	var locales []string
+	seen := make(map[string]bool)
+	for _, tag := range tags {
+		// the wildcard is parsed as "mul", any language is the default one anyway
+		if tag == wildcardLanguage {
+			continue
+		}
+		for ; tag != language.Und && len(locales) < maxAcceptedLanguages; tag = tag.Parent() {
+			if !seen[tag.String()] {
+				seen[tag.String()] = true
+				locales = append(locales, tag.String())
+			}
+		}
+	}
+	return locales
+}
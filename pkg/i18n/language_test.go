@@ -0,0 +1,42 @@
+package i18n
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseAcceptLanguage(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		want   []string
+	}{
+		{
+			name:   "ordered by quality with parents",
+			header: "en;q=0.5, fr-CA, de-de;q=0.8",
+			want:   []string{"fr-CA", "fr", "de-DE", "de", "en"},
+		},
+		{
+			name:   "wildcard and duplicates are dropped",
+			header: "pt-BR, pt;q=0.9, *;q=0.1",
+			want:   []string{"pt-BR", "pt"},
+		},
+		{
+			name:   "missing header",
+			header: "",
+			want:   nil,
+		},
+		{
+			name:   "malformed header",
+			header: "en;q=abc",
+			want:   nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ParseAcceptLanguage(tt.header); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseAcceptLanguage() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package imaging
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestThumbnail(t *testing.T) {
+	tests := []struct {
+		name           string
+		width          int
+		height         int
+		thumbnailWidth int
+		expectedSize   image.Point
+	}{
+		{
+			name:           "Scaled down keeping the aspect ratio",
+			width:          600,
+			height:         900,
+			thumbnailWidth: 200,
+			expectedSize:   image.Pt(200, 300),
+		},
+		{
+			name:           "Never scaled up",
+			width:          100,
+			height:         150,
+			thumbnailWidth: 200,
+			expectedSize:   image.Pt(100, 150),
+		},
+		{
+			name:           "Very wide image keeps at least one row",
+			width:          1000,
+			height:         1,
+			thumbnailWidth: 100,
+			expectedSize:   image.Pt(100, 1),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			img := image.NewRGBA(image.Rect(0, 0, tt.width, tt.height))
+
+			thumbnail := Thumbnail(img, tt.thumbnailWidth)
+
+			assert.Equal(t, tt.expectedSize, thumbnail.Bounds().Size())
+		})
+	}
+}
+
+func TestEncodeJPEG(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 10, 10))
+	img.Set(0, 0, color.White)
+
+	data, err := EncodeJPEG(img, 80)
+
+	assert.NoError(t, err)
+	decoded, err := jpeg.Decode(bytes.NewReader(data))
+	assert.NoError(t, err)
+	assert.Equal(t, image.Pt(10, 10), decoded.Bounds().Size())
+}
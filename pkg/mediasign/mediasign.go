@@ -0,0 +1,97 @@
+package mediasign
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	paramExpires   = "exp"
+	paramSignature = "sig"
+)
+
+var (
+	ErrInvalidSignature = errors.New("invalid media signature")
+	ErrExpired          = errors.New("media url expired")
+)
+
+// Signer builds media URLs that only work until they expire. Whoever holds a URL can use it,
+// the players fetching the segments of a playlist have no token to tell the user.
+// The first key signs, every key verifies: a new key is put first, the old one is removed
+// once the URLs it signed have expired
+type Signer struct {
+	keys    [][]byte
+	baseURL string
+	now     func() time.Time
+}
+
+func NewSigner(keys []string, baseURL string) *Signer {
+	signer := &Signer{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		now:     time.Now,
+	}
+	for _, key := range keys {
+		key = strings.TrimSpace(key)
+		if key != "" {
+			signer.keys = append(signer.keys, []byte(key))
+		}
+	}
+	if len(signer.keys) == 0 {
+		panic(keys)
+	}
+	return signer
+}
+
+// URL signs the blob key for ttl, the URL is served under the media base URL
+func (s *Signer) URL(key string, ttl time.Duration) string {
+	return s.baseURL + "/" + key + "?" + s.Query(key, ttl).Encode()
+}
+
+// Query signs the key for ttl, for the resources served elsewhere than the media base URL. The
+// expiry is rounded up to the next ttl boundary plus one ttl, so the query stays the same, and
+// cacheable, for a whole ttl
+func (s *Signer) Query(key string, ttl time.Duration) url.Values {
+	expires := s.now().Truncate(ttl).Add(2 * ttl).Unix()
+	query := url.Values{}
+	query.Set(paramExpires, strconv.FormatInt(expires, 10))
+	query.Set(paramSignature, base64.RawURLEncoding.EncodeToString(sign(s.keys[0], key, expires)))
+	return query
+}
+
+// Verify checks the signature of the key against the query of its URL
+func (s *Signer) Verify(key string, query url.Values) error {
+	expires, err := strconv.ParseInt(query.Get(paramExpires), 10, 64)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(query.Get(paramSignature))
+	if err != nil {
+		return ErrInvalidSignature
+	}
+	valid := false
+	for _, secret := range s.keys {
+		if hmac.Equal(signature, sign(secret, key, expires)) {
+			valid = true
+			break
+		}
+	}
+	if !valid {
+		return ErrInvalidSignature
+	}
+	if s.now().Unix() > expires {
+		return ErrExpired
+	}
+	return nil
+}
+
+func sign(secret []byte, key string, expires int64) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(key + "\n" + strconv.FormatInt(expires, 10)))
+	return mac.Sum(nil)
+}
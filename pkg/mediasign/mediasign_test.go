@@ -0,0 +1,123 @@
+package mediasign
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testNow = time.Date(2024, 5, 1, 12, 30, 0, 0, time.UTC)
+
+func newTestSigner(keys ...string) *Signer {
+	signer := NewSigner(keys, "http://localhost/media/")
+	signer.now = func() time.Time {
+		return testNow
+	}
+	return signer
+}
+
+func signedQuery(t *testing.T, signer *Signer, key string) url.Values {
+	signed, err := url.Parse(signer.URL(key, time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, "/media/"+key, signed.Path)
+	return signed.Query()
+}
+
+func TestSigner(t *testing.T) {
+	signer := newTestSigner("current")
+	signed := signedQuery(t, signer, "posters/1/small.jpg")
+
+	tests := []struct {
+		name    string
+		signer  *Signer
+		key     string
+		query   url.Values
+		wantErr error
+	}{
+		{
+			name:   "signed url",
+			signer: signer,
+			key:    "posters/1/small.jpg",
+			query:  signed,
+		},
+		{
+			name:    "signature of another blob",
+			signer:  signer,
+			key:     "posters/2/small.jpg",
+			query:   signed,
+			wantErr: ErrInvalidSignature,
+		},
+		{
+			name:   "expiry pushed back",
+			signer: signer,
+			key:    "posters/1/small.jpg",
+			query: url.Values{
+				paramExpires:   {"9999999999"},
+				paramSignature: signed[paramSignature],
+			},
+			wantErr: ErrInvalidSignature,
+		},
+		{
+			name:    "signed with a removed key",
+			signer:  newTestSigner("next"),
+			key:     "posters/1/small.jpg",
+			query:   signed,
+			wantErr: ErrInvalidSignature,
+		},
+		{
+			name:   "signed with the previous key during a rotation",
+			signer: newTestSigner("next", "current"),
+			key:    "posters/1/small.jpg",
+			query:  signed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.signer.Verify(tt.key, tt.query)
+
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
+
+func TestSignerExpiry(t *testing.T) {
+	signer := newTestSigner("current")
+	query := signedQuery(t, signer, "posters/1/small.jpg")
+
+	// same url for the whole hour
+	signer.now = func() time.Time {
+		return testNow.Add(20 * time.Minute)
+	}
+	assert.Equal(t, query, signedQuery(t, signer, "posters/1/small.jpg"))
+
+	// valid until the end of the next hour
+	signer.now = func() time.Time {
+		return time.Date(2024, 5, 1, 13, 59, 0, 0, time.UTC)
+	}
+	err := signer.Verify("posters/1/small.jpg", query)
+	assert.NoError(t, err)
+
+	signer.now = func() time.Time {
+		return time.Date(2024, 5, 1, 14, 0, 1, 0, time.UTC)
+	}
+	err = signer.Verify("posters/1/small.jpg", query)
+	assert.Equal(t, ErrExpired, err)
+}
+
+func TestNewSignerWithoutKeys(t *testing.T) {
+	assert.Panics(t, func() {
+		NewSigner(strings.Split(" ,", ","), "")
+	})
+}
+
+func TestSignerQuery(t *testing.T) {
+	signer := newTestSigner("current")
+
+	query := signer.Query("films/1/stream/720p", time.Hour)
+
+	assert.NoError(t, signer.Verify("films/1/stream/720p", query))
+	assert.Equal(t, ErrInvalidSignature, signer.Verify("films/1/stream/360p", query))
+}
@@ -2,31 +2,56 @@ package middlewares
 
 import (
 	"KTOnlinePlatform/internal/dto"
+	"KTOnlinePlatform/internal/models/kterrors"
+	"KTOnlinePlatform/pkg/customerror"
 	"KTOnlinePlatform/pkg/logger"
 	"KTOnlinePlatform/pkg/utils"
+	"context"
+	"errors"
+	"fmt"
 	"github.com/golang-jwt/jwt"
+	jwtv5 "github.com/golang-jwt/jwt/v5"
 	echojwt "github.com/labstack/echo-jwt/v4"
 	"github.com/labstack/echo/v4"
+	"net/http"
 	"strconv"
 	"time"
 )
 
+const (
+	// claimTokenType tells the refresh tokens apart, the access tokens do not have it
+	claimTokenType   = "typ"
+	tokenTypeRefresh = "refresh"
+)
+
+var errRefreshToken = errors.New("refresh tokens cannot authenticate a request")
+
 // global interface since it will be used in multiple places
 type AuthMiddleware interface {
 	Authenticated() echo.MiddlewareFunc
 }
 
+// SuspensionChecker tells whether a user was suspended, the tokens issued before stay valid
+type SuspensionChecker interface {
+	IsUserSuspended(ctx context.Context, userID int) (bool, error)
+}
+
 type Middleware struct {
 	jwtSecret string
+	users     SuspensionChecker
 }
 
-func NewMiddleware(jwtSecret string) *Middleware {
+func NewMiddleware(jwtSecret string, users SuspensionChecker) *Middleware {
 	if jwtSecret == "" {
 		panic(jwtSecret)
 	}
+	if users == nil {
+		panic(users)
+	}
 
 	return &Middleware{
 		jwtSecret: jwtSecret,
+		users:     users,
 	}
 }
 
@@ -39,7 +64,7 @@ func (m *Middleware) authenticatedWithTokenLookup(tokenLookup string) echo.Middl
 		return func(c echo.Context) error {
 			jwtMiddleware := m.configureJWT(tokenLookup)
 			setNoCacheHeaders(c)
-			if err := jwtMiddleware(next)(c); err != nil {
+			if err := jwtMiddleware(rejectRefreshTokens(m.rejectSuspendedWrites(next)))(c); err != nil {
 				return err
 			}
 			return nil
@@ -47,6 +72,46 @@ func (m *Middleware) authenticatedWithTokenLookup(tokenLookup string) echo.Middl
 	}
 }
 
+// rejectRefreshTokens keeps the refresh tokens for the refresh of the tokens, they live longer than the access ones
+func rejectRefreshTokens(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		token, ok := c.Get("user").(*jwtv5.Token)
+		if ok {
+			claims, ok := token.Claims.(jwtv5.MapClaims)
+			if ok && claims[claimTokenType] == tokenTypeRefresh {
+				return echo.NewHTTPError(http.StatusUnauthorized, errRefreshToken.Error()).SetInternal(errRefreshToken)
+			}
+		}
+		return next(c)
+	}
+}
+
+// rejectSuspendedWrites checks the user on every write, a suspension takes effect before the
+// tokens of the user expire. The reads stay allowed like for the other users
+func (m *Middleware) rejectSuspendedWrites(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		switch c.Request().Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			return next(c)
+		}
+		userID, err := utils.GetUserID(c)
+		if err != nil {
+			return echo.ErrUnauthorized.WithInternal(err)
+		}
+		suspended, err := m.users.IsUserSuspended(c.Request().Context(), userID)
+		if err != nil {
+			if customerror.IsNotFoundError(err) {
+				return echo.ErrUnauthorized.WithInternal(err)
+			}
+			return err
+		}
+		if suspended {
+			return customerror.NewCustomErrorWithHttpCode(kterrors.UserSuspendedError, http.StatusForbidden)
+		}
+		return next(c)
+	}
+}
+
 func (m *Middleware) configureJWT(tokenLookup string) echo.MiddlewareFunc {
 	return echojwt.WithConfig(echojwt.Config{
 		SigningKey:  []byte(m.jwtSecret),
@@ -68,6 +133,7 @@ func (m *Middleware) GenerateAuthTokens(userID int, username string) (dto.JWTTok
 	}
 
 	rt := createClaims(userID, username, 1440)
+	rt.Claims.(jwt.MapClaims)[claimTokenType] = tokenTypeRefresh
 	refreshToken, err := rt.SignedString([]byte(m.jwtSecret))
 	if err != nil {
 		return dto.JWTTokens{}, err
@@ -89,3 +155,27 @@ func createClaims(userID int, username string, validationTimeInMinutes int) *jwt
 
 	return t
 }
+
+// ParseRefreshToken returns the user of a refresh token signed with the secret of the middleware and not expired
+func (m *Middleware) ParseRefreshToken(refreshToken string) (int, string, error) {
+	token, err := jwt.Parse(refreshToken, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method %v", token.Header["alg"])
+		}
+		return []byte(m.jwtSecret), nil
+	})
+	if err != nil {
+		return 0, "", err
+	}
+	claims := token.Claims.(jwt.MapClaims)
+	if claims[claimTokenType] != tokenTypeRefresh {
+		return 0, "", errors.New("not a refresh token")
+	}
+	sub, _ := claims["sub"].(string)
+	userID, err := strconv.Atoi(sub)
+	if err != nil {
+		return 0, "", err
+	}
+	username, _ := claims["username"].(string)
+	return userID, username, nil
+}
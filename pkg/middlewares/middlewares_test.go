@@ -0,0 +1,90 @@
+package middlewares
+
+import (
+	"KTOnlinePlatform/internal/models/kterrors"
+	"KTOnlinePlatform/pkg/customerror"
+	"KTOnlinePlatform/pkg/logger"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+const testUserID = 1
+
+type suspensions map[int]bool
+
+func (s suspensions) IsUserSuspended(ctx context.Context, userID int) (bool, error) {
+	suspended, ok := s[userID]
+	if !ok {
+		return false, gorm.ErrRecordNotFound
+	}
+	return suspended, nil
+}
+
+func TestAuthenticatedSuspendedUser(t *testing.T) {
+	logger.InitializeForTest()
+
+	testCases := []struct {
+		name           string
+		method         string
+		users          suspensions
+		expectedCalled bool
+		expectedError  error
+	}{
+		{
+			name:           "Active user writes",
+			method:         http.MethodPost,
+			users:          suspensions{testUserID: false},
+			expectedCalled: true,
+		},
+		{
+			name:          "Suspended user cannot write",
+			method:        http.MethodPut,
+			users:         suspensions{testUserID: true},
+			expectedError: customerror.NewCustomErrorWithHttpCode(kterrors.UserSuspendedError, http.StatusForbidden),
+		},
+		{
+			name:           "Suspended user still reads",
+			method:         http.MethodGet,
+			users:          suspensions{testUserID: true},
+			expectedCalled: true,
+		},
+		{
+			name:          "Deleted user",
+			method:        http.MethodDelete,
+			users:         suspensions{},
+			expectedError: echo.ErrUnauthorized.WithInternal(gorm.ErrRecordNotFound),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			m := NewMiddleware("secret", tc.users)
+			tokens, err := m.GenerateAuthTokens(testUserID, "ripley")
+			require.NoError(t, err)
+			request := httptest.NewRequest(tc.method, "/", nil)
+			request.Header.Set(echo.HeaderAuthorization, "Bearer "+tokens.AccessToken)
+			c := echo.New().NewContext(request, httptest.NewRecorder())
+			called := false
+
+			err = m.Authenticated()(func(c echo.Context) error {
+				called = true
+				return nil
+			})(c)
+
+			assert.Equal(t, tc.expectedCalled, called)
+			if tc.expectedError != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tc.expectedError.Error(), err.Error())
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
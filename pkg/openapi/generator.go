@@ -0,0 +1,285 @@
+package openapi
+
+import (
+	"KTOnlinePlatform/pkg/customerror"
+	"fmt"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	bearerAuth      = "bearerAuth"
+	jsonContentType = "application/json"
+	multipartForm   = "multipart/form-data"
+	binaryFormat    = "binary"
+	// wildcardParam names the path param of a trailing * in an echo path
+	wildcardParam = "path"
+)
+
+// echoParam is a :name segment of an echo path
+var echoParam = regexp.MustCompile(`:([^/]+)`)
+
+// Route documents a route registered in echo
+type Route struct {
+	Method string
+	// Path is the echo path, with :name params and a trailing * wildcard
+	Path    string
+	Tag     string
+	Summary string
+	// Public routes are called without a bearer token
+	Public bool
+	// Request is the struct bound by the handler, its param, query and header fields are the
+	// parameters and its other fields the JSON body
+	Request interface{}
+	// Parameters are the params the handler reads itself instead of binding them
+	Parameters []Parameter
+	// RequestTypes are the content types of a raw body, sent as is instead of JSON
+	RequestTypes []string
+	// Upload is the form field of a file sent as multipart/form-data
+	Upload string
+	// FormFields are the text fields sent along the upload
+	FormFields []string
+	// Status is the status of a success, 200 with a response and 204 without by default
+	Status int
+	// Response is the JSON body of a success
+	Response interface{}
+	// ResponseTypes are the content types of a raw response
+	ResponseTypes []string
+}
+
+func (r Route) key() string {
+	return r.Method + " " + r.Path
+}
+
+// Generator builds the document of the routes registered in echo from their Route
+type Generator struct {
+	info    Info
+	known   map[reflect.Type]Schema
+	schemas map[string]*Schema
+	names   map[string]reflect.Type
+}
+
+func NewGenerator(info Info) *Generator {
+	return &Generator{
+		info:  info,
+		known: map[reflect.Type]Schema{},
+	}
+}
+
+// Register describes a type that is not sent as its fields, a value marshalled as a string for instance
+func (g *Generator) Register(value interface{}, schema Schema) {
+	g.known[reflect.TypeOf(value)] = schema
+}
+
+// Document describes the registered routes that have a Route, the others are left out,
+// see Undocumented. Errors are the problems of customerror
+func (g *Generator) Document(registered []*echo.Route, routes []Route) Document {
+	g.schemas = map[string]*Schema{}
+	g.names = map[string]reflect.Type{}
+	byKey := make(map[string]Route, len(routes))
+	for _, route := range routes {
+		byKey[route.key()] = route
+	}
+	problem := g.schemaOf(reflect.TypeOf(customerror.Problem{}))
+
+	document := Document{
+		OpenAPI: Version,
+		Info:    g.info,
+		Paths:   map[string]PathItem{},
+		Components: Components{
+			Schemas: g.schemas,
+			SecuritySchemes: map[string]SecurityScheme{
+				bearerAuth: {Type: "http", Scheme: "bearer", BearerFormat: "JWT"},
+			},
+		},
+		Security: []SecurityRequirement{{bearerAuth: {}}},
+	}
+	tags := map[string]bool{}
+	for _, registeredRoute := range documentedRoutes(registered) {
+		route, ok := byKey[registeredRoute.Method+" "+registeredRoute.Path]
+		if !ok {
+			continue
+		}
+		path := toPath(route.Path)
+		if document.Paths[path] == nil {
+			document.Paths[path] = PathItem{}
+		}
+		document.Paths[path][strings.ToLower(route.Method)] = g.operation(route, problem)
+		if route.Tag != "" && !tags[route.Tag] {
+			tags[route.Tag] = true
+			document.Tags = append(document.Tags, Tag{Name: route.Tag})
+		}
+	}
+	sort.Slice(document.Tags, func(i, j int) bool {
+		return document.Tags[i].Name < document.Tags[j].Name
+	})
+	return document
+}
+
+func (g *Generator) operation(route Route, problem *Schema) *Operation {
+	operation := &Operation{
+		OperationID: operationID(route),
+		Summary:     route.Summary,
+		Responses:   map[string]Response{},
+	}
+	if route.Tag != "" {
+		operation.Tags = []string{route.Tag}
+	}
+	if route.Public {
+		operation.Security = []SecurityRequirement{{}}
+	}
+
+	var parameters []Parameter
+	if route.Request != nil {
+		requestType := reflect.TypeOf(route.Request)
+		parameters = g.parameters(requestType)
+		if hasBody(requestType) && route.Method != http.MethodGet && route.Method != http.MethodDelete {
+			operation.RequestBody = &RequestBody{
+				Required: true,
+				Content:  map[string]MediaType{jsonContentType: {Schema: g.schemaOf(requestType)}},
+			}
+		}
+	}
+	operation.Parameters = withPathParams(append(parameters, route.Parameters...), route.Path)
+	if len(route.RequestTypes) > 0 {
+		operation.RequestBody = &RequestBody{Required: true, Content: binaryContent(route.RequestTypes)}
+	}
+	if route.Upload != "" {
+		form := &Schema{
+			Type:       "object",
+			Properties: map[string]*Schema{route.Upload: {Type: "string", Format: binaryFormat}},
+			Required:   []string{route.Upload},
+		}
+		for _, field := range route.FormFields {
+			form.Properties[field] = &Schema{Type: "string"}
+		}
+		operation.RequestBody = &RequestBody{Required: true, Content: map[string]MediaType{multipartForm: {Schema: form}}}
+	}
+
+	status := route.Status
+	if status == 0 {
+		status = http.StatusNoContent
+		if route.Response != nil || len(route.ResponseTypes) > 0 {
+			status = http.StatusOK
+		}
+	}
+	success := Response{Description: http.StatusText(status)}
+	if route.Response != nil {
+		success.Content = map[string]MediaType{jsonContentType: {Schema: g.schemaOf(reflect.TypeOf(route.Response))}}
+	}
+	if len(route.ResponseTypes) > 0 {
+		success.Content = binaryContent(route.ResponseTypes)
+	}
+	operation.Responses[strconv.Itoa(status)] = success
+	operation.Responses["default"] = Response{
+		Description: "Problem",
+		Content:     map[string]MediaType{customerror.ProblemContentType: {Schema: problem}},
+	}
+	return operation
+}
+
+// withPathParams adds the params of the path the request struct does not bind, read by the handler itself
+func withPathParams(parameters []Parameter, path string) []Parameter {
+	bound := map[string]bool{}
+	for _, parameter := range parameters {
+		if parameter.In == "path" {
+			bound[parameter.Name] = true
+		}
+	}
+	var pathParams []Parameter
+	for _, name := range pathParamNames(path) {
+		if bound[name] {
+			continue
+		}
+		schema := &Schema{Type: "string"}
+		if name == "id" || strings.HasSuffix(name, "Id") {
+			schema = &Schema{Type: "integer", Format: "int32"}
+		}
+		pathParams = append(pathParams, Parameter{Name: name, In: "path", Required: true, Schema: schema})
+	}
+	return append(pathParams, parameters...)
+}
+
+func binaryContent(contentTypes []string) map[string]MediaType {
+	content := make(map[string]MediaType, len(contentTypes))
+	for _, contentType := range contentTypes {
+		content[contentType] = MediaType{Schema: &Schema{Type: "string", Format: binaryFormat}}
+	}
+	return content
+}
+
+func pathParamNames(path string) []string {
+	var names []string
+	for _, match := range echoParam.FindAllStringSubmatch(path, -1) {
+		names = append(names, match[1])
+	}
+	if strings.HasSuffix(path, "*") {
+		names = append(names, wildcardParam)
+	}
+	return names
+}
+
+// toPath turns an echo path into an OpenAPI one, /films/:id/* becomes /films/{id}/{path}
+func toPath(path string) string {
+	path = echoParam.ReplaceAllString(path, "{$1}")
+	if strings.HasSuffix(path, "*") {
+		path = strings.TrimSuffix(path, "*") + "{" + wildcardParam + "}"
+	}
+	return path
+}
+
+func operationID(route Route) string {
+	id := strings.ToLower(route.Method)
+	for _, segment := range strings.Split(toPath(route.Path), "/") {
+		segment = strings.Trim(segment, "{}")
+		if segment == "" || segment == "api" || segment == "v1" {
+			continue
+		}
+		id += strings.ToUpper(segment[:1]) + segment[1:]
+	}
+	return id
+}
+
+// documentedRoutes drops the routes echo adds for its own use, like the not found handlers of the groups
+func documentedRoutes(registered []*echo.Route) []*echo.Route {
+	routes := make([]*echo.Route, 0, len(registered))
+	for _, route := range registered {
+		if route.Method == echo.RouteNotFound {
+			continue
+		}
+		routes = append(routes, route)
+	}
+	sort.Slice(routes, func(i, j int) bool {
+		if routes[i].Path != routes[j].Path {
+			return routes[i].Path < routes[j].Path
+		}
+		return routes[i].Method < routes[j].Method
+	})
+	return routes
+}
+
+// Undocumented lists the registered routes without a Route, and the Routes that are not registered
+func Undocumented(registered []*echo.Route, routes []Route) []string {
+	byKey := make(map[string]bool, len(routes))
+	for _, route := range routes {
+		byKey[route.key()] = true
+	}
+	var undocumented []string
+	for _, route := range documentedRoutes(registered) {
+		key := route.Method + " " + route.Path
+		if !byKey[key] {
+			undocumented = append(undocumented, key)
+		}
+		delete(byKey, key)
+	}
+	for key := range byKey {
+		undocumented = append(undocumented, fmt.Sprintf("%s (not registered)", key))
+	}
+	sort.Strings(undocumented)
+	return undocumented
+}
@@ -0,0 +1,81 @@
+package openapi
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+type testDate struct{}
+
+type testRequest struct {
+	ID       int      `param:"id" validate:"required"`
+	Page     int      `query:"page" validate:"omitempty,gte=1"`
+	Title    string   `json:"title" validate:"title"`
+	Kind     string   `json:"kind,omitempty" validate:"omitempty,oneof=a b"`
+	Tags     []string `json:"tags" validate:"max=3,dive,max=10"`
+	Date     testDate `json:"date"`
+	Internal int      `json:"-"`
+}
+
+func TestToPath(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     string
+		expected string
+	}{
+		{name: "Static", path: "/api/v1/films", expected: "/api/v1/films"},
+		{name: "Params", path: "/api/v1/films/:id/reviews/:reviewId", expected: "/api/v1/films/{id}/reviews/{reviewId}"},
+		{name: "Wildcard", path: "/media/*", expected: "/media/{path}"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, toPath(tt.path))
+		})
+	}
+}
+
+func TestDocument(t *testing.T) {
+	generator := NewGenerator(Info{Title: "test", Version: "1"})
+	generator.Register(testDate{}, Schema{Type: "string", Format: "date"})
+	e := echo.New()
+	e.PUT("/things/:id", nil)
+
+	document := generator.Document(e.Routes(), []Route{{Method: http.MethodPut, Path: "/things/:id", Request: testRequest{}}})
+
+	operation := document.Paths["/things/{id}"]["put"]
+	if !assert.NotNil(t, operation) {
+		return
+	}
+	one := 1.0
+	assert.Equal(t, []Parameter{
+		{Name: "id", In: "path", Required: true, Schema: &Schema{Type: "integer", Format: "int32"}},
+		{Name: "page", In: "query", Schema: &Schema{Type: "integer", Format: "int32", Minimum: &one}},
+	}, operation.Parameters)
+	assert.Contains(t, operation.Responses, "204")
+	assert.Equal(t, &Schema{Ref: "#/components/schemas/testRequest"}, operation.RequestBody.Content[jsonContentType].Schema)
+	assert.Equal(t, &Schema{
+		Type: "object",
+		Properties: map[string]*Schema{
+			"title": {Type: "string", MinLength: intPointer(1), MaxLength: intPointer(255)},
+			"kind":  {Type: "string", Enum: []string{"a", "b"}},
+			"tags":  {Type: []string{"array", "null"}, Items: &Schema{Type: "string"}, MaxItems: intPointer(3)},
+			"date":  {Type: "string", Format: "date"},
+		},
+		Required: []string{"title"},
+	}, document.Components.Schemas["testRequest"])
+}
+
+func TestUndocumented(t *testing.T) {
+	e := echo.New()
+	e.GET("/things", nil)
+	e.Group("/things", func(next echo.HandlerFunc) echo.HandlerFunc { return next }).POST("", nil)
+
+	undocumented := Undocumented(e.Routes(), []Route{
+		{Method: http.MethodGet, Path: "/things"},
+		{Method: http.MethodDelete, Path: "/things"},
+	})
+
+	assert.Equal(t, []string{"DELETE /things (not registered)", "POST /things"}, undocumented)
+}
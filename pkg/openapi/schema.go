@@ -0,0 +1,274 @@
+package openapi
+
+import (
+	"KTOnlinePlatform/pkg/validation"
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	componentsSchemas = "#/components/schemas/"
+	nullType          = "null"
+)
+
+// parameterTags are the echo bind tags of the request params, in the order their location is looked up
+var parameterTags = []struct {
+	tag string
+	in  string
+}{
+	{tag: "param", in: "path"},
+	{tag: "query", in: "query"},
+	{tag: "header", in: "header"},
+}
+
+var (
+	timeType       = reflect.TypeOf(time.Time{})
+	rawMessageType = reflect.TypeOf(json.RawMessage{})
+	bytesType      = reflect.TypeOf([]byte{})
+)
+
+// schemaOf returns the schema of a type, named structs are added to the components and referenced
+func (g *Generator) schemaOf(t reflect.Type) *Schema {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if schema, ok := g.known[t]; ok {
+		copied := schema
+		return &copied
+	}
+	switch t {
+	case timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case rawMessageType:
+		return &Schema{}
+	case bytesType:
+		return &Schema{Type: "string", Format: "byte"}
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int64, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.Slice, reflect.Array:
+		return &Schema{Type: "array", Items: g.schemaOf(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: g.schemaOf(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return g.structSchema(t)
+		}
+		return g.refOf(t)
+	default:
+		// interfaces accept any value
+		return &Schema{}
+	}
+}
+
+func (g *Generator) refOf(t reflect.Type) *Schema {
+	name := t.Name()
+	if other, ok := g.names[name]; ok && other != t {
+		// two packages use the same name, the package qualifies the second one
+		name = pkgName(t) + name
+	}
+	if _, ok := g.schemas[name]; !ok {
+		g.names[name] = t
+		// reserved first so a recursive type refers to itself instead of looping
+		g.schemas[name] = &Schema{}
+		*g.schemas[name] = *g.structSchema(t)
+	}
+	return &Schema{Ref: componentsSchemas + name}
+}
+
+func pkgName(t reflect.Type) string {
+	pkgPath := t.PkgPath()
+	name := pkgPath[strings.LastIndex(pkgPath, "/")+1:]
+	return strings.ToUpper(name[:1]) + name[1:]
+}
+
+// structSchema describes the JSON body of a struct, the request params are left to parameters
+func (g *Generator) structSchema(t reflect.Type) *Schema {
+	schema := &Schema{Type: "object", Properties: map[string]*Schema{}}
+	g.addProperties(schema, t)
+	return schema
+}
+
+func (g *Generator) addProperties(schema *Schema, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		if _, in := parameterOf(field); in != "" {
+			continue
+		}
+		name, omitempty := jsonName(field)
+		if name == "-" {
+			continue
+		}
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			g.addProperties(schema, field.Type)
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		property := g.schemaOf(field.Type)
+		required := applyRules(property, field)
+		if required && !omitempty {
+			schema.Required = append(schema.Required, name)
+		}
+		switch field.Type.Kind() {
+		case reflect.Pointer, reflect.Slice, reflect.Map:
+			// encoding/json sends nil as null
+			property = nullable(property)
+		}
+		schema.Properties[name] = property
+	}
+}
+
+func nullable(schema *Schema) *Schema {
+	if typeName, ok := schema.Type.(string); ok {
+		schema.Type = []string{typeName, nullType}
+		return schema
+	}
+	if schema.Ref != "" {
+		return &Schema{AnyOf: []*Schema{schema, {Type: nullType}}}
+	}
+	// a schema without type already accepts null
+	return schema
+}
+
+// parameters lists the path, query and header params of a request struct
+func (g *Generator) parameters(t reflect.Type) []Parameter {
+	var parameters []Parameter
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			parameters = append(parameters, g.parameters(field.Type)...)
+			continue
+		}
+		name, in := parameterOf(field)
+		if in == "" {
+			continue
+		}
+		schema := g.schemaOf(field.Type)
+		required := applyRules(schema, field)
+		parameters = append(parameters, Parameter{
+			Name:     name,
+			In:       in,
+			Required: required || in == "path",
+			Schema:   schema,
+		})
+	}
+	return parameters
+}
+
+// hasBody tells whether some fields of a request struct are read from the JSON body
+func hasBody(t reflect.Type) bool {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		if _, in := parameterOf(field); in != "" {
+			continue
+		}
+		if name, _ := jsonName(field); name == "-" {
+			continue
+		}
+		if field.Anonymous && field.Type.Kind() == reflect.Struct && !hasBody(field.Type) {
+			continue
+		}
+		return true
+	}
+	return false
+}
+
+func parameterOf(field reflect.StructField) (string, string) {
+	for _, parameterTag := range parameterTags {
+		name, _, _ := strings.Cut(field.Tag.Get(parameterTag.tag), ",")
+		if name != "" {
+			return name, parameterTag.in
+		}
+	}
+	return "", ""
+}
+
+func jsonName(field reflect.StructField) (string, bool) {
+	name, options, _ := strings.Cut(field.Tag.Get("json"), ",")
+	return name, strings.Contains(options, "omitempty")
+}
+
+// applyRules translates the validate tag of a field into schema constraints, and tells whether it is required
+func applyRules(schema *Schema, field reflect.StructField) bool {
+	tag := field.Tag.Get("validate")
+	if tag == "" {
+		return false
+	}
+	required := false
+	for _, rule := range strings.Split(tag, ",") {
+		name, param, _ := strings.Cut(rule, "=")
+		switch name {
+		case "dive":
+			// the following rules apply to the items
+			return required
+		case "required":
+			required = true
+		case "title":
+			required = true
+			schema.MinLength = intPointer(1)
+			schema.MaxLength = intPointer(validation.TitleMaxLength)
+		case "oneof":
+			schema.Enum = strings.Fields(param)
+		case "min", "max", "gte", "lte":
+			setBound(schema, name == "min" || name == "gte", param)
+		case "maxyearsahead":
+			schema.Description = "at most " + param + " years from now"
+		}
+	}
+	return required
+}
+
+func setBound(schema *Schema, lower bool, param string) {
+	value, err := strconv.Atoi(param)
+	if err != nil {
+		return
+	}
+	switch schema.Type {
+	case "string":
+		if lower {
+			schema.MinLength = intPointer(value)
+		} else {
+			schema.MaxLength = intPointer(value)
+		}
+	case "array":
+		if lower {
+			schema.MinItems = intPointer(value)
+		} else {
+			schema.MaxItems = intPointer(value)
+		}
+	case "integer", "number":
+		bound := float64(value)
+		if lower {
+			schema.Minimum = &bound
+		} else {
+			schema.Maximum = &bound
+		}
+	}
+}
+
+func intPointer(value int) *int {
+	return &value
+}
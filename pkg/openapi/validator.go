@@ -0,0 +1,454 @@
+package openapi
+
+import (
+	"KTOnlinePlatform/pkg/customerror"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/labstack/echo/v4"
+	"github.com/samber/lo"
+	"io"
+	"mime"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const dateFormat = "2006-01-02"
+
+// Validator checks the requests and the responses of the routes against their operation in a document
+type Validator struct {
+	document Document
+	// code is the error code of the invalid requests
+	code string
+}
+
+func NewValidator(document Document, code string) *Validator {
+	if code == "" {
+		panic(code)
+	}
+	return &Validator{
+		document: document,
+		code:     code,
+	}
+}
+
+func (v *Validator) operation(method string, path string) *Operation {
+	return v.document.Paths[toPath(path)][strings.ToLower(method)]
+}
+
+// Requests rejects the requests whose params or JSON body break the schema of their operation, with a
+// validation error listing every broken rule. Routes without an operation are left to their handler
+func (v *Validator) Requests() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			operation := v.operation(c.Request().Method, c.Path())
+			if operation == nil {
+				return next(c)
+			}
+			fields, err := v.validateRequest(c, operation)
+			if err != nil {
+				return err
+			}
+			if len(fields) > 0 {
+				return customerror.NewValidationError(v.code, fields)
+			}
+			return next(c)
+		}
+	}
+}
+
+func (v *Validator) validateRequest(c echo.Context, operation *Operation) ([]customerror.FieldError, error) {
+	var fields []customerror.FieldError
+	for _, parameter := range operation.Parameters {
+		raw := parameterValues(c, parameter)
+		if len(raw) == 0 {
+			if parameter.Required {
+				fields = append(fields, customerror.FieldError{Field: parameter.Name, Rule: "required"})
+			}
+			continue
+		}
+		fields = append(fields, v.validate(parameterValue(raw, v.resolve(parameter.Schema)), parameter.Schema, parameter.Name)...)
+	}
+
+	if operation.RequestBody == nil || !isJSON(c.Request().Header.Get(echo.HeaderContentType)) {
+		return fields, nil
+	}
+	body, ok := operation.RequestBody.Content[jsonContentType]
+	if !ok {
+		return fields, nil
+	}
+	content, err := io.ReadAll(c.Request().Body)
+	if err != nil {
+		return nil, err
+	}
+	// the handler binds the body again
+	c.Request().Body = io.NopCloser(bytes.NewReader(content))
+	if len(bytes.TrimSpace(content)) == 0 {
+		// echo binds an empty body as the zero value, like an empty object
+		content = []byte("{}")
+	}
+	value, err := decode(content)
+	if err != nil {
+		// the handler reports the malformed body while binding it
+		return fields, nil
+	}
+	return append(fields, v.validate(value, body.Schema, "")...), nil
+}
+
+func parameterValues(c echo.Context, parameter Parameter) []string {
+	var values []string
+	switch parameter.In {
+	case "path":
+		name := parameter.Name
+		if name == wildcardParam {
+			name = "*"
+		}
+		values = []string{c.Param(name)}
+	case "query":
+		values = c.QueryParams()[parameter.Name]
+	case "header":
+		values = c.Request().Header.Values(parameter.Name)
+	}
+	present := values[:0:0]
+	for _, value := range values {
+		if value != "" {
+			present = append(present, value)
+		}
+	}
+	return present
+}
+
+// parameterValue converts the strings of a param into the JSON value its schema describes
+func parameterValue(raw []string, schema *Schema) interface{} {
+	if schema != nil && hasType(schema, "array") {
+		values := make([]interface{}, 0, len(raw))
+		for _, value := range raw {
+			values = append(values, parameterValue([]string{value}, schema.Items))
+		}
+		return values
+	}
+	value := raw[0]
+	switch {
+	case schema == nil:
+		return value
+	case hasType(schema, "integer"), hasType(schema, "number"):
+		return json.Number(value)
+	case hasType(schema, "boolean"):
+		if parsed, err := strconv.ParseBool(value); err == nil {
+			return parsed
+		}
+	}
+	return value
+}
+
+// ValidateResponse checks a response sent by the handler of a route, identified by its echo path
+func (v *Validator) ValidateResponse(method string, path string, status int, contentType string, body []byte) error {
+	operation := v.operation(method, path)
+	if operation == nil {
+		return fmt.Errorf("%s %s is not documented", method, path)
+	}
+	response, ok := operation.Responses[strconv.Itoa(status)]
+	if !ok && status >= http.StatusBadRequest {
+		response, ok = operation.Responses["default"]
+	}
+	if !ok {
+		return fmt.Errorf("%s %s: status %d is not documented", method, path, status)
+	}
+	if len(response.Content) == 0 {
+		if len(body) > 0 {
+			return fmt.Errorf("%s %s: status %d has no documented body", method, path, status)
+		}
+		return nil
+	}
+	if method == http.MethodHead {
+		return nil
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return fmt.Errorf("%s %s: content type %q: %w", method, path, contentType, err)
+	}
+	content, ok := response.Content[mediaType]
+	if !ok {
+		return fmt.Errorf("%s %s: content type %s is not documented", method, path, mediaType)
+	}
+	if !isJSON(contentType) || content.Schema == nil || content.Schema.Format == binaryFormat {
+		// raw bodies, like an export, are sent as is
+		return nil
+	}
+	value, err := decode(body)
+	if err != nil {
+		return fmt.Errorf("%s %s: body: %w", method, path, err)
+	}
+	if fields := v.validate(value, content.Schema, ""); len(fields) > 0 {
+		return fmt.Errorf("%s %s: body does not match the schema: %s", method, path, describe(fields))
+	}
+	return nil
+}
+
+// Responses checks the responses of the handlers with ValidateResponse and passes the mismatches to
+// report, like t.Error. It keeps a copy of every body, it is meant for the tests
+func (v *Validator) Responses(report func(error)) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			response := c.Response()
+			recorder := &bodyRecorder{ResponseWriter: response.Writer}
+			response.Writer = recorder
+			defer func() {
+				response.Writer = recorder.ResponseWriter
+			}()
+
+			err := next(c)
+			if err != nil {
+				// the error handler sends the problem after the middlewares
+				return err
+			}
+			err = v.ValidateResponse(c.Request().Method, c.Path(), response.Status, response.Header().Get(echo.HeaderContentType), recorder.body.Bytes())
+			if err != nil {
+				report(err)
+			}
+			return nil
+		}
+	}
+}
+
+type bodyRecorder struct {
+	http.ResponseWriter
+	body bytes.Buffer
+}
+
+func (r *bodyRecorder) Write(b []byte) (int, error) {
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
+
+func (r *bodyRecorder) Flush() {
+	if flusher, ok := r.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (r *bodyRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
+// validate lists the rules of a schema a JSON value breaks, field is the path of the value in the request
+func (v *Validator) validate(value interface{}, schema *Schema, field string) []customerror.FieldError {
+	schema = v.resolve(schema)
+	if schema == nil {
+		return nil
+	}
+	if len(schema.AnyOf) > 0 {
+		var first []customerror.FieldError
+		for i, option := range schema.AnyOf {
+			fields := v.validate(value, option, field)
+			if len(fields) == 0 {
+				return nil
+			}
+			if i == 0 {
+				first = fields
+			}
+		}
+		return first
+	}
+	types := typesOf(schema)
+	if len(types) == 0 {
+		return nil
+	}
+	typeName, ok := typeOf(value, types)
+	if !ok {
+		return []customerror.FieldError{{Field: field, Rule: "type", Param: strings.Join(types, " ")}}
+	}
+
+	var fields []customerror.FieldError
+	broken := func(rule string, param interface{}) {
+		fields = append(fields, customerror.FieldError{Field: field, Rule: rule, Param: fmt.Sprint(param)})
+	}
+	switch typeName {
+	case "string":
+		text := value.(string)
+		length := utf8.RuneCountInString(text)
+		if schema.MinLength != nil && length < *schema.MinLength {
+			broken("minLength", *schema.MinLength)
+		}
+		if schema.MaxLength != nil && length > *schema.MaxLength {
+			broken("maxLength", *schema.MaxLength)
+		}
+		if len(schema.Enum) > 0 && !lo.Contains(schema.Enum, text) {
+			broken("enum", strings.Join(schema.Enum, " "))
+		}
+		if !validFormat(schema.Format, text) {
+			broken("format", schema.Format)
+		}
+	case "integer", "number":
+		number, _ := value.(json.Number).Float64()
+		if schema.Minimum != nil && number < *schema.Minimum {
+			broken("minimum", *schema.Minimum)
+		}
+		if schema.Maximum != nil && number > *schema.Maximum {
+			broken("maximum", *schema.Maximum)
+		}
+	case "array":
+		items := value.([]interface{})
+		if schema.MinItems != nil && len(items) < *schema.MinItems {
+			broken("minItems", *schema.MinItems)
+		}
+		if schema.MaxItems != nil && len(items) > *schema.MaxItems {
+			broken("maxItems", *schema.MaxItems)
+		}
+		for i, item := range items {
+			fields = append(fields, v.validate(item, schema.Items, fmt.Sprintf("%s[%d]", field, i))...)
+		}
+	case "object":
+		object := value.(map[string]interface{})
+		for _, name := range schema.Required {
+			if _, ok := object[name]; !ok {
+				fields = append(fields, customerror.FieldError{Field: join(field, name), Rule: "required"})
+			}
+		}
+		// the unknown properties are ignored, like encoding/json does
+		for _, name := range sortedKeys(object) {
+			property, ok := schema.Properties[name]
+			if !ok {
+				property = schema.AdditionalProperties
+			}
+			if property != nil {
+				fields = append(fields, v.validate(object[name], property, join(field, name))...)
+			}
+		}
+	}
+	return fields
+}
+
+func (v *Validator) resolve(schema *Schema) *Schema {
+	for schema != nil && schema.Ref != "" {
+		schema = v.document.Components.Schemas[strings.TrimPrefix(schema.Ref, componentsSchemas)]
+	}
+	return schema
+}
+
+// typesOf returns the types a schema accepts, Type is a string or a list of them
+func typesOf(schema *Schema) []string {
+	switch typeNames := schema.Type.(type) {
+	case string:
+		return []string{typeNames}
+	case []string:
+		return typeNames
+	case []interface{}:
+		types := make([]string, 0, len(typeNames))
+		for _, typeName := range typeNames {
+			types = append(types, fmt.Sprint(typeName))
+		}
+		return types
+	}
+	return nil
+}
+
+func hasType(schema *Schema, typeName string) bool {
+	return lo.Contains(typesOf(schema), typeName)
+}
+
+// typeOf returns the type of types a decoded JSON value is
+func typeOf(value interface{}, types []string) (string, bool) {
+	for _, typeName := range types {
+		switch typeName {
+		case "string":
+			_, ok := value.(string)
+			if ok {
+				return typeName, true
+			}
+		case "number":
+			number, ok := value.(json.Number)
+			if ok {
+				if _, err := number.Float64(); err == nil {
+					return typeName, true
+				}
+			}
+		case "integer":
+			number, ok := value.(json.Number)
+			if ok {
+				if _, err := number.Int64(); err == nil {
+					return typeName, true
+				}
+			}
+		case "boolean":
+			_, ok := value.(bool)
+			if ok {
+				return typeName, true
+			}
+		case "array":
+			_, ok := value.([]interface{})
+			if ok {
+				return typeName, true
+			}
+		case "object":
+			_, ok := value.(map[string]interface{})
+			if ok {
+				return typeName, true
+			}
+		case nullType:
+			if value == nil {
+				return typeName, true
+			}
+		}
+	}
+	return "", false
+}
+
+func validFormat(format string, value string) bool {
+	switch format {
+	case "date":
+		_, err := time.Parse(dateFormat, value)
+		return err == nil
+	case "date-time":
+		_, err := time.Parse(time.RFC3339, value)
+		return err == nil
+	}
+	return true
+}
+
+// decode parses a JSON body keeping the numbers as written, to tell integers apart
+func decode(content []byte) (interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.UseNumber()
+	var value interface{}
+	err := decoder.Decode(&value)
+	return value, err
+}
+
+func isJSON(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	return mediaType == jsonContentType || strings.HasSuffix(mediaType, "+json")
+}
+
+func describe(fields []customerror.FieldError) string {
+	descriptions := make([]string, 0, len(fields))
+	for _, field := range fields {
+		description := field.Field + " " + field.Rule
+		if field.Param != "" {
+			description += "=" + field.Param
+		}
+		descriptions = append(descriptions, description)
+	}
+	return strings.Join(descriptions, ", ")
+}
+
+func join(parent string, name string) string {
+	if parent == "" {
+		return name
+	}
+	return parent + "." + name
+}
+
+// sortedKeys ranges over the properties in a stable order, the broken rules are listed the same way every time
+func sortedKeys[T any](values map[string]T) []string {
+	keys := lo.Keys(values)
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,183 @@
+package openapi
+
+import (
+	"KTOnlinePlatform/pkg/customerror"
+	"errors"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const testCode = "VALIDATION_ERROR"
+
+type testItem struct {
+	Name  string    `json:"name" validate:"required,max=5"`
+	Date  testDate  `json:"date"`
+	Child *testItem `json:"child"`
+}
+
+type testItemRequest struct {
+	ID    int        `param:"id" validate:"required"`
+	Page  int        `query:"page" validate:"omitempty,gte=1"`
+	Kind  string     `query:"kind" validate:"omitempty,oneof=a b"`
+	Title string     `json:"title" validate:"title"`
+	Items []testItem `json:"items" validate:"max=2,dive"`
+}
+
+func newTestValidator(e *echo.Echo) *Validator {
+	generator := NewGenerator(Info{Title: "test", Version: "1"})
+	generator.Register(testDate{}, Schema{Type: "string", Format: "date"})
+	return NewValidator(generator.Document(e.Routes(), []Route{
+		{Method: http.MethodPut, Path: "/items/:id", Request: testItemRequest{}},
+		{Method: http.MethodGet, Path: "/items/:id", Response: testItem{}},
+	}), testCode)
+}
+
+func TestRequests(t *testing.T) {
+	tests := []struct {
+		name     string
+		target   string
+		body     string
+		expected error
+	}{
+		{
+			name:   "Valid",
+			target: "/items/1?page=2&kind=a",
+			body:   `{"title": "Alien", "items": [{"name": "ship", "date": "1979-05-25", "child": null}]}`,
+		},
+		{
+			name:   "Invalid params",
+			target: "/items/one?page=0&kind=c",
+			body:   `{"title": "Alien"}`,
+			expected: customerror.NewValidationError(testCode, []customerror.FieldError{
+				{Field: "id", Rule: "type", Param: "integer"},
+				{Field: "page", Rule: "minimum", Param: "1"},
+				{Field: "kind", Rule: "enum", Param: "a b"},
+			}),
+		},
+		{
+			name:   "Invalid body",
+			target: "/items/1",
+			body:   `{"title": "", "items": [{"name": "spaceship", "date": "May 1979"}, {"child": {"name": 1}}, {}]}`,
+			expected: customerror.NewValidationError(testCode, []customerror.FieldError{
+				{Field: "items", Rule: "maxItems", Param: "2"},
+				{Field: "items[0].date", Rule: "format", Param: "date"},
+				{Field: "items[0].name", Rule: "maxLength", Param: "5"},
+				{Field: "items[1].name", Rule: "required"},
+				{Field: "items[1].child.name", Rule: "type", Param: "string"},
+				{Field: "items[2].name", Rule: "required"},
+				{Field: "title", Rule: "minLength", Param: "1"},
+			}),
+		},
+		{
+			name:   "Empty body",
+			target: "/items/1",
+			expected: customerror.NewValidationError(testCode, []customerror.FieldError{
+				{Field: "title", Rule: "required"},
+			}),
+		},
+		{
+			name:   "Malformed body left to the handler",
+			target: "/items/1",
+			body:   `{"title": `,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			var bound string
+			e.PUT("/items/:id", func(c echo.Context) error {
+				body, err := io.ReadAll(c.Request().Body)
+				bound = string(body)
+				return err
+			})
+			e.GET("/items/:id", nil)
+			validator := newTestValidator(e)
+			var handled error
+			e.HTTPErrorHandler = func(err error, c echo.Context) {
+				handled = err
+			}
+			e.Use(validator.Requests())
+
+			request := httptest.NewRequest(http.MethodPut, tt.target, strings.NewReader(tt.body))
+			request.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			e.ServeHTTP(httptest.NewRecorder(), request)
+
+			if tt.expected == nil {
+				assert.NoError(t, handled)
+				assert.Equal(t, tt.body, bound, "the handler reads the body again")
+				return
+			}
+			var customError *customerror.CustomError
+			if assert.True(t, errors.As(handled, &customError)) {
+				assert.Equal(t, tt.expected.(*customerror.CustomError).Fields, customError.Fields)
+				assert.Equal(t, http.StatusUnprocessableEntity, customError.HttpCode)
+			}
+		})
+	}
+}
+
+func TestResponses(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      int
+		contentType string
+		body        string
+		expected    string
+	}{
+		{
+			name:        "Valid",
+			status:      http.StatusOK,
+			contentType: echo.MIMEApplicationJSONCharsetUTF8,
+			body:        `{"name": "ship", "date": "1979-05-25", "child": {"name": "pod", "child": null}}`,
+		},
+		{
+			name:        "Drifted body",
+			status:      http.StatusOK,
+			contentType: echo.MIMEApplicationJSON,
+			body:        `{"name": 1, "date": "1979-05-25T00:00:00Z"}`,
+			expected:    "GET /items/:id: body does not match the schema: date format=date, name type=string",
+		},
+		{
+			name:        "Undocumented status",
+			status:      http.StatusCreated,
+			contentType: echo.MIMEApplicationJSON,
+			body:        `{"name": "ship"}`,
+			expected:    "GET /items/:id: status 201 is not documented",
+		},
+		{
+			name:        "Undocumented content type",
+			status:      http.StatusOK,
+			contentType: echo.MIMETextPlain,
+			body:        "ship",
+			expected:    "GET /items/:id: content type text/plain is not documented",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			e.PUT("/items/:id", nil)
+			e.GET("/items/:id", func(c echo.Context) error {
+				return c.Blob(tt.status, tt.contentType, []byte(tt.body))
+			})
+			var reported []string
+			e.Use(newTestValidator(e).Responses(func(err error) {
+				reported = append(reported, err.Error())
+			}))
+
+			recorder := httptest.NewRecorder()
+			e.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/items/1", nil))
+
+			assert.Equal(t, tt.body, recorder.Body.String())
+			if tt.expected == "" {
+				assert.Empty(t, reported)
+			} else {
+				assert.Equal(t, []string{tt.expected}, reported)
+			}
+		})
+	}
+}
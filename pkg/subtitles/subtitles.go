@@ -0,0 +1,241 @@
+package subtitles
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	FormatSRT    = "srt"
+	FormatWebVTT = "vtt"
+
+	vttHeader = "WEBVTT"
+	arrow     = "-->"
+)
+
+// Cue is one subtitle, Text is WebVTT cue text whatever the source format
+type Cue struct {
+	ID       string
+	Start    time.Duration
+	End      time.Duration
+	Settings string
+	Text     string
+}
+
+// ParseError tells which line of the file is wrong, lines start at 1
+type ParseError struct {
+	Line   int
+	Reason string
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("line %d: %s", e.Line, e.Reason)
+}
+
+var (
+	// SRT allows a dot in place of the comma, many tools write one
+	srtTimestamp = regexp.MustCompile(`^(\d{2,}):([0-5]\d):([0-5]\d)[,.](\d{3})$`)
+	vttTimestamp = regexp.MustCompile(`^(?:(\d{2,}):)?([0-5]\d):([0-5]\d)\.(\d{3})$`)
+	srtEscaper   = strings.NewReplacer("&", "&amp;", arrow, "--&gt;")
+)
+
+// Detect returns the format of the file, empty when it is neither SRT nor WebVTT
+func Detect(data []byte) string {
+	text := normalize(data)
+	if text == vttHeader || strings.HasPrefix(text, vttHeader+" ") || strings.HasPrefix(text, vttHeader+"\t") ||
+		strings.HasPrefix(text, vttHeader+"\n") {
+		return FormatWebVTT
+	}
+	firstLine, _, _ := strings.Cut(strings.TrimLeft(text, "\n"), "\n")
+	if _, err := strconv.Atoi(strings.TrimSpace(firstLine)); err == nil {
+		return FormatSRT
+	}
+	return ""
+}
+
+// Parse reads the cues of a file in the given format. Cues must start in order and end after they start
+func Parse(data []byte, format string) ([]Cue, error) {
+	switch format {
+	case FormatSRT:
+		return parseSRT(normalize(data))
+	case FormatWebVTT:
+		return parseWebVTT(normalize(data))
+	default:
+		return nil, fmt.Errorf("unsupported subtitles format %q", format)
+	}
+}
+
+// WriteWebVTT serializes the cues into a WebVTT file
+func WriteWebVTT(cues []Cue) []byte {
+	var buf bytes.Buffer
+	buf.WriteString(vttHeader + "\n")
+	for _, cue := range cues {
+		buf.WriteString("\n")
+		if cue.ID != "" {
+			buf.WriteString(cue.ID + "\n")
+		}
+		buf.WriteString(formatTimestamp(cue.Start) + " " + arrow + " " + formatTimestamp(cue.End))
+		if cue.Settings != "" {
+			buf.WriteString(" " + cue.Settings)
+		}
+		buf.WriteString("\n" + cue.Text + "\n")
+	}
+	return buf.Bytes()
+}
+
+// normalize drops the byte order mark and the carriage returns
+func normalize(data []byte) string {
+	text := strings.TrimPrefix(string(data), "\uFEFF")
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	return strings.ReplaceAll(text, "\r", "\n")
+}
+
+// block is a group of lines separated by blank lines, start is the number of its first line
+type block struct {
+	start int
+	lines []string
+}
+
+func splitBlocks(text string) []block {
+	var blocks []block
+	var current *block
+	for i, line := range strings.Split(text, "\n") {
+		if strings.TrimSpace(line) == "" {
+			current = nil
+			continue
+		}
+		if current == nil {
+			blocks = append(blocks, block{start: i + 1})
+			current = &blocks[len(blocks)-1]
+		}
+		current.lines = append(current.lines, line)
+	}
+	return blocks
+}
+
+func parseSRT(text string) ([]Cue, error) {
+	var cues []Cue
+	for _, b := range splitBlocks(text) {
+		if _, err := strconv.Atoi(strings.TrimSpace(b.lines[0])); err != nil {
+			return nil, &ParseError{Line: b.start, Reason: "expected a cue number"}
+		}
+		if len(b.lines) < 2 {
+			return nil, &ParseError{Line: b.start, Reason: "missing cue timings"}
+		}
+		// SRT may put coordinates after the end time, WebVTT has no equivalent
+		start, end, _, err := parseTimings(b.lines[1], srtTimestamp)
+		if err != nil {
+			return nil, &ParseError{Line: b.start + 1, Reason: err.Error()}
+		}
+		cue := Cue{
+			Start: start,
+			End:   end,
+			Text:  srtEscaper.Replace(strings.Join(b.lines[2:], "\n")),
+		}
+		err = checkOrder(cues, cue)
+		if err != nil {
+			return nil, &ParseError{Line: b.start + 1, Reason: err.Error()}
+		}
+		cues = append(cues, cue)
+	}
+	return cues, nil
+}
+
+func parseWebVTT(text string) ([]Cue, error) {
+	blocks := splitBlocks(text)
+	if len(blocks) == 0 || !strings.HasPrefix(blocks[0].lines[0], vttHeader) {
+		return nil, &ParseError{Line: 1, Reason: "missing WEBVTT header"}
+	}
+	var cues []Cue
+	// the first block is the header with its metadata
+	for _, b := range blocks[1:] {
+		first := b.lines[0]
+		if first == "NOTE" || strings.HasPrefix(first, "NOTE ") || strings.HasPrefix(first, "NOTE\t") ||
+			first == "STYLE" || first == "REGION" {
+			continue
+		}
+		cue := Cue{}
+		timingLine := 0
+		if !strings.Contains(first, arrow) {
+			cue.ID = first
+			timingLine = 1
+		}
+		if timingLine >= len(b.lines) {
+			return nil, &ParseError{Line: b.start, Reason: "missing cue timings"}
+		}
+		var err error
+		cue.Start, cue.End, cue.Settings, err = parseTimings(b.lines[timingLine], vttTimestamp)
+		if err != nil {
+			return nil, &ParseError{Line: b.start + timingLine, Reason: err.Error()}
+		}
+		for _, line := range b.lines[timingLine+1:] {
+			if strings.Contains(line, arrow) {
+				return nil, &ParseError{Line: b.start + timingLine + 1, Reason: "cue text cannot contain " + arrow}
+			}
+		}
+		cue.Text = strings.Join(b.lines[timingLine+1:], "\n")
+		err = checkOrder(cues, cue)
+		if err != nil {
+			return nil, &ParseError{Line: b.start + timingLine, Reason: err.Error()}
+		}
+		cues = append(cues, cue)
+	}
+	return cues, nil
+}
+
+// parseTimings reads "start --> end" and returns what follows the end time
+func parseTimings(line string, timestamp *regexp.Regexp) (time.Duration, time.Duration, string, error) {
+	before, after, found := strings.Cut(line, arrow)
+	if !found {
+		return 0, 0, "", fmt.Errorf("expected cue timings, got %q", line)
+	}
+	fields := strings.Fields(after)
+	if len(fields) == 0 {
+		return 0, 0, "", fmt.Errorf("missing end time")
+	}
+	start, err := parseTimestamp(strings.TrimSpace(before), timestamp)
+	if err != nil {
+		return 0, 0, "", err
+	}
+	end, err := parseTimestamp(fields[0], timestamp)
+	if err != nil {
+		return 0, 0, "", err
+	}
+	if end <= start {
+		return 0, 0, "", fmt.Errorf("cue ends at %s before it starts at %s", formatTimestamp(end), formatTimestamp(start))
+	}
+	return start, end, strings.Join(fields[1:], " "), nil
+}
+
+func parseTimestamp(value string, timestamp *regexp.Regexp) (time.Duration, error) {
+	parts := timestamp.FindStringSubmatch(value)
+	if parts == nil {
+		return 0, fmt.Errorf("invalid timestamp %q", value)
+	}
+	hours := 0
+	if parts[1] != "" {
+		hours, _ = strconv.Atoi(parts[1])
+	}
+	minutes, _ := strconv.Atoi(parts[2])
+	seconds, _ := strconv.Atoi(parts[3])
+	millis, _ := strconv.Atoi(parts[4])
+	return time.Duration(hours)*time.Hour + time.Duration(minutes)*time.Minute +
+		time.Duration(seconds)*time.Second + time.Duration(millis)*time.Millisecond, nil
+}
+
+// checkOrder rejects a cue starting before the previous one, players expect them sorted
+func checkOrder(cues []Cue, cue Cue) error {
+	if len(cues) > 0 && cue.Start < cues[len(cues)-1].Start {
+		return fmt.Errorf("cue starts at %s before the previous one", formatTimestamp(cue.Start))
+	}
+	return nil
+}
+
+func formatTimestamp(d time.Duration) string {
+	millis := d.Milliseconds()
+	return fmt.Sprintf("%02d:%02d:%02d.%03d", millis/3600000, millis/60000%60, millis/1000%60, millis%1000)
+}
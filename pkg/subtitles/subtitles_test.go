@@ -0,0 +1,101 @@
+package subtitles
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	testCases := []struct {
+		name          string
+		data          string
+		format        string
+		expectedCues  []Cue
+		expectedError error
+	}{
+		{
+			name:   "SRT with a byte order mark and CRLF",
+			data:   "\uFEFF1\r\n00:00:01,000 --> 00:00:04,500\r\nHello <i>there</i>\r\n\r\n2\r\n00:01:02.250 --> 00:01:03,000 X1:40 X2:600\r\nTom & Jerry\r\n--> next\r\n",
+			format: FormatSRT,
+			expectedCues: []Cue{
+				{Start: time.Second, End: 4500 * time.Millisecond, Text: "Hello <i>there</i>"},
+				{Start: 62250 * time.Millisecond, End: 63 * time.Second, Text: "Tom &amp; Jerry\n--&gt; next"},
+			},
+		},
+		{
+			name: "WebVTT skips notes and styles and keeps identifiers and settings",
+			data: "WEBVTT - Film\nKind: captions\n\nNOTE made by hand\n\nSTYLE\n::cue { color: yellow }\n\n" +
+				"intro\n00:01.000 --> 00:02.000 align:start\nHi\n\n01:00:00.000 --> 01:00:01.000\n<v Bob>Bye",
+			format: FormatWebVTT,
+			expectedCues: []Cue{
+				{ID: "intro", Start: time.Second, End: 2 * time.Second, Settings: "align:start", Text: "Hi"},
+				{Start: time.Hour, End: time.Hour + time.Second, Text: "<v Bob>Bye"},
+			},
+		},
+		{
+			name:          "Cue ending before it starts",
+			data:          "1\n00:00:05,000 --> 00:00:04,000\nHello\n",
+			format:        FormatSRT,
+			expectedError: &ParseError{Line: 2, Reason: "cue ends at 00:00:04.000 before it starts at 00:00:05.000"},
+		},
+		{
+			name:          "Cues out of order",
+			data:          "WEBVTT\n\n00:05.000 --> 00:06.000\nB\n\n00:01.000 --> 00:02.000\nA\n",
+			format:        FormatWebVTT,
+			expectedError: &ParseError{Line: 6, Reason: "cue starts at 00:00:01.000 before the previous one"},
+		},
+		{
+			name:          "Invalid minutes",
+			data:          "1\n00:61:00,000 --> 01:02:00,000\nHello\n",
+			format:        FormatSRT,
+			expectedError: &ParseError{Line: 2, Reason: `invalid timestamp "00:61:00,000"`},
+		},
+		{
+			name:          "WebVTT timestamps need a dot",
+			data:          "WEBVTT\n\n00:00:01,000 --> 00:00:02,000\nHello\n",
+			format:        FormatWebVTT,
+			expectedError: &ParseError{Line: 3, Reason: `invalid timestamp "00:00:01,000"`},
+		},
+		{
+			name:          "SRT without timings",
+			data:          "1\nHello\n",
+			format:        FormatSRT,
+			expectedError: &ParseError{Line: 2, Reason: `expected cue timings, got "Hello"`},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cues, err := Parse([]byte(tc.data), tc.format)
+
+			if tc.expectedError != nil {
+				assert.Equal(t, tc.expectedError, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedCues, cues)
+		})
+	}
+}
+
+func TestDetect(t *testing.T) {
+	assert.Equal(t, FormatWebVTT, Detect([]byte("\uFEFFWEBVTT\n\n")))
+	assert.Equal(t, FormatWebVTT, Detect([]byte("WEBVTT - title\n")))
+	assert.Equal(t, FormatSRT, Detect([]byte("\n1\n00:00:01,000 --> 00:00:02,000\nHi\n")))
+	assert.Equal(t, "", Detect([]byte("WEBVTTX\n")))
+	assert.Equal(t, "", Detect([]byte("<tt xmlns=\"http://www.w3.org/ns/ttml\">")))
+}
+
+func TestWriteWebVTTRoundTrip(t *testing.T) {
+	cues, err := Parse([]byte("1\n00:00:01,000 --> 00:00:04,000\nFish & chips\nsecond line\n\n2\n01:02:03,004 --> 01:02:05,000\nBye\n"), FormatSRT)
+	assert.NoError(t, err)
+
+	vtt := WriteWebVTT(cues)
+
+	assert.Equal(t, "WEBVTT\n\n00:00:01.000 --> 00:00:04.000\nFish &amp; chips\nsecond line\n\n01:02:03.004 --> 01:02:05.000\nBye\n", string(vtt))
+	parsed, err := Parse(vtt, FormatWebVTT)
+	assert.NoError(t, err)
+	assert.Equal(t, cues, parsed)
+}
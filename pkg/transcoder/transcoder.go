@@ -0,0 +1,89 @@
+package transcoder
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+)
+
+const (
+	PlaylistName   = "index.m3u8"
+	segmentPattern = "segment_%03d.ts"
+	// only the end of the output is kept for the error, ffmpeg is verbose
+	maxErrorOutput = 2048
+)
+
+// Rendition is one quality of the stream, bitrates are in kbit/s
+type Rendition struct {
+	Name         string
+	Height       int
+	VideoBitrate int
+	AudioBitrate int
+}
+
+// Transcoder shells out to ffmpeg to produce HLS renditions
+type Transcoder struct {
+	binary         string
+	segmentSeconds int
+}
+
+func New(binary string, segmentSeconds int) *Transcoder {
+	if binary == "" {
+		panic(binary)
+	}
+	return &Transcoder{
+		binary:         binary,
+		segmentSeconds: segmentSeconds,
+	}
+}
+
+// HLS transcodes the source into dir/<rendition name>/index.m3u8 and its segments
+func (t *Transcoder) HLS(ctx context.Context, source string, dir string, rendition Rendition) error {
+	output := filepath.Join(dir, rendition.Name)
+	err := os.MkdirAll(output, 0755)
+	if err != nil {
+		return err
+	}
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, t.binary, t.hlsArgs(source, output, rendition)...)
+	cmd.Stderr = &stderr
+	err = cmd.Run()
+	if err != nil {
+		message := stderr.Bytes()
+		if len(message) > maxErrorOutput {
+			message = message[len(message)-maxErrorOutput:]
+		}
+		return fmt.Errorf("transcode %s: %w: %s", rendition.Name, err, message)
+	}
+	return nil
+}
+
+func (t *Transcoder) hlsArgs(source string, output string, rendition Rendition) []string {
+	return []string{
+		"-hide_banner", "-loglevel", "error", "-y",
+		"-i", source,
+		// -2 keeps the width even, as x264 requires
+		"-vf", "scale=-2:" + strconv.Itoa(rendition.Height),
+		"-c:v", "libx264", "-preset", "veryfast", "-profile:v", "main",
+		"-b:v", kbps(rendition.VideoBitrate),
+		"-maxrate", kbps(rendition.VideoBitrate * 107 / 100),
+		"-bufsize", kbps(rendition.VideoBitrate * 3 / 2),
+		// a keyframe at every segment boundary so every rendition switches at the same points
+		"-force_key_frames", "expr:gte(t,n_forced*" + strconv.Itoa(t.segmentSeconds) + ")",
+		// only the default audio track is kept, there are no alternate audio renditions
+		"-c:a", "aac", "-ac", "2", "-b:a", kbps(rendition.AudioBitrate),
+		"-f", "hls",
+		"-hls_time", strconv.Itoa(t.segmentSeconds),
+		"-hls_playlist_type", "vod",
+		"-hls_segment_filename", filepath.Join(output, segmentPattern),
+		filepath.Join(output, PlaylistName),
+	}
+}
+
+func kbps(bitrate int) string {
+	return strconv.Itoa(bitrate) + "k"
+}
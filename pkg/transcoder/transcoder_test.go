@@ -0,0 +1,63 @@
+package transcoder
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeFFmpeg writes a script standing in for ffmpeg: it records its arguments and writes a
+// playlist with two segments where the real one would
+const fakeFFmpeg = `#!/bin/sh
+for last; do :; done
+dir=$(dirname "$last")
+echo "$@" > "$dir/args"
+printf 'ts' > "$dir/segment_000.ts"
+printf 'ts' > "$dir/segment_001.ts"
+printf '#EXTM3U\n#EXTINF:6.0,\nsegment_000.ts\n#EXTINF:2.0,\nsegment_001.ts\n#EXT-X-ENDLIST\n' > "$last"
+`
+
+const failingFFmpeg = `#!/bin/sh
+echo "source.mp4: Invalid data found when processing input" >&2
+exit 1
+`
+
+func writeScript(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "ffmpeg")
+	err := os.WriteFile(path, []byte(content), 0755)
+	assert.NoError(t, err)
+	return path
+}
+
+func TestHLS(t *testing.T) {
+	dir := t.TempDir()
+	source := filepath.Join(dir, "source.mp4")
+	_ = os.WriteFile(source, []byte("not really a video"), 0644)
+	transcoder := New(writeScript(t, fakeFFmpeg), 6)
+
+	err := transcoder.HLS(context.Background(), source, dir, Rendition{Name: "720p", Height: 720, VideoBitrate: 3000, AudioBitrate: 128})
+
+	assert.NoError(t, err)
+	playlist, err := os.ReadFile(filepath.Join(dir, "720p", PlaylistName))
+	assert.NoError(t, err)
+	assert.Contains(t, string(playlist), "segment_001.ts")
+	args, _ := os.ReadFile(filepath.Join(dir, "720p", "args"))
+	assert.Contains(t, string(args), "-i "+source)
+	assert.Contains(t, string(args), "scale=-2:720")
+	assert.Contains(t, string(args), "-b:v 3000k")
+	assert.Contains(t, string(args), "-hls_time 6")
+}
+
+func TestHLSFailure(t *testing.T) {
+	transcoder := New(writeScript(t, failingFFmpeg), 6)
+
+	err := transcoder.HLS(context.Background(), "source.mp4", t.TempDir(), Rendition{Name: "360p", Height: 360})
+
+	assert.Error(t, err)
+	assert.True(t, strings.HasPrefix(err.Error(), "transcode 360p: exit status 1"))
+	assert.Contains(t, err.Error(), "Invalid data found")
+}
@@ -1,8 +1,10 @@
 package utils
 
 import (
+	"KTOnlinePlatform/internal/models/consts"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/labstack/echo/v4"
+	"math"
 	"strconv"
 	"time"
 )
@@ -21,3 +23,20 @@ func GetUserID(e echo.Context) (int, error) {
 	}
 	return sub, nil
 }
+
+// CalculateOffset is the offset of a page, the first page when the page is not positive
+func CalculateOffset(page int, pageSize int) int {
+	offset := (page - 1) * pageSize
+	if offset < 0 {
+		return consts.BasicPaginationDefaultOffset
+	}
+	return offset
+}
+
+// AverageRating rounds the average of the ratings to one decimal, 0 without ratings
+func AverageRating(sum int, count int) float64 {
+	if count == 0 {
+		return 0
+	}
+	return math.Round(float64(sum)/float64(count)*10) / 10
+}
@@ -0,0 +1,41 @@
+package utils
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestCalculateOffset(t *testing.T) {
+	tests := []struct {
+		name     string
+		page     int
+		pageSize int
+		expected int
+	}{
+		{name: "First page", page: 1, pageSize: 10, expected: 0},
+		{name: "Third page", page: 3, pageSize: 10, expected: 20},
+		{name: "Page zero is the first page", page: 0, pageSize: 10, expected: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, CalculateOffset(tt.page, tt.pageSize))
+		})
+	}
+}
+
+func TestAverageRating(t *testing.T) {
+	tests := []struct {
+		name     string
+		sum      int
+		count    int
+		expected float64
+	}{
+		{name: "No rating", sum: 0, count: 0, expected: 0},
+		{name: "Rounded to one decimal", sum: 10, count: 3, expected: 3.3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, AverageRating(tt.sum, tt.count))
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package validation
+
+import (
+	"KTOnlinePlatform/pkg/database/entities/entitiescustom"
+	"KTOnlinePlatform/pkg/utils"
+	"github.com/go-playground/validator/v10"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// TitleMaxLength is the longest title accepted by the title rule, in characters
+	TitleMaxLength = 255
+
+	ruleTitle         = "title"
+	ruleMaxYearsAhead = "maxyearsahead"
+)
+
+// nameTags are looked up in order to name a field the way the client sent it
+var nameTags = []string{"json", "query", "param", "form"}
+
+// New returns a validator naming the fields by their JSON, query or path names, with the rules
+// of the platform registered:
+//   - title: not blank and at most TitleMaxLength characters
+//   - maxyearsahead=n: a date at most n years from now, an unset date is valid
+func New() *validator.Validate {
+	validate := validator.New()
+	validate.RegisterTagNameFunc(fieldName)
+	validate.RegisterCustomTypeFunc(releaseDateValue, entitiescustom.ReleaseDate{})
+	_ = validate.RegisterValidation(ruleTitle, isTitle)
+	_ = validate.RegisterValidation(ruleMaxYearsAhead, isAtMostYearsAhead)
+	return validate
+}
+
+func fieldName(field reflect.StructField) string {
+	for _, tag := range nameTags {
+		name, _, _ := strings.Cut(field.Tag.Get(tag), ",")
+		if name == "-" {
+			return ""
+		}
+		if name != "" {
+			return name
+		}
+	}
+	return field.Name
+}
+
+func releaseDateValue(value reflect.Value) interface{} {
+	return value.Interface().(entitiescustom.ReleaseDate).Time
+}
+
+func isTitle(fl validator.FieldLevel) bool {
+	title := strings.TrimSpace(fl.Field().String())
+	return title != "" && utf8.RuneCountInString(title) <= TitleMaxLength
+}
+
+func isAtMostYearsAhead(fl validator.FieldLevel) bool {
+	date, ok := fl.Field().Interface().(time.Time)
+	if !ok {
+		return false
+	}
+	if date.IsZero() {
+		return true
+	}
+	years, err := strconv.Atoi(fl.Param())
+	if err != nil {
+		panic(fl.Param())
+	}
+	return !date.After(utils.TimeNowInUTC().AddDate(years, 0, 0))
+}
@@ -0,0 +1,90 @@
+package validation
+
+import (
+	"KTOnlinePlatform/pkg/customerror"
+	"KTOnlinePlatform/pkg/database/entities/entitiescustom"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+)
+
+type testCredit struct {
+	Role string `json:"role" validate:"oneof=ACTOR WRITER"`
+}
+
+type testFilm struct {
+	ID          int                         `param:"id" validate:"required"`
+	Title       string                      `json:"title" validate:"title"`
+	ReleaseDate entitiescustom.ReleaseDate  `json:"release_date" validate:"maxyearsahead=10"`
+	Rerelease   *entitiescustom.ReleaseDate `json:"rerelease_date" validate:"omitempty,maxyearsahead=10"`
+	Credits     []testCredit                `json:"credits" validate:"dive"`
+	UserID      int                         `json:"-"`
+}
+
+func releaseDate(years int) entitiescustom.ReleaseDate {
+	return entitiescustom.ReleaseDate{Time: time.Now().AddDate(years, 0, 0)}
+}
+
+func TestNew(t *testing.T) {
+	farFuture := releaseDate(11)
+
+	testCases := []struct {
+		name           string
+		film           testFilm
+		expectedFields []customerror.FieldError
+	}{
+		{
+			name: "Valid film",
+			film: testFilm{ID: 1, Title: "Heat", ReleaseDate: releaseDate(9), Credits: []testCredit{{Role: "ACTOR"}}},
+		},
+		{
+			name: "Unset release date",
+			film: testFilm{ID: 1, Title: "Heat"},
+		},
+		{
+			name: "Fields are named like the client sent them",
+			film: testFilm{Title: "Heat", Credits: []testCredit{{Role: "ACTOR"}, {Role: "GRIP"}}},
+			expectedFields: []customerror.FieldError{
+				{Field: "id", Rule: "required"},
+				{Field: "credits[1].role", Rule: "oneof", Param: "ACTOR WRITER"},
+			},
+		},
+		{
+			name:           "Blank title",
+			film:           testFilm{ID: 1, Title: "   "},
+			expectedFields: []customerror.FieldError{{Field: "title", Rule: "title"}},
+		},
+		{
+			name:           "Title too long",
+			film:           testFilm{ID: 1, Title: strings.Repeat("é", TitleMaxLength+1)},
+			expectedFields: []customerror.FieldError{{Field: "title", Rule: "title"}},
+		},
+		{
+			name: "Release dates in the far future",
+			film: testFilm{ID: 1, Title: "Heat", ReleaseDate: releaseDate(11), Rerelease: &farFuture},
+			expectedFields: []customerror.FieldError{
+				{Field: "release_date", Rule: "maxyearsahead", Param: "10"},
+				{Field: "rerelease_date", Rule: "maxyearsahead", Param: "10"},
+			},
+		},
+	}
+
+	validate := New()
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validate.Struct(tc.film)
+
+			if tc.expectedFields == nil {
+				assert.NoError(t, err)
+				return
+			}
+			var validationErrors validator.ValidationErrors
+			assert.True(t, errors.As(err, &validationErrors))
+			assert.Equal(t, tc.expectedFields, customerror.FieldErrors(validationErrors))
+		})
+	}
+}
@@ -1,7 +1,8 @@
 package webutils
 
 import (
-	"fmt"
+	"KTOnlinePlatform/internal/models/kterrors"
+	"KTOnlinePlatform/pkg/customerror"
 	"github.com/labstack/echo/v4"
 	"strconv"
 )
@@ -14,7 +15,7 @@ func CheckParamToInt(context echo.Context, paramName string) (int, error) {
 
 	paramInt, err := strconv.Atoi(paramValue)
 	if err != nil {
-		return -1, err
+		return -1, invalidPathParamError(paramName)
 	}
 	return paramInt, nil
 }
@@ -22,7 +23,11 @@ func CheckParamToInt(context echo.Context, paramName string) (int, error) {
 func CheckParam(context echo.Context, paramName string) (string, error) {
 	paramValue := context.Param(paramName)
 	if paramValue == "" {
-		return "", fmt.Errorf("missing url param: %s", paramName)
+		return "", invalidPathParamError(paramName)
 	}
 	return paramValue, nil
 }
+
+func invalidPathParamError(paramName string) error {
+	return customerror.NewI18nErrorWithParams(kterrors.InvalidPathParamError, map[string]interface{}{"param": paramName})
+}
@@ -0,0 +1,54 @@
+package webutils
+
+import (
+	"KTOnlinePlatform/internal/models/consts"
+	"KTOnlinePlatform/internal/models/kterrors"
+	"KTOnlinePlatform/pkg/customerror"
+	"github.com/labstack/echo/v4"
+	"strconv"
+	"strings"
+)
+
+const (
+	HeaderETag    = "ETag"
+	HeaderIfMatch = "If-Match"
+
+	weakETagPrefix = "W/"
+	anyETag        = "*"
+)
+
+// FormatETag builds a strong entity tag from a record version
+func FormatETag(version int) string {
+	return strconv.Quote(strconv.Itoa(version))
+}
+
+// ParseETag extracts the record version from an entity tag, weak tags are accepted
+func ParseETag(etag string) (int, error) {
+	value := strings.TrimPrefix(strings.TrimSpace(etag), weakETagPrefix)
+	unquoted, err := strconv.Unquote(value)
+	if err != nil {
+		return -1, invalidETagError(etag)
+	}
+	version, err := strconv.Atoi(unquoted)
+	if err != nil {
+		return -1, invalidETagError(etag)
+	}
+	return version, nil
+}
+
+// IfMatchVersion returns the version sent in the If-Match header, 0 when the header is absent and
+// consts.AnyVersion for "*" which matches any version
+func IfMatchVersion(context echo.Context) (int, error) {
+	ifMatch := strings.TrimSpace(context.Request().Header.Get(HeaderIfMatch))
+	if ifMatch == "" {
+		return 0, nil
+	}
+	if ifMatch == anyETag {
+		return consts.AnyVersion, nil
+	}
+	return ParseETag(ifMatch)
+}
+
+func invalidETagError(etag string) error {
+	return customerror.NewI18nErrorWithParams(kterrors.InvalidETagError, map[string]interface{}{"etag": etag})
+}
@@ -0,0 +1,98 @@
+package webutils
+
+import (
+	"KTOnlinePlatform/internal/models/consts"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestFormatETag(t *testing.T) {
+	if got := FormatETag(7); got != `"7"` {
+		t.Errorf("FormatETag() = %v, want %v", got, `"7"`)
+	}
+}
+
+func TestParseETag(t *testing.T) {
+	tests := []struct {
+		name    string
+		etag    string
+		want    int
+		wantErr bool
+	}{
+		{
+			name: "strong etag",
+			etag: `"3"`,
+			want: 3,
+		},
+		{
+			name: "weak etag",
+			etag: `W/"12"`,
+			want: 12,
+		},
+		{
+			name:    "unquoted etag",
+			etag:    "3",
+			want:    -1,
+			wantErr: true,
+		},
+		{
+			name:    "non numeric etag",
+			etag:    `"abc"`,
+			want:    -1,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseETag(tt.etag)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseETag() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("ParseETag() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIfMatchVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		ifMatch string
+		want    int
+	}{
+		{
+			name: "no header",
+			want: 0,
+		},
+		{
+			name:    "any version",
+			ifMatch: "*",
+			want:    consts.AnyVersion,
+		},
+		{
+			name:    "version",
+			ifMatch: `"4"`,
+			want:    4,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodPut, "/", nil)
+			if tt.ifMatch != "" {
+				request.Header.Set(HeaderIfMatch, tt.ifMatch)
+			}
+			got, err := IfMatchVersion(echo.New().NewContext(request, httptest.NewRecorder()))
+			if err != nil {
+				t.Errorf("IfMatchVersion() error = %v", err)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("IfMatchVersion() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
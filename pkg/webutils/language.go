@@ -0,0 +1,16 @@
+package webutils
+
+import (
+	"KTOnlinePlatform/pkg/i18n"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	HeaderAcceptLanguage  = "Accept-Language"
+	HeaderContentLanguage = "Content-Language"
+)
+
+// AcceptedLanguages returns the languages of the Accept-Language header by preference, with their parents
+func AcceptedLanguages(context echo.Context) []string {
+	return i18n.ParseAcceptLanguage(context.Request().Header.Get(HeaderAcceptLanguage))
+}
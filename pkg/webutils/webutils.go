@@ -1,29 +1,44 @@
 package webutils
 
 import (
+	"KTOnlinePlatform/internal/models/kterrors"
 	"KTOnlinePlatform/pkg/configuration"
 	"KTOnlinePlatform/pkg/customerror"
 	"KTOnlinePlatform/pkg/logger"
+	"KTOnlinePlatform/pkg/validation"
 	"context"
+	"errors"
 	"github.com/go-playground/validator/v10"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
+	"time"
 )
 
+// shutdownTimeout is how long the requests in flight have to finish once the server stops
+const shutdownTimeout = 30 * time.Second
+
 type customValidator struct {
 	validator *validator.Validate
 }
 
+// Validate reports every field breaking a rule at once
 func (cv *customValidator) Validate(i interface{}) error {
-	return cv.validator.Struct(i)
+	err := cv.validator.Struct(i)
+	var validationErrors validator.ValidationErrors
+	if errors.As(err, &validationErrors) {
+		return customerror.NewValidationError(kterrors.ValidationError, customerror.FieldErrors(validationErrors))
+	}
+	return err
 }
 
-func NewEcho(config configuration.ConfigEcho) *echo.Echo {
+func NewEcho(config configuration.ConfigEcho, translator customerror.Translator, statuses map[string]int) *echo.Echo {
 	logger.Info().Msg("Initializing echo")
 	e := echo.New()
 	logger.Debug().Msg("Setting up echo validator")
-	e.Validator = &customValidator{validator: validator.New()}
+	e.Validator = &customValidator{validator: validation.New()}
 
+	// the ID is sent back in the X-Request-Id header and in the problems, to find the logs of a request
+	e.Use(middleware.RequestID())
 	e.Use(middleware.CORSWithConfig(middleware.CORSConfig{
 		AllowOrigins:     []string{config.AllowedOrigins},
 		AllowCredentials: config.AllowCredentials,
@@ -31,16 +46,31 @@ func NewEcho(config configuration.ConfigEcho) *echo.Echo {
 
 	e.HideBanner = true
 
-	e.HTTPErrorHandler = customerror.ErrorHandler
+	e.HTTPErrorHandler = customerror.NewErrorHandler(translator, statuses)
 	logger.Info().Msg("Finished echo initialization")
 	return e
 }
 
-func StartEcho(echo *echo.Echo, address string) {
-	err := echo.Start(address)
+// StartEcho serves until ctx is done, then lets the requests in flight finish for shutdownTimeout
+// before returning
+func StartEcho(ctx context.Context, echo *echo.Echo, address string) {
+	started := make(chan error, 1)
+	go func() {
+		started <- echo.Start(address)
+	}()
 
-	if err != nil {
+	select {
+	case err := <-started:
 		_ = echo.Shutdown(context.Background())
+		logger.Fatal().Msgf("Cannot start Echo: %v", err)
+	case <-ctx.Done():
+	}
+
+	logger.Info().Msg("Shutting down echo")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	err := echo.Shutdown(shutdownCtx)
+	if err != nil {
+		logger.Error().Err(err).Msg("echo shutdown failed")
 	}
-	logger.Fatal().Msgf("Cannot start Echo: %v", err)
 }
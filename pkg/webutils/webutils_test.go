@@ -1,8 +1,13 @@
 package webutils
 
 import (
+	"KTOnlinePlatform/pkg/logger"
+	"context"
 	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+	"net/http"
 	"testing"
+	"time"
 )
 
 func Test_customValidator_Validate(t *testing.T) {
@@ -62,3 +67,47 @@ func Test_customValidator_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestStartEchoDrainsRequests(t *testing.T) {
+	logger.InitializeForTest()
+	e := echo.New()
+	e.HideBanner = true
+	e.HidePort = true
+	received := make(chan struct{})
+	e.GET("/slow", func(c echo.Context) error {
+		close(received)
+		time.Sleep(100 * time.Millisecond)
+		return c.NoContent(http.StatusNoContent)
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan struct{})
+	go func() {
+		StartEcho(ctx, e, "127.0.0.1:0")
+		close(stopped)
+	}()
+	for e.ListenerAddr() == nil {
+		time.Sleep(time.Millisecond)
+	}
+
+	status := make(chan int, 1)
+	go func() {
+		response, err := http.Get("http://" + e.ListenerAddr().String() + "/slow")
+		if err != nil {
+			status <- 0
+			return
+		}
+		response.Body.Close()
+		status <- response.StatusCode
+	}()
+	<-received
+	cancel()
+
+	if got := <-status; got != http.StatusNoContent {
+		t.Errorf("request in flight got %v, want %v", got, http.StatusNoContent)
+	}
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Error("StartEcho did not return after the shutdown")
+	}
+}
@@ -0,0 +1,64 @@
+package wordfilter
+
+import (
+	"KTOnlinePlatform/internal/models/kterrors"
+	"KTOnlinePlatform/pkg/customerror"
+	"strings"
+	"unicode"
+)
+
+// Filter finds banned words in free text. Words match whole and case-insensitively,
+// so banning "ass" does not reject "class"
+type Filter struct {
+	words map[string]struct{}
+}
+
+func NewFilter(words []string) *Filter {
+	f := &Filter{
+		words: make(map[string]struct{}, len(words)),
+	}
+	for _, word := range words {
+		word = strings.ToLower(strings.TrimSpace(word))
+		if word != "" {
+			f.words[word] = struct{}{}
+		}
+	}
+	return f
+}
+
+// Find returns the banned words present in the texts, each once, in order of appearance
+func (f *Filter) Find(texts ...string) []string {
+	if len(f.words) == 0 {
+		return nil
+	}
+	var found []string
+	seen := make(map[string]struct{})
+	for _, text := range texts {
+		for _, word := range strings.FieldsFunc(strings.ToLower(text), isSeparator) {
+			if _, banned := f.words[word]; !banned {
+				continue
+			}
+			if _, ok := seen[word]; ok {
+				continue
+			}
+			seen[word] = struct{}{}
+			found = append(found, word)
+		}
+	}
+	return found
+}
+
+// Check rejects the texts containing any banned word, the error lists them
+func (f *Filter) Check(texts ...string) error {
+	words := f.Find(texts...)
+	if len(words) > 0 {
+		return customerror.NewI18nErrorWithParams(
+			kterrors.ContentContainsBannedWordsError,
+			map[string]interface{}{"words": words})
+	}
+	return nil
+}
+
+func isSeparator(r rune) bool {
+	return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+}
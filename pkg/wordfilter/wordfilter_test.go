@@ -0,0 +1,51 @@
+package wordfilter
+
+import (
+	"KTOnlinePlatform/internal/models/kterrors"
+	"KTOnlinePlatform/pkg/customerror"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFind(t *testing.T) {
+	filter := NewFilter([]string{"Spam", " scam ", ""})
+
+	testCases := []struct {
+		name     string
+		texts    []string
+		expected []string
+	}{
+		{
+			name:  "Clean text",
+			texts: []string{"A classic heist film"},
+		},
+		{
+			name:     "Case insensitive whole words",
+			texts:    []string{"Total SCAM, pure spam.", "more spam"},
+			expected: []string{"scam", "spam"},
+		},
+		{
+			name:  "Banned word inside another word",
+			texts: []string{"spammer scammed"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, filter.Find(tc.texts...))
+		})
+	}
+}
+
+func TestFindWithoutWords(t *testing.T) {
+	assert.Nil(t, NewFilter(nil).Find("anything"))
+}
+
+func TestCheck(t *testing.T) {
+	filter := NewFilter([]string{"spam"})
+
+	assert.NoError(t, filter.Check("A classic heist film"))
+	assert.Equal(t, customerror.NewI18nErrorWithParams(kterrors.ContentContainsBannedWordsError,
+		map[string]interface{}{"words": []string{"spam"}}), filter.Check("pure spam"))
+}
@@ -2,6 +2,8 @@ CREATE TABLE users (
                        id SERIAL PRIMARY KEY,
                        username VARCHAR(50) UNIQUE NOT NULL,
                        password VARCHAR(255) NOT NULL,
+                       role VARCHAR(20) NOT NULL DEFAULT 'USER',
+                       suspended_at timestamptz,
                        created_at          timestamptz  NOT NULL DEFAULT now(),
                        updated_at          timestamptz  NOT NULL DEFAULT now()
 );
@@ -14,7 +16,256 @@ CREATE TABLE films (
                        genre VARCHAR(50),
                        synopsis TEXT,
                        user_id INT NOT NULL,
+                       version INT NOT NULL DEFAULT 1,
+                       rating_sum INT NOT NULL DEFAULT 0,
+                       rating_count INT NOT NULL DEFAULT 0,
+                       hidden BOOLEAN NOT NULL DEFAULT FALSE,
+                       poster_key VARCHAR(255) NOT NULL DEFAULT '',
                        created_at          timestamptz  NOT NULL DEFAULT now(),
                        updated_at          timestamptz  NOT NULL DEFAULT now(),
                        FOREIGN KEY (user_id) REFERENCES users(id)
-);
\ No newline at end of file
+);
+
+CREATE TABLE jobs (
+                       id SERIAL PRIMARY KEY,
+                       type VARCHAR(50) NOT NULL,
+                       status VARCHAR(20) NOT NULL,
+                       user_id INT NOT NULL,
+                       total INT NOT NULL DEFAULT 0,
+                       processed INT NOT NULL DEFAULT 0,
+                       succeeded INT NOT NULL DEFAULT 0,
+                       skipped INT NOT NULL DEFAULT 0,
+                       failed INT NOT NULL DEFAULT 0,
+                       row_errors JSONB NOT NULL DEFAULT '[]',
+                       error TEXT NOT NULL DEFAULT '',
+                       created_at          timestamptz  NOT NULL DEFAULT now(),
+                       updated_at          timestamptz  NOT NULL DEFAULT now(),
+                       FOREIGN KEY (user_id) REFERENCES users(id)
+);
+
+CREATE TABLE people (
+                       id SERIAL PRIMARY KEY,
+                       name VARCHAR(255) NOT NULL,
+                       biography TEXT,
+                       user_id INT,
+                       created_at          timestamptz  NOT NULL DEFAULT now(),
+                       updated_at          timestamptz  NOT NULL DEFAULT now(),
+                       FOREIGN KEY (user_id) REFERENCES users(id)
+);
+
+CREATE TABLE film_credits (
+                       id SERIAL PRIMARY KEY,
+                       film_id INT NOT NULL,
+                       person_id INT NOT NULL,
+                       role VARCHAR(20) NOT NULL,
+                       character_name VARCHAR(255) NOT NULL DEFAULT '',
+                       position INT NOT NULL DEFAULT 0,
+                       created_at          timestamptz  NOT NULL DEFAULT now(),
+                       updated_at          timestamptz  NOT NULL DEFAULT now(),
+                       UNIQUE (film_id, person_id, role, character_name),
+                       FOREIGN KEY (film_id) REFERENCES films(id) ON DELETE CASCADE,
+                       FOREIGN KEY (person_id) REFERENCES people(id) ON DELETE CASCADE
+);
+
+CREATE INDEX film_credits_person_id_idx ON film_credits (person_id);
+
+CREATE TABLE film_ratings (
+                       film_id INT NOT NULL,
+                       user_id INT NOT NULL,
+                       score SMALLINT NOT NULL CHECK (score BETWEEN 1 AND 10),
+                       created_at          timestamptz  NOT NULL DEFAULT now(),
+                       updated_at          timestamptz  NOT NULL DEFAULT now(),
+                       PRIMARY KEY (film_id, user_id),
+                       FOREIGN KEY (film_id) REFERENCES films(id) ON DELETE CASCADE,
+                       FOREIGN KEY (user_id) REFERENCES users(id)
+);
+
+CREATE TABLE reviews (
+                       id SERIAL PRIMARY KEY,
+                       film_id INT NOT NULL,
+                       user_id INT NOT NULL,
+                       title VARCHAR(255) NOT NULL,
+                       body TEXT NOT NULL,
+                       spoiler BOOLEAN NOT NULL DEFAULT FALSE,
+                       like_count INT NOT NULL DEFAULT 0,
+                       hidden BOOLEAN NOT NULL DEFAULT FALSE,
+                       created_at          timestamptz  NOT NULL DEFAULT now(),
+                       updated_at          timestamptz  NOT NULL DEFAULT now(),
+                       UNIQUE (film_id, user_id),
+                       FOREIGN KEY (film_id) REFERENCES films(id) ON DELETE CASCADE,
+                       FOREIGN KEY (user_id) REFERENCES users(id)
+);
+
+CREATE TABLE review_likes (
+                       review_id INT NOT NULL,
+                       user_id INT NOT NULL,
+                       created_at          timestamptz  NOT NULL DEFAULT now(),
+                       PRIMARY KEY (review_id, user_id),
+                       FOREIGN KEY (review_id) REFERENCES reviews(id) ON DELETE CASCADE,
+                       FOREIGN KEY (user_id) REFERENCES users(id)
+);
+
+-- root_id is the top level comment of the thread, NULL on the top level comment itself
+CREATE TABLE review_comments (
+                       id SERIAL PRIMARY KEY,
+                       review_id INT NOT NULL,
+                       parent_id INT,
+                       root_id INT,
+                       user_id INT NOT NULL,
+                       body TEXT NOT NULL,
+                       created_at          timestamptz  NOT NULL DEFAULT now(),
+                       updated_at          timestamptz  NOT NULL DEFAULT now(),
+                       FOREIGN KEY (review_id) REFERENCES reviews(id) ON DELETE CASCADE,
+                       FOREIGN KEY (parent_id) REFERENCES review_comments(id) ON DELETE CASCADE,
+                       FOREIGN KEY (root_id) REFERENCES review_comments(id) ON DELETE CASCADE,
+                       FOREIGN KEY (user_id) REFERENCES users(id)
+);
+
+CREATE INDEX review_comments_review_id_idx ON review_comments (review_id, created_at) WHERE parent_id IS NULL;
+CREATE INDEX review_comments_root_id_idx ON review_comments (root_id);
+
+CREATE TABLE reports (
+                       id SERIAL PRIMARY KEY,
+                       reporter_id INT NOT NULL,
+                       target_type VARCHAR(20) NOT NULL,
+                       target_id INT NOT NULL,
+                       reason TEXT NOT NULL,
+                       status VARCHAR(20) NOT NULL DEFAULT 'OPEN',
+                       moderator_id INT,
+                       resolution VARCHAR(20),
+                       created_at          timestamptz  NOT NULL DEFAULT now(),
+                       updated_at          timestamptz  NOT NULL DEFAULT now(),
+                       FOREIGN KEY (reporter_id) REFERENCES users(id),
+                       FOREIGN KEY (moderator_id) REFERENCES users(id)
+);
+
+-- a user can only have one unresolved report per target
+CREATE UNIQUE INDEX reports_open_target_idx ON reports (reporter_id, target_type, target_id) WHERE status <> 'RESOLVED';
+CREATE INDEX reports_status_idx ON reports (status, created_at);
+
+CREATE TABLE moderation_actions (
+                       id SERIAL PRIMARY KEY,
+                       moderator_id INT NOT NULL,
+                       report_id INT,
+                       action VARCHAR(20) NOT NULL,
+                       target_type VARCHAR(20) NOT NULL,
+                       target_id INT NOT NULL,
+                       note TEXT,
+                       created_at          timestamptz  NOT NULL DEFAULT now(),
+                       FOREIGN KEY (moderator_id) REFERENCES users(id),
+                       FOREIGN KEY (report_id) REFERENCES reports(id)
+);
+
+CREATE INDEX moderation_actions_moderator_id_idx ON moderation_actions (moderator_id, created_at);
+
+CREATE TABLE user_lists (
+                       id SERIAL PRIMARY KEY,
+                       user_id INT NOT NULL,
+                       name VARCHAR(100) NOT NULL,
+                       description TEXT,
+                       public BOOLEAN NOT NULL DEFAULT FALSE,
+                       share_slug VARCHAR(32) UNIQUE NOT NULL,
+                       created_at          timestamptz  NOT NULL DEFAULT now(),
+                       updated_at          timestamptz  NOT NULL DEFAULT now(),
+                       UNIQUE (user_id, name),
+                       FOREIGN KEY (user_id) REFERENCES users(id)
+);
+
+-- deleting a film removes it from every list through the cascade
+CREATE TABLE user_list_entries (
+                       list_id INT NOT NULL,
+                       film_id INT NOT NULL,
+                       position INT NOT NULL,
+                       created_at          timestamptz  NOT NULL DEFAULT now(),
+                       PRIMARY KEY (list_id, film_id),
+                       FOREIGN KEY (list_id) REFERENCES user_lists(id) ON DELETE CASCADE,
+                       FOREIGN KEY (film_id) REFERENCES films(id) ON DELETE CASCADE
+);
+
+CREATE INDEX user_list_entries_film_id_idx ON user_list_entries (film_id);
+
+CREATE TABLE viewing_progress (
+                       user_id INT NOT NULL,
+                       film_id INT NOT NULL,
+                       position_seconds INT NOT NULL,
+                       duration_seconds INT NOT NULL,
+                       completed BOOLEAN NOT NULL DEFAULT FALSE,
+                       updated_at          timestamptz  NOT NULL DEFAULT now(),
+                       PRIMARY KEY (user_id, film_id),
+                       FOREIGN KEY (user_id) REFERENCES users(id),
+                       FOREIGN KEY (film_id) REFERENCES films(id) ON DELETE CASCADE
+);
+
+CREATE INDEX viewing_progress_user_id_idx ON viewing_progress (user_id, updated_at DESC);
+
+-- rebuilt from scratch by the recommendations service, score is the cosine of the co-occurrence
+CREATE TABLE film_similarities (
+                       film_id INT NOT NULL,
+                       similar_film_id INT NOT NULL,
+                       score DOUBLE PRECISION NOT NULL,
+                       PRIMARY KEY (film_id, similar_film_id),
+                       FOREIGN KEY (film_id) REFERENCES films(id) ON DELETE CASCADE,
+                       FOREIGN KEY (similar_film_id) REFERENCES films(id) ON DELETE CASCADE
+);
+
+CREATE INDEX film_ratings_user_id_idx ON film_ratings (user_id);
+
+-- rebuilt from scratch by the trending service, one ranking per time window
+CREATE TABLE film_trending (
+                       time_window VARCHAR(10) NOT NULL,
+                       film_id INT NOT NULL,
+                       score DOUBLE PRECISION NOT NULL,
+                       PRIMARY KEY (time_window, film_id),
+                       FOREIGN KEY (film_id) REFERENCES films(id) ON DELETE CASCADE
+);
+
+CREATE INDEX film_trending_score_idx ON film_trending (time_window, score DESC);
+CREATE INDEX films_release_date_idx ON films (release_date DESC);
+
+-- version is the latest upload, ready_version the one streamed, renditions belong to ready_version
+CREATE TABLE video_assets (
+                       id SERIAL PRIMARY KEY,
+                       film_id INT UNIQUE NOT NULL,
+                       status VARCHAR(20) NOT NULL,
+                       version VARCHAR(32) NOT NULL,
+                       ready_version VARCHAR(32) NOT NULL DEFAULT '',
+                       renditions JSONB NOT NULL DEFAULT '[]',
+                       error TEXT NOT NULL DEFAULT '',
+                       created_at          timestamptz  NOT NULL DEFAULT now(),
+                       updated_at          timestamptz  NOT NULL DEFAULT now(),
+                       FOREIGN KEY (film_id) REFERENCES films(id) ON DELETE CASCADE
+);
+
+-- no foreign key, the blobs of a version are removed even when its film was deleted
+CREATE TABLE video_retired_versions (
+                       id SERIAL PRIMARY KEY,
+                       film_id INT NOT NULL,
+                       version VARCHAR(32) NOT NULL,
+                       renditions JSONB NOT NULL DEFAULT '[]',
+                       retired_at          timestamptz  NOT NULL DEFAULT now()
+);
+
+-- language is a canonical BCP 47 tag
+CREATE TABLE film_subtitles (
+                       id SERIAL PRIMARY KEY,
+                       film_id INT NOT NULL,
+                       language VARCHAR(35) NOT NULL,
+                       label VARCHAR(100) NOT NULL DEFAULT '',
+                       blob_key VARCHAR(255) NOT NULL,
+                       created_at          timestamptz  NOT NULL DEFAULT now(),
+                       updated_at          timestamptz  NOT NULL DEFAULT now(),
+                       UNIQUE (film_id, language),
+                       FOREIGN KEY (film_id) REFERENCES films(id) ON DELETE CASCADE
+);
+
+-- locale is a canonical BCP 47 tag, films without a translation show their original text
+CREATE TABLE film_translations (
+                       film_id INT NOT NULL,
+                       locale VARCHAR(35) NOT NULL,
+                       title VARCHAR(255) NOT NULL,
+                       synopsis TEXT NOT NULL DEFAULT '',
+                       created_at          timestamptz  NOT NULL DEFAULT now(),
+                       updated_at          timestamptz  NOT NULL DEFAULT now(),
+                       PRIMARY KEY (film_id, locale),
+                       FOREIGN KEY (film_id) REFERENCES films(id) ON DELETE CASCADE
+);
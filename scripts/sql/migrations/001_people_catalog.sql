@@ -0,0 +1,45 @@
+-- Structured people catalog for databases created before people and film_credits existed.
+-- Every distinct films.director becomes a person credited as DIRECTOR on its films.
+-- user_id stays NULL for backfilled people: nobody owns them.
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS people (
+                       id SERIAL PRIMARY KEY,
+                       name VARCHAR(255) NOT NULL,
+                       biography TEXT,
+                       user_id INT,
+                       created_at          timestamptz  NOT NULL DEFAULT now(),
+                       updated_at          timestamptz  NOT NULL DEFAULT now(),
+                       FOREIGN KEY (user_id) REFERENCES users(id)
+);
+
+CREATE TABLE IF NOT EXISTS film_credits (
+                       id SERIAL PRIMARY KEY,
+                       film_id INT NOT NULL,
+                       person_id INT NOT NULL,
+                       role VARCHAR(20) NOT NULL,
+                       character_name VARCHAR(255) NOT NULL DEFAULT '',
+                       position INT NOT NULL DEFAULT 0,
+                       created_at          timestamptz  NOT NULL DEFAULT now(),
+                       updated_at          timestamptz  NOT NULL DEFAULT now(),
+                       UNIQUE (film_id, person_id, role, character_name),
+                       FOREIGN KEY (film_id) REFERENCES films(id) ON DELETE CASCADE,
+                       FOREIGN KEY (person_id) REFERENCES people(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS film_credits_person_id_idx ON film_credits (person_id);
+
+INSERT INTO people (name)
+SELECT DISTINCT btrim(f.director)
+FROM films f
+WHERE btrim(coalesce(f.director, '')) <> ''
+  AND NOT EXISTS (SELECT 1 FROM people p WHERE p.name = btrim(f.director));
+
+INSERT INTO film_credits (film_id, person_id, role)
+SELECT f.id, min(p.id), 'DIRECTOR'
+FROM films f
+         JOIN people p ON p.name = btrim(f.director)
+GROUP BY f.id
+ON CONFLICT DO NOTHING;
+
+COMMIT;
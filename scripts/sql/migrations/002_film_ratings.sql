@@ -0,0 +1,18 @@
+-- User ratings, with the aggregates kept on films so reading them never scans film_ratings.
+BEGIN;
+
+ALTER TABLE films ADD COLUMN IF NOT EXISTS rating_sum INT NOT NULL DEFAULT 0;
+ALTER TABLE films ADD COLUMN IF NOT EXISTS rating_count INT NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS film_ratings (
+                       film_id INT NOT NULL,
+                       user_id INT NOT NULL,
+                       score SMALLINT NOT NULL CHECK (score BETWEEN 1 AND 10),
+                       created_at          timestamptz  NOT NULL DEFAULT now(),
+                       updated_at          timestamptz  NOT NULL DEFAULT now(),
+                       PRIMARY KEY (film_id, user_id),
+                       FOREIGN KEY (film_id) REFERENCES films(id) ON DELETE CASCADE,
+                       FOREIGN KEY (user_id) REFERENCES users(id)
+);
+
+COMMIT;
@@ -0,0 +1,47 @@
+-- Written reviews with likes and threaded comments.
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS reviews (
+                       id SERIAL PRIMARY KEY,
+                       film_id INT NOT NULL,
+                       user_id INT NOT NULL,
+                       title VARCHAR(255) NOT NULL,
+                       body TEXT NOT NULL,
+                       spoiler BOOLEAN NOT NULL DEFAULT FALSE,
+                       like_count INT NOT NULL DEFAULT 0,
+                       created_at          timestamptz  NOT NULL DEFAULT now(),
+                       updated_at          timestamptz  NOT NULL DEFAULT now(),
+                       UNIQUE (film_id, user_id),
+                       FOREIGN KEY (film_id) REFERENCES films(id) ON DELETE CASCADE,
+                       FOREIGN KEY (user_id) REFERENCES users(id)
+);
+
+CREATE TABLE IF NOT EXISTS review_likes (
+                       review_id INT NOT NULL,
+                       user_id INT NOT NULL,
+                       created_at          timestamptz  NOT NULL DEFAULT now(),
+                       PRIMARY KEY (review_id, user_id),
+                       FOREIGN KEY (review_id) REFERENCES reviews(id) ON DELETE CASCADE,
+                       FOREIGN KEY (user_id) REFERENCES users(id)
+);
+
+-- root_id is the top level comment of the thread, NULL on the top level comment itself
+CREATE TABLE IF NOT EXISTS review_comments (
+                       id SERIAL PRIMARY KEY,
+                       review_id INT NOT NULL,
+                       parent_id INT,
+                       root_id INT,
+                       user_id INT NOT NULL,
+                       body TEXT NOT NULL,
+                       created_at          timestamptz  NOT NULL DEFAULT now(),
+                       updated_at          timestamptz  NOT NULL DEFAULT now(),
+                       FOREIGN KEY (review_id) REFERENCES reviews(id) ON DELETE CASCADE,
+                       FOREIGN KEY (parent_id) REFERENCES review_comments(id) ON DELETE CASCADE,
+                       FOREIGN KEY (root_id) REFERENCES review_comments(id) ON DELETE CASCADE,
+                       FOREIGN KEY (user_id) REFERENCES users(id)
+);
+
+CREATE INDEX IF NOT EXISTS review_comments_review_id_idx ON review_comments (review_id, created_at) WHERE parent_id IS NULL;
+CREATE INDEX IF NOT EXISTS review_comments_root_id_idx ON review_comments (root_id);
+
+COMMIT;
@@ -0,0 +1,44 @@
+-- Reports, the moderation queue and its audit trail.
+-- Moderators are granted by hand: UPDATE users SET role = 'MODERATOR' WHERE username = '...';
+BEGIN;
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS role VARCHAR(20) NOT NULL DEFAULT 'USER';
+ALTER TABLE users ADD COLUMN IF NOT EXISTS suspended_at timestamptz;
+ALTER TABLE films ADD COLUMN IF NOT EXISTS hidden BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE reviews ADD COLUMN IF NOT EXISTS hidden BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE TABLE IF NOT EXISTS reports (
+                       id SERIAL PRIMARY KEY,
+                       reporter_id INT NOT NULL,
+                       target_type VARCHAR(20) NOT NULL,
+                       target_id INT NOT NULL,
+                       reason TEXT NOT NULL,
+                       status VARCHAR(20) NOT NULL DEFAULT 'OPEN',
+                       moderator_id INT,
+                       resolution VARCHAR(20),
+                       created_at          timestamptz  NOT NULL DEFAULT now(),
+                       updated_at          timestamptz  NOT NULL DEFAULT now(),
+                       FOREIGN KEY (reporter_id) REFERENCES users(id),
+                       FOREIGN KEY (moderator_id) REFERENCES users(id)
+);
+
+-- a user can only have one unresolved report per target
+CREATE UNIQUE INDEX IF NOT EXISTS reports_open_target_idx ON reports (reporter_id, target_type, target_id) WHERE status <> 'RESOLVED';
+CREATE INDEX IF NOT EXISTS reports_status_idx ON reports (status, created_at);
+
+CREATE TABLE IF NOT EXISTS moderation_actions (
+                       id SERIAL PRIMARY KEY,
+                       moderator_id INT NOT NULL,
+                       report_id INT,
+                       action VARCHAR(20) NOT NULL,
+                       target_type VARCHAR(20) NOT NULL,
+                       target_id INT NOT NULL,
+                       note TEXT,
+                       created_at          timestamptz  NOT NULL DEFAULT now(),
+                       FOREIGN KEY (moderator_id) REFERENCES users(id),
+                       FOREIGN KEY (report_id) REFERENCES reports(id)
+);
+
+CREATE INDEX IF NOT EXISTS moderation_actions_moderator_id_idx ON moderation_actions (moderator_id, created_at);
+
+COMMIT;
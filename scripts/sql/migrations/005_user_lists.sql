@@ -0,0 +1,30 @@
+-- Named user lists of films, private unless made public.
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS user_lists (
+                       id SERIAL PRIMARY KEY,
+                       user_id INT NOT NULL,
+                       name VARCHAR(100) NOT NULL,
+                       description TEXT,
+                       public BOOLEAN NOT NULL DEFAULT FALSE,
+                       share_slug VARCHAR(32) UNIQUE NOT NULL,
+                       created_at          timestamptz  NOT NULL DEFAULT now(),
+                       updated_at          timestamptz  NOT NULL DEFAULT now(),
+                       UNIQUE (user_id, name),
+                       FOREIGN KEY (user_id) REFERENCES users(id)
+);
+
+-- deleting a film removes it from every list through the cascade
+CREATE TABLE IF NOT EXISTS user_list_entries (
+                       list_id INT NOT NULL,
+                       film_id INT NOT NULL,
+                       position INT NOT NULL,
+                       created_at          timestamptz  NOT NULL DEFAULT now(),
+                       PRIMARY KEY (list_id, film_id),
+                       FOREIGN KEY (list_id) REFERENCES user_lists(id) ON DELETE CASCADE,
+                       FOREIGN KEY (film_id) REFERENCES films(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS user_list_entries_film_id_idx ON user_list_entries (film_id);
+
+COMMIT;
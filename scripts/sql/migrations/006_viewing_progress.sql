@@ -0,0 +1,18 @@
+-- Playback progress, one row per user and film.
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS viewing_progress (
+                       user_id INT NOT NULL,
+                       film_id INT NOT NULL,
+                       position_seconds INT NOT NULL,
+                       duration_seconds INT NOT NULL,
+                       completed BOOLEAN NOT NULL DEFAULT FALSE,
+                       updated_at          timestamptz  NOT NULL DEFAULT now(),
+                       PRIMARY KEY (user_id, film_id),
+                       FOREIGN KEY (user_id) REFERENCES users(id),
+                       FOREIGN KEY (film_id) REFERENCES films(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS viewing_progress_user_id_idx ON viewing_progress (user_id, updated_at DESC);
+
+COMMIT;
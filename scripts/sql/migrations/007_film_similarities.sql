@@ -0,0 +1,15 @@
+-- Item-item similarities rebuilt periodically by the recommendations service.
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS film_similarities (
+                       film_id INT NOT NULL,
+                       similar_film_id INT NOT NULL,
+                       score DOUBLE PRECISION NOT NULL,
+                       PRIMARY KEY (film_id, similar_film_id),
+                       FOREIGN KEY (film_id) REFERENCES films(id) ON DELETE CASCADE,
+                       FOREIGN KEY (similar_film_id) REFERENCES films(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS film_ratings_user_id_idx ON film_ratings (user_id);
+
+COMMIT;
@@ -0,0 +1,15 @@
+-- Engagement rankings rebuilt periodically by the trending service.
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS film_trending (
+                       time_window VARCHAR(10) NOT NULL,
+                       film_id INT NOT NULL,
+                       score DOUBLE PRECISION NOT NULL,
+                       PRIMARY KEY (time_window, film_id),
+                       FOREIGN KEY (film_id) REFERENCES films(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS film_trending_score_idx ON film_trending (time_window, score DESC);
+CREATE INDEX IF NOT EXISTS films_release_date_idx ON films (release_date DESC);
+
+COMMIT;
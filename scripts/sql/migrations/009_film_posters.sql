@@ -0,0 +1,6 @@
+-- Poster of a film, the key prefix of its thumbnails in the blob store.
+BEGIN;
+
+ALTER TABLE films ADD COLUMN IF NOT EXISTS poster_key VARCHAR(255) NOT NULL DEFAULT '';
+
+COMMIT;
@@ -0,0 +1,17 @@
+-- Source videos and their HLS renditions, one per film.
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS video_assets (
+                       id SERIAL PRIMARY KEY,
+                       film_id INT UNIQUE NOT NULL,
+                       status VARCHAR(20) NOT NULL,
+                       version VARCHAR(32) NOT NULL,
+                       ready_version VARCHAR(32) NOT NULL DEFAULT '',
+                       renditions JSONB NOT NULL DEFAULT '[]',
+                       error TEXT NOT NULL DEFAULT '',
+                       created_at          timestamptz  NOT NULL DEFAULT now(),
+                       updated_at          timestamptz  NOT NULL DEFAULT now(),
+                       FOREIGN KEY (film_id) REFERENCES films(id) ON DELETE CASCADE
+);
+
+COMMIT;
@@ -0,0 +1,16 @@
+-- WebVTT subtitle tracks of the films, one per language.
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS film_subtitles (
+                       id SERIAL PRIMARY KEY,
+                       film_id INT NOT NULL,
+                       language VARCHAR(35) NOT NULL,
+                       label VARCHAR(100) NOT NULL DEFAULT '',
+                       blob_key VARCHAR(255) NOT NULL,
+                       created_at          timestamptz  NOT NULL DEFAULT now(),
+                       updated_at          timestamptz  NOT NULL DEFAULT now(),
+                       UNIQUE (film_id, language),
+                       FOREIGN KEY (film_id) REFERENCES films(id) ON DELETE CASCADE
+);
+
+COMMIT;
@@ -0,0 +1,15 @@
+-- Titles and synopses of the films in other languages.
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS film_translations (
+                       film_id INT NOT NULL,
+                       locale VARCHAR(35) NOT NULL,
+                       title VARCHAR(255) NOT NULL,
+                       synopsis TEXT NOT NULL DEFAULT '',
+                       created_at          timestamptz  NOT NULL DEFAULT now(),
+                       updated_at          timestamptz  NOT NULL DEFAULT now(),
+                       PRIMARY KEY (film_id, locale),
+                       FOREIGN KEY (film_id) REFERENCES films(id) ON DELETE CASCADE
+);
+
+COMMIT;
@@ -0,0 +1,6 @@
+-- Version of the films checked by the updates, sent as their ETag.
+BEGIN;
+
+ALTER TABLE films ADD COLUMN IF NOT EXISTS version INT NOT NULL DEFAULT 1;
+
+COMMIT;
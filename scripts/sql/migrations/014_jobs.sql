@@ -0,0 +1,21 @@
+-- Background jobs, the film imports and the video transcodings, and their progress.
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS jobs (
+                       id SERIAL PRIMARY KEY,
+                       type VARCHAR(50) NOT NULL,
+                       status VARCHAR(20) NOT NULL,
+                       user_id INT NOT NULL,
+                       total INT NOT NULL DEFAULT 0,
+                       processed INT NOT NULL DEFAULT 0,
+                       succeeded INT NOT NULL DEFAULT 0,
+                       skipped INT NOT NULL DEFAULT 0,
+                       failed INT NOT NULL DEFAULT 0,
+                       row_errors JSONB NOT NULL DEFAULT '[]',
+                       error TEXT NOT NULL DEFAULT '',
+                       created_at          timestamptz  NOT NULL DEFAULT now(),
+                       updated_at          timestamptz  NOT NULL DEFAULT now(),
+                       FOREIGN KEY (user_id) REFERENCES users(id)
+);
+
+COMMIT;
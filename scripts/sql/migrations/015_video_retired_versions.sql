@@ -0,0 +1,12 @@
+-- Video versions replaced by a new upload, their blobs are removed once the URLs signed for them expired.
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS video_retired_versions (
+                       id SERIAL PRIMARY KEY,
+                       film_id INT NOT NULL,
+                       version VARCHAR(32) NOT NULL,
+                       renditions JSONB NOT NULL DEFAULT '[]',
+                       retired_at          timestamptz  NOT NULL DEFAULT now()
+);
+
+COMMIT;